@@ -0,0 +1,77 @@
+const http = require("http");
+const assert = require("assert").strict;
+const settings = require("./settings").load();
+
+// request sends a form, as a page from another site would, and keeps the cookies of the session in the jar
+const request = (method, path, form, jar) => {
+	return new Promise((resolve, reject) => {
+		const body = new URLSearchParams(form || {}).toString();
+		const req = http.request(settings.url + path, {
+			method: method,
+			headers: {
+				"Content-Type": "application/x-www-form-urlencoded",
+				"Content-Length": Buffer.byteLength(body),
+				"Cookie": Object.entries(jar).map(([name, value]) => `${name}=${value}`).join("; "),
+			},
+		}, (res) => {
+			(res.headers["set-cookie"] || []).forEach((cookie) => {
+				const pair = cookie.split(";")[0];
+				const separator = pair.indexOf("=");
+				jar[pair.slice(0, separator)] = pair.slice(separator + 1);
+			});
+
+			let text = "";
+			res.on("data", (chunk) => text += chunk);
+			res.on("end", () => resolve({ status: res.statusCode, text: text }));
+		});
+
+		req.on("error", reject);
+		req.end(body);
+	});
+};
+
+const csrfToken = (page) => {
+	const match = page.text.match(/name="csrf_token" value="([^"]+)"/);
+	assert.ok(match, "page doesn't contain a csrf token");
+
+	return match[1];
+};
+
+const login = async (jar) => {
+	const token = csrfToken(await request("GET", "/login", null, jar));
+	const res = await request("POST", "/login", { username: settings.username, password: settings.password, csrf_token: token }, jar);
+	assert.equal(res.status, 302);
+};
+
+describe("forged requests", () => {
+	test("login without csrf token is rejected", async () => {
+		const res = await request("POST", "/login", { username: settings.username, password: settings.password }, {});
+
+		assert.equal(res.status, 403);
+	});
+
+	test("login with the csrf token of another session is rejected", async () => {
+		const token = csrfToken(await request("GET", "/login", null, {}));
+		const res = await request("POST", "/login", { username: settings.username, password: settings.password, csrf_token: token }, {});
+
+		assert.equal(res.status, 403);
+	});
+
+	test("deletion without csrf token is rejected", async () => {
+		const jar = {};
+		await login(jar);
+
+		const res = await request("POST", "/running-session/202101312201/delete", {}, jar);
+
+		assert.equal(res.status, 403);
+	});
+
+	test("settings with a forged csrf token are rejected", async () => {
+		const jar = {};
+		await login(jar);
+
+		const res = await request("POST", "/settings", { locale: "fr", units: "metric", "speed-display": "pace", csrf_token: "forged" }, jar);
+
+		assert.equal(res.status, 403);
+	});
+});
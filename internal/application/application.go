@@ -9,8 +9,46 @@ import (
 )
 
 type Application interface {
+	AssignOrphanActivities(ctx context.Context, username string) error
+	AuthenticateAPIToken(ctx context.Context, secret string) (domain.APIToken, error)
+	AuthenticateUser(ctx context.Context, username string, password string) (domain.User, error)
+	ChangeRunningSessionVisibility(ctx context.Context, username string, slug domain.RunningActivitySlug, visibility domain.ActivityVisibility) error
+	ChangeUserPassword(ctx context.Context, username string, currentPassword string, newPassword string) error
+	CreateAPIToken(ctx context.Context, username string, name string, scope string) (domain.APIToken, string, error)
+	CreateLegacyUser(ctx context.Context, username string, password string) (domain.User, error)
+	CreatePrivacyZone(ctx context.Context, username string, name string, latitude string, longitude string, radius string) (domain.PrivacyZone, error)
+	CreateUser(ctx context.Context, username string, password string, isAdmin bool) (domain.User, error)
 	DeleteRunningSession(context.Context, domain.RunningActivitySlug) error
-	GetRunningSession(context.Context, domain.RunningActivitySlug) (domain.RunningActivity, error)
-	ListRunningSessions(context.Context) ([]domain.RunningActivity, error)
-	TrackRunningSession(context.Context, time.Time, io.Reader) error
+	DeletePrivacyZone(ctx context.Context, username string, id domain.ID) (domain.PrivacyZone, error)
+	DisableUser(ctx context.Context, username string) error
+	EnableUser(ctx context.Context, username string) error
+	GenerateExport(context.Context, domain.ID, domain.UserPreferences) error
+	GeneratePendingMaps(context.Context) error
+	GetExport(ctx context.Context, username string, id domain.ID) (domain.Export, error)
+	GetImport(ctx context.Context, username string, id domain.ID) (domain.Import, error)
+	GetManageableRunningSession(ctx context.Context, username string, slug domain.RunningActivitySlug) (domain.RunningActivity, error)
+	GetRunningSession(ctx context.Context, viewer string, slug domain.RunningActivitySlug) (domain.RunningActivity, error)
+	GetRunningSessionAsset(ctx context.Context, viewer string, slug domain.RunningActivitySlug, name string) (io.ReadCloser, error)
+	GetRunningSessionTrack(ctx context.Context, viewer string, slug domain.RunningActivitySlug, format domain.TrackFormat, hidePrivacyZones bool) (io.Reader, error)
+	GetUser(ctx context.Context, username string) (domain.User, error)
+	GetUserPreferences(ctx context.Context, username string) (domain.UserPreferences, error)
+	ImportActivity(ctx context.Context, importID domain.ID, externalID string) error
+	ListAPITokens(ctx context.Context, username string) ([]domain.APIToken, error)
+	ListExports(ctx context.Context, username string) ([]domain.Export, error)
+	ListImportItems(ctx context.Context, importID domain.ID) ([]domain.ImportItem, error)
+	ListImports(ctx context.Context, username string) ([]domain.Import, error)
+	ListPendingMaps(context.Context) ([]domain.RunningActivity, error)
+	ListPrivacyZoneRunningSessions(ctx context.Context, zone domain.PrivacyZone) ([]domain.RunningActivity, error)
+	ListPrivacyZones(ctx context.Context, username string) (domain.PrivacyZones, error)
+	ListRunningSessions(ctx context.Context, viewer string) ([]domain.RunningActivity, error)
+	ListUserRunningSessions(ctx context.Context, viewer string, username string) ([]domain.RunningActivity, error)
+	ListUsers(context.Context) ([]domain.User, error)
+	PrepareImport(context.Context, domain.ID) ([]domain.ImportItem, error)
+	RegenerateRunningSession(context.Context, domain.RunningActivitySlug) error
+	RequestExport(ctx context.Context, username string) (domain.Export, error)
+	ResetUserPassword(ctx context.Context, username string) (string, error)
+	RevokeAPIToken(ctx context.Context, username string, id domain.ID) error
+	StartImport(ctx context.Context, username string, archivePath string) (domain.Import, error)
+	TrackRunningSession(ctx context.Context, username string, ranAt time.Time, details domain.RunningActivityDetails, prefs domain.UserPreferences, file io.Reader) error
+	UpdateUserPreferences(ctx context.Context, username string, prefs domain.UserPreferences) error
 }
@@ -37,6 +37,154 @@ func (m *MockApplication) EXPECT() *MockApplicationMockRecorder {
 	return m.recorder
 }
 
+// AssignOrphanActivities mocks base method.
+func (m *MockApplication) AssignOrphanActivities(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignOrphanActivities", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignOrphanActivities indicates an expected call of AssignOrphanActivities.
+func (mr *MockApplicationMockRecorder) AssignOrphanActivities(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignOrphanActivities", reflect.TypeOf((*MockApplication)(nil).AssignOrphanActivities), arg0, arg1)
+}
+
+// AuthenticateAPIToken mocks base method.
+func (m *MockApplication) AuthenticateAPIToken(arg0 context.Context, arg1 string) (domain.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIToken", arg0, arg1)
+	ret0, _ := ret[0].(domain.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIToken indicates an expected call of AuthenticateAPIToken.
+func (mr *MockApplicationMockRecorder) AuthenticateAPIToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIToken", reflect.TypeOf((*MockApplication)(nil).AuthenticateAPIToken), arg0, arg1)
+}
+
+// AuthenticateUser mocks base method.
+func (m *MockApplication) AuthenticateUser(arg0 context.Context, arg1, arg2 string) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateUser indicates an expected call of AuthenticateUser.
+func (mr *MockApplicationMockRecorder) AuthenticateUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateUser", reflect.TypeOf((*MockApplication)(nil).AuthenticateUser), arg0, arg1, arg2)
+}
+
+// ChangeRunningSessionVisibility mocks base method.
+func (m *MockApplication) ChangeRunningSessionVisibility(arg0 context.Context, arg1 string, arg2 domain.RunningActivitySlug, arg3 domain.ActivityVisibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeRunningSessionVisibility", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeRunningSessionVisibility indicates an expected call of ChangeRunningSessionVisibility.
+func (mr *MockApplicationMockRecorder) ChangeRunningSessionVisibility(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeRunningSessionVisibility", reflect.TypeOf((*MockApplication)(nil).ChangeRunningSessionVisibility), arg0, arg1, arg2, arg3)
+}
+
+// ChangeUserPassword mocks base method.
+func (m *MockApplication) ChangeUserPassword(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeUserPassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeUserPassword indicates an expected call of ChangeUserPassword.
+func (mr *MockApplicationMockRecorder) ChangeUserPassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUserPassword", reflect.TypeOf((*MockApplication)(nil).ChangeUserPassword), arg0, arg1, arg2, arg3)
+}
+
+// CreateAPIToken mocks base method.
+func (m *MockApplication) CreateAPIToken(arg0 context.Context, arg1, arg2, arg3 string) (domain.APIToken, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIToken", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.APIToken)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAPIToken indicates an expected call of CreateAPIToken.
+func (mr *MockApplicationMockRecorder) CreateAPIToken(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIToken", reflect.TypeOf((*MockApplication)(nil).CreateAPIToken), arg0, arg1, arg2, arg3)
+}
+
+// CreateLegacyUser mocks base method.
+func (m *MockApplication) CreateLegacyUser(arg0 context.Context, arg1, arg2 string) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLegacyUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLegacyUser indicates an expected call of CreateLegacyUser.
+func (mr *MockApplicationMockRecorder) CreateLegacyUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLegacyUser", reflect.TypeOf((*MockApplication)(nil).CreateLegacyUser), arg0, arg1, arg2)
+}
+
+// CreatePrivacyZone mocks base method.
+func (m *MockApplication) CreatePrivacyZone(arg0 context.Context, arg1, arg2, arg3, arg4, arg5 string) (domain.PrivacyZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePrivacyZone", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(domain.PrivacyZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePrivacyZone indicates an expected call of CreatePrivacyZone.
+func (mr *MockApplicationMockRecorder) CreatePrivacyZone(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePrivacyZone", reflect.TypeOf((*MockApplication)(nil).CreatePrivacyZone), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// CreateUser mocks base method.
+func (m *MockApplication) CreateUser(arg0 context.Context, arg1, arg2 string, arg3 bool) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockApplicationMockRecorder) CreateUser(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockApplication)(nil).CreateUser), arg0, arg1, arg2, arg3)
+}
+
+// DeletePrivacyZone mocks base method.
+func (m *MockApplication) DeletePrivacyZone(arg0 context.Context, arg1 string, arg2 domain.ID) (domain.PrivacyZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivacyZone", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.PrivacyZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePrivacyZone indicates an expected call of DeletePrivacyZone.
+func (mr *MockApplicationMockRecorder) DeletePrivacyZone(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivacyZone", reflect.TypeOf((*MockApplication)(nil).DeletePrivacyZone), arg0, arg1, arg2)
+}
+
 // DeleteRunningSession mocks base method.
 func (m *MockApplication) DeleteRunningSession(arg0 context.Context, arg1 domain.RunningActivitySlug) error {
 	m.ctrl.T.Helper()
@@ -51,46 +199,458 @@ func (mr *MockApplicationMockRecorder) DeleteRunningSession(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRunningSession", reflect.TypeOf((*MockApplication)(nil).DeleteRunningSession), arg0, arg1)
 }
 
+// DisableUser mocks base method.
+func (m *MockApplication) DisableUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableUser indicates an expected call of DisableUser.
+func (mr *MockApplicationMockRecorder) DisableUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUser", reflect.TypeOf((*MockApplication)(nil).DisableUser), arg0, arg1)
+}
+
+// EnableUser mocks base method.
+func (m *MockApplication) EnableUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableUser indicates an expected call of EnableUser.
+func (mr *MockApplicationMockRecorder) EnableUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUser", reflect.TypeOf((*MockApplication)(nil).EnableUser), arg0, arg1)
+}
+
+// GenerateExport mocks base method.
+func (m *MockApplication) GenerateExport(arg0 context.Context, arg1 domain.ID, arg2 domain.UserPreferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateExport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GenerateExport indicates an expected call of GenerateExport.
+func (mr *MockApplicationMockRecorder) GenerateExport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateExport", reflect.TypeOf((*MockApplication)(nil).GenerateExport), arg0, arg1, arg2)
+}
+
+// GeneratePendingMaps mocks base method.
+func (m *MockApplication) GeneratePendingMaps(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GeneratePendingMaps", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GeneratePendingMaps indicates an expected call of GeneratePendingMaps.
+func (mr *MockApplicationMockRecorder) GeneratePendingMaps(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePendingMaps", reflect.TypeOf((*MockApplication)(nil).GeneratePendingMaps), arg0)
+}
+
+// GetExport mocks base method.
+func (m *MockApplication) GetExport(arg0 context.Context, arg1 string, arg2 domain.ID) (domain.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockApplicationMockRecorder) GetExport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockApplication)(nil).GetExport), arg0, arg1, arg2)
+}
+
+// GetImport mocks base method.
+func (m *MockApplication) GetImport(arg0 context.Context, arg1 string, arg2 domain.ID) (domain.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImport indicates an expected call of GetImport.
+func (mr *MockApplicationMockRecorder) GetImport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImport", reflect.TypeOf((*MockApplication)(nil).GetImport), arg0, arg1, arg2)
+}
+
+// GetManageableRunningSession mocks base method.
+func (m *MockApplication) GetManageableRunningSession(arg0 context.Context, arg1 string, arg2 domain.RunningActivitySlug) (domain.RunningActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManageableRunningSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.RunningActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManageableRunningSession indicates an expected call of GetManageableRunningSession.
+func (mr *MockApplicationMockRecorder) GetManageableRunningSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManageableRunningSession", reflect.TypeOf((*MockApplication)(nil).GetManageableRunningSession), arg0, arg1, arg2)
+}
+
 // GetRunningSession mocks base method.
-func (m *MockApplication) GetRunningSession(arg0 context.Context, arg1 domain.RunningActivitySlug) (domain.RunningActivity, error) {
+func (m *MockApplication) GetRunningSession(arg0 context.Context, arg1 string, arg2 domain.RunningActivitySlug) (domain.RunningActivity, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRunningSession", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetRunningSession", arg0, arg1, arg2)
 	ret0, _ := ret[0].(domain.RunningActivity)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRunningSession indicates an expected call of GetRunningSession.
-func (mr *MockApplicationMockRecorder) GetRunningSession(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockApplicationMockRecorder) GetRunningSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningSession", reflect.TypeOf((*MockApplication)(nil).GetRunningSession), arg0, arg1, arg2)
+}
+
+// GetRunningSessionAsset mocks base method.
+func (m *MockApplication) GetRunningSessionAsset(arg0 context.Context, arg1 string, arg2 domain.RunningActivitySlug, arg3 string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningSessionAsset", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningSessionAsset indicates an expected call of GetRunningSessionAsset.
+func (mr *MockApplicationMockRecorder) GetRunningSessionAsset(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningSessionAsset", reflect.TypeOf((*MockApplication)(nil).GetRunningSessionAsset), arg0, arg1, arg2, arg3)
+}
+
+// GetRunningSessionTrack mocks base method.
+func (m *MockApplication) GetRunningSessionTrack(arg0 context.Context, arg1 string, arg2 domain.RunningActivitySlug, arg3 domain.TrackFormat, arg4 bool) (io.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningSessionTrack", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningSessionTrack indicates an expected call of GetRunningSessionTrack.
+func (mr *MockApplicationMockRecorder) GetRunningSessionTrack(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningSessionTrack", reflect.TypeOf((*MockApplication)(nil).GetRunningSessionTrack), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetUser mocks base method.
+func (m *MockApplication) GetUser(arg0 context.Context, arg1 string) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockApplicationMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockApplication)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserPreferences mocks base method.
+func (m *MockApplication) GetUserPreferences(arg0 context.Context, arg1 string) (domain.UserPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPreferences", arg0, arg1)
+	ret0, _ := ret[0].(domain.UserPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPreferences indicates an expected call of GetUserPreferences.
+func (mr *MockApplicationMockRecorder) GetUserPreferences(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningSession", reflect.TypeOf((*MockApplication)(nil).GetRunningSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPreferences", reflect.TypeOf((*MockApplication)(nil).GetUserPreferences), arg0, arg1)
+}
+
+// ImportActivity mocks base method.
+func (m *MockApplication) ImportActivity(arg0 context.Context, arg1 domain.ID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportActivity", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportActivity indicates an expected call of ImportActivity.
+func (mr *MockApplicationMockRecorder) ImportActivity(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportActivity", reflect.TypeOf((*MockApplication)(nil).ImportActivity), arg0, arg1, arg2)
+}
+
+// ListAPITokens mocks base method.
+func (m *MockApplication) ListAPITokens(arg0 context.Context, arg1 string) ([]domain.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPITokens", arg0, arg1)
+	ret0, _ := ret[0].([]domain.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPITokens indicates an expected call of ListAPITokens.
+func (mr *MockApplicationMockRecorder) ListAPITokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPITokens", reflect.TypeOf((*MockApplication)(nil).ListAPITokens), arg0, arg1)
+}
+
+// ListExports mocks base method.
+func (m *MockApplication) ListExports(arg0 context.Context, arg1 string) ([]domain.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExports", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExports indicates an expected call of ListExports.
+func (mr *MockApplicationMockRecorder) ListExports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExports", reflect.TypeOf((*MockApplication)(nil).ListExports), arg0, arg1)
+}
+
+// ListImportItems mocks base method.
+func (m *MockApplication) ListImportItems(arg0 context.Context, arg1 domain.ID) ([]domain.ImportItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImportItems", arg0, arg1)
+	ret0, _ := ret[0].([]domain.ImportItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImportItems indicates an expected call of ListImportItems.
+func (mr *MockApplicationMockRecorder) ListImportItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImportItems", reflect.TypeOf((*MockApplication)(nil).ListImportItems), arg0, arg1)
+}
+
+// ListImports mocks base method.
+func (m *MockApplication) ListImports(arg0 context.Context, arg1 string) ([]domain.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImports", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImports indicates an expected call of ListImports.
+func (mr *MockApplicationMockRecorder) ListImports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImports", reflect.TypeOf((*MockApplication)(nil).ListImports), arg0, arg1)
+}
+
+// ListPendingMaps mocks base method.
+func (m *MockApplication) ListPendingMaps(arg0 context.Context) ([]domain.RunningActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingMaps", arg0)
+	ret0, _ := ret[0].([]domain.RunningActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingMaps indicates an expected call of ListPendingMaps.
+func (mr *MockApplicationMockRecorder) ListPendingMaps(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingMaps", reflect.TypeOf((*MockApplication)(nil).ListPendingMaps), arg0)
+}
+
+// ListPrivacyZoneRunningSessions mocks base method.
+func (m *MockApplication) ListPrivacyZoneRunningSessions(arg0 context.Context, arg1 domain.PrivacyZone) ([]domain.RunningActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrivacyZoneRunningSessions", arg0, arg1)
+	ret0, _ := ret[0].([]domain.RunningActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrivacyZoneRunningSessions indicates an expected call of ListPrivacyZoneRunningSessions.
+func (mr *MockApplicationMockRecorder) ListPrivacyZoneRunningSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrivacyZoneRunningSessions", reflect.TypeOf((*MockApplication)(nil).ListPrivacyZoneRunningSessions), arg0, arg1)
+}
+
+// ListPrivacyZones mocks base method.
+func (m *MockApplication) ListPrivacyZones(arg0 context.Context, arg1 string) (domain.PrivacyZones, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrivacyZones", arg0, arg1)
+	ret0, _ := ret[0].(domain.PrivacyZones)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrivacyZones indicates an expected call of ListPrivacyZones.
+func (mr *MockApplicationMockRecorder) ListPrivacyZones(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrivacyZones", reflect.TypeOf((*MockApplication)(nil).ListPrivacyZones), arg0, arg1)
 }
 
 // ListRunningSessions mocks base method.
-func (m *MockApplication) ListRunningSessions(arg0 context.Context) ([]domain.RunningActivity, error) {
+func (m *MockApplication) ListRunningSessions(arg0 context.Context, arg1 string) ([]domain.RunningActivity, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRunningSessions", arg0)
+	ret := m.ctrl.Call(m, "ListRunningSessions", arg0, arg1)
 	ret0, _ := ret[0].([]domain.RunningActivity)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListRunningSessions indicates an expected call of ListRunningSessions.
-func (mr *MockApplicationMockRecorder) ListRunningSessions(arg0 interface{}) *gomock.Call {
+func (mr *MockApplicationMockRecorder) ListRunningSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRunningSessions", reflect.TypeOf((*MockApplication)(nil).ListRunningSessions), arg0, arg1)
+}
+
+// ListUserRunningSessions mocks base method.
+func (m *MockApplication) ListUserRunningSessions(arg0 context.Context, arg1, arg2 string) ([]domain.RunningActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserRunningSessions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.RunningActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserRunningSessions indicates an expected call of ListUserRunningSessions.
+func (mr *MockApplicationMockRecorder) ListUserRunningSessions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRunningSessions", reflect.TypeOf((*MockApplication)(nil).ListUserRunningSessions), arg0, arg1, arg2)
+}
+
+// ListUsers mocks base method.
+func (m *MockApplication) ListUsers(arg0 context.Context) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockApplicationMockRecorder) ListUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockApplication)(nil).ListUsers), arg0)
+}
+
+// PrepareImport mocks base method.
+func (m *MockApplication) PrepareImport(arg0 context.Context, arg1 domain.ID) ([]domain.ImportItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrepareImport", arg0, arg1)
+	ret0, _ := ret[0].([]domain.ImportItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrepareImport indicates an expected call of PrepareImport.
+func (mr *MockApplicationMockRecorder) PrepareImport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareImport", reflect.TypeOf((*MockApplication)(nil).PrepareImport), arg0, arg1)
+}
+
+// RegenerateRunningSession mocks base method.
+func (m *MockApplication) RegenerateRunningSession(arg0 context.Context, arg1 domain.RunningActivitySlug) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateRunningSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegenerateRunningSession indicates an expected call of RegenerateRunningSession.
+func (mr *MockApplicationMockRecorder) RegenerateRunningSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateRunningSession", reflect.TypeOf((*MockApplication)(nil).RegenerateRunningSession), arg0, arg1)
+}
+
+// RequestExport mocks base method.
+func (m *MockApplication) RequestExport(arg0 context.Context, arg1 string) (domain.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestExport", arg0, arg1)
+	ret0, _ := ret[0].(domain.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestExport indicates an expected call of RequestExport.
+func (mr *MockApplicationMockRecorder) RequestExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestExport", reflect.TypeOf((*MockApplication)(nil).RequestExport), arg0, arg1)
+}
+
+// ResetUserPassword mocks base method.
+func (m *MockApplication) ResetUserPassword(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetUserPassword", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetUserPassword indicates an expected call of ResetUserPassword.
+func (mr *MockApplicationMockRecorder) ResetUserPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserPassword", reflect.TypeOf((*MockApplication)(nil).ResetUserPassword), arg0, arg1)
+}
+
+// RevokeAPIToken mocks base method.
+func (m *MockApplication) RevokeAPIToken(arg0 context.Context, arg1 string, arg2 domain.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIToken indicates an expected call of RevokeAPIToken.
+func (mr *MockApplicationMockRecorder) RevokeAPIToken(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRunningSessions", reflect.TypeOf((*MockApplication)(nil).ListRunningSessions), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIToken", reflect.TypeOf((*MockApplication)(nil).RevokeAPIToken), arg0, arg1, arg2)
+}
+
+// StartImport mocks base method.
+func (m *MockApplication) StartImport(arg0 context.Context, arg1, arg2 string) (domain.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartImport indicates an expected call of StartImport.
+func (mr *MockApplicationMockRecorder) StartImport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImport", reflect.TypeOf((*MockApplication)(nil).StartImport), arg0, arg1, arg2)
 }
 
 // TrackRunningSession mocks base method.
-func (m *MockApplication) TrackRunningSession(arg0 context.Context, arg1 time.Time, arg2 io.Reader) error {
+func (m *MockApplication) TrackRunningSession(arg0 context.Context, arg1 string, arg2 time.Time, arg3 domain.RunningActivityDetails, arg4 domain.UserPreferences, arg5 io.Reader) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TrackRunningSession", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "TrackRunningSession", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // TrackRunningSession indicates an expected call of TrackRunningSession.
-func (mr *MockApplicationMockRecorder) TrackRunningSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockApplicationMockRecorder) TrackRunningSession(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackRunningSession", reflect.TypeOf((*MockApplication)(nil).TrackRunningSession), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// UpdateUserPreferences mocks base method.
+func (m *MockApplication) UpdateUserPreferences(arg0 context.Context, arg1 string, arg2 domain.UserPreferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPreferences", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPreferences indicates an expected call of UpdateUserPreferences.
+func (mr *MockApplicationMockRecorder) UpdateUserPreferences(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackRunningSession", reflect.TypeOf((*MockApplication)(nil).TrackRunningSession), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPreferences", reflect.TypeOf((*MockApplication)(nil).UpdateUserPreferences), arg0, arg1, arg2)
 }
@@ -10,25 +10,181 @@ import (
 )
 
 type Application struct {
-	repo repository.ReadWriter
+	repo          repository.ReadWriter
+	mapStyles     domain.MapStyles
+	cardTemplates domain.CardTemplates
+	// preferences are used for visitors, users who never saved theirs and background work nobody requested
+	preferences domain.UserPreferences
 }
 
-func NewApplication(repo repository.ReadWriter) Application {
-	return Application{repo: repo}
+func NewApplication(repo repository.ReadWriter, mapStyles domain.MapStyles, cardTemplates domain.CardTemplates, preferences domain.UserPreferences) Application {
+	return Application{repo: repo, mapStyles: mapStyles, cardTemplates: cardTemplates, preferences: preferences}
 }
 
 func (a Application) DeleteRunningSession(ctx context.Context, slug domain.RunningActivitySlug) error {
 	return DeleteRunningSession(a.repo, ctx, slug)
 }
 
-func (a Application) GetRunningSession(ctx context.Context, slug domain.RunningActivitySlug) (domain.RunningActivity, error) {
-	return GetRunningSession(a.repo, ctx, slug)
+func (a Application) GetRunningSession(ctx context.Context, viewer string, slug domain.RunningActivitySlug) (domain.RunningActivity, error) {
+	return GetRunningSession(a.repo, ctx, viewer, slug)
 }
 
-func (a Application) ListRunningSessions(ctx context.Context) ([]domain.RunningActivity, error) {
-	return ListRunningSessions(a.repo, ctx)
+func (a Application) GetRunningSessionAsset(ctx context.Context, viewer string, slug domain.RunningActivitySlug, name string) (io.ReadCloser, error) {
+	return GetRunningSessionAsset(a.repo, ctx, viewer, slug, name)
 }
 
-func (a Application) TrackRunningSession(ctx context.Context, ranAt time.Time, file io.Reader) error {
-	return TrackRunningSession(a.repo, ctx, ranAt, file)
+func (a Application) GetRunningSessionTrack(ctx context.Context, viewer string, slug domain.RunningActivitySlug, format domain.TrackFormat, hidePrivacyZones bool) (io.Reader, error) {
+	return GetRunningSessionTrack(a.repo, ctx, viewer, slug, format, hidePrivacyZones)
+}
+
+func (a Application) ListRunningSessions(ctx context.Context, viewer string) ([]domain.RunningActivity, error) {
+	return ListRunningSessions(a.repo, ctx, viewer)
+}
+
+func (a Application) ListUserRunningSessions(ctx context.Context, viewer string, username string) ([]domain.RunningActivity, error) {
+	return ListUserRunningSessions(a.repo, ctx, viewer, username)
+}
+
+func (a Application) GetManageableRunningSession(ctx context.Context, username string, slug domain.RunningActivitySlug) (domain.RunningActivity, error) {
+	return GetManageableRunningSession(a.repo, ctx, username, slug)
+}
+
+func (a Application) ChangeRunningSessionVisibility(ctx context.Context, username string, slug domain.RunningActivitySlug, visibility domain.ActivityVisibility) error {
+	return ChangeRunningSessionVisibility(a.repo, ctx, username, slug, visibility)
+}
+
+func (a Application) AssignOrphanActivities(ctx context.Context, username string) error {
+	return AssignOrphanActivities(a.repo, ctx, username)
+}
+
+func (a Application) TrackRunningSession(ctx context.Context, username string, ranAt time.Time, details domain.RunningActivityDetails, prefs domain.UserPreferences, file io.Reader) error {
+	return TrackRunningSession(a.repo, ctx, a.mapStyles, a.cardTemplates, prefs, username, ranAt, details, file)
+}
+
+func (a Application) ListPendingMaps(ctx context.Context) ([]domain.RunningActivity, error) {
+	return ListPendingMaps(a.repo, ctx)
+}
+
+func (a Application) GeneratePendingMaps(ctx context.Context) error {
+	return GeneratePendingMaps(a.repo, ctx, a.cardTemplates, a.preferences)
+}
+
+func (a Application) RegenerateRunningSession(ctx context.Context, slug domain.RunningActivitySlug) error {
+	return RegenerateRunningSession(a.repo, ctx, a.cardTemplates, a.preferences, slug)
+}
+
+func (a Application) RequestExport(ctx context.Context, username string) (domain.Export, error) {
+	return RequestExport(a.repo, ctx, username, time.Now())
+}
+
+func (a Application) GenerateExport(ctx context.Context, id domain.ID, prefs domain.UserPreferences) error {
+	return GenerateExport(a.repo, ctx, id, prefs, time.Now())
+}
+
+func (a Application) ListExports(ctx context.Context, username string) ([]domain.Export, error) {
+	return ListExports(a.repo, ctx, username)
+}
+
+func (a Application) GetExport(ctx context.Context, username string, id domain.ID) (domain.Export, error) {
+	return GetExport(a.repo, ctx, username, id)
+}
+
+func (a Application) StartImport(ctx context.Context, username string, archivePath string) (domain.Import, error) {
+	return StartImport(a.repo, ctx, username, archivePath, time.Now())
+}
+
+func (a Application) PrepareImport(ctx context.Context, id domain.ID) ([]domain.ImportItem, error) {
+	return PrepareImport(a.repo, ctx, id)
+}
+
+func (a Application) ImportActivity(ctx context.Context, importID domain.ID, externalID string) error {
+	return ImportActivity(a.repo, ctx, a.mapStyles, a.cardTemplates, a.preferences, importID, externalID)
+}
+
+func (a Application) GetImport(ctx context.Context, username string, id domain.ID) (domain.Import, error) {
+	return GetImport(a.repo, ctx, username, id)
+}
+
+func (a Application) ListImports(ctx context.Context, username string) ([]domain.Import, error) {
+	return ListImports(a.repo, ctx, username)
+}
+
+func (a Application) ListImportItems(ctx context.Context, importID domain.ID) ([]domain.ImportItem, error) {
+	return ListImportItems(a.repo, ctx, importID)
+}
+
+func (a Application) GetUserPreferences(ctx context.Context, username string) (domain.UserPreferences, error) {
+	return GetUserPreferences(a.repo, ctx, username, a.preferences)
+}
+
+func (a Application) UpdateUserPreferences(ctx context.Context, username string, prefs domain.UserPreferences) error {
+	return UpdateUserPreferences(a.repo, ctx, username, prefs)
+}
+
+func (a Application) CreateAPIToken(ctx context.Context, username string, name string, scope string) (domain.APIToken, string, error) {
+	return CreateAPIToken(a.repo, ctx, username, name, scope, time.Now())
+}
+
+func (a Application) ListAPITokens(ctx context.Context, username string) ([]domain.APIToken, error) {
+	return ListAPITokens(a.repo, ctx, username)
+}
+
+func (a Application) RevokeAPIToken(ctx context.Context, username string, id domain.ID) error {
+	return RevokeAPIToken(a.repo, ctx, username, id)
+}
+
+func (a Application) CreatePrivacyZone(ctx context.Context, username string, name string, latitude string, longitude string, radius string) (domain.PrivacyZone, error) {
+	return CreatePrivacyZone(a.repo, ctx, username, name, latitude, longitude, radius, time.Now())
+}
+
+func (a Application) ListPrivacyZones(ctx context.Context, username string) (domain.PrivacyZones, error) {
+	return ListPrivacyZones(a.repo, ctx, username)
+}
+
+func (a Application) DeletePrivacyZone(ctx context.Context, username string, id domain.ID) (domain.PrivacyZone, error) {
+	return DeletePrivacyZone(a.repo, ctx, username, id)
+}
+
+func (a Application) ListPrivacyZoneRunningSessions(ctx context.Context, zone domain.PrivacyZone) ([]domain.RunningActivity, error) {
+	return ListPrivacyZoneRunningSessions(a.repo, ctx, zone)
+}
+
+func (a Application) AuthenticateAPIToken(ctx context.Context, secret string) (domain.APIToken, error) {
+	return AuthenticateAPIToken(a.repo, ctx, secret, time.Now())
+}
+
+func (a Application) CreateUser(ctx context.Context, username string, password string, isAdmin bool) (domain.User, error) {
+	return CreateUser(a.repo, ctx, username, password, isAdmin, time.Now())
+}
+
+func (a Application) CreateLegacyUser(ctx context.Context, username string, password string) (domain.User, error) {
+	return CreateLegacyUser(a.repo, ctx, username, password, time.Now())
+}
+
+func (a Application) GetUser(ctx context.Context, username string) (domain.User, error) {
+	return GetUser(a.repo, ctx, username)
+}
+
+func (a Application) ListUsers(ctx context.Context) ([]domain.User, error) {
+	return ListUsers(a.repo, ctx)
+}
+
+func (a Application) AuthenticateUser(ctx context.Context, username string, password string) (domain.User, error) {
+	return AuthenticateUser(a.repo, ctx, username, password)
+}
+
+func (a Application) ChangeUserPassword(ctx context.Context, username string, currentPassword string, newPassword string) error {
+	return ChangeUserPassword(a.repo, ctx, username, currentPassword, newPassword)
+}
+
+func (a Application) ResetUserPassword(ctx context.Context, username string) (string, error) {
+	return ResetUserPassword(a.repo, ctx, username)
+}
+
+func (a Application) DisableUser(ctx context.Context, username string) error {
+	return DisableUser(a.repo, ctx, username, time.Now())
+}
+
+func (a Application) EnableUser(ctx context.Context, username string) error {
+	return EnableUser(a.repo, ctx, username)
 }
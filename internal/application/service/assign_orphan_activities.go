@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// AssignOrphanActivities gives the activities and imports recorded before they had an owner to the user
+func AssignOrphanActivities(repo repository.ReadWriter, ctx context.Context, username string) error {
+	if _, err := repo.GetUser(ctx, username); err != nil {
+		return fmt.Errorf("can't get user %s: %w", username, err)
+	}
+
+	if err := repo.AssignOrphanRunningActivities(ctx, username); err != nil {
+		return fmt.Errorf("can't assign activities to %s: %w", username, err)
+	}
+
+	if err := repo.AssignOrphanImports(ctx, username); err != nil {
+		return fmt.Errorf("can't assign imports to %s: %w", username, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestAssignOrphanActivitiesSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	orphan := domaintest.NewRunningActivity(t).WithRawSlug("202101010000").Persist(repo)
+	owned := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithUsername("bob").Persist(repo)
+	imp := domaintest.NewImport(t).Persist(repo)
+
+	err := service.AssignOrphanActivities(repo, context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't assign activities")
+
+	activity, err := repo.GetRunningActivity(context.Background(), orphan.Slug)
+	testutils.RequireNoError(t, err, "can't get orphan activity")
+	testutils.AssertEqualString(t, "alice", activity.Username, "orphan activity should be assigned")
+
+	activity, err = repo.GetRunningActivity(context.Background(), owned.Slug)
+	testutils.RequireNoError(t, err, "can't get owned activity")
+	testutils.AssertEqualString(t, "bob", activity.Username, "owned activity shouldn't be assigned")
+
+	storedImport, err := repo.GetImport(context.Background(), imp.ID)
+	testutils.RequireNoError(t, err, "can't get import")
+	testutils.AssertEqualString(t, "alice", storedImport.Username, "orphan import should be assigned")
+}
+
+func TestAssignOrphanActivitiesUnknownUser(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	err := service.AssignOrphanActivities(repo, context.Background(), "alice")
+
+	testutils.AssertErrorIs(t, domain.ErrUserNotFound, err, "unexpected error")
+}
+
+func TestAssignOrphanActivitiesAssignError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	repo.OverrideAssignOrphanActivities(errors.New("boom"))
+
+	err := service.AssignOrphanActivities(repo, context.Background(), "alice")
+
+	testutils.AssertErrorContains(t, "can't assign activities", err, "unexpected error")
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
+
+func TestAssignOrphanActivitiesAssignImportsError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	repo.OverrideAssignOrphanImports(errors.New("boom"))
+
+	err := service.AssignOrphanActivities(repo, context.Background(), "alice")
+
+	testutils.AssertErrorContains(t, "can't assign imports", err, "unexpected error")
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// AuthenticateAPIToken returns the token matching the secret and records it was used. The tokens of users who were
+// disabled or no longer exist are rejected as unknown ones.
+func AuthenticateAPIToken(repo repository.ReadWriter, ctx context.Context, secret string, now time.Time) (domain.APIToken, error) {
+	token, err := repo.GetAPITokenByHash(ctx, domain.HashAPIToken(secret))
+	if err != nil {
+		return domain.APIToken{}, fmt.Errorf("can't get api token: %w", err)
+	}
+
+	user, err := repo.GetUser(ctx, token.Username)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.APIToken{}, fmt.Errorf("owner %s of api token %s doesn't exist: %w", token.Username, token.ID, domain.ErrAPITokenNotFound)
+	}
+	if err != nil {
+		return domain.APIToken{}, fmt.Errorf("can't get owner %s of api token %s: %w", token.Username, token.ID, err)
+	}
+
+	if user.IsDisabled() {
+		return domain.APIToken{}, fmt.Errorf("owner %s of api token %s is disabled: %w", token.Username, token.ID, domain.ErrAPITokenNotFound)
+	}
+
+	if err := repo.TouchAPIToken(ctx, token.ID, now); err != nil {
+		return domain.APIToken{}, fmt.Errorf("can't mark api token %s as used: %w", token.ID, err)
+	}
+
+	token.LastUsedAt = now
+
+	return token, nil
+}
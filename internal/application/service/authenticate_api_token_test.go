@@ -0,0 +1,74 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestAuthenticateAPITokenSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	builder := domaintest.NewAPIToken(t).WithUsername("alice")
+	expected := builder.Persist(repo)
+	now := time.Date(2022, 4, 25, 9, 0, 0, 0, time.UTC)
+
+	token, err := service.AuthenticateAPIToken(repo, context.Background(), builder.Secret(), now)
+	testutils.RequireNoError(t, err, "can't authenticate api token")
+
+	testutils.AssertEqualString(t, expected.ID.String(), token.ID.String(), "unexpected token")
+	testutils.AssertEqualTime(t, now, token.LastUsedAt, "unexpected last used at")
+
+	stored, err := repo.GetAPITokenByHash(context.Background(), expected.Hash)
+	testutils.RequireNoError(t, err, "can't get stored api token")
+	testutils.AssertEqualTime(t, now, stored.LastUsedAt, "unexpected stored last used at")
+}
+
+func TestAuthenticateAPITokenUnknown(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewAPIToken(t).Persist(repo)
+
+	_, err := service.AuthenticateAPIToken(repo, context.Background(), "unknown", time.Now())
+
+	testutils.AssertErrorIs(t, domain.ErrAPITokenNotFound, err, "unexpected error")
+}
+
+func TestAuthenticateAPITokenDisabledUser(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").WithDisabledAt(time.Now()).Persist(repo)
+	builder := domaintest.NewAPIToken(t).WithUsername("alice")
+	builder.Persist(repo)
+
+	_, err := service.AuthenticateAPIToken(repo, context.Background(), builder.Secret(), time.Now())
+
+	testutils.AssertErrorIs(t, domain.ErrAPITokenNotFound, err, "unexpected error")
+}
+
+func TestAuthenticateAPITokenDeletedUser(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	builder := domaintest.NewAPIToken(t).WithUsername("alice")
+	builder.Persist(repo)
+
+	_, err := service.AuthenticateAPIToken(repo, context.Background(), builder.Secret(), time.Now())
+
+	testutils.AssertErrorIs(t, domain.ErrAPITokenNotFound, err, "unexpected error")
+}
+
+func TestAuthenticateAPITokenTouchError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	builder := domaintest.NewAPIToken(t).WithUsername("alice")
+	builder.Persist(repo)
+	repo.OverrideTouchAPIToken(errors.New("boom"))
+
+	_, err := service.AuthenticateAPIToken(repo, context.Background(), builder.Secret(), time.Now())
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
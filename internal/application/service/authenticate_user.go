@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// AuthenticateUser returns the user when the password is correct. Unknown and disabled users are reported as
+// invalid credentials, so the login form doesn't reveal which accounts exist.
+func AuthenticateUser(repo repository.Reader, ctx context.Context, username string, password string) (domain.User, error) {
+	user, err := repo.GetUser(ctx, username)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.User{}, fmt.Errorf("can't authenticate user %s: %w", username, domain.ErrInvalidCredentials)
+	}
+	if err != nil {
+		return domain.User{}, fmt.Errorf("can't get user %s: %w", username, err)
+	}
+
+	if user.IsDisabled() || !user.VerifyPassword(password) {
+		return domain.User{}, fmt.Errorf("can't authenticate user %s: %w", username, domain.ErrInvalidCredentials)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,56 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestAuthenticateUserSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	expected := domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	user, err := service.AuthenticateUser(repo, context.Background(), "alice", domaintest.UserPassword)
+	testutils.RequireNoError(t, err, "can't authenticate user")
+
+	domaintest.AssertEqualUser(t, expected, user, "unexpected user")
+}
+
+func TestAuthenticateUserInvalidCredentials(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	domaintest.NewUser(t).WithUsername("bob").WithDisabledAt(time.Now()).Persist(repo)
+
+	tcs := map[string]struct {
+		Username string
+		Password string
+	}{
+		"wrongPassword": {Username: "alice", Password: "not the password"},
+		"unknownUser":   {Username: "carol", Password: domaintest.UserPassword},
+		"disabledUser":  {Username: "bob", Password: domaintest.UserPassword},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.AuthenticateUser(repo, context.Background(), tc.Username, tc.Password)
+
+			testutils.AssertErrorIs(t, domain.ErrInvalidCredentials, err, "unexpected error")
+		})
+	}
+}
+
+func TestAuthenticateUserGetError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	repo.OverrideGetUser(errors.New("boom"))
+
+	_, err := service.AuthenticateUser(repo, context.Background(), "alice", domaintest.UserPassword)
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
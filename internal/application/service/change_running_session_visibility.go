@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// ChangeRunningSessionVisibility restricts who can see the activity, when the user is allowed to manage it. The files
+// are moved to the folder matching the visibility, so the ones of private activities can't be found from their date.
+func ChangeRunningSessionVisibility(repo repository.ReadWriter, ctx context.Context, username string, slug domain.RunningActivitySlug, visibility domain.ActivityVisibility) error {
+	activity, err := GetManageableRunningSession(repo, ctx, username, slug)
+	if err != nil {
+		return err
+	}
+
+	updated := activity.WithVisibility(visibility)
+	if !updated.HasMisplacedAssets() {
+		if err := repo.UpdateRunningActivity(ctx, updated); err != nil {
+			return fmt.Errorf("can't update visibility of activity %s: %v", slug, err)
+		}
+
+		return nil
+	}
+
+	folder, err := domain.NewAssetsFolder(updated.RanAt, visibility)
+	if err != nil {
+		return fmt.Errorf("can't build assets folder of activity %s: %v", slug, err)
+	}
+
+	return moveRunningSessionAssets(repo, ctx, activity, updated.WithAssetsFolder(folder))
+}
+
+// moveRunningSessionAssets copies the files of the activity to the paths of the moved one before recording it, and
+// only then deletes the previous files. Only the GPX file is stored while the map is pending.
+func moveRunningSessionAssets(repo repository.ReadWriter, ctx context.Context, activity domain.RunningActivity, moved domain.RunningActivity) error {
+	from, to := activity.AssetPaths(), moved.AssetPaths()
+	if activity.IsMapPending() {
+		from, to = from[:1], to[:1]
+	}
+
+	for i := range from {
+		if err := copyAsset(repo, from[i], to[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := repo.UpdateRunningActivity(ctx, moved); err != nil {
+		return fmt.Errorf("can't update visibility of activity %s: %v", activity.Slug, err)
+	}
+
+	for _, assetPath := range activity.AssetPaths() {
+		if err := repo.DeleteAsset(assetPath); err != nil {
+			return fmt.Errorf("can't delete previous file of activity %s (path=%s): %v", activity.Slug, assetPath, err)
+		}
+	}
+
+	return nil
+}
+
+func copyAsset(repo repository.ReadWriter, from string, to string) error {
+	content, err := repo.FetchAsset(from)
+	if err != nil {
+		return fmt.Errorf("can't fetch file (path=%s): %v", from, err)
+	}
+	defer content.Close()
+
+	if err := repo.StoreAsset(content, to); err != nil {
+		return fmt.Errorf("can't store file (path=%s): %v", to, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestChangeRunningSessionVisibilitySuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+	storeAssets(t, repo, activity.AssetPaths()...)
+	repo.ExpectDeleteAssets(activity.AssetPaths()...)
+
+	err := service.ChangeRunningSessionVisibility(repo, context.Background(), "alice", activity.Slug, domain.ActivityVisibilityPrivate)
+	testutils.AssertNoError(t, err, "can't change visibility")
+
+	actualActivity, err := repo.GetRunningActivity(context.Background(), activity.Slug)
+	testutils.AssertNoError(t, err, "can't get running activity")
+	testutils.AssertEqualString(t, string(domain.ActivityVisibilityPrivate), string(actualActivity.Visibility), "unexpected visibility")
+	testutils.AssertEqualBool(t, false, actualActivity.HasMisplacedAssets(), "files should be in a private folder, got %s", actualActivity.GPXPath)
+	for _, assetPath := range actualActivity.AssetPaths() {
+		assertAssetContent(t, repo, assetPath, path.Base(assetPath))
+	}
+}
+
+func TestChangeRunningSessionVisibilityToPublic(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Build()
+	activity = activity.
+		WithVisibility(domain.ActivityVisibilityPrivate).
+		WithAssetsFolder("private/0123/" + activity.RanAt.Format("2006-01-02.15h04"))
+	testutils.RequireNoError(t, repo.RecordRunningActivity(context.Background(), activity), "can't record activity")
+	storeAssets(t, repo, activity.AssetPaths()...)
+	repo.ExpectDeleteAssets(activity.AssetPaths()...)
+
+	err := service.ChangeRunningSessionVisibility(repo, context.Background(), "alice", activity.Slug, domain.ActivityVisibilityUnlisted)
+	testutils.AssertNoError(t, err, "can't change visibility")
+
+	actualActivity, err := repo.GetRunningActivity(context.Background(), activity.Slug)
+	testutils.AssertNoError(t, err, "can't get running activity")
+	testutils.AssertEqualString(t, "runs/"+activity.RanAt.Format("2006-01-02.15h04")+"/run.gpx", actualActivity.GPXPath.String(), "unexpected gpx path")
+	for _, assetPath := range actualActivity.AssetPaths() {
+		assertAssetContent(t, repo, assetPath, path.Base(assetPath))
+	}
+}
+
+func TestChangeRunningSessionVisibilityPendingMap(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").WithPendingMap("mapbox is down").Persist(repo)
+	storeAssets(t, repo, activity.GPXPath.String())
+
+	err := service.ChangeRunningSessionVisibility(repo, context.Background(), "alice", activity.Slug, domain.ActivityVisibilityPrivate)
+	testutils.AssertNoError(t, err, "can't change visibility")
+
+	actualActivity, err := repo.GetRunningActivity(context.Background(), activity.Slug)
+	testutils.AssertNoError(t, err, "can't get running activity")
+	testutils.AssertEqualBool(t, false, actualActivity.HasMisplacedAssets(), "files should be in a private folder, got %s", actualActivity.GPXPath)
+	assertAssetContent(t, repo, actualActivity.GPXPath.String(), "run.gpx")
+}
+
+func TestChangeRunningSessionVisibilityCopyFailure(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+	storeAssets(t, repo, activity.AssetPaths()...)
+	repo.OverrideFetchAsset(activity.MapPath.String(), errors.New("s3 is down"))
+
+	err := service.ChangeRunningSessionVisibility(repo, context.Background(), "alice", activity.Slug, domain.ActivityVisibilityPrivate)
+	testutils.AssertErrorContains(t, "s3 is down", err, "unexpected error")
+
+	actualActivity, err := repo.GetRunningActivity(context.Background(), activity.Slug)
+	testutils.AssertNoError(t, err, "can't get running activity")
+	domaintest.AssertEqualRunningActivity(t, activity, actualActivity, "activity shouldn't change")
+	assertAssetContent(t, repo, activity.GPXPath.String(), "run.gpx")
+}
+
+func TestChangeRunningSessionVisibilityKeepingFolder(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+
+	err := service.ChangeRunningSessionVisibility(repo, context.Background(), "alice", activity.Slug, domain.ActivityVisibilityUnlisted)
+	testutils.AssertNoError(t, err, "can't change visibility")
+
+	actualActivity, err := repo.GetRunningActivity(context.Background(), activity.Slug)
+	testutils.AssertNoError(t, err, "can't get running activity")
+	domaintest.AssertEqualRunningActivity(t, activity.WithVisibility(domain.ActivityVisibilityUnlisted), actualActivity, "unexpected activity")
+}
+
+func TestChangeRunningSessionVisibilityOtherAthlete(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("bob").Persist(repo)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+
+	err := service.ChangeRunningSessionVisibility(repo, context.Background(), "bob", activity.Slug, domain.ActivityVisibilityPrivate)
+	testutils.AssertErrorIs(t, domain.ErrRunningActivityForbidden, err, "unexpected error")
+
+	actualActivity, err := repo.GetRunningActivity(context.Background(), activity.Slug)
+	testutils.AssertNoError(t, err, "can't get running activity")
+	domaintest.AssertEqualRunningActivity(t, activity, actualActivity, "activity shouldn't change")
+}
+
+func storeAssets(t *testing.T, repo *repositorytest.Fake, assetPaths ...string) {
+	for _, assetPath := range assetPaths {
+		err := repo.StoreAsset(strings.NewReader(path.Base(assetPath)), assetPath)
+		testutils.RequireNoError(t, err, "can't store asset %s", assetPath)
+	}
+}
+
+func assertAssetContent(t *testing.T, repo *repositorytest.Fake, assetPath string, expected string) {
+	content, err := repo.FetchAsset(assetPath)
+	testutils.RequireNoError(t, err, "can't fetch asset %s", assetPath)
+	defer content.Close()
+
+	actual, err := ioutil.ReadAll(content)
+	testutils.AssertNoError(t, err, "can't read asset %s", assetPath)
+	testutils.AssertEqualString(t, expected, string(actual), "unexpected content of asset %s", assetPath)
+}
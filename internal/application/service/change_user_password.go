@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// ChangeUserPassword replaces the password of the user, once the current one is confirmed
+func ChangeUserPassword(repo repository.ReadWriter, ctx context.Context, username string, currentPassword string, newPassword string) error {
+	user, err := AuthenticateUser(repo, ctx, username, currentPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := user.ChangePassword(newPassword); err != nil {
+		return fmt.Errorf("can't change password of user %s: %w", username, err)
+	}
+
+	if err := repo.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("can't update user %s: %w", username, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestChangeUserPasswordSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	err := service.ChangeUserPassword(repo, context.Background(), "alice", domaintest.UserPassword, "battery staple")
+	testutils.RequireNoError(t, err, "can't change password")
+
+	stored, err := repo.GetUser(context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't get stored user")
+	testutils.AssertEqualBool(t, true, stored.VerifyPassword("battery staple"), "new password should be valid")
+	testutils.AssertEqualBool(t, false, stored.VerifyPassword(domaintest.UserPassword), "old password shouldn't be valid")
+}
+
+func TestChangeUserPasswordWrongCurrentPassword(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	err := service.ChangeUserPassword(repo, context.Background(), "alice", "not the password", "battery staple")
+	testutils.AssertErrorIs(t, domain.ErrInvalidCredentials, err, "unexpected error")
+
+	stored, err := repo.GetUser(context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't get stored user")
+	testutils.AssertEqualBool(t, true, stored.VerifyPassword(domaintest.UserPassword), "password shouldn't change")
+}
+
+func TestChangeUserPasswordInvalidNewPassword(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	err := service.ChangeUserPassword(repo, context.Background(), "alice", domaintest.UserPassword, "short")
+
+	var invalidErr *domain.InvalidInputErrors
+	testutils.AssertErrorAs(t, &invalidErr, err, "expected invalid input")
+}
+
+func TestChangeUserPasswordUpdateError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	repo.OverrideUpdateUser(errors.New("boom"))
+
+	err := service.ChangeUserPassword(repo, context.Background(), "alice", domaintest.UserPassword, "battery staple")
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
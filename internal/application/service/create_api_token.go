@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// CreateAPIToken records a new personal access token of the user and returns it along with its secret, which is
+// only known at creation time
+func CreateAPIToken(repo repository.Writer, ctx context.Context, username string, name string, scope string, now time.Time) (domain.APIToken, string, error) {
+	token, secret, err := domain.NewAPIToken(username, name, scope, now)
+	if err != nil {
+		return domain.APIToken{}, "", fmt.Errorf("can't create api token: %w", err)
+	}
+
+	if err := repo.RecordAPIToken(ctx, token); err != nil {
+		return domain.APIToken{}, "", fmt.Errorf("can't record api token: %w", err)
+	}
+
+	return token, secret, nil
+}
@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestCreateAPITokenSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	now := time.Date(2022, 4, 25, 9, 0, 0, 0, time.UTC)
+
+	token, secret, err := service.CreateAPIToken(repo, context.Background(), "alice", "backup", "write", now)
+	testutils.RequireNoError(t, err, "can't create api token")
+
+	testutils.AssertEqualString(t, "write", token.Scope.String(), "unexpected scope")
+	testutils.AssertEqualTime(t, now, token.CreatedAt, "unexpected created at")
+
+	stored, err := repo.GetAPITokenByHash(context.Background(), domain.HashAPIToken(secret))
+	testutils.RequireNoError(t, err, "can't get stored api token")
+	domaintest.AssertEqualAPIToken(t, token, stored, "unexpected stored api token")
+}
+
+func TestCreateAPITokenInvalidInput(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	_, _, err := service.CreateAPIToken(repo, context.Background(), "alice", "", "write", time.Now())
+
+	var invalidErr *domain.InvalidInputErrors
+	testutils.RequireErrorAs(t, &invalidErr, err, "expected invalid input")
+
+	tokens, err := repo.ListAPITokens(context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't list api tokens")
+	testutils.AssertEqualInt(t, 0, len(tokens), "no token should be recorded")
+}
+
+func TestCreateAPITokenRecordError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	repo.OverrideRecordAPIToken(errors.New("boom"))
+
+	_, _, err := service.CreateAPIToken(repo, context.Background(), "alice", "backup", "read", time.Now())
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// CreatePrivacyZone records a new zone of the user. The maps of the activities crossing it must be generated again
+// for its points to be hidden.
+func CreatePrivacyZone(repo repository.Writer, ctx context.Context, username string, name string, latitude string, longitude string, radius string, now time.Time) (domain.PrivacyZone, error) {
+	zone, err := domain.NewPrivacyZone(username, name, latitude, longitude, radius, now)
+	if err != nil {
+		return domain.PrivacyZone{}, fmt.Errorf("can't create privacy zone: %w", err)
+	}
+
+	if err := repo.RecordPrivacyZone(ctx, zone); err != nil {
+		return domain.PrivacyZone{}, fmt.Errorf("can't record privacy zone: %w", err)
+	}
+
+	return zone, nil
+}
@@ -0,0 +1,52 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestCreatePrivacyZoneSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	now := time.Date(2022, 4, 29, 9, 0, 0, 0, time.UTC)
+
+	zone, err := service.CreatePrivacyZone(repo, context.Background(), "alice", "Home", "48.8566", "2.3522", "300", now)
+	testutils.RequireNoError(t, err, "can't create privacy zone")
+
+	testutils.AssertEqualInt(t, 300, zone.RadiusMeters, "unexpected radius")
+	testutils.AssertEqualTime(t, now, zone.CreatedAt, "unexpected created at")
+
+	zones, err := repo.ListPrivacyZones(context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't list privacy zones")
+	testutils.RequireEqualInt(t, 1, len(zones), "unexpected number of privacy zones")
+	domaintest.AssertEqualPrivacyZone(t, zone, zones[0], "unexpected stored privacy zone")
+}
+
+func TestCreatePrivacyZoneInvalidInput(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	_, err := service.CreatePrivacyZone(repo, context.Background(), "alice", "Home", "north", "2.3522", "300", time.Now())
+
+	var invalidErr *domain.InvalidInputErrors
+	testutils.RequireErrorAs(t, &invalidErr, err, "expected invalid input")
+
+	zones, err := repo.ListPrivacyZones(context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't list privacy zones")
+	testutils.AssertEqualInt(t, 0, len(zones), "no zone should be recorded")
+}
+
+func TestCreatePrivacyZoneRecordError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	repo.OverrideRecordPrivacyZone(errors.New("boom"))
+
+	_, err := service.CreatePrivacyZone(repo, context.Background(), "alice", "Home", "48.8566", "2.3522", "300", time.Now())
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
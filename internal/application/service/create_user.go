@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// CreateUser records a new account allowed to log in with the password
+func CreateUser(repo repository.ReadWriter, ctx context.Context, username string, password string, isAdmin bool, now time.Time) (domain.User, error) {
+	user, err := domain.NewUser(username, password, isAdmin, now)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("can't create user: %w", err)
+	}
+
+	return recordNewUser(repo, ctx, user)
+}
+
+// CreateLegacyUser records an administrator declared in SPORT_USERS, without the username and password rules of
+// CreateUser
+func CreateLegacyUser(repo repository.ReadWriter, ctx context.Context, username string, password string, now time.Time) (domain.User, error) {
+	user, err := domain.NewLegacyUser(username, password, now)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("can't create user: %w", err)
+	}
+
+	return recordNewUser(repo, ctx, user)
+}
+
+func recordNewUser(repo repository.ReadWriter, ctx context.Context, user domain.User) (domain.User, error) {
+	if _, err := repo.GetUser(ctx, user.Username); err == nil {
+		return domain.User{}, fmt.Errorf("can't create user %s: %w", user.Username, domain.ErrUserAlreadyExists)
+	} else if !errors.Is(err, domain.ErrUserNotFound) {
+		return domain.User{}, fmt.Errorf("can't check user %s exists: %w", user.Username, err)
+	}
+
+	if err := repo.RecordUser(ctx, user); err != nil {
+		return domain.User{}, fmt.Errorf("can't record user %s: %w", user.Username, err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestCreateUserSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	now := time.Date(2022, 4, 26, 9, 0, 0, 0, time.UTC)
+
+	user, err := service.CreateUser(repo, context.Background(), "alice", "correct horse", true, now)
+	testutils.RequireNoError(t, err, "can't create user")
+
+	testutils.AssertEqualBool(t, true, user.IsAdmin, "unexpected admin flag")
+	testutils.AssertEqualBool(t, true, user.VerifyPassword("correct horse"), "unexpected password")
+
+	stored, err := repo.GetUser(context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't get stored user")
+	domaintest.AssertEqualUser(t, user, stored, "unexpected stored user")
+}
+
+func TestCreateUserInvalidInput(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	_, err := service.CreateUser(repo, context.Background(), "alice", "short", false, time.Now())
+
+	var invalidErr *domain.InvalidInputErrors
+	testutils.RequireErrorAs(t, &invalidErr, err, "expected invalid input")
+	_, err = repo.GetUser(context.Background(), "alice")
+	testutils.AssertErrorIs(t, domain.ErrUserNotFound, err, "no user should be recorded")
+}
+
+func TestCreateUserAlreadyExists(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	existing := domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	_, err := service.CreateUser(repo, context.Background(), "alice", "correct horse", true, time.Now())
+	testutils.AssertErrorIs(t, domain.ErrUserAlreadyExists, err, "unexpected error")
+
+	stored, err := repo.GetUser(context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't get stored user")
+	domaintest.AssertEqualUser(t, existing, stored, "existing user shouldn't change")
+}
+
+func TestCreateUserRecordError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	repo.OverrideRecordUser(errors.New("boom"))
+
+	_, err := service.CreateUser(repo, context.Background(), "alice", "correct horse", false, time.Now())
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
+
+func TestCreateLegacyUserSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	now := time.Date(2022, 4, 26, 9, 0, 0, 0, time.UTC)
+
+	user, err := service.CreateLegacyUser(repo, context.Background(), "Alice", "short", now)
+	testutils.RequireNoError(t, err, "can't create legacy user")
+
+	testutils.AssertEqualBool(t, true, user.IsAdmin, "unexpected admin flag")
+	testutils.AssertEqualBool(t, true, user.VerifyPassword("short"), "unexpected password")
+
+	stored, err := repo.GetUser(context.Background(), "Alice")
+	testutils.RequireNoError(t, err, "can't get stored user")
+	domaintest.AssertEqualUser(t, user, stored, "unexpected stored user")
+}
+
+func TestCreateLegacyUserAlreadyExists(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	_, err := service.CreateLegacyUser(repo, context.Background(), "alice", "short", time.Now())
+
+	testutils.AssertErrorIs(t, domain.ErrUserAlreadyExists, err, "unexpected error")
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// DeletePrivacyZone deletes the zone and returns it, so the maps of the activities crossing it can be generated again.
+// Zones of other users are reported as not found.
+func DeletePrivacyZone(repo repository.ReadWriter, ctx context.Context, username string, id domain.ID) (domain.PrivacyZone, error) {
+	zones, err := ListPrivacyZones(repo, ctx, username)
+	if err != nil {
+		return domain.PrivacyZone{}, err
+	}
+
+	for _, zone := range zones {
+		if zone.ID != id {
+			continue
+		}
+
+		if err := repo.DeletePrivacyZone(ctx, username, id); err != nil {
+			return domain.PrivacyZone{}, fmt.Errorf("can't delete privacy zone %s: %w", id, err)
+		}
+
+		return zone, nil
+	}
+
+	return domain.PrivacyZone{}, fmt.Errorf("can't find privacy zone %s: %w", id, domain.ErrPrivacyZoneNotFound)
+}
@@ -0,0 +1,37 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestDeletePrivacyZoneSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	zone := domaintest.NewPrivacyZone(t).Persist(repo)
+
+	deleted, err := service.DeletePrivacyZone(repo, context.Background(), "alice", zone.ID)
+	testutils.RequireNoError(t, err, "can't delete privacy zone")
+	domaintest.AssertEqualPrivacyZone(t, zone, deleted, "unexpected deleted privacy zone")
+
+	zones, err := repo.ListPrivacyZones(context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't list privacy zones")
+	testutils.AssertEqualInt(t, 0, len(zones), "expected zone to be deleted")
+}
+
+func TestDeletePrivacyZoneOfAnotherUser(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	zone := domaintest.NewPrivacyZone(t).WithUsername("bob").Persist(repo)
+
+	_, err := service.DeletePrivacyZone(repo, context.Background(), "alice", zone.ID)
+	testutils.AssertErrorIs(t, domain.ErrPrivacyZoneNotFound, err, "unexpected error")
+
+	zones, err := repo.ListPrivacyZones(context.Background(), "bob")
+	testutils.RequireNoError(t, err, "can't list privacy zones")
+	testutils.AssertEqualInt(t, 1, len(zones), "expected zone to be kept")
+}
@@ -22,8 +22,12 @@ func DeleteRunningSession(repo repository.ReadWriter, ctx context.Context, slug
 		return fmt.Errorf("can't delete map file %s for run %s: %w", activity.MapPath, slug, err)
 	}
 
-	if err := repo.DeleteAsset(activity.ShareableMapPath.String()); err != nil {
-		return fmt.Errorf("can't delete shareable map file %s for run %s: %w", activity.ShareableMapPath, slug, err)
+	if err := deleteCardAssets(repo, activity); err != nil {
+		return err
+	}
+
+	if err := deleteChartAssets(repo, activity); err != nil {
+		return err
 	}
 
 	if err := repo.DeleteRunningActivity(ctx, slug); err != nil {
@@ -32,3 +36,35 @@ func DeleteRunningSession(repo repository.ReadWriter, ctx context.Context, slug
 
 	return nil
 }
+
+// deleteCardAssets deletes every card, the first one being the shareable map
+func deleteCardAssets(repo repository.ReadWriter, activity domain.RunningActivity) error {
+	for _, card := range activity.ShareableCards() {
+		if err := repo.DeleteAsset(card.Path.String()); err != nil {
+			return fmt.Errorf("can't delete shareable card file %s for run %s: %w", card.Path, activity.Slug, err)
+		}
+	}
+
+	return nil
+}
+
+func deleteChartAssets(repo repository.ReadWriter, activity domain.RunningActivity) error {
+	if !activity.HasCharts() {
+		return nil
+	}
+
+	chartPaths := []string{
+		activity.ElevationChartPath.PNG(),
+		activity.ElevationChartPath.SVG(),
+		activity.PaceChartPath.PNG(),
+		activity.PaceChartPath.SVG(),
+	}
+
+	for _, chartPath := range chartPaths {
+		if err := repo.DeleteAsset(chartPath); err != nil {
+			return fmt.Errorf("can't delete chart file %s for run %s: %w", chartPath, activity.Slug, err)
+		}
+	}
+
+	return nil
+}
@@ -97,6 +97,19 @@ func TestDeleteRunningSessionActivityCantDeleteActivityBecauseUnexpectedErrorHap
 	testutils.AssertContainsString(t, "boom", err.Error(), "unexpected error content")
 }
 
+func TestDeleteRunningSessionActivityCantDeleteChart(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity := domaintest.NewRunningActivity(t).Persist(repo)
+
+	repo.OverrideDeleteAsset(activity.PaceChartPath.SVG(), errors.New("boom"))
+
+	err := service.DeleteRunningSession(repo, context.Background(), activity.Slug)
+
+	testutils.AssertHasError(t, err, "unexpected running session result")
+	testutils.AssertContainsString(t, "boom", err.Error(), "unexpected error message")
+	testutils.AssertContainsString(t, activity.PaceChartPath.SVG(), err.Error(), "unexpected error message")
+}
+
 func TestDeleteRunningSessionActivitySuccess(t *testing.T) {
 	repo := repositorytest.NewFake(t)
 	activity := domaintest.NewRunningActivity(t).Persist(repo)
@@ -107,6 +120,16 @@ func TestDeleteRunningSessionActivitySuccess(t *testing.T) {
 		activity.MapPath.String(),
 		activity.ShareableMapPath.String(),
 	)
+	repo.ExpectDeleteAssets(
+		activity.Cards[1].Path.String(),
+		activity.Cards[2].Path.String(),
+	)
+	repo.ExpectDeleteAssets(
+		activity.ElevationChartPath.PNG(),
+		activity.ElevationChartPath.SVG(),
+		activity.PaceChartPath.PNG(),
+		activity.PaceChartPath.SVG(),
+	)
 
 	err := service.DeleteRunningSession(repo, context.Background(), activity.Slug)
 	testutils.AssertNoError(t, err, "unexpected running session result")
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// DisableUser prevents the user from logging in, without deleting any of their data
+func DisableUser(repo repository.ReadWriter, ctx context.Context, username string, now time.Time) error {
+	user, err := repo.GetUser(ctx, username)
+	if err != nil {
+		return fmt.Errorf("can't get user %s: %w", username, err)
+	}
+
+	if user.IsDisabled() {
+		return nil
+	}
+
+	user.DisabledAt = now
+	if err := repo.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("can't disable user %s: %w", username, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestDisableUserSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	now := time.Date(2022, 4, 26, 9, 0, 0, 0, time.UTC)
+
+	err := service.DisableUser(repo, context.Background(), "alice", now)
+	testutils.RequireNoError(t, err, "can't disable user")
+
+	stored, err := repo.GetUser(context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't get stored user")
+	testutils.AssertEqualTime(t, now, stored.DisabledAt, "unexpected disabled at")
+}
+
+func TestDisableUserAlreadyDisabled(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	disabledAt := time.Date(2022, 4, 25, 9, 0, 0, 0, time.UTC)
+	domaintest.NewUser(t).WithUsername("alice").WithDisabledAt(disabledAt).Persist(repo)
+
+	err := service.DisableUser(repo, context.Background(), "alice", time.Now())
+	testutils.RequireNoError(t, err, "can't disable user")
+
+	stored, err := repo.GetUser(context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't get stored user")
+	testutils.AssertEqualTime(t, disabledAt, stored.DisabledAt, "disabled at shouldn't change")
+}
+
+func TestDisableUserNotFound(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	err := service.DisableUser(repo, context.Background(), "alice", time.Now())
+
+	testutils.AssertErrorIs(t, domain.ErrUserNotFound, err, "unexpected error")
+}
+
+func TestDisableUserUpdateError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	repo.OverrideUpdateUser(errors.New("boom"))
+
+	err := service.DisableUser(repo, context.Background(), "alice", time.Now())
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// EnableUser allows a disabled user to log in again
+func EnableUser(repo repository.ReadWriter, ctx context.Context, username string) error {
+	user, err := repo.GetUser(ctx, username)
+	if err != nil {
+		return fmt.Errorf("can't get user %s: %w", username, err)
+	}
+
+	user.DisabledAt = time.Time{}
+	if err := repo.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("can't enable user %s: %w", username, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestEnableUserSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").WithDisabledAt(time.Now()).Persist(repo)
+
+	err := service.EnableUser(repo, context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't enable user")
+
+	stored, err := repo.GetUser(context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't get stored user")
+	testutils.AssertEqualBool(t, false, stored.IsDisabled(), "user should be enabled")
+}
+
+func TestEnableUserNotFound(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	err := service.EnableUser(repo, context.Background(), "alice")
+
+	testutils.AssertErrorIs(t, domain.ErrUserNotFound, err, "unexpected error")
+}
+
+func TestEnableUserUpdateError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").WithDisabledAt(time.Now()).Persist(repo)
+	repo.OverrideUpdateUser(errors.New("boom"))
+
+	err := service.EnableUser(repo, context.Background(), "alice")
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// GenerateExport builds and stores the archive of the activities of the athlete who requested the export, its manifests
+// formatting values as the requester prefers
+func GenerateExport(repo repository.ReadWriter, ctx context.Context, id domain.ID, prefs domain.UserPreferences, now time.Time) error {
+	export, err := repo.GetExport(ctx, id)
+	if err != nil {
+		return fmt.Errorf("can't find export %s: %w", id, err)
+	}
+
+	if export.IsReady() {
+		return nil
+	}
+
+	activities, err := repo.ListUserRunningActivities(ctx, export.Username)
+	if err != nil {
+		return fmt.Errorf("can't list activities: %w", err)
+	}
+
+	archive, err := repo.BuildExportArchive(ctx, activities, prefs)
+	if err != nil {
+		return fmt.Errorf("can't build export archive: %w", err)
+	}
+	defer archive.Close()
+
+	archivePath := domain.ExportArchivePath(path.Join("exports", id.String(), "sport-export.zip"))
+	if err := repo.StoreAsset(archive.File(), archivePath.String()); err != nil {
+		return fmt.Errorf("can't store export archive %s: %w", archivePath, err)
+	}
+
+	if err := repo.UpdateExport(ctx, export.Complete(archivePath, now)); err != nil {
+		return fmt.Errorf("can't mark export %s as ready: %w", id, err)
+	}
+
+	return nil
+}
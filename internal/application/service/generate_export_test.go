@@ -0,0 +1,74 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestGenerateExportSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity1 := domaintest.NewRunningActivity(t).WithRawSlug("202101010000").WithUsername("alice").Persist(repo)
+	activity2 := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithUsername("alice").Persist(repo)
+	domaintest.NewRunningActivity(t).WithRawSlug("202203030000").WithUsername("bob").Persist(repo)
+	export := domaintest.NewExport(t).WithUsername("alice").Persist(repo)
+	now := time.Date(2022, 4, 17, 9, 0, 0, 0, time.UTC)
+
+	archivePath := "exports/" + export.ID.String() + "/sport-export.zip"
+	repo.ExpectBuildExportArchives([]domain.RunningActivity{activity2, activity1})
+	repo.ExpectStoreAssets(archivePath)
+	repo.ExpectExports(export.Complete(domain.ExportArchivePath(archivePath), now))
+
+	err := service.GenerateExport(repo, context.Background(), export.ID, domain.DefaultUserPreferences(), now)
+	testutils.AssertNoError(t, err, "can't generate export")
+}
+
+func TestGenerateExportAlreadyReady(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	export := domaintest.NewExport(t).Ready().Persist(repo)
+
+	repo.ExpectBuildExportArchives()
+	repo.ExpectExports(export)
+
+	err := service.GenerateExport(repo, context.Background(), export.ID, domain.DefaultUserPreferences(), time.Now())
+	testutils.AssertNoError(t, err, "can't generate export")
+}
+
+func TestGenerateExportNotFound(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	err := service.GenerateExport(repo, context.Background(), domain.NewID(), domain.DefaultUserPreferences(), time.Now())
+
+	testutils.AssertErrorIs(t, domain.ErrExportNotFound, err, "unexpected error")
+}
+
+func TestGenerateExportCannotBuildArchive(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	export := domaintest.NewExport(t).Persist(repo)
+
+	repo.OverrideBuildExportArchive(errors.New("boom"))
+	repo.ExpectExports(export)
+
+	err := service.GenerateExport(repo, context.Background(), export.ID, domain.DefaultUserPreferences(), time.Now())
+
+	testutils.AssertErrorContains(t, "can't build export archive", err, "unexpected error")
+}
+
+func TestGenerateExportCannotStoreArchive(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	export := domaintest.NewExport(t).Persist(repo)
+
+	repo.OverrideStoreAsset("exports/"+export.ID.String()+"/sport-export.zip", errors.New("boom"))
+	repo.ExpectExports(export)
+
+	err := service.GenerateExport(repo, context.Background(), export.ID, domain.DefaultUserPreferences(), time.Now())
+
+	testutils.AssertErrorContains(t, "can't store export archive", err, "unexpected error")
+}
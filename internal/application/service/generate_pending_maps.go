@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// GeneratePendingMaps generates the map, cards and charts of the activities recorded without them. Cards are drawn
+// with the current templates and the preferences of the owner, falling back to defaults, and activities recorded
+// before charts existed get chart paths next to their GPX file.
+//
+// The reason of a failed generation is stored on its activity, which stays pending until the next run, so a failure
+// doesn't prevent the other maps from being generated.
+func GeneratePendingMaps(repo repository.ReadWriter, ctx context.Context, cardTemplates domain.CardTemplates, defaults domain.UserPreferences) error {
+	activities, err := ListPendingMaps(repo, ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, activity := range activities {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("can't generate remaining maps: %v", err)
+		}
+
+		if err := generatePendingMap(repo, ctx, cardTemplates, defaults, activity); err != nil {
+			if err := repo.UpdateRunningActivity(ctx, activity.WithPendingMap(err.Error())); err != nil {
+				return fmt.Errorf("can't record map failure of activity %s: %v", activity.Slug, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func generatePendingMap(repo repository.ReadWriter, ctx context.Context, cardTemplates domain.CardTemplates, defaults domain.UserPreferences, activity domain.RunningActivity) error {
+	prefs, err := GetUserPreferences(repo, ctx, activity.Username, defaults)
+	if err != nil {
+		return err
+	}
+
+	content, err := repo.FetchAsset(activity.GPXPath.String())
+	if err != nil {
+		return fmt.Errorf("can't fetch gpx file: %v", err)
+	}
+	defer content.Close()
+
+	gpx, err := repo.CleanGPXFile(ctx, content)
+	if err != nil {
+		return fmt.Errorf("can't load gpx file: %v", err)
+	}
+
+	basePath := path.Dir(activity.GPXPath.String())
+	activity = activity.WithCards(cardTemplates.Cards(basePath))
+	if !activity.HasCharts() {
+		activity = activity.WithCharts(
+			domain.ElevationChartFilePath(path.Join(basePath, "elevation")),
+			domain.PaceChartFilePath(path.Join(basePath, "pace")),
+		)
+	}
+
+	if err := generateAssets(repo, ctx, cardTemplates, prefs, activity, gpx); err != nil {
+		return err
+	}
+
+	if err := repo.UpdateRunningActivity(ctx, activity.WithReadyMap()); err != nil {
+		return fmt.Errorf("can't mark map as ready: %v", err)
+	}
+
+	return nil
+}
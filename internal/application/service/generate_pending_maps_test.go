@@ -0,0 +1,88 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestGeneratePendingMapsSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+
+	ready := domaintest.NewRunningActivity(t).WithRawSlug("202101010000").Persist(repo)
+	pending := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithPendingMap("mapbox is down").Persist(repo)
+	testutils.AssertNoError(t, repo.StoreAsset(bytes.NewBuffer(gpxFileBytes), pending.GPXPath.String()), "can't store gpx file")
+
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.ExpectGenerateMaps(gpxFile)
+	repo.ExpectStoreAssets(pending.MapPath.String(), pending.ShareableMapPath.String())
+	repo.ExpectStoreAssets(pending.ElevationChartPath.PNG(), pending.PaceChartPath.SVG())
+	repo.ExpectRecordActivities(ready, pending.WithReadyMap())
+
+	err := service.GeneratePendingMaps(repo, context.Background(), domain.DefaultCardTemplates(), domain.DefaultUserPreferences())
+	testutils.AssertNoError(t, err, "can't generate pending maps")
+}
+
+func TestGeneratePendingMapsWithoutCharts(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithPendingMap("mapbox is down").Build()
+	testutils.AssertNoError(t, repo.RecordRunningActivity(context.Background(), activity.WithCharts("", "")), "can't record activity")
+	testutils.AssertNoError(t, repo.StoreAsset(bytes.NewBuffer(gpxFileBytes), activity.GPXPath.String()), "can't store gpx file")
+
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.ExpectStoreAssets("runs/2022-02-02.00h00/elevation.png", "runs/2022-02-02.00h00/pace.svg")
+	repo.ExpectRecordActivities(activity.WithReadyMap())
+
+	err := service.GeneratePendingMaps(repo, context.Background(), domain.DefaultCardTemplates(), domain.DefaultUserPreferences())
+	testutils.AssertNoError(t, err, "can't generate pending maps")
+}
+
+func TestGeneratePendingMapsFailure(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+
+	pending := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithPendingMap("mapbox is down").Persist(repo)
+	testutils.AssertNoError(t, repo.StoreAsset(bytes.NewBuffer(gpxFileBytes), pending.GPXPath.String()), "can't store gpx file")
+
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.OverrideGenerateMap(gpxFile, domain.MapFile{}, errors.New("invalid token"))
+	repo.ExpectRecordActivities(pending.WithPendingMap("can't generate image from gpx: invalid token"))
+
+	err := service.GeneratePendingMaps(repo, context.Background(), domain.DefaultCardTemplates(), domain.DefaultUserPreferences())
+	testutils.AssertNoError(t, err, "failures should be recorded on the activity")
+}
+
+func TestGeneratePendingMapsMissingGPXFile(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	pending := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithPendingMap("mapbox is down").Persist(repo)
+
+	repo.OverrideFetchAsset(pending.GPXPath.String(), errors.New("boom"))
+	repo.ExpectRecordActivities(pending.WithPendingMap("can't fetch gpx file: boom"))
+
+	err := service.GeneratePendingMaps(repo, context.Background(), domain.DefaultCardTemplates(), domain.DefaultUserPreferences())
+	testutils.AssertNoError(t, err, "failures should be recorded on the activity")
+}
+
+func TestGeneratePendingMapsCannotRecordFailure(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	pending := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithPendingMap("mapbox is down").Persist(repo)
+
+	repo.OverrideFetchAsset(pending.GPXPath.String(), errors.New("boom"))
+	repo.OverrideUpdateActivity(pending.Slug, errors.New("database is down"))
+
+	err := service.GeneratePendingMaps(repo, context.Background(), domain.DefaultCardTemplates(), domain.DefaultUserPreferences())
+	testutils.AssertErrorContains(t, "database is down", err, "unexpected error")
+}
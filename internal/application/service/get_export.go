@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// GetExport returns the export when it was requested by the user: the exports of other users are reported as not found
+func GetExport(repo repository.Reader, ctx context.Context, username string, id domain.ID) (domain.Export, error) {
+	export, err := repo.GetExport(ctx, id)
+	if err != nil {
+		return domain.Export{}, err
+	}
+
+	if !export.IsOwnedBy(username) {
+		return domain.Export{}, domain.ErrExportNotFound
+	}
+
+	return export, nil
+}
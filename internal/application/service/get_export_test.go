@@ -0,0 +1,39 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestGetExportSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	expected := domaintest.NewExport(t).WithUsername("alice").Persist(repo)
+
+	actual, err := service.GetExport(repo, context.Background(), "alice", expected.ID)
+
+	testutils.AssertNoError(t, err, "can't get export")
+	domaintest.AssertEqualExport(t, expected, actual, "unexpected export")
+}
+
+func TestGetExportNotFound(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	_, err := service.GetExport(repo, context.Background(), "alice", domain.NewID())
+
+	testutils.AssertErrorIs(t, domain.ErrExportNotFound, err, "unexpected error")
+}
+
+func TestGetExportOfAnotherUser(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	export := domaintest.NewExport(t).WithUsername("bob").Persist(repo)
+
+	_, err := service.GetExport(repo, context.Background(), "alice", export.ID)
+
+	testutils.AssertErrorIs(t, domain.ErrExportNotFound, err, "unexpected error")
+}
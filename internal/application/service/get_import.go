@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// GetImport returns the import when the user is allowed to follow it, that is when they uploaded the archive or are an
+// administrator: the imports of other athletes are reported as not found
+func GetImport(repo repository.Reader, ctx context.Context, username string, id domain.ID) (domain.Import, error) {
+	imp, err := repo.GetImport(ctx, id)
+	if err != nil {
+		return domain.Import{}, err
+	}
+
+	user, err := repo.GetUser(ctx, username)
+	if err != nil {
+		return domain.Import{}, fmt.Errorf("can't get user %s: %w", username, err)
+	}
+
+	if !imp.CanBeViewedBy(user) {
+		return domain.Import{}, fmt.Errorf("%s can't view import %s: %w", username, id, domain.ErrImportNotFound)
+	}
+
+	return imp, nil
+}
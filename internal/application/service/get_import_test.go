@@ -0,0 +1,56 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestGetImportSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	expected := domaintest.NewImport(t).WithUsername("alice").Persist(repo)
+	domaintest.NewImportItem(t, expected.ID).Persist(repo)
+	domaintest.NewImportItem(t, expected.ID).WithStatus(domain.ImportItemStatusFailed).Persist(repo)
+	expected.Progress = domain.ImportProgress{Pending: 1, Failed: 1}
+
+	actual, err := service.GetImport(repo, context.Background(), "alice", expected.ID)
+
+	testutils.AssertNoError(t, err, "can't get import")
+	domaintest.AssertEqualImport(t, expected, actual, "unexpected import")
+}
+
+func TestGetImportNotFound(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	_, err := service.GetImport(repo, context.Background(), "alice", domain.NewID())
+
+	testutils.AssertErrorIs(t, domain.ErrImportNotFound, err, "unexpected error")
+}
+
+func TestGetImportAdmin(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("bob").WithAdmin().Persist(repo)
+	expected := domaintest.NewImport(t).WithUsername("alice").Persist(repo)
+
+	actual, err := service.GetImport(repo, context.Background(), "bob", expected.ID)
+
+	testutils.AssertNoError(t, err, "can't get import")
+	domaintest.AssertEqualImport(t, expected, actual, "unexpected import")
+}
+
+func TestGetImportOtherAthlete(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("bob").Persist(repo)
+	imp := domaintest.NewImport(t).WithUsername("alice").Persist(repo)
+
+	_, err := service.GetImport(repo, context.Background(), "bob", imp.ID)
+
+	testutils.AssertErrorIs(t, domain.ErrImportNotFound, err, "unexpected error")
+}
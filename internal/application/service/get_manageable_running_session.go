@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// GetManageableRunningSession returns the activity when the user is allowed to edit or delete it, that is when they
+// uploaded it or are an administrator
+func GetManageableRunningSession(repo repository.Reader, ctx context.Context, username string, slug domain.RunningActivitySlug) (domain.RunningActivity, error) {
+	activity, err := repo.GetRunningActivity(ctx, slug)
+	if err != nil {
+		return domain.RunningActivity{}, err
+	}
+
+	user, err := repo.GetUser(ctx, username)
+	if err != nil {
+		return domain.RunningActivity{}, fmt.Errorf("can't get user %s: %w", username, err)
+	}
+
+	if !activity.CanBeManagedBy(user) {
+		return domain.RunningActivity{}, fmt.Errorf("%s can't manage activity %s: %w", username, slug, domain.ErrRunningActivityForbidden)
+	}
+
+	return activity, nil
+}
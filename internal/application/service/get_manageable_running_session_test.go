@@ -0,0 +1,64 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestGetManageableRunningSessionOwner(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	expectedActivity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+
+	actualActivity, err := service.GetManageableRunningSession(repo, context.Background(), "alice", expectedActivity.Slug)
+
+	testutils.AssertNoError(t, err, "can't get running session")
+	domaintest.AssertEqualRunningActivity(t, expectedActivity, actualActivity, "unexpected activity")
+}
+
+func TestGetManageableRunningSessionAdmin(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("bob").WithAdmin().Persist(repo)
+	expectedActivity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+
+	actualActivity, err := service.GetManageableRunningSession(repo, context.Background(), "bob", expectedActivity.Slug)
+
+	testutils.AssertNoError(t, err, "can't get running session")
+	domaintest.AssertEqualRunningActivity(t, expectedActivity, actualActivity, "unexpected activity")
+}
+
+func TestGetManageableRunningSessionOtherAthlete(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("bob").Persist(repo)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+
+	_, err := service.GetManageableRunningSession(repo, context.Background(), "bob", activity.Slug)
+
+	testutils.AssertErrorIs(t, domain.ErrRunningActivityForbidden, err, "unexpected error")
+}
+
+func TestGetManageableRunningSessionNotFound(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	slug, err := domain.NewRunnningActivitySlugFromString("202202162149")
+	testutils.AssertNoError(t, err, "can't build slug")
+
+	_, err = service.GetManageableRunningSession(repo, context.Background(), "alice", slug)
+
+	testutils.AssertErrorIs(t, domain.ErrCantGetRunningSession, err, "unexpected error")
+}
+
+func TestGetManageableRunningSessionUnknownUser(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+
+	_, err := service.GetManageableRunningSession(repo, context.Background(), "alice", activity.Slug)
+
+	testutils.AssertErrorIs(t, domain.ErrUserNotFound, err, "unexpected error")
+}
@@ -2,16 +2,28 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/lonepeon/sport/internal/domain"
 	"github.com/lonepeon/sport/internal/repository"
 )
 
-func GetRunningSession(repo repository.Reader, ctx context.Context, slug domain.RunningActivitySlug) (domain.RunningActivity, error) {
+// GetRunningSession returns the activity when the viewer is allowed to see it. Private activities of other athletes
+// are reported as missing, so their existence isn't disclosed. An empty viewer is a visitor.
+func GetRunningSession(repo repository.Reader, ctx context.Context, viewer string, slug domain.RunningActivitySlug) (domain.RunningActivity, error) {
 	activity, err := repo.GetRunningActivity(ctx, slug)
 	if err != nil {
 		return domain.RunningActivity{}, err
 	}
 
+	user, err := getViewer(repo, ctx, viewer)
+	if err != nil {
+		return domain.RunningActivity{}, err
+	}
+
+	if !activity.CanBeSeenBy(user) {
+		return domain.RunningActivity{}, fmt.Errorf("activity %s is private: %w", slug, domain.ErrCantGetRunningSession)
+	}
+
 	return activity, nil
 }
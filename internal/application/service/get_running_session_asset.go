@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// GetRunningSessionAsset returns the content of a file of the activity, such as map.png, when the viewer is allowed to
+// see the activity. The points of the GPX file inside the privacy zones of the owner are removed for other viewers.
+// The caller is in charge of closing it.
+func GetRunningSessionAsset(repo repository.ReadWriter, ctx context.Context, viewer string, slug domain.RunningActivitySlug, name string) (io.ReadCloser, error) {
+	activity, err := GetRunningSession(repo, ctx, viewer, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	assetPath, ok := activity.AssetPath(name)
+	if !ok {
+		return nil, fmt.Errorf("activity %s has no file %s: %w", slug, name, domain.ErrRunningActivityAssetNotFound)
+	}
+
+	if assetPath == activity.GPXPath.String() {
+		zones, err := hiddenPrivacyZones(repo, ctx, viewer, activity)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(zones) > 0 {
+			return maskGPXFile(repo, ctx, activity, zones)
+		}
+	}
+
+	content, err := repo.FetchAsset(assetPath)
+	if err != nil {
+		return nil, fmt.Errorf("can't fetch file %s of activity %s: %v", assetPath, slug, err)
+	}
+
+	return content, nil
+}
+
+// hiddenPrivacyZones returns the privacy zones of the owner of the activity whose points are hidden to the viewer
+func hiddenPrivacyZones(repo repository.Reader, ctx context.Context, viewer string, activity domain.RunningActivity) (domain.PrivacyZones, error) {
+	user, err := getViewer(repo, ctx, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if activity.RevealsTrackTo(user) {
+		return nil, nil
+	}
+
+	zones, err := repo.ListPrivacyZones(ctx, activity.Username)
+	if err != nil {
+		return nil, fmt.Errorf("can't list privacy zones of user %s: %v", activity.Username, err)
+	}
+
+	return zones, nil
+}
+
+func maskGPXFile(repo repository.ReadWriter, ctx context.Context, activity domain.RunningActivity, zones domain.PrivacyZones) (io.ReadCloser, error) {
+	gpx, err := loadGPXFile(repo, ctx, activity)
+	if err != nil {
+		return nil, err
+	}
+
+	masked, err := repo.WriteGPXFile(ctx, zones.Mask(gpx.Points))
+	if err != nil {
+		return nil, fmt.Errorf("can't write gpx file of activity %s: %v", activity.Slug, err)
+	}
+
+	return ioutil.NopCloser(masked), nil
+}
@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestGetRunningSessionAssetSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").
+		WithVisibility(domain.ActivityVisibilityPrivate).Persist(repo)
+	err := repo.StoreAsset(bytes.NewBufferString("png content"), activity.MapPath.String())
+	testutils.AssertNoError(t, err, "can't store map")
+
+	content, err := service.GetRunningSessionAsset(repo, context.Background(), "alice", activity.Slug, "map.png")
+	testutils.AssertNoError(t, err, "can't get asset")
+	defer content.Close()
+
+	actual, err := ioutil.ReadAll(content)
+	testutils.AssertNoError(t, err, "can't read asset")
+	testutils.AssertEqualString(t, "png content", string(actual), "unexpected asset content")
+}
+
+func TestGetRunningSessionAssetPrivate(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").
+		WithVisibility(domain.ActivityVisibilityPrivate).Persist(repo)
+
+	_, err := service.GetRunningSessionAsset(repo, context.Background(), "", activity.Slug, "map.png")
+
+	testutils.AssertErrorIs(t, domain.ErrCantGetRunningSession, err, "unexpected error")
+}
+
+func TestGetRunningSessionAssetUnknownFile(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+
+	_, err := service.GetRunningSessionAsset(repo, context.Background(), "", activity.Slug, "secrets.txt")
+
+	testutils.AssertErrorIs(t, domain.ErrRunningActivityAssetNotFound, err, "unexpected error")
+}
+
+func TestGetRunningSessionAssetGPXWithPrivacyZones(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+	domaintest.NewUser(t).WithUsername("bob").Persist(repo)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+	testutils.AssertNoError(t, repo.StoreAsset(bytes.NewBuffer(gpxFileBytes), activity.GPXPath.String()), "can't store gpx file")
+	domaintest.NewPrivacyZone(t).WithCentre(gpxFile.Points[0].Latitude, gpxFile.Points[0].Longitude).Persist(repo)
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+
+	for _, viewer := range []string{"", "bob"} {
+		content, err := service.GetRunningSessionAsset(repo, context.Background(), viewer, activity.Slug, "run.gpx")
+		testutils.RequireNoError(t, err, "can't get gpx file as %s", viewer)
+
+		actual, err := ioutil.ReadAll(content)
+		testutils.AssertNoError(t, err, "can't read gpx file")
+		content.Close()
+		testutils.AssertEqualString(t, "40.700000,-120.950000\n43.252000,-126.453000\n", string(actual), "unexpected points for %s", viewer)
+	}
+}
+
+func TestGetRunningSessionAssetGPXOfOwner(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	gpxFileBytes := domaintest.GetGPXBytes()
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+	testutils.AssertNoError(t, repo.StoreAsset(bytes.NewBuffer(gpxFileBytes), activity.GPXPath.String()), "can't store gpx file")
+	domaintest.NewPrivacyZone(t).Persist(repo)
+
+	content, err := service.GetRunningSessionAsset(repo, context.Background(), "alice", activity.Slug, "run.gpx")
+	testutils.RequireNoError(t, err, "can't get gpx file")
+	defer content.Close()
+
+	actual, err := ioutil.ReadAll(content)
+	testutils.AssertNoError(t, err, "can't read gpx file")
+	testutils.AssertEqualString(t, string(gpxFileBytes), string(actual), "owner should get the full track")
+}
+
+func TestGetRunningSessionAssetGPXOfAdmin(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+	domaintest.NewUser(t).WithUsername("bob").WithAdmin().Persist(repo)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+	testutils.AssertNoError(t, repo.StoreAsset(bytes.NewBuffer(gpxFileBytes), activity.GPXPath.String()), "can't store gpx file")
+	domaintest.NewPrivacyZone(t).WithCentre(gpxFile.Points[0].Latitude, gpxFile.Points[0].Longitude).Persist(repo)
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+
+	content, err := service.GetRunningSessionAsset(repo, context.Background(), "bob", activity.Slug, "run.gpx")
+	testutils.RequireNoError(t, err, "can't get gpx file")
+	defer content.Close()
+
+	actual, err := ioutil.ReadAll(content)
+	testutils.AssertNoError(t, err, "can't read gpx file")
+	testutils.AssertEqualString(t, "40.700000,-120.950000\n43.252000,-126.453000\n", string(actual), "admin shouldn't get the points inside the privacy zones")
+}
+
+func TestGetRunningSessionAssetGPXPrivacyZonesFailure(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+	repo.OverrideListPrivacyZones(errors.New("boom"))
+
+	_, err := service.GetRunningSessionAsset(repo, context.Background(), "", activity.Slug, "run.gpx")
+
+	testutils.AssertErrorContains(t, "can't list privacy zones", err, "unexpected error")
+}
@@ -15,7 +15,7 @@ func TestGetRunningSessionSuccess(t *testing.T) {
 	repo := repositorytest.NewFake(t)
 	expectedActivity := domaintest.NewRunningActivity(t).Persist(repo)
 
-	actualActivity, err := service.GetRunningSession(repo, context.Background(), expectedActivity.Slug)
+	actualActivity, err := service.GetRunningSession(repo, context.Background(), "", expectedActivity.Slug)
 
 	testutils.AssertNoError(t, err, "can't get running session")
 	domaintest.AssertEqualRunningActivity(t, expectedActivity, actualActivity, "unexpected activity")
@@ -26,7 +26,41 @@ func TestGetRunningSessionNotFound(t *testing.T) {
 	slug, err := domain.NewRunnningActivitySlugFromString("202202162149")
 	testutils.AssertNoError(t, err, "can't build slug")
 
-	_, err = service.GetRunningSession(repo, context.Background(), slug)
+	_, err = service.GetRunningSession(repo, context.Background(), "", slug)
 
 	testutils.AssertErrorIs(t, domain.ErrCantGetRunningSession, err, "unexpected error")
 }
+
+func TestGetRunningSessionPrivateOwner(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	expectedActivity := domaintest.NewRunningActivity(t).WithUsername("alice").
+		WithVisibility(domain.ActivityVisibilityPrivate).Persist(repo)
+
+	actualActivity, err := service.GetRunningSession(repo, context.Background(), "alice", expectedActivity.Slug)
+
+	testutils.AssertNoError(t, err, "can't get running session")
+	domaintest.AssertEqualRunningActivity(t, expectedActivity, actualActivity, "unexpected activity")
+}
+
+func TestGetRunningSessionPrivateOtherAthlete(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("bob").Persist(repo)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").
+		WithVisibility(domain.ActivityVisibilityPrivate).Persist(repo)
+
+	_, err := service.GetRunningSession(repo, context.Background(), "bob", activity.Slug)
+
+	testutils.AssertErrorIs(t, domain.ErrCantGetRunningSession, err, "unexpected error")
+}
+
+func TestGetRunningSessionUnlistedVisitor(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	expectedActivity := domaintest.NewRunningActivity(t).WithUsername("alice").
+		WithVisibility(domain.ActivityVisibilityUnlisted).Persist(repo)
+
+	actualActivity, err := service.GetRunningSession(repo, context.Background(), "", expectedActivity.Slug)
+
+	testutils.AssertNoError(t, err, "can't get running session")
+	domaintest.AssertEqualRunningActivity(t, expectedActivity, actualActivity, "unexpected activity")
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// GetRunningSessionTrack returns the track of the activity written in the format, when the viewer is allowed to see
+// the activity. The points inside the privacy zones of the owner are removed for other viewers, and for the owner or
+// administrators when they ask for it.
+func GetRunningSessionTrack(repo repository.ReadWriter, ctx context.Context, viewer string, slug domain.RunningActivitySlug, format domain.TrackFormat, hidePrivacyZones bool) (io.Reader, error) {
+	activity, err := GetRunningSession(repo, ctx, viewer, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := trackPrivacyZones(repo, ctx, viewer, activity, hidePrivacyZones)
+	if err != nil {
+		return nil, err
+	}
+
+	gpx, err := loadGPXFile(repo, ctx, activity)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := writeTrack(repo, ctx, format, zones.Mask(gpx.Points))
+	if err != nil {
+		return nil, fmt.Errorf("can't write %s file of activity %s: %v", format, slug, err)
+	}
+
+	return content, nil
+}
+
+func trackPrivacyZones(repo repository.Reader, ctx context.Context, viewer string, activity domain.RunningActivity, hidePrivacyZones bool) (domain.PrivacyZones, error) {
+	if hidePrivacyZones {
+		return ListPrivacyZones(repo, ctx, activity.Username)
+	}
+
+	return hiddenPrivacyZones(repo, ctx, viewer, activity)
+}
+
+func writeTrack(repo repository.Writer, ctx context.Context, format domain.TrackFormat, points domain.GPXPoints) (io.Reader, error) {
+	switch format {
+	case domain.TrackFormatGeoJSON:
+		return repo.WriteGeoJSONFile(ctx, points)
+	case domain.TrackFormatKML:
+		return repo.WriteKMLFile(ctx, points)
+	default:
+		return repo.WriteGPXFile(ctx, points)
+	}
+}
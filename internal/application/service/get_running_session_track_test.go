@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestGetRunningSessionTrackWithPrivacyZones(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity := persistActivityWithTrack(t, repo)
+	domaintest.NewUser(t).WithUsername("bob").Persist(repo)
+
+	for _, viewer := range []string{"", "bob"} {
+		content, err := service.GetRunningSessionTrack(repo, context.Background(), viewer, activity.Slug, domain.TrackFormatGeoJSON, false)
+		testutils.RequireNoError(t, err, "can't get track as %s", viewer)
+
+		actual, err := ioutil.ReadAll(content)
+		testutils.AssertNoError(t, err, "can't read track")
+		testutils.AssertEqualString(t, "geojson:40.700000,-120.950000\ngeojson:43.252000,-126.453000\n", string(actual), "unexpected points for %s", viewer)
+	}
+}
+
+func TestGetRunningSessionTrackOfOwner(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity := persistActivityWithTrack(t, repo)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	content, err := service.GetRunningSessionTrack(repo, context.Background(), "alice", activity.Slug, domain.TrackFormatKML, false)
+	testutils.RequireNoError(t, err, "can't get track")
+
+	actual, err := ioutil.ReadAll(content)
+	testutils.AssertNoError(t, err, "can't read track")
+	testutils.AssertEqualString(t, "kml:38.500000,-120.200000\nkml:40.700000,-120.950000\nkml:43.252000,-126.453000\n", string(actual), "owner should get the full track")
+}
+
+func TestGetRunningSessionTrackOfAdmin(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity := persistActivityWithTrack(t, repo)
+	domaintest.NewUser(t).WithUsername("bob").WithAdmin().Persist(repo)
+
+	content, err := service.GetRunningSessionTrack(repo, context.Background(), "bob", activity.Slug, domain.TrackFormatGeoJSON, false)
+	testutils.RequireNoError(t, err, "can't get track")
+
+	actual, err := ioutil.ReadAll(content)
+	testutils.AssertNoError(t, err, "can't read track")
+	testutils.AssertEqualString(t, "geojson:40.700000,-120.950000\ngeojson:43.252000,-126.453000\n", string(actual), "admin shouldn't get the points inside the privacy zones")
+}
+
+func TestGetRunningSessionTrackOfOwnerHidingPrivacyZones(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity := persistActivityWithTrack(t, repo)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	content, err := service.GetRunningSessionTrack(repo, context.Background(), "alice", activity.Slug, domain.TrackFormatGPX, true)
+	testutils.RequireNoError(t, err, "can't get track")
+
+	actual, err := ioutil.ReadAll(content)
+	testutils.AssertNoError(t, err, "can't read track")
+	testutils.AssertEqualString(t, "40.700000,-120.950000\n43.252000,-126.453000\n", string(actual), "unexpected points")
+}
+
+func TestGetRunningSessionTrackPrivate(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").
+		WithVisibility(domain.ActivityVisibilityPrivate).Persist(repo)
+
+	_, err := service.GetRunningSessionTrack(repo, context.Background(), "", activity.Slug, domain.TrackFormatGPX, false)
+
+	testutils.AssertErrorIs(t, domain.ErrCantGetRunningSession, err, "unexpected error")
+}
+
+func TestGetRunningSessionTrackWriteFailure(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity := persistActivityWithTrack(t, repo)
+	repo.OverrideWriteKMLFile(errors.New("boom"))
+
+	_, err := service.GetRunningSessionTrack(repo, context.Background(), "", activity.Slug, domain.TrackFormatKML, false)
+
+	testutils.AssertErrorContains(t, "can't write kml file", err, "unexpected error")
+}
+
+// persistActivityWithTrack records a public activity of alice whose first point is inside one of her privacy zones
+func persistActivityWithTrack(t *testing.T, repo *repositorytest.Fake) domain.RunningActivity {
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+	testutils.RequireNoError(t, repo.StoreAsset(bytes.NewBuffer(gpxFileBytes), activity.GPXPath.String()), "can't store gpx file")
+	domaintest.NewPrivacyZone(t).WithCentre(gpxFile.Points[0].Latitude, gpxFile.Points[0].Longitude).Persist(repo)
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+
+	return activity
+}
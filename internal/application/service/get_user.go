@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// GetUser returns the account of the user
+func GetUser(repo repository.Reader, ctx context.Context, username string) (domain.User, error) {
+	user, err := repo.GetUser(ctx, username)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("can't get user %s: %w", username, err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// GetUserPreferences returns the preferences saved by the user, or the default ones for visitors and users who
+// never saved theirs
+func GetUserPreferences(repo repository.Reader, ctx context.Context, username string, defaults domain.UserPreferences) (domain.UserPreferences, error) {
+	if username == "" {
+		return defaults, nil
+	}
+
+	prefs, err := repo.GetUserPreferences(ctx, username)
+	if errors.Is(err, domain.ErrUserPreferencesNotFound) {
+		return defaults, nil
+	}
+	if err != nil {
+		return domain.UserPreferences{}, fmt.Errorf("can't get preferences of user %s: %w", username, err)
+	}
+
+	return prefs, nil
+}
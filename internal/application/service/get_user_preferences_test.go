@@ -0,0 +1,53 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestGetUserPreferencesSaved(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	expected := domain.UserPreferences{Locale: domain.LocaleFrench, Units: domain.UnitSystemImperial, SpeedDisplay: domain.SpeedDisplaySpeed}
+	testutils.RequireNoError(t, repo.SaveUserPreferences(context.Background(), "alice", expected), "can't save preferences")
+
+	actual, err := service.GetUserPreferences(repo, context.Background(), "alice", domain.DefaultUserPreferences())
+
+	testutils.AssertNoError(t, err, "can't get preferences")
+	testutils.AssertEqualString(t, "fr", actual.Locale.String(), "unexpected locale")
+	testutils.AssertEqualString(t, "imperial", actual.Units.String(), "unexpected units")
+}
+
+func TestGetUserPreferencesDefaults(t *testing.T) {
+	defaults := domain.UserPreferences{Locale: domain.LocaleFrench, Units: domain.UnitSystemMetric, SpeedDisplay: domain.SpeedDisplayPace}
+
+	tcs := map[string]string{
+		"visitor":         "",
+		"neverSavedPrefs": "alice",
+	}
+
+	for name, username := range tcs {
+		t.Run(name, func(t *testing.T) {
+			repo := repositorytest.NewFake(t)
+
+			actual, err := service.GetUserPreferences(repo, context.Background(), username, defaults)
+
+			testutils.AssertNoError(t, err, "can't get preferences")
+			testutils.AssertEqualString(t, "fr", actual.Locale.String(), "unexpected locale")
+		})
+	}
+}
+
+func TestGetUserPreferencesError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	repo.OverrideGetUserPreferences("alice", errors.New("boom"))
+
+	_, err := service.GetUserPreferences(repo, context.Background(), "alice", domain.DefaultUserPreferences())
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
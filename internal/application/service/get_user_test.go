@@ -0,0 +1,30 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestGetUserSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	expected := domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	user, err := service.GetUser(repo, context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't get user")
+
+	domaintest.AssertEqualUser(t, expected, user, "unexpected user")
+}
+
+func TestGetUserNotFound(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	_, err := service.GetUser(repo, context.Background(), "alice")
+
+	testutils.AssertErrorIs(t, domain.ErrUserNotFound, err, "unexpected error")
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// ImportActivity records the running activity of a pending import item and stores the outcome on the item.
+//
+// Errors related to the file itself mark the item as failed or skipped and aren't returned, so the job isn't retried.
+// Other errors, such as a storage failure, are returned so the job is retried. Cards are drawn with the preferences of
+// the user who started the import, falling back to defaults.
+func ImportActivity(repo repository.ReadWriter, ctx context.Context, mapStyles domain.MapStyles, cardTemplates domain.CardTemplates, defaults domain.UserPreferences, importID domain.ID, externalID string) error {
+	imp, err := repo.GetImport(ctx, importID)
+	if err != nil {
+		return fmt.Errorf("can't find import %s: %w", importID, err)
+	}
+
+	item, err := repo.GetImportItem(ctx, importID, externalID)
+	if err != nil {
+		return fmt.Errorf("can't find item %s of import %s: %w", externalID, importID, err)
+	}
+
+	if !item.IsPending() {
+		return nil
+	}
+
+	prefs, err := GetUserPreferences(repo, ctx, imp.Username, defaults)
+	if err != nil {
+		return err
+	}
+
+	outcome, err := importActivity(repo, ctx, mapStyles, cardTemplates, prefs, imp, item)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.UpdateImportItem(ctx, outcome); err != nil {
+		return fmt.Errorf("can't update item %s of import %s: %w", externalID, importID, err)
+	}
+
+	return nil
+}
+
+func importActivity(repo repository.ReadWriter, ctx context.Context, mapStyles domain.MapStyles, cardTemplates domain.CardTemplates, prefs domain.UserPreferences, imp domain.Import, item domain.ImportItem) (domain.ImportItem, error) {
+	slug, err := domain.NewRunnningActivitySlugFromTime(item.RanAt)
+	if err != nil {
+		return item.Fail(fmt.Sprintf("can't build activity slug: %v", err)), nil
+	}
+
+	_, err = repo.GetRunningActivity(ctx, slug)
+	if err == nil {
+		return item.Skip("an activity already exists at this time"), nil
+	}
+	if !errors.Is(err, domain.ErrCantGetRunningSession) {
+		return domain.ImportItem{}, fmt.Errorf("can't check existing activity %s: %w", slug, err)
+	}
+
+	file, err := repo.OpenImportItemFile(ctx, imp, item)
+	if errors.Is(err, domain.ErrUnsupportedActivityFormat) {
+		return item.Skip(err.Error()), nil
+	}
+	if err != nil {
+		return item.Fail(err.Error()), nil
+	}
+	defer file.Close()
+
+	err = TrackRunningSession(repo, ctx, mapStyles, cardTemplates, prefs, imp.Username, item.RanAt, item.Details(), file)
+	if errors.Is(err, domain.ErrRunningActivityAlreadyExists) {
+		return item.Skip("an activity already exists at this time"), nil
+	}
+	if errors.Is(err, domain.ErrInvalidActivityFile) {
+		return item.Fail(err.Error()), nil
+	}
+	if err != nil {
+		return domain.ImportItem{}, fmt.Errorf("can't track activity of item %s: %w", item.ExternalID, err)
+	}
+
+	return item.Imported(), nil
+}
@@ -0,0 +1,174 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestImportActivitySuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	imp := domaintest.NewImport(t).WithUsername("alice").Persist(repo)
+	item := domaintest.NewImportItem(t, imp.ID).WithRawRanAt("2022-04-10T07:30:00Z").Persist(repo)
+
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+	activity := domaintest.NewRunningActivity(t).
+		WithRawSlug("202204100730").
+		WithDistanceMeters(gpxFile.Distance.Meters()).
+		WithDuration(gpxFile.Duration).
+		WithSpeedKmh(gpxFile.Speed.KilometersPerHour()).
+		WithDetails(item.Name, item.Description).
+		WithUsername("alice").
+		Build()
+
+	repo.OverrideOpenImportItemFile(item.ExternalID, gpxFileBytes, nil)
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.ExpectRecordActivities(activity)
+	repo.ExpectImportItems(item.Imported())
+
+	err := service.ImportActivity(repo, context.Background(), domain.MapStyles{Default: domain.DefaultMapStyle()}, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), imp.ID, item.ExternalID)
+	testutils.AssertNoError(t, err, "can't import activity")
+}
+
+func TestImportActivityAlreadyProcessed(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	imp := domaintest.NewImport(t).Persist(repo)
+	item := domaintest.NewImportItem(t, imp.ID).WithStatus(domain.ImportItemStatusImported).Persist(repo)
+
+	repo.ExpectRecordActivities()
+	repo.ExpectImportItems(item)
+
+	err := service.ImportActivity(repo, context.Background(), domain.MapStyles{Default: domain.DefaultMapStyle()}, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), imp.ID, item.ExternalID)
+	testutils.AssertNoError(t, err, "can't import activity")
+}
+
+func TestImportActivityExistingActivity(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewRunningActivity(t).WithRawSlug("202204100730").Persist(repo)
+	imp := domaintest.NewImport(t).Persist(repo)
+	item := domaintest.NewImportItem(t, imp.ID).WithRawRanAt("2022-04-10T07:30:42Z").Persist(repo)
+
+	repo.ExpectImportItems(item.Skip("an activity already exists at this time"))
+
+	err := service.ImportActivity(repo, context.Background(), domain.MapStyles{Default: domain.DefaultMapStyle()}, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), imp.ID, item.ExternalID)
+	testutils.AssertNoError(t, err, "can't import activity")
+}
+
+func TestImportActivityUnsupportedFormat(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	imp := domaintest.NewImport(t).Persist(repo)
+	item := domaintest.NewImportItem(t, imp.ID).WithFileName("activities/1.fit.gz").Persist(repo)
+
+	repo.OverrideOpenImportItemFile(item.ExternalID, nil, domain.ErrUnsupportedActivityFormat)
+	repo.ExpectImportItems(item.Skip(domain.ErrUnsupportedActivityFormat.Error()))
+
+	err := service.ImportActivity(repo, context.Background(), domain.MapStyles{Default: domain.DefaultMapStyle()}, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), imp.ID, item.ExternalID)
+	testutils.AssertNoError(t, err, "can't import activity")
+}
+
+func TestImportActivityInvalidFile(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	imp := domaintest.NewImport(t).Persist(repo)
+	item := domaintest.NewImportItem(t, imp.ID).Persist(repo)
+	content := []byte("not a gpx file")
+
+	repo.OverrideOpenImportItemFile(item.ExternalID, content, nil)
+	repo.OverrideCleanGPXFile(content, domain.GPXFile{}, errors.New("boom"))
+	repo.ExpectImportItems(item.Fail("can't load gpx file: invalid activity file: boom"))
+
+	err := service.ImportActivity(repo, context.Background(), domain.MapStyles{Default: domain.DefaultMapStyle()}, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), imp.ID, item.ExternalID)
+	testutils.AssertNoError(t, err, "can't import activity")
+}
+
+func TestImportActivityMapProviderUnavailable(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	imp := domaintest.NewImport(t).Persist(repo)
+	item := domaintest.NewImportItem(t, imp.ID).WithRawRanAt("2022-04-10T07:30:00Z").Persist(repo)
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+	mapErr := fmt.Errorf("rate limited: %w", domain.ErrMapProviderUnavailable)
+
+	activity := domaintest.NewRunningActivity(t).
+		WithRawSlug("202204100730").
+		WithDistanceMeters(gpxFile.Distance.Meters()).
+		WithDuration(gpxFile.Duration).
+		WithSpeedKmh(gpxFile.Speed.KilometersPerHour()).
+		WithDetails(item.Name, item.Description).
+		WithPendingMap(fmt.Sprintf("can't generate image from gpx: %v", mapErr)).
+		Build()
+
+	repo.OverrideOpenImportItemFile(item.ExternalID, gpxFileBytes, nil)
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.OverrideGenerateMap(gpxFile, domain.MapFile{}, mapErr)
+	repo.ExpectRecordActivities(activity)
+	repo.ExpectImportItems(item.Imported())
+
+	err := service.ImportActivity(repo, context.Background(), domain.MapStyles{Default: domain.DefaultMapStyle()}, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), imp.ID, item.ExternalID)
+	testutils.AssertNoError(t, err, "can't import activity")
+}
+
+func TestImportActivityStorageFailure(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	imp := domaintest.NewImport(t).Persist(repo)
+	item := domaintest.NewImportItem(t, imp.ID).WithRawRanAt("2022-04-10T07:30:00Z").Persist(repo)
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202204100730").Build()
+
+	repo.OverrideOpenImportItemFile(item.ExternalID, gpxFileBytes, nil)
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.OverrideStoreAsset(activity.GPXPath.String(), errors.New("s3 is down"))
+	repo.ExpectRecordActivities()
+	repo.ExpectImportItems(item)
+
+	err := service.ImportActivity(repo, context.Background(), domain.MapStyles{Default: domain.DefaultMapStyle()}, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), imp.ID, item.ExternalID)
+	testutils.AssertErrorContains(t, "s3 is down", err, "storage failures should be retried")
+}
+
+func TestImportActivityRecordedMeanwhile(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	imp := domaintest.NewImport(t).Persist(repo)
+	item := domaintest.NewImportItem(t, imp.ID).WithRawRanAt("2022-04-10T07:30:00Z").Persist(repo)
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+	slug, err := domain.NewRunnningActivitySlugFromString("202204100730")
+	testutils.RequireNoError(t, err, "can't build slug")
+
+	repo.OverrideOpenImportItemFile(item.ExternalID, gpxFileBytes, nil)
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.OverrideRecordActivity(slug, fmt.Errorf("can't record activity: %w", domain.ErrRunningActivityAlreadyExists))
+	repo.ExpectImportItems(item.Skip("an activity already exists at this time"))
+
+	err = service.ImportActivity(repo, context.Background(), domain.MapStyles{Default: domain.DefaultMapStyle()}, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), imp.ID, item.ExternalID)
+	testutils.AssertNoError(t, err, "can't import activity")
+}
+
+func TestImportActivityCannotUpdateItem(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	imp := domaintest.NewImport(t).Persist(repo)
+	item := domaintest.NewImportItem(t, imp.ID).Persist(repo)
+
+	repo.OverrideOpenImportItemFile(item.ExternalID, nil, domain.ErrUnsupportedActivityFormat)
+	repo.OverrideUpdateImportItem(item.ExternalID, errors.New("boom"))
+
+	err := service.ImportActivity(repo, context.Background(), domain.MapStyles{Default: domain.DefaultMapStyle()}, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), imp.ID, item.ExternalID)
+
+	testutils.AssertErrorContains(t, "can't update item", err, "unexpected error")
+}
+
+func TestImportActivityItemNotFound(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	imp := domaintest.NewImport(t).Persist(repo)
+
+	err := service.ImportActivity(repo, context.Background(), domain.MapStyles{Default: domain.DefaultMapStyle()}, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), imp.ID, "unknown")
+
+	testutils.AssertErrorIs(t, domain.ErrImportNotFound, err, "unexpected error")
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+func ListAPITokens(repo repository.Reader, ctx context.Context, username string) ([]domain.APIToken, error) {
+	tokens, err := repo.ListAPITokens(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("can't list api tokens of user %s: %w", username, err)
+	}
+
+	return tokens, nil
+}
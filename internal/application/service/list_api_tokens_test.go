@@ -0,0 +1,33 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestListAPITokensSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	expected := domaintest.NewAPIToken(t).Persist(repo)
+	domaintest.NewAPIToken(t).WithUsername("bob").Persist(repo)
+
+	tokens, err := service.ListAPITokens(repo, context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't list api tokens")
+
+	testutils.AssertEqualInt(t, 1, len(tokens), "unexpected number of tokens")
+	domaintest.AssertEqualAPIToken(t, expected, tokens[0], "unexpected token")
+}
+
+func TestListAPITokensError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	repo.OverrideListAPITokens(errors.New("boom"))
+
+	_, err := service.ListAPITokens(repo, context.Background(), "alice")
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+func ListExports(repo repository.Reader, ctx context.Context, username string) ([]domain.Export, error) {
+	return repo.ListExports(ctx, username)
+}
@@ -0,0 +1,27 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestListExportsSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	export1 := domaintest.NewExport(t).WithUsername("alice").WithRequestedAt(now.Add(-time.Hour)).Persist(repo)
+	export2 := domaintest.NewExport(t).WithUsername("alice").WithRequestedAt(now).Persist(repo)
+	domaintest.NewExport(t).WithUsername("bob").WithRequestedAt(now).Persist(repo)
+
+	exports, err := service.ListExports(repo, context.Background(), "alice")
+
+	testutils.AssertNoError(t, err, "can't list exports")
+	testutils.AssertEqualInt(t, 2, len(exports), "unexpected number of exports")
+	domaintest.AssertEqualExport(t, export2, exports[0], "unexpected export")
+	domaintest.AssertEqualExport(t, export1, exports[1], "unexpected export")
+}
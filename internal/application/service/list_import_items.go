@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+func ListImportItems(repo repository.Reader, ctx context.Context, importID domain.ID) ([]domain.ImportItem, error) {
+	return repo.ListImportItems(ctx, importID)
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+func ListImports(repo repository.Reader, ctx context.Context, username string) ([]domain.Import, error) {
+	return repo.ListImports(ctx, username)
+}
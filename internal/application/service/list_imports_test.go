@@ -0,0 +1,45 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestListImportsSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	import1 := domaintest.NewImport(t).WithUsername("alice").WithCreatedAt(now.Add(-time.Hour)).Persist(repo)
+	import2 := domaintest.NewImport(t).WithUsername("alice").WithCreatedAt(now).Persist(repo)
+	domaintest.NewImport(t).WithUsername("bob").WithCreatedAt(now).Persist(repo)
+	domaintest.NewImportItem(t, import2.ID).WithStatus(domain.ImportItemStatusImported).Persist(repo)
+	import2.Progress.Imported = 1
+
+	imports, err := service.ListImports(repo, context.Background(), "alice")
+
+	testutils.AssertNoError(t, err, "can't list imports")
+	testutils.AssertEqualInt(t, 2, len(imports), "unexpected number of imports")
+	domaintest.AssertEqualImport(t, import2, imports[0], "unexpected import")
+	domaintest.AssertEqualImport(t, import1, imports[1], "unexpected import")
+}
+
+func TestListImportItemsSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	imp := domaintest.NewImport(t).Persist(repo)
+	item1 := domaintest.NewImportItem(t, imp.ID).WithRawRanAt("2022-03-03T08:00:00Z").Persist(repo)
+	item2 := domaintest.NewImportItem(t, imp.ID).WithRawRanAt("2022-01-01T08:00:00Z").Persist(repo)
+	domaintest.NewImportItem(t, domain.NewID()).Persist(repo)
+
+	items, err := service.ListImportItems(repo, context.Background(), imp.ID)
+
+	testutils.AssertNoError(t, err, "can't list import items")
+	testutils.AssertEqualInt(t, 2, len(items), "unexpected number of import items")
+	domaintest.AssertEqualImportItem(t, item2, items[0], "unexpected import item")
+	domaintest.AssertEqualImportItem(t, item1, items[1], "unexpected import item")
+}
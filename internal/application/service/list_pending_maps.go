@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// ListPendingMaps returns the activities recorded without their map, most recent first
+func ListPendingMaps(repo repository.Reader, ctx context.Context) ([]domain.RunningActivity, error) {
+	activities, err := repo.ListRunningActivities(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't list activities: %v", err)
+	}
+
+	var pending []domain.RunningActivity
+	for _, activity := range activities {
+		if activity.IsMapPending() {
+			pending = append(pending, activity)
+		}
+	}
+
+	return pending, nil
+}
@@ -0,0 +1,33 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestListPendingMapsSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewRunningActivity(t).WithRawSlug("202101010000").Persist(repo)
+	pending := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithPendingMap("mapbox is down").Persist(repo)
+
+	activities, err := service.ListPendingMaps(repo, context.Background())
+
+	testutils.AssertNoError(t, err, "can't list pending maps")
+	testutils.AssertEqualInt(t, 1, len(activities), "unexpected number of activities")
+	domaintest.AssertEqualRunningActivity(t, pending, activities[0], "unexpected activity")
+}
+
+func TestListPendingMapsError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	repo.OverrideListActivities(errors.New("boom"))
+
+	_, err := service.ListPendingMaps(repo, context.Background())
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
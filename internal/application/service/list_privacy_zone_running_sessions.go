@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// ListPrivacyZoneRunningSessions returns the activities of the owner of the zone whose track crosses it, and so whose
+// map changes when the zone is created or deleted
+func ListPrivacyZoneRunningSessions(repo repository.ReadWriter, ctx context.Context, zone domain.PrivacyZone) ([]domain.RunningActivity, error) {
+	activities, err := repo.ListUserRunningActivities(ctx, zone.Username)
+	if err != nil {
+		return nil, fmt.Errorf("can't list activities of user %s: %w", zone.Username, err)
+	}
+
+	var crossing []domain.RunningActivity
+	for _, activity := range activities {
+		gpx, err := loadGPXFile(repo, ctx, activity)
+		if err != nil {
+			return nil, err
+		}
+
+		if zone.IsCrossedBy(gpx.Points) {
+			crossing = append(crossing, activity)
+		}
+	}
+
+	return crossing, nil
+}
+
+func loadGPXFile(repo repository.ReadWriter, ctx context.Context, activity domain.RunningActivity) (domain.GPXFile, error) {
+	content, err := repo.FetchAsset(activity.GPXPath.String())
+	if err != nil {
+		return domain.GPXFile{}, fmt.Errorf("can't fetch gpx file of activity %s: %v", activity.Slug, err)
+	}
+	defer content.Close()
+
+	gpx, err := repo.CleanGPXFile(ctx, content)
+	if err != nil {
+		return domain.GPXFile{}, fmt.Errorf("can't load gpx file of activity %s: %v", activity.Slug, err)
+	}
+
+	return gpx, nil
+}
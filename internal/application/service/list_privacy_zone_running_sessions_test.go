@@ -0,0 +1,47 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestListPrivacyZoneRunningSessionsSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	crossingBytes := []byte("crossing gpx")
+	awayBytes := []byte("away gpx")
+	crossingGPX := domaintest.NewGPXFile(t).WithFileContent(crossingBytes).Build()
+	awayGPX := domaintest.NewGPXFile(t).WithFileContent(awayBytes).WithPoints(crossingGPX.Points[1:]).Build()
+
+	crossing := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithUsername("alice").Persist(repo)
+	away := domaintest.NewRunningActivity(t).WithRawSlug("202202030000").WithUsername("alice").Persist(repo)
+	domaintest.NewRunningActivity(t).WithRawSlug("202202040000").WithUsername("bob").Persist(repo)
+	testutils.AssertNoError(t, repo.StoreAsset(bytes.NewBuffer(crossingBytes), crossing.GPXPath.String()), "can't store gpx file")
+	testutils.AssertNoError(t, repo.StoreAsset(bytes.NewBuffer(awayBytes), away.GPXPath.String()), "can't store gpx file")
+	repo.OverrideCleanGPXFile(crossingBytes, crossingGPX, nil)
+	repo.OverrideCleanGPXFile(awayBytes, awayGPX, nil)
+
+	zone := domaintest.NewPrivacyZone(t).WithCentre(crossingGPX.Points[0].Latitude, crossingGPX.Points[0].Longitude).Build()
+
+	activities, err := service.ListPrivacyZoneRunningSessions(repo, context.Background(), zone)
+	testutils.RequireNoError(t, err, "can't list activities crossing the zone")
+
+	testutils.RequireEqualInt(t, 1, len(activities), "unexpected number of activities")
+	testutils.AssertEqualString(t, crossing.Slug.String(), activities[0].Slug.String(), "unexpected activity")
+}
+
+func TestListPrivacyZoneRunningSessionsMissingGPXFile(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+	repo.OverrideFetchAsset(activity.GPXPath.String(), errors.New("boom"))
+
+	_, err := service.ListPrivacyZoneRunningSessions(repo, context.Background(), domaintest.NewPrivacyZone(t).Build())
+
+	testutils.AssertErrorContains(t, "can't fetch gpx file", err, "unexpected error")
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+func ListPrivacyZones(repo repository.Reader, ctx context.Context, username string) (domain.PrivacyZones, error) {
+	zones, err := repo.ListPrivacyZones(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("can't list privacy zones of user %s: %w", username, err)
+	}
+
+	return zones, nil
+}
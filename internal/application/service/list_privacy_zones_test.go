@@ -0,0 +1,33 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestListPrivacyZonesSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	zone := domaintest.NewPrivacyZone(t).Persist(repo)
+	domaintest.NewPrivacyZone(t).WithUsername("bob").Persist(repo)
+
+	zones, err := service.ListPrivacyZones(repo, context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't list privacy zones")
+
+	testutils.RequireEqualInt(t, 1, len(zones), "unexpected number of privacy zones")
+	domaintest.AssertEqualPrivacyZone(t, zone, zones[0], "unexpected privacy zone")
+}
+
+func TestListPrivacyZonesError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	repo.OverrideListPrivacyZones(errors.New("boom"))
+
+	_, err := service.ListPrivacyZones(repo, context.Background(), "alice")
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
@@ -2,11 +2,54 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/lonepeon/sport/internal/domain"
 	"github.com/lonepeon/sport/internal/repository"
 )
 
-func ListRunningSessions(repo repository.Reader, ctx context.Context) ([]domain.RunningActivity, error) {
-	return repo.ListRunningActivities(ctx)
+// ListRunningSessions returns the activities listed to the viewer, from the most recent one: the public ones and the
+// ones they uploaded. An empty viewer is a visitor.
+func ListRunningSessions(repo repository.Reader, ctx context.Context, viewer string) ([]domain.RunningActivity, error) {
+	activities, err := repo.ListRunningActivities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return listedRunningActivities(repo, ctx, viewer, activities)
+}
+
+// listedRunningActivities keeps the activities listed to the viewer
+func listedRunningActivities(repo repository.Reader, ctx context.Context, viewer string, activities []domain.RunningActivity) ([]domain.RunningActivity, error) {
+	user, err := getViewer(repo, ctx, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make([]domain.RunningActivity, 0, len(activities))
+	for _, activity := range activities {
+		if activity.IsListedFor(user) {
+			listed = append(listed, activity)
+		}
+	}
+
+	return listed, nil
+}
+
+// getViewer returns the user browsing activities, or an empty user for visitors and users who no longer exist
+func getViewer(repo repository.Reader, ctx context.Context, username string) (domain.User, error) {
+	if username == "" {
+		return domain.User{}, nil
+	}
+
+	user, err := repo.GetUser(ctx, username)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.User{}, nil
+	}
+	if err != nil {
+		return domain.User{}, fmt.Errorf("can't get user %s: %v", username, err)
+	}
+
+	return user, nil
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/lonepeon/golib/testutils"
 	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
 	"github.com/lonepeon/sport/internal/domain/domaintest"
 	"github.com/lonepeon/sport/internal/repository/repositorytest"
 )
@@ -16,7 +17,7 @@ func TestListRunningSessionsSuccess(t *testing.T) {
 	activity2 := domaintest.NewRunningActivity(t).WithRawSlug("202303030000").Persist(repo)
 	activity3 := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").Persist(repo)
 
-	actualActivities, err := service.ListRunningSessions(repo, context.Background())
+	actualActivities, err := service.ListRunningSessions(repo, context.Background(), "")
 
 	testutils.AssertNoError(t, err, "can't get running sessions")
 	testutils.AssertEqualInt(t, 3, len(actualActivities), "unexpected number of activities")
@@ -29,8 +30,41 @@ func TestListRunningSessionsSuccess(t *testing.T) {
 func TestListRunningSessionsNoEntries(t *testing.T) {
 	repo := repositorytest.NewFake(t)
 
-	actualActivities, err := service.ListRunningSessions(repo, context.Background())
+	actualActivities, err := service.ListRunningSessions(repo, context.Background(), "")
 
 	testutils.AssertNoError(t, err, "can't get running sessions")
 	testutils.AssertEqualInt(t, 0, len(actualActivities), "unexpected number of activities")
 }
+
+func TestListRunningSessionsVisibility(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	public := domaintest.NewRunningActivity(t).WithRawSlug("202101010000").WithUsername("alice").Persist(repo)
+	unlisted := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithUsername("alice").
+		WithVisibility(domain.ActivityVisibilityUnlisted).Persist(repo)
+	private := domaintest.NewRunningActivity(t).WithRawSlug("202303030000").WithUsername("alice").
+		WithVisibility(domain.ActivityVisibilityPrivate).Persist(repo)
+
+	ownerActivities, err := service.ListRunningSessions(repo, context.Background(), "alice")
+	testutils.AssertNoError(t, err, "can't get running sessions of the owner")
+	testutils.RequireEqualInt(t, 3, len(ownerActivities), "unexpected number of activities listed to the owner")
+	domaintest.AssertEqualRunningActivity(t, private, ownerActivities[0], "unexpected activity")
+	domaintest.AssertEqualRunningActivity(t, unlisted, ownerActivities[1], "unexpected activity")
+
+	visitorActivities, err := service.ListRunningSessions(repo, context.Background(), "")
+	testutils.AssertNoError(t, err, "can't get running sessions of a visitor")
+	testutils.RequireEqualInt(t, 1, len(visitorActivities), "unexpected number of activities listed to a visitor")
+	domaintest.AssertEqualRunningActivity(t, public, visitorActivities[0], "unexpected activity")
+}
+
+func TestListRunningSessionsUnknownViewer(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	public := domaintest.NewRunningActivity(t).WithUsername("alice").Persist(repo)
+	domaintest.NewRunningActivity(t).WithUsername("alice").WithVisibility(domain.ActivityVisibilityPrivate).Persist(repo)
+
+	actualActivities, err := service.ListRunningSessions(repo, context.Background(), "ghost")
+
+	testutils.AssertNoError(t, err, "can't get running sessions")
+	testutils.RequireEqualInt(t, 1, len(actualActivities), "unexpected number of activities")
+	domaintest.AssertEqualRunningActivity(t, public, actualActivities[0], "unexpected activity")
+}
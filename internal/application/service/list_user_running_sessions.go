@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// ListUserRunningSessions returns the activities uploaded by the athlete and listed to the viewer, from the most
+// recent one
+func ListUserRunningSessions(repo repository.Reader, ctx context.Context, viewer string, username string) ([]domain.RunningActivity, error) {
+	activities, err := repo.ListUserRunningActivities(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return listedRunningActivities(repo, ctx, viewer, activities)
+}
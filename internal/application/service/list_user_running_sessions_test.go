@@ -0,0 +1,56 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestListUserRunningSessionsSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	activity1 := domaintest.NewRunningActivity(t).WithRawSlug("202101010000").WithUsername("alice").Persist(repo)
+	domaintest.NewRunningActivity(t).WithRawSlug("202303030000").WithUsername("bob").Persist(repo)
+	activity3 := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithUsername("alice").Persist(repo)
+
+	actualActivities, err := service.ListUserRunningSessions(repo, context.Background(), "", "alice")
+
+	testutils.AssertNoError(t, err, "can't get running sessions")
+	testutils.RequireEqualInt(t, 2, len(actualActivities), "unexpected number of activities")
+
+	domaintest.AssertEqualRunningActivity(t, activity3, actualActivities[0], "unexpected activity")
+	domaintest.AssertEqualRunningActivity(t, activity1, actualActivities[1], "unexpected activity")
+}
+
+func TestListUserRunningSessionsNoEntries(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewRunningActivity(t).WithUsername("bob").Persist(repo)
+
+	actualActivities, err := service.ListUserRunningSessions(repo, context.Background(), "", "alice")
+
+	testutils.AssertNoError(t, err, "can't get running sessions")
+	testutils.AssertEqualInt(t, 0, len(actualActivities), "unexpected number of activities")
+}
+
+func TestListUserRunningSessionsHidesUnlistedToOtherUsers(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	domaintest.NewUser(t).WithUsername("bob").Persist(repo)
+	public := domaintest.NewRunningActivity(t).WithRawSlug("202101010000").WithUsername("alice").Persist(repo)
+	unlisted := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithUsername("alice").
+		WithVisibility(domain.ActivityVisibilityUnlisted).Persist(repo)
+
+	ownerActivities, err := service.ListUserRunningSessions(repo, context.Background(), "alice", "alice")
+	testutils.AssertNoError(t, err, "can't get running sessions of the owner")
+	testutils.RequireEqualInt(t, 2, len(ownerActivities), "unexpected number of activities listed to the owner")
+	domaintest.AssertEqualRunningActivity(t, unlisted, ownerActivities[0], "unexpected activity")
+
+	otherActivities, err := service.ListUserRunningSessions(repo, context.Background(), "bob", "alice")
+	testutils.AssertNoError(t, err, "can't get running sessions of another athlete")
+	testutils.RequireEqualInt(t, 1, len(otherActivities), "unexpected number of activities listed to another athlete")
+	domaintest.AssertEqualRunningActivity(t, public, otherActivities[0], "unexpected activity")
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// ListUsers returns all the accounts, sorted by username
+func ListUsers(repo repository.Reader, ctx context.Context) ([]domain.User, error) {
+	users, err := repo.ListUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't list users: %w", err)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,34 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestListUsersSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	bob := domaintest.NewUser(t).WithUsername("bob").Persist(repo)
+	alice := domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	users, err := service.ListUsers(repo, context.Background())
+	testutils.RequireNoError(t, err, "can't list users")
+
+	testutils.RequireEqualInt(t, 2, len(users), "unexpected number of users")
+	domaintest.AssertEqualUser(t, alice, users[0], "unexpected first user")
+	domaintest.AssertEqualUser(t, bob, users[1], "unexpected second user")
+}
+
+func TestListUsersError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	repo.OverrideListUsers(errors.New("boom"))
+
+	_, err := service.ListUsers(repo, context.Background())
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
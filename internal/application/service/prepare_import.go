@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// PrepareImport extracts the archive, records its activities and returns the ones still waiting to be imported.
+//
+// Running it again on the same import keeps the progress of the already recorded activities.
+func PrepareImport(repo repository.ReadWriter, ctx context.Context, id domain.ID) ([]domain.ImportItem, error) {
+	imp, err := repo.GetImport(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("can't find import %s: %w", id, err)
+	}
+
+	items, err := repo.ExtractStravaArchive(ctx, imp)
+	if err != nil {
+		return nil, fmt.Errorf("can't extract archive of import %s: %w", id, err)
+	}
+
+	for i, item := range items {
+		if !item.IsPending() {
+			continue
+		}
+
+		if !item.IsRun() {
+			items[i] = item.Skip(fmt.Sprintf("%s is not a run", item.Type))
+		} else if item.FileName == "" {
+			items[i] = item.Skip("activity has no recorded track")
+		}
+	}
+
+	if err := repo.RecordImportItems(ctx, items); err != nil {
+		return nil, fmt.Errorf("can't record items of import %s: %w", id, err)
+	}
+
+	recorded, err := repo.ListImportItems(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("can't list items of import %s: %w", id, err)
+	}
+
+	var pending []domain.ImportItem
+	for _, item := range recorded {
+		if item.IsPending() {
+			pending = append(pending, item)
+		}
+	}
+
+	return pending, nil
+}
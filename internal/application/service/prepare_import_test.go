@@ -0,0 +1,68 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestPrepareImportSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	imp := domaintest.NewImport(t).Persist(repo)
+	run := domaintest.NewImportItem(t, imp.ID).WithRawRanAt("2022-04-10T07:30:00Z").Build()
+	ride := domaintest.NewImportItem(t, imp.ID).WithType("Ride").Build()
+	treadmill := domaintest.NewImportItem(t, imp.ID).WithFileName("").Build()
+	broken := domaintest.NewImportItem(t, imp.ID).Build().Fail("can't parse activity date")
+
+	repo.OverrideExtractStravaArchive(imp.ID, []domain.ImportItem{run, ride, treadmill, broken}, nil)
+	repo.ExpectImportItems(run, ride.Skip("Ride is not a run"), treadmill.Skip("activity has no recorded track"), broken)
+
+	pending, err := service.PrepareImport(repo, context.Background(), imp.ID)
+
+	testutils.AssertNoError(t, err, "can't prepare import")
+	testutils.RequireEqualInt(t, 1, len(pending), "unexpected number of pending items")
+	domaintest.AssertEqualImportItem(t, run, pending[0], "unexpected pending item")
+}
+
+func TestPrepareImportResume(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	imp := domaintest.NewImport(t).Persist(repo)
+	imported := domaintest.NewImportItem(t, imp.ID).WithStatus(domain.ImportItemStatusImported).Persist(repo)
+	remaining := domaintest.NewImportItem(t, imp.ID).Persist(repo)
+
+	extracted := imported
+	extracted.Status = domain.ImportItemStatusPending
+	repo.OverrideExtractStravaArchive(imp.ID, []domain.ImportItem{extracted, remaining}, nil)
+	repo.ExpectImportItems(imported, remaining)
+
+	pending, err := service.PrepareImport(repo, context.Background(), imp.ID)
+
+	testutils.AssertNoError(t, err, "can't prepare import")
+	testutils.RequireEqualInt(t, 1, len(pending), "unexpected number of pending items")
+	domaintest.AssertEqualImportItem(t, remaining, pending[0], "unexpected pending item")
+}
+
+func TestPrepareImportNotFound(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	_, err := service.PrepareImport(repo, context.Background(), domain.NewID())
+
+	testutils.AssertErrorIs(t, domain.ErrImportNotFound, err, "unexpected error")
+}
+
+func TestPrepareImportCannotExtractArchive(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	imp := domaintest.NewImport(t).Persist(repo)
+
+	repo.OverrideExtractStravaArchive(imp.ID, nil, errors.New("boom"))
+
+	_, err := service.PrepareImport(repo, context.Background(), imp.ID)
+
+	testutils.AssertErrorContains(t, "can't extract archive", err, "unexpected error")
+}
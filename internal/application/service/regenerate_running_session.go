@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// RegenerateRunningSession generates the map, cards and charts of the activity again from its GPX file, drawing the
+// cards with the current templates and the preferences of the owner, falling back to defaults. Like GeneratePendingMaps, a failed generation is stored on the activity, which
+// stays pending until the next run.
+func RegenerateRunningSession(repo repository.ReadWriter, ctx context.Context, cardTemplates domain.CardTemplates, defaults domain.UserPreferences, slug domain.RunningActivitySlug) error {
+	activity, err := repo.GetRunningActivity(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("can't find run activity %s: %w", slug, err)
+	}
+
+	if err := generatePendingMap(repo, ctx, cardTemplates, defaults, activity); err != nil {
+		if err := repo.UpdateRunningActivity(ctx, activity.WithPendingMap(err.Error())); err != nil {
+			return fmt.Errorf("can't record map failure of activity %s: %v", activity.Slug, err)
+		}
+	}
+
+	return nil
+}
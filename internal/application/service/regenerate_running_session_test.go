@@ -0,0 +1,77 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestRegenerateRunningSessionSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").Persist(repo)
+	testutils.AssertNoError(t, repo.StoreAsset(bytes.NewBuffer(gpxFileBytes), activity.GPXPath.String()), "can't store gpx file")
+
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.ExpectGenerateMaps(gpxFile)
+	repo.ExpectStoreAssets(activity.MapPath.String(), activity.ShareableMapPath.String())
+	repo.ExpectStoreAssets(activity.ElevationChartPath.PNG(), activity.PaceChartPath.SVG())
+	repo.ExpectRecordActivities(activity.WithReadyMap())
+
+	err := service.RegenerateRunningSession(repo, context.Background(), domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), activity.Slug)
+	testutils.AssertNoError(t, err, "can't regenerate activity")
+}
+
+func TestRegenerateRunningSessionNotFound(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	slug := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").Build().Slug
+
+	err := service.RegenerateRunningSession(repo, context.Background(), domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), slug)
+	testutils.AssertErrorIs(t, domain.ErrCantGetRunningSession, err, "unexpected error")
+}
+
+func TestRegenerateRunningSessionFailure(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").Persist(repo)
+	testutils.AssertNoError(t, repo.StoreAsset(bytes.NewBuffer(gpxFileBytes), activity.GPXPath.String()), "can't store gpx file")
+
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.OverrideGenerateMap(gpxFile, domain.MapFile{}, errors.New("invalid token"))
+	repo.ExpectRecordActivities(activity.WithPendingMap("can't generate image from gpx: invalid token"))
+
+	err := service.RegenerateRunningSession(repo, context.Background(), domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), activity.Slug)
+	testutils.AssertNoError(t, err, "failures should be recorded on the activity")
+}
+
+func TestRegenerateRunningSessionPrivacyZones(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithUsername("alice").Persist(repo)
+	testutils.AssertNoError(t, repo.StoreAsset(bytes.NewBuffer(gpxFileBytes), activity.GPXPath.String()), "can't store gpx file")
+	domaintest.NewPrivacyZone(t).WithCentre(gpxFile.Points[2].Latitude, gpxFile.Points[2].Longitude).Persist(repo)
+
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.ExpectRecordActivities(activity.WithReadyMap())
+
+	// zones are the ones of the owner, whoever requested the regeneration
+	err := service.RegenerateRunningSession(repo, context.Background(), domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), activity.Slug)
+	testutils.AssertNoError(t, err, "can't regenerate activity")
+
+	maps := repo.GeneratedMapPoints()
+	testutils.RequireEqualInt(t, 1, len(maps), "unexpected number of maps")
+	testutils.AssertEqualInt(t, 2, len(maps[0]), "points inside the privacy zone of the owner shouldn't be drawn")
+}
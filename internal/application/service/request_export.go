@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+func RequestExport(repo repository.Writer, ctx context.Context, username string, now time.Time) (domain.Export, error) {
+	export := domain.NewExport(username, now)
+	if err := repo.RecordExport(ctx, export); err != nil {
+		return domain.Export{}, fmt.Errorf("can't record export: %w", err)
+	}
+
+	return export, nil
+}
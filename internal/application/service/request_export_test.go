@@ -0,0 +1,36 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestRequestExportSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	now := time.Date(2022, 4, 17, 9, 0, 0, 0, time.UTC)
+
+	export, err := service.RequestExport(repo, context.Background(), "alice", now)
+	testutils.AssertNoError(t, err, "can't request export")
+
+	testutils.AssertEqualString(t, "alice", export.Username, "unexpected username")
+	testutils.AssertEqualString(t, domain.ExportStatusPending.String(), export.Status.String(), "unexpected status")
+	testutils.AssertEqualTime(t, now, export.RequestedAt, "unexpected requested at")
+
+	repo.ExpectExports(export)
+}
+
+func TestRequestExportCannotRecord(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	repo.OverrideRecordExport(errors.New("boom"))
+
+	_, err := service.RequestExport(repo, context.Background(), "alice", time.Now())
+
+	testutils.AssertErrorContains(t, "can't record export", err, "unexpected error")
+}
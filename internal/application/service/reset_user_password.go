@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// ResetUserPassword replaces the password of the user by a generated one, returned so it can be given to the user
+func ResetUserPassword(repo repository.ReadWriter, ctx context.Context, username string) (string, error) {
+	user, err := repo.GetUser(ctx, username)
+	if err != nil {
+		return "", fmt.Errorf("can't get user %s: %w", username, err)
+	}
+
+	password, err := domain.GenerateUserPassword()
+	if err != nil {
+		return "", err
+	}
+
+	if err := user.ChangePassword(password); err != nil {
+		return "", fmt.Errorf("can't change password of user %s: %w", username, err)
+	}
+
+	if err := repo.UpdateUser(ctx, user); err != nil {
+		return "", fmt.Errorf("can't update user %s: %w", username, err)
+	}
+
+	return password, nil
+}
@@ -0,0 +1,44 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestResetUserPasswordSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	password, err := service.ResetUserPassword(repo, context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't reset password")
+
+	stored, err := repo.GetUser(context.Background(), "alice")
+	testutils.RequireNoError(t, err, "can't get stored user")
+	testutils.AssertEqualBool(t, true, stored.VerifyPassword(password), "generated password should be valid")
+	testutils.AssertEqualBool(t, false, stored.VerifyPassword(domaintest.UserPassword), "old password shouldn't be valid")
+}
+
+func TestResetUserPasswordNotFound(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	_, err := service.ResetUserPassword(repo, context.Background(), "alice")
+
+	testutils.AssertErrorIs(t, domain.ErrUserNotFound, err, "unexpected error")
+}
+
+func TestResetUserPasswordUpdateError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	repo.OverrideUpdateUser(errors.New("boom"))
+
+	_, err := service.ResetUserPassword(repo, context.Background(), "alice")
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// RevokeAPIToken deletes the token so it can't authenticate anymore. Tokens of other users are reported as not found.
+func RevokeAPIToken(repo repository.Writer, ctx context.Context, username string, id domain.ID) error {
+	if err := repo.DeleteAPIToken(ctx, username, id); err != nil {
+		return fmt.Errorf("can't revoke api token %s: %w", id, err)
+	}
+
+	return nil
+}
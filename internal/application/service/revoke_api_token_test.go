@@ -0,0 +1,34 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestRevokeAPITokenSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	token := domaintest.NewAPIToken(t).Persist(repo)
+
+	err := service.RevokeAPIToken(repo, context.Background(), "alice", token.ID)
+	testutils.RequireNoError(t, err, "can't revoke api token")
+
+	_, err = repo.GetAPITokenByHash(context.Background(), token.Hash)
+	testutils.AssertErrorIs(t, domain.ErrAPITokenNotFound, err, "expected token to be deleted")
+}
+
+func TestRevokeAPITokenOfAnotherUser(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	token := domaintest.NewAPIToken(t).WithUsername("bob").Persist(repo)
+
+	err := service.RevokeAPIToken(repo, context.Background(), "alice", token.ID)
+	testutils.AssertErrorIs(t, domain.ErrAPITokenNotFound, err, "unexpected error")
+
+	_, err = repo.GetAPITokenByHash(context.Background(), token.Hash)
+	testutils.AssertNoError(t, err, "expected token to be kept")
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+func StartImport(repo repository.Writer, ctx context.Context, username string, archivePath string, now time.Time) (domain.Import, error) {
+	imp := domain.NewImport(username, archivePath, now)
+	if err := repo.RecordImport(ctx, imp); err != nil {
+		return domain.Import{}, fmt.Errorf("can't record import: %w", err)
+	}
+
+	return imp, nil
+}
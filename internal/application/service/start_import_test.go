@@ -0,0 +1,36 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestStartImportSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	now := time.Date(2022, 4, 18, 9, 0, 0, 0, time.UTC)
+
+	imp, err := service.StartImport(repo, context.Background(), "alice", "uploads/export.zip", now)
+	testutils.AssertNoError(t, err, "can't start import")
+
+	testutils.AssertEqualString(t, "uploads/export.zip", imp.ArchivePath, "unexpected archive path")
+	testutils.AssertEqualTime(t, now, imp.CreatedAt, "unexpected creation time")
+	testutils.AssertEqualString(t, "alice", imp.Username, "unexpected owner")
+	repo.ExpectImports(imp)
+}
+
+func TestStartImportCannotRecord(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	repo.OverrideRecordImport(errors.New("boom"))
+
+	_, err := service.StartImport(repo, context.Background(), "alice", "uploads/export.zip", time.Now())
+
+	testutils.AssertErrorContains(t, "can't record import", err, "unexpected error")
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
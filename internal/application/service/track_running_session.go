@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"path"
@@ -11,26 +12,36 @@ import (
 	"github.com/lonepeon/sport/internal/repository"
 )
 
-func TrackRunningSession(repo repository.Writer, ctx context.Context, when time.Time, gpxFile io.Reader) error {
+// TrackRunningSession records the activity of the GPX file. When its map can't be generated, the activity is still
+// recorded with a pending map, generated later by GeneratePendingMaps. The activity belongs to its uploader, whose
+// preferences are used to show the stats on the cards. An activity starting at the same minute as an existing one is
+// rejected with domain.ErrRunningActivityAlreadyExists, since it would share its slug and overwrite its files. It's
+// checked before doing any work, and enforced by the repository when both are recorded at the same time. Files which
+// can't be parsed or describe an impossible activity return a domain.ErrInvalidActivityFile.
+func TrackRunningSession(repo repository.ReadWriter, ctx context.Context, mapStyles domain.MapStyles, cardTemplates domain.CardTemplates, prefs domain.UserPreferences, username string, when time.Time, details domain.RunningActivityDetails, gpxFile io.Reader) error {
+	if err := ensureNoRunningActivityAt(repo, ctx, when); err != nil {
+		return err
+	}
+
 	gpx, err := repo.CleanGPXFile(ctx, gpxFile)
 	if err != nil {
-		return fmt.Errorf("can't load gpx file: %v", err)
+		return fmt.Errorf("can't load gpx file: %w: %v", domain.ErrInvalidActivityFile, err)
 	}
 
-	imageMap, err := repo.GenerateMap(ctx, gpx)
-	if err != nil {
-		return fmt.Errorf("can't generate image from gpx: %v", err)
+	if len(cardTemplates) == 0 {
+		return fmt.Errorf("can't share activity without card templates")
 	}
 
-	shareableMap, err := repo.AnnotateMapWithStats(ctx, imageMap, gpx.Distance, gpx.Speed)
+	basePath, err := domain.NewAssetsFolder(when, details.Visibility)
 	if err != nil {
-		return fmt.Errorf("can't generate shareable image from map: %v", err)
+		return fmt.Errorf("can't build assets folder: %v", err)
 	}
 
-	basePath := path.Join("runs", when.Format("2006-01-02.15h04"))
 	mapPath := path.Join(basePath, "map.png")
-	shareableMapPath := path.Join(basePath, "share-map.png")
+	cards := cardTemplates.Cards(basePath)
 	gpxPath := path.Join(basePath, "run.gpx")
+	elevationChartPath := path.Join(basePath, "elevation")
+	paceChartPath := path.Join(basePath, "pace")
 
 	activity, err := domain.NewRunningActivity(
 		when,
@@ -39,34 +50,114 @@ func TrackRunningSession(repo repository.Writer, ctx context.Context, when time.
 		gpx.Speed,
 		domain.GPXFilePath(gpxPath),
 		domain.MapFilePath(mapPath),
-		domain.ShareableMapFilePath(shareableMapPath),
+		cards[0].Path,
 	)
 	if err != nil {
-		return fmt.Errorf("can't build activity: %v", err)
+		return fmt.Errorf("can't build activity: %w: %v", domain.ErrInvalidActivityFile, err)
 	}
+	activity = activity.
+		WithDetails(details).
+		WithOwner(username).
+		WithMapStyle(mapStyles.For(details.Type)).
+		WithCards(cards).
+		WithCharts(domain.ElevationChartFilePath(elevationChartPath), domain.PaceChartFilePath(paceChartPath))
 
-	assets := map[string]io.Reader{
-		activity.MapPath.String():          imageMap.File(),
-		activity.ShareableMapPath.String(): shareableMap.File(),
-		activity.GPXPath.String():          gpx.File(),
+	if err := repo.StoreAsset(gpx.File(), activity.GPXPath.String()); err != nil {
+		return fmt.Errorf("can't store gpx file (path=%s): %v", activity.GPXPath, err)
 	}
 
-	if err := uploadPNGs(repo, assets); err != nil {
-		return err
+	if err := generateAssets(repo, ctx, cardTemplates, prefs, activity, gpx); err != nil {
+		activity = activity.WithPendingMap(err.Error())
 	}
 
 	err = repo.RecordRunningActivity(ctx, activity)
 	if err != nil {
-		return fmt.Errorf("can't persists run: %v", err)
+		return fmt.Errorf("can't persists run: %w", err)
+	}
+
+	return nil
+}
+
+func ensureNoRunningActivityAt(repo repository.Reader, ctx context.Context, when time.Time) error {
+	slug, err := domain.NewRunnningActivitySlugFromTime(when)
+	if err != nil {
+		return fmt.Errorf("can't build activity slug: %v", err)
+	}
+
+	_, err = repo.GetRunningActivity(ctx, slug)
+	if err == nil {
+		return fmt.Errorf("can't record activity %s: %w", slug, domain.ErrRunningActivityAlreadyExists)
+	}
+	if !errors.Is(err, domain.ErrCantGetRunningSession) {
+		return fmt.Errorf("can't check existing activity %s: %w", slug, err)
+	}
+
+	return nil
+}
+
+// generateAssets generates and stores the map, the cards and the charts of the activity. The cards of the activity
+// must have been built from the templates.
+//
+// Points inside the privacy zones of the owner aren't drawn on the map, and so on the cards, while stats and charts
+// are computed from the full track.
+func generateAssets(repo repository.ReadWriter, ctx context.Context, cardTemplates domain.CardTemplates, prefs domain.UserPreferences, activity domain.RunningActivity, gpx domain.GPXFile) error {
+	zones, err := repo.ListPrivacyZones(ctx, activity.Username)
+	if err != nil {
+		return fmt.Errorf("can't list privacy zones of user %s: %v", activity.Username, err)
+	}
+
+	mapGPX := gpx.WithoutPointsIn(zones)
+	if len(zones) > 0 && len(mapGPX.Points) == 0 {
+		return fmt.Errorf("can't generate image from gpx: every point is inside a privacy zone")
+	}
+
+	imageMap, err := repo.GenerateMap(ctx, mapGPX, activity.MapStyle)
+	if err != nil {
+		return fmt.Errorf("can't generate image from gpx: %w", err)
 	}
 
+	assets := map[string]io.Reader{activity.MapPath.String(): imageMap.File()}
+
+	stats := domain.NewCardStats(activity, gpx.Points)
+	for i, template := range cardTemplates {
+		card, err := repo.DrawCard(ctx, imageMap, template, stats, prefs)
+		if err != nil {
+			return fmt.Errorf("can't generate %s card from map: %v", template.Name, err)
+		}
+
+		assets[activity.Cards[i].Path.String()] = card.File()
+	}
+
+	if err := drawCharts(repo, ctx, activity, gpx, assets); err != nil {
+		return err
+	}
+
+	return uploadAssets(repo, assets)
+}
+
+func drawCharts(repo repository.Writer, ctx context.Context, activity domain.RunningActivity, gpx domain.GPXFile, assets map[string]io.Reader) error {
+	elevationChart, err := repo.DrawChart(ctx, domain.NewElevationChart(gpx.Points))
+	if err != nil {
+		return fmt.Errorf("can't generate elevation chart: %v", err)
+	}
+
+	paceChart, err := repo.DrawChart(ctx, domain.NewPaceChart(gpx.Points))
+	if err != nil {
+		return fmt.Errorf("can't generate pace chart: %v", err)
+	}
+
+	assets[activity.ElevationChartPath.PNG()] = elevationChart.PNG()
+	assets[activity.ElevationChartPath.SVG()] = elevationChart.SVG()
+	assets[activity.PaceChartPath.PNG()] = paceChart.PNG()
+	assets[activity.PaceChartPath.SVG()] = paceChart.SVG()
+
 	return nil
 }
 
-func uploadPNGs(repo repository.Writer, assets map[string]io.Reader) error {
+func uploadAssets(repo repository.Writer, assets map[string]io.Reader) error {
 	for assetPath, assetContent := range assets {
 		if err := repo.StoreAsset(assetContent, assetPath); err != nil {
-			return fmt.Errorf("can't store png file (png=%s): %v", assetPath, err)
+			return fmt.Errorf("can't store asset file (path=%s): %v", assetPath, err)
 		}
 	}
 
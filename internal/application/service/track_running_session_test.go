@@ -3,7 +3,9 @@ package service_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/lonepeon/golib/testutils"
@@ -29,6 +31,8 @@ func TestTrackRunningSessionSuccess(t *testing.T) {
 		WithDistanceMeters(gpxFile.Distance.Meters()).
 		WithDuration(gpxFile.Duration).
 		WithSpeedKmh(gpxFile.Speed.KilometersPerHour()).
+		WithDetails("Morning run", "Along the river").
+		WithUsername("alice").
 		Build()
 
 	ctx := context.Background()
@@ -38,10 +42,209 @@ func TestTrackRunningSessionSuccess(t *testing.T) {
 
 	repo.ExpectCleanGPXFiles(gpxFileBytes)
 	repo.ExpectGenerateMaps(gpxFile)
-	repo.ExpectAnnotateMapsWithStats(mapFile)
-	repo.ExpectStoreAssets(activity.GPXPath.String(), activity.MapPath.String(), activity.ShareableMapPath.String())
+	repo.ExpectDrawCards("opengraph", "square", "story")
+	repo.ExpectStoreAssets(activity.GPXPath.String(), activity.MapPath.String())
+	repo.ExpectStoreAssets(activity.Cards[0].Path.String(), activity.Cards[1].Path.String(), activity.Cards[2].Path.String())
+	repo.ExpectStoreAssets(activity.ElevationChartPath.PNG(), activity.ElevationChartPath.SVG(), activity.PaceChartPath.PNG(), activity.PaceChartPath.SVG())
 	repo.ExpectRecordActivities(activity)
 
-	err := service.TrackRunningSession(repo, ctx, activity.RanAt, bytes.NewBuffer(gpxFileBytes))
+	mapStyles := domain.MapStyles{Default: domain.DefaultMapStyle()}
+	details := domain.RunningActivityDetails{Title: "Morning run", Description: "Along the river"}
+	err := service.TrackRunningSession(repo, ctx, mapStyles, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), "alice", activity.RanAt, details, bytes.NewBuffer(gpxFileBytes))
 	testutils.AssertNoError(t, err, "can't create running session")
 }
+
+func TestTrackRunningSessionActivityTypeMapStyle(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+
+	mapStyles, err := domain.NewMapStyles("", []string{"hike:theme=mapbox/satellite-v9"})
+	testutils.AssertNoError(t, err, "can't build map styles")
+
+	activity := domaintest.NewRunningActivity(t).
+		WithDistanceMeters(gpxFile.Distance.Meters()).
+		WithDuration(gpxFile.Duration).
+		WithSpeedKmh(gpxFile.Speed.KilometersPerHour()).
+		WithType(domain.ActivityTypeHike).
+		WithMapStyle(mapStyles.For(domain.ActivityTypeHike)).
+		WithUsername("alice").
+		Build()
+
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.ExpectRecordActivities(activity)
+
+	details := domain.RunningActivityDetails{Type: domain.ActivityTypeHike}
+	err = service.TrackRunningSession(repo, context.Background(), mapStyles, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), "alice", activity.RanAt, details, bytes.NewBuffer(gpxFileBytes))
+	testutils.AssertNoError(t, err, "can't create running session")
+}
+
+func TestTrackRunningSessionMapUnavailable(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+
+	activity := domaintest.NewRunningActivity(t).
+		WithDistanceMeters(gpxFile.Distance.Meters()).
+		WithDuration(gpxFile.Duration).
+		WithSpeedKmh(gpxFile.Speed.KilometersPerHour()).
+		WithPendingMap("can't generate image from gpx: invalid token").
+		WithUsername("alice").
+		Build()
+
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.OverrideGenerateMap(gpxFile, domain.MapFile{}, errors.New("invalid token"))
+	repo.ExpectStoreAssets(activity.GPXPath.String())
+	repo.ExpectRecordActivities(activity)
+
+	mapStyles := domain.MapStyles{Default: domain.DefaultMapStyle()}
+	err := service.TrackRunningSession(repo, context.Background(), mapStyles, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), "alice", activity.RanAt, domain.RunningActivityDetails{}, bytes.NewBuffer(gpxFileBytes))
+	testutils.AssertNoError(t, err, "the activity should be recorded without its map")
+}
+
+func TestTrackRunningSessionChartFailure(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+
+	activity := domaintest.NewRunningActivity(t).
+		WithDistanceMeters(gpxFile.Distance.Meters()).
+		WithDuration(gpxFile.Duration).
+		WithSpeedKmh(gpxFile.Speed.KilometersPerHour()).
+		WithPendingMap("can't generate pace chart: boom").
+		WithUsername("alice").
+		Build()
+
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.OverrideDrawChart(domain.ChartKindPace, errors.New("boom"))
+	repo.ExpectRecordActivities(activity)
+
+	mapStyles := domain.MapStyles{Default: domain.DefaultMapStyle()}
+	err := service.TrackRunningSession(repo, context.Background(), mapStyles, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), "alice", activity.RanAt, domain.RunningActivityDetails{}, bytes.NewBuffer(gpxFileBytes))
+	testutils.AssertNoError(t, err, "the activity should be recorded without its charts")
+}
+
+func TestTrackRunningSessionPrivacyZones(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+
+	domaintest.NewPrivacyZone(t).WithCentre(gpxFile.Points[0].Latitude, gpxFile.Points[0].Longitude).Persist(repo)
+	domaintest.NewPrivacyZone(t).WithUsername("bob").WithCentre(gpxFile.Points[1].Latitude, gpxFile.Points[1].Longitude).Persist(repo)
+
+	activity := domaintest.NewRunningActivity(t).
+		WithDistanceMeters(gpxFile.Distance.Meters()).
+		WithDuration(gpxFile.Duration).
+		WithSpeedKmh(gpxFile.Speed.KilometersPerHour()).
+		WithUsername("alice").
+		Build()
+
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.ExpectStoreAssets(activity.GPXPath.String(), activity.MapPath.String())
+	repo.ExpectRecordActivities(activity)
+
+	mapStyles := domain.MapStyles{Default: domain.DefaultMapStyle()}
+	err := service.TrackRunningSession(repo, context.Background(), mapStyles, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), "alice", activity.RanAt, domain.RunningActivityDetails{}, bytes.NewBuffer(gpxFileBytes))
+	testutils.AssertNoError(t, err, "can't create running session")
+
+	maps := repo.GeneratedMapPoints()
+	testutils.RequireEqualInt(t, 1, len(maps), "unexpected number of maps")
+	testutils.RequireEqualInt(t, 2, len(maps[0]), "points inside the privacy zone of the owner shouldn't be drawn")
+	testutils.AssertEqualFloat64(t, gpxFile.Points[1].Latitude, maps[0][0].Latitude, "unexpected first drawn point")
+}
+
+func TestTrackRunningSessionEveryPointInPrivacyZone(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+
+	domaintest.NewPrivacyZone(t).WithRadius(1000000).Persist(repo)
+
+	activity := domaintest.NewRunningActivity(t).
+		WithDistanceMeters(gpxFile.Distance.Meters()).
+		WithDuration(gpxFile.Duration).
+		WithSpeedKmh(gpxFile.Speed.KilometersPerHour()).
+		WithPendingMap("can't generate image from gpx: every point is inside a privacy zone").
+		WithUsername("alice").
+		Build()
+
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.ExpectStoreAssets(activity.GPXPath.String())
+	repo.ExpectRecordActivities(activity)
+
+	mapStyles := domain.MapStyles{Default: domain.DefaultMapStyle()}
+	err := service.TrackRunningSession(repo, context.Background(), mapStyles, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), "alice", activity.RanAt, domain.RunningActivityDetails{}, bytes.NewBuffer(gpxFileBytes))
+	testutils.AssertNoError(t, err, "the activity should be recorded without its map")
+
+	testutils.AssertEqualInt(t, 0, len(repo.GeneratedMapPoints()), "map shouldn't be generated")
+}
+
+func TestTrackRunningSessionPrivacyZonesFailure(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+
+	activity := domaintest.NewRunningActivity(t).
+		WithDistanceMeters(gpxFile.Distance.Meters()).
+		WithDuration(gpxFile.Duration).
+		WithSpeedKmh(gpxFile.Speed.KilometersPerHour()).
+		WithPendingMap("can't list privacy zones of user alice: boom").
+		WithUsername("alice").
+		Build()
+
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+	repo.OverrideListPrivacyZones(errors.New("boom"))
+	repo.ExpectRecordActivities(activity)
+
+	mapStyles := domain.MapStyles{Default: domain.DefaultMapStyle()}
+	err := service.TrackRunningSession(repo, context.Background(), mapStyles, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), "alice", activity.RanAt, domain.RunningActivityDetails{}, bytes.NewBuffer(gpxFileBytes))
+	testutils.AssertNoError(t, err, "the activity should be recorded without its map")
+
+	testutils.AssertEqualInt(t, 0, len(repo.GeneratedMapPoints()), "map shouldn't be generated without knowing the privacy zones")
+}
+
+func TestTrackRunningSessionSameMinute(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	existing := domaintest.NewRunningActivity(t).WithRawSlug("202202162149").WithUsername("bob").Persist(repo)
+
+	repo.ExpectRecordActivities(existing)
+
+	mapStyles := domain.MapStyles{Default: domain.DefaultMapStyle()}
+	when := existing.RanAt.Add(42 * time.Second)
+	err := service.TrackRunningSession(repo, context.Background(), mapStyles, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), "alice", when, domain.RunningActivityDetails{}, bytes.NewBuffer(domaintest.GetGPXBytes()))
+	testutils.AssertErrorIs(t, domain.ErrRunningActivityAlreadyExists, err, "unexpected error")
+
+	testutils.AssertEqualInt(t, 0, len(repo.GeneratedMapPoints()), "map of the existing activity shouldn't be overwritten")
+}
+
+func TestTrackRunningSessionPrivate(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	gpxFileBytes := domaintest.GetGPXBytes()
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(gpxFileBytes).Build()
+	repo.OverrideCleanGPXFile(gpxFileBytes, gpxFile, nil)
+
+	mapStyles := domain.MapStyles{Default: domain.DefaultMapStyle()}
+	when := time.Date(2022, time.February, 16, 21, 49, 0, 0, time.UTC)
+	details := domain.RunningActivityDetails{Visibility: domain.ActivityVisibilityPrivate}
+	err := service.TrackRunningSession(repo, context.Background(), mapStyles, domain.DefaultCardTemplates(), domain.DefaultUserPreferences(), "alice", when, details, bytes.NewBuffer(gpxFileBytes))
+	testutils.RequireNoError(t, err, "can't create running session")
+
+	slug, err := domain.NewRunnningActivitySlugFromTime(when)
+	testutils.RequireNoError(t, err, "can't build slug")
+	activity, err := repo.GetRunningActivity(context.Background(), slug)
+	testutils.RequireNoError(t, err, "can't get running activity")
+	testutils.AssertEqualBool(t, false, activity.HasMisplacedAssets(), "files should be in a private folder, got %s", activity.GPXPath)
+	for _, assetPath := range activity.AssetPaths() {
+		content, err := repo.FetchAsset(assetPath)
+		testutils.AssertNoError(t, err, "asset %s should be stored", assetPath)
+		if err == nil {
+			content.Close()
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+func UpdateUserPreferences(repo repository.Writer, ctx context.Context, username string, prefs domain.UserPreferences) error {
+	if err := repo.SaveUserPreferences(ctx, username, prefs); err != nil {
+		return fmt.Errorf("can't save preferences of user %s: %w", username, err)
+	}
+
+	return nil
+}
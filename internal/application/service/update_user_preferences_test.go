@@ -0,0 +1,33 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/service"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+func TestUpdateUserPreferencesSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	expected := domain.UserPreferences{Locale: domain.LocaleFrench, Units: domain.UnitSystemImperial, SpeedDisplay: domain.SpeedDisplaySpeed}
+
+	err := service.UpdateUserPreferences(repo, context.Background(), "alice", expected)
+	testutils.AssertNoError(t, err, "can't update preferences")
+
+	actual, err := repo.GetUserPreferences(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "can't get preferences")
+	testutils.AssertEqualString(t, "speed", actual.SpeedDisplay.String(), "unexpected speed display")
+}
+
+func TestUpdateUserPreferencesError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	repo.OverrideSaveUserPreferences("alice", errors.New("boom"))
+
+	err := service.UpdateUserPreferences(repo, context.Background(), "alice", domain.DefaultUserPreferences())
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+}
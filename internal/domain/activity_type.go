@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ActivityType represents the kind of sport practiced during an activity
+type ActivityType string
+
+const (
+	ActivityTypeRun      ActivityType = "run"
+	ActivityTypeTrailRun ActivityType = "trail-run"
+	ActivityTypeHike     ActivityType = "hike"
+)
+
+// ActivityTypes lists every supported activity type
+var ActivityTypes = []ActivityType{ActivityTypeRun, ActivityTypeTrailRun, ActivityTypeHike}
+
+// ParseActivityType returns the activity type matching the value. An empty value is a run.
+func ParseActivityType(value string) (ActivityType, error) {
+	if value == "" {
+		return ActivityTypeRun, nil
+	}
+
+	for _, activityType := range ActivityTypes {
+		if string(activityType) == value {
+			return activityType, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported activity type %s", value)
+}
+
+// activityTypeFromStrava returns the activity type of a Strava activity type label (e.g. "Trail Run")
+func activityTypeFromStrava(label string) ActivityType {
+	label = strings.ToLower(label)
+	switch {
+	case strings.Contains(label, "trail"):
+		return ActivityTypeTrailRun
+	case strings.Contains(label, "hike"):
+		return ActivityTypeHike
+	default:
+		return ActivityTypeRun
+	}
+}
+
+func (t ActivityType) String() string {
+	return string(t)
+}
+
+// Label returns the human readable name of the activity type
+func (t ActivityType) Label() string {
+	switch t {
+	case ActivityTypeTrailRun:
+		return "Trail run"
+	case ActivityTypeHike:
+		return "Hike"
+	default:
+		return "Run"
+	}
+}
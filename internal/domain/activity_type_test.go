@@ -0,0 +1,29 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestParseActivityTypeSuccess(t *testing.T) {
+	tcs := map[string]domain.ActivityType{
+		"":          domain.ActivityTypeRun,
+		"run":       domain.ActivityTypeRun,
+		"trail-run": domain.ActivityTypeTrailRun,
+		"hike":      domain.ActivityTypeHike,
+	}
+
+	for value, expected := range tcs {
+		actual, err := domain.ParseActivityType(value)
+		testutils.AssertNoError(t, err, "can't parse activity type %s", value)
+		testutils.AssertEqualString(t, expected.String(), actual.String(), "unexpected activity type")
+	}
+}
+
+func TestParseActivityTypeError(t *testing.T) {
+	_, err := domain.ParseActivityType("swim")
+
+	testutils.AssertHasError(t, err, "expected an error")
+}
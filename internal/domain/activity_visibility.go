@@ -0,0 +1,49 @@
+package domain
+
+import "fmt"
+
+// ActivityVisibility represents who is allowed to see an activity
+type ActivityVisibility string
+
+const (
+	// ActivityVisibilityPublic activities are listed and readable by everyone
+	ActivityVisibilityPublic ActivityVisibility = "public"
+	// ActivityVisibilityUnlisted activities are readable by everyone knowing their link, but only listed to their owner
+	ActivityVisibilityUnlisted ActivityVisibility = "unlisted"
+	// ActivityVisibilityPrivate activities are only readable by their owner
+	ActivityVisibilityPrivate ActivityVisibility = "private"
+)
+
+// ActivityVisibilities lists every supported visibility
+var ActivityVisibilities = []ActivityVisibility{ActivityVisibilityPublic, ActivityVisibilityUnlisted, ActivityVisibilityPrivate}
+
+// ParseActivityVisibility returns the visibility matching the value. An empty value is public.
+func ParseActivityVisibility(value string) (ActivityVisibility, error) {
+	if value == "" {
+		return ActivityVisibilityPublic, nil
+	}
+
+	for _, visibility := range ActivityVisibilities {
+		if string(visibility) == value {
+			return visibility, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported activity visibility %s", value)
+}
+
+func (v ActivityVisibility) String() string {
+	return string(v)
+}
+
+// Label returns the human readable name of the visibility
+func (v ActivityVisibility) Label() string {
+	switch v {
+	case ActivityVisibilityUnlisted:
+		return "Unlisted"
+	case ActivityVisibilityPrivate:
+		return "Private"
+	default:
+		return "Public"
+	}
+}
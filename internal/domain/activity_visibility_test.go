@@ -0,0 +1,29 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestParseActivityVisibilitySuccess(t *testing.T) {
+	tcs := map[string]domain.ActivityVisibility{
+		"":         domain.ActivityVisibilityPublic,
+		"public":   domain.ActivityVisibilityPublic,
+		"unlisted": domain.ActivityVisibilityUnlisted,
+		"private":  domain.ActivityVisibilityPrivate,
+	}
+
+	for value, expected := range tcs {
+		actual, err := domain.ParseActivityVisibility(value)
+		testutils.AssertNoError(t, err, "can't parse activity visibility %s", value)
+		testutils.AssertEqualString(t, expected.String(), actual.String(), "unexpected activity visibility")
+	}
+}
+
+func TestParseActivityVisibilityError(t *testing.T) {
+	_, err := domain.ParseActivityVisibility("friends")
+
+	testutils.AssertHasError(t, err, "expected an error")
+}
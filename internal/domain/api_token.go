@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// apiTokenSecretSize is the number of random bytes of a token secret
+const apiTokenSecretSize = 32
+
+// apiTokenNameMaxLength is the maximum number of characters of a token name
+const apiTokenNameMaxLength = 100
+
+// APITokenScope represents what a personal access token is allowed to do
+type APITokenScope string
+
+const (
+	// APITokenScopeRead only allows to read activities
+	APITokenScopeRead APITokenScope = "read"
+	// APITokenScopeWrite allows to read, upload, regenerate and delete activities
+	APITokenScopeWrite APITokenScope = "write"
+)
+
+// APITokenScopes returns the supported scopes, from the most restricted one
+func APITokenScopes() []APITokenScope {
+	return []APITokenScope{APITokenScopeRead, APITokenScopeWrite}
+}
+
+// ParseAPITokenScope returns the scope matching the value
+func ParseAPITokenScope(value string) (APITokenScope, error) {
+	for _, scope := range APITokenScopes() {
+		if string(scope) == value {
+			return scope, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported api token scope '%s'", value)
+}
+
+// String implements Stringer interface
+func (s APITokenScope) String() string {
+	return string(s)
+}
+
+// Label returns the description of the scope, as shown to the user
+func (s APITokenScope) Label() string {
+	if s == APITokenScopeWrite {
+		return "Read and write"
+	}
+
+	return "Read only"
+}
+
+// Allows returns whether a token with this scope can be used where the required scope is expected
+func (s APITokenScope) Allows(required APITokenScope) bool {
+	return s == APITokenScopeWrite || s == required
+}
+
+// APITokenHash represents the hash of a token secret, the secret itself is never stored
+type APITokenHash string
+
+// HashAPIToken returns the hash of the token secret
+func HashAPIToken(secret string) APITokenHash {
+	sum := sha256.Sum256([]byte(secret))
+	return APITokenHash(hex.EncodeToString(sum[:]))
+}
+
+// String implements Stringer interface
+func (h APITokenHash) String() string {
+	return string(h)
+}
+
+// APIToken represents a personal access token used by scripts to call the API on behalf of a user
+type APIToken struct {
+	ID         ID
+	Username   string
+	Name       string
+	Scope      APITokenScope
+	Hash       APITokenHash
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// NewAPIToken initializes a token and returns it along with its secret. The secret can't be retrieved later on.
+func NewAPIToken(username string, name string, scope string, createdAt time.Time) (APIToken, string, error) {
+	var errs InvalidInputErrors
+	errs.ValidateRequiredString(name, "name is required")
+	if utf8.RuneCountInString(name) > apiTokenNameMaxLength {
+		errs.Append(fmt.Sprintf("name can't be longer than %d characters", apiTokenNameMaxLength))
+	}
+
+	parsedScope, err := ParseAPITokenScope(scope)
+	if err != nil {
+		errs.Append("scope must be read or write")
+	}
+
+	if !errs.IsEmpty() {
+		return APIToken{}, "", &errs
+	}
+
+	secret, err := newAPITokenSecret()
+	if err != nil {
+		return APIToken{}, "", err
+	}
+
+	token := APIToken{
+		ID:        NewID(),
+		Username:  username,
+		Name:      name,
+		Scope:     parsedScope,
+		Hash:      HashAPIToken(secret),
+		CreatedAt: createdAt,
+	}
+
+	return token, secret, nil
+}
+
+// IsUsed returns whether the token already authenticated a request
+func (t APIToken) IsUsed() bool {
+	return !t.LastUsedAt.IsZero()
+}
+
+func newAPITokenSecret() (string, error) {
+	secret := make([]byte, apiTokenSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("can't generate api token secret: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
@@ -0,0 +1,57 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestNewAPIToken(t *testing.T) {
+	createdAt := time.Date(2022, 4, 25, 9, 0, 0, 0, time.UTC)
+
+	token, secret, err := domain.NewAPIToken("alice", "backup script", "read", createdAt)
+	testutils.RequireNoError(t, err, "can't create api token")
+
+	testutils.AssertEqualString(t, "alice", token.Username, "unexpected username")
+	testutils.AssertEqualString(t, "backup script", token.Name, "unexpected name")
+	testutils.AssertEqualString(t, "read", token.Scope.String(), "unexpected scope")
+	testutils.AssertEqualTime(t, createdAt, token.CreatedAt, "unexpected created at")
+	testutils.AssertEqualBool(t, false, token.IsUsed(), "token shouldn't be used")
+	testutils.AssertEqualString(t, domain.HashAPIToken(secret).String(), token.Hash.String(), "unexpected hash")
+	testutils.AssertEqualBool(t, false, strings.Contains(token.Hash.String(), secret), "hash shouldn't contain the secret")
+
+	_, otherSecret, err := domain.NewAPIToken("alice", "backup script", "read", createdAt)
+	testutils.RequireNoError(t, err, "can't create api token")
+	testutils.AssertEqualBool(t, false, secret == otherSecret, "secrets should be random")
+}
+
+func TestNewAPITokenInvalid(t *testing.T) {
+	_, _, err := domain.NewAPIToken("alice", "", "admin", time.Now())
+
+	var invalidErr *domain.InvalidInputErrors
+	testutils.RequireErrorAs(t, &invalidErr, err, "expected invalid input")
+	testutils.AssertEqualStrings(t, []string{"name is required", "scope must be read or write"}, invalidErr.Detail(), "unexpected errors")
+
+	_, _, err = domain.NewAPIToken("alice", strings.Repeat("a", 101), "write", time.Now())
+	testutils.RequireErrorAs(t, &invalidErr, err, "expected invalid input")
+	testutils.AssertEqualStrings(t, []string{"name can't be longer than 100 characters"}, invalidErr.Detail(), "unexpected errors")
+}
+
+func TestAPITokenScopeAllows(t *testing.T) {
+	testutils.AssertEqualBool(t, true, domain.APITokenScopeRead.Allows(domain.APITokenScopeRead), "read should allow read")
+	testutils.AssertEqualBool(t, false, domain.APITokenScopeRead.Allows(domain.APITokenScopeWrite), "read shouldn't allow write")
+	testutils.AssertEqualBool(t, true, domain.APITokenScopeWrite.Allows(domain.APITokenScopeRead), "write should allow read")
+	testutils.AssertEqualBool(t, true, domain.APITokenScopeWrite.Allows(domain.APITokenScopeWrite), "write should allow write")
+}
+
+func TestParseAPITokenScope(t *testing.T) {
+	scope, err := domain.ParseAPITokenScope("write")
+	testutils.AssertNoError(t, err, "can't parse scope")
+	testutils.AssertEqualString(t, "write", scope.String(), "unexpected scope")
+
+	_, err = domain.ParseAPITokenScope("admin")
+	testutils.AssertHasError(t, err, "expected unsupported scope")
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"time"
+)
+
+// privateAssetsFolder holds the files of private activities, which a CDN in front of the bucket must not serve
+const privateAssetsFolder = "private"
+
+// assetsFolderSecretSize is the number of random bytes naming the folder of a private activity
+const assetsFolderSecretSize = 16
+
+// NewAssetsFolder returns the folder storing the files of an activity. The files of private activities are stored
+// in a random folder under the private one, so they can't be found from the date of the activity.
+func NewAssetsFolder(ranAt time.Time, visibility ActivityVisibility) (string, error) {
+	name := ranAt.Format("2006-01-02.15h04")
+	if visibility != ActivityVisibilityPrivate {
+		return path.Join("runs", name), nil
+	}
+
+	secret := make([]byte, assetsFolderSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("can't generate private assets folder: %v", err)
+	}
+
+	return path.Join(privateAssetsFolder, hex.EncodeToString(secret), name), nil
+}
@@ -0,0 +1,33 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestNewAssetsFolderPublic(t *testing.T) {
+	ranAt := time.Date(2022, time.April, 21, 9, 0, 0, 0, time.UTC)
+
+	for _, visibility := range []domain.ActivityVisibility{domain.ActivityVisibilityPublic, domain.ActivityVisibilityUnlisted, ""} {
+		folder, err := domain.NewAssetsFolder(ranAt, visibility)
+		testutils.RequireNoError(t, err, "can't build folder of %s activity", visibility)
+		testutils.AssertEqualString(t, "runs/2022-04-21.09h00", folder, "unexpected folder of %s activity", visibility)
+	}
+}
+
+func TestNewAssetsFolderPrivate(t *testing.T) {
+	ranAt := time.Date(2022, time.April, 21, 9, 0, 0, 0, time.UTC)
+
+	folder, err := domain.NewAssetsFolder(ranAt, domain.ActivityVisibilityPrivate)
+	testutils.RequireNoError(t, err, "can't build folder")
+	other, err := domain.NewAssetsFolder(ranAt, domain.ActivityVisibilityPrivate)
+	testutils.RequireNoError(t, err, "can't build folder")
+
+	testutils.AssertEqualBool(t, true, strings.HasPrefix(folder, "private/"), "unexpected folder %s", folder)
+	testutils.AssertEqualBool(t, true, strings.HasSuffix(folder, "/2022-04-21.09h00"), "unexpected folder %s", folder)
+	testutils.AssertEqualBool(t, true, folder != other, "folders of private activities should be random")
+}
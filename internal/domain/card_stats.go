@@ -0,0 +1,83 @@
+package domain
+
+import "time"
+
+// CardStats represents the values of an activity drawn on its cards
+type CardStats struct {
+	Title         string
+	RanAt         time.Time
+	Distance      Distance
+	Duration      time.Duration
+	Speed         Speed
+	ElevationGain float64
+}
+
+// NewCardStats returns the values of the activity, its elevation gain being computed from the points. Activities
+// without a title are named after their type.
+func NewCardStats(activity RunningActivity, points GPXPoints) CardStats {
+	title := activity.Title
+	if title == "" {
+		title = activity.Type.Label()
+	}
+
+	return CardStats{
+		Title:         title,
+		RanAt:         activity.RanAt,
+		Distance:      activity.Distance,
+		Duration:      activity.Duration,
+		Speed:         activity.Speed,
+		ElevationGain: points.ElevationGain(),
+	}
+}
+
+var cardStatLabels = map[CardStat]string{
+	CardStatDistance:  "Distance",
+	CardStatDuration:  "Time",
+	CardStatPace:      "Pace",
+	CardStatSpeed:     "Speed",
+	CardStatElevation: "Elevation gain",
+}
+
+var cardStatValues = map[CardStat]func(s CardStats, p UserPreferences) string{
+	CardStatTitle: func(s CardStats, p UserPreferences) string {
+		return s.Title
+	},
+	CardStatDate: func(s CardStats, p UserPreferences) string {
+		return p.FormatDate(s.RanAt)
+	},
+	CardStatDistance: func(s CardStats, p UserPreferences) string {
+		return p.FormatDistance(s.Distance)
+	},
+	CardStatDuration: func(s CardStats, p UserPreferences) string {
+		return p.FormatDuration(s.Duration)
+	},
+	CardStatPace: func(s CardStats, p UserPreferences) string {
+		return p.FormatPace(s.Speed.Pace())
+	},
+	CardStatSpeed: func(s CardStats, p UserPreferences) string {
+		return p.FormatSpeed(s.Speed)
+	},
+	CardStatElevation: func(s CardStats, p UserPreferences) string {
+		return p.FormatElevation(s.ElevationGain)
+	},
+}
+
+// Label returns the caption drawn above the value of the stat in the locale of the user, headings having none
+func (s CardStats) Label(stat CardStat, prefs UserPreferences) string {
+	label, ok := cardStatLabels[stat]
+	if !ok {
+		return ""
+	}
+
+	return prefs.Translate(label)
+}
+
+// Value returns the value of the stat formatted as the user prefers
+func (s CardStats) Value(stat CardStat, prefs UserPreferences) string {
+	format, ok := cardStatValues[stat]
+	if !ok {
+		return ""
+	}
+
+	return format(s, prefs)
+}
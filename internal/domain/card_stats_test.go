@@ -0,0 +1,55 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestCardStatsValues(t *testing.T) {
+	distance, err := domain.NewDistanceFromMeters(10500)
+	testutils.RequireNoError(t, err, "can't build distance")
+	speed, err := domain.NewSpeedFromKmh(12)
+	testutils.RequireNoError(t, err, "can't build speed")
+
+	activity := domain.RunningActivity{
+		RanAt:    time.Date(2022, time.April, 21, 9, 0, 0, 0, time.UTC),
+		Distance: distance,
+		Duration: 52*time.Minute + 30*time.Second,
+		Speed:    speed,
+		Type:     domain.ActivityTypeTrailRun,
+	}
+	points := domain.GPXPoints{{Elevation: 100}, {Elevation: 110}, {Elevation: 105}, {Elevation: 125}}
+
+	stats := domain.NewCardStats(activity, points)
+	prefs := domain.DefaultUserPreferences()
+
+	testutils.AssertEqualString(t, "Trail run", stats.Value(domain.CardStatTitle, prefs), "untitled activities should be named after their type")
+	testutils.AssertEqualString(t, "2022/04/21", stats.Value(domain.CardStatDate, prefs), "unexpected date")
+	testutils.AssertEqualString(t, "10.50km", stats.Value(domain.CardStatDistance, prefs), "unexpected distance")
+	testutils.AssertEqualString(t, "52:30", stats.Value(domain.CardStatDuration, prefs), "unexpected duration")
+	testutils.AssertEqualString(t, "5:00/km", stats.Value(domain.CardStatPace, prefs), "unexpected pace")
+	testutils.AssertEqualString(t, "12.0km/h", stats.Value(domain.CardStatSpeed, prefs), "unexpected speed")
+	testutils.AssertEqualString(t, "30m", stats.Value(domain.CardStatElevation, prefs), "unexpected elevation gain")
+	testutils.AssertEqualString(t, "Pace", stats.Label(domain.CardStatPace, prefs), "unexpected label")
+	testutils.AssertEqualString(t, "", stats.Label(domain.CardStatTitle, prefs), "headings shouldn't have a label")
+}
+
+func TestCardStatsFollowPreferences(t *testing.T) {
+	distance, err := domain.NewDistanceFromMeters(10500)
+	testutils.RequireNoError(t, err, "can't build distance")
+	stats := domain.CardStats{RanAt: time.Date(2022, time.April, 21, 9, 0, 0, 0, time.UTC), Distance: distance}
+	prefs := domain.UserPreferences{Locale: domain.LocaleFrench, Units: domain.UnitSystemImperial, SpeedDisplay: domain.SpeedDisplayPace}
+
+	testutils.AssertEqualString(t, "21/04/2022", stats.Value(domain.CardStatDate, prefs), "unexpected date")
+	testutils.AssertEqualString(t, "6,52mi", stats.Value(domain.CardStatDistance, prefs), "unexpected distance")
+	testutils.AssertEqualString(t, "Dénivelé positif", stats.Label(domain.CardStatElevation, prefs), "unexpected label")
+}
+
+func TestGPXPointsElevationGainIgnoresNoise(t *testing.T) {
+	points := domain.GPXPoints{{Elevation: 100}, {Elevation: 101}, {Elevation: 100}, {Elevation: 101.5}, {Elevation: 104}}
+
+	testutils.AssertEqualFloat64(t, 4, points.ElevationGain(), "unexpected elevation gain")
+}
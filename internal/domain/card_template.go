@@ -0,0 +1,294 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// cardTemplateNamePattern restricts template names to what can be used in file names
+var cardTemplateNamePattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// CardFormat represents the dimensions, in pixels, of a shareable card
+type CardFormat struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+var (
+	// CardFormatSquare fits social network posts
+	CardFormatSquare = CardFormat{Name: "square", Width: 1080, Height: 1080}
+	// CardFormatStory fits 9:16 stories
+	CardFormatStory = CardFormat{Name: "story", Width: 1080, Height: 1920}
+	// CardFormatOpenGraph fits 1.91:1 link previews
+	CardFormatOpenGraph = CardFormat{Name: "opengraph", Width: 1200, Height: 630}
+)
+
+var cardFormats = map[string]CardFormat{
+	CardFormatSquare.Name:    CardFormatSquare,
+	CardFormatStory.Name:     CardFormatStory,
+	CardFormatOpenGraph.Name: CardFormatOpenGraph,
+}
+
+// CardStat represents a statistic shown on a shareable card
+type CardStat string
+
+const (
+	CardStatTitle     CardStat = "title"
+	CardStatDate      CardStat = "date"
+	CardStatDistance  CardStat = "distance"
+	CardStatDuration  CardStat = "duration"
+	CardStatPace      CardStat = "pace"
+	CardStatSpeed     CardStat = "speed"
+	CardStatElevation CardStat = "elevation"
+)
+
+var cardStats = map[CardStat]bool{
+	CardStatTitle:     true,
+	CardStatDate:      true,
+	CardStatDistance:  true,
+	CardStatDuration:  true,
+	CardStatPace:      true,
+	CardStatSpeed:     true,
+	CardStatElevation: true,
+}
+
+// IsHeading returns whether the stat is drawn on its own line above the other stats
+func (s CardStat) IsHeading() bool {
+	return s == CardStatTitle || s == CardStatDate
+}
+
+// CardPosition represents the edge of the card where stats are drawn
+type CardPosition string
+
+const (
+	CardPositionTop    CardPosition = "top"
+	CardPositionBottom CardPosition = "bottom"
+)
+
+// CardTemplate represents which stats a shareable card shows and how they are laid out over the map.
+//
+// Title and date are drawn on their own line, the other stats are drawn in a grid of Columns columns.
+type CardTemplate struct {
+	Name     string
+	Format   CardFormat
+	Stats    []CardStat
+	Position CardPosition
+	Columns  int
+}
+
+// DefaultCardTemplates returns the templates used when nothing is configured
+func DefaultCardTemplates() CardTemplates {
+	return CardTemplates{
+		{
+			Name:     "opengraph",
+			Format:   CardFormatOpenGraph,
+			Stats:    []CardStat{CardStatDistance, CardStatPace, CardStatDuration},
+			Position: CardPositionBottom,
+			Columns:  3,
+		},
+		{
+			Name:     "square",
+			Format:   CardFormatSquare,
+			Stats:    []CardStat{CardStatTitle, CardStatDistance, CardStatPace, CardStatDuration, CardStatElevation},
+			Position: CardPositionBottom,
+			Columns:  4,
+		},
+		{
+			Name:     "story",
+			Format:   CardFormatStory,
+			Stats:    []CardStat{CardStatTitle, CardStatDate, CardStatDistance, CardStatDuration, CardStatPace, CardStatElevation},
+			Position: CardPositionBottom,
+			Columns:  2,
+		},
+	}
+}
+
+// ParseCardTemplate returns the template named after the comma-separated list of key=value settings
+// (e.g. "format=story,stats=title+distance+pace,position=top,columns=2"). Supported keys are format, stats,
+// position and columns, columns defaulting to the number of stats which aren't headings.
+func ParseCardTemplate(name string, spec string) (CardTemplate, error) {
+	template := CardTemplate{Name: name, Format: CardFormatSquare, Position: CardPositionBottom}
+	for _, setting := range strings.Split(spec, ",") {
+		setting = strings.TrimSpace(setting)
+		if setting == "" {
+			continue
+		}
+
+		parts := strings.SplitN(setting, "=", 2)
+		if len(parts) != 2 {
+			return CardTemplate{}, fmt.Errorf("card template setting must be formatted as key=value (setting=%s)", setting)
+		}
+
+		if err := template.set(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])); err != nil {
+			return CardTemplate{}, err
+		}
+	}
+
+	if template.Columns == 0 {
+		template.Columns = len(template.GridStats())
+	}
+
+	if err := template.validate(); err != nil {
+		return CardTemplate{}, err
+	}
+
+	return template, nil
+}
+
+// cardTemplateSetters parses the value of each setting into the template
+var cardTemplateSetters = map[string]func(t *CardTemplate, value string) error{
+	"format": func(t *CardTemplate, value string) error {
+		format, ok := cardFormats[value]
+		if !ok {
+			return fmt.Errorf("format must be square, story or opengraph")
+		}
+
+		t.Format = format
+		return nil
+	},
+	"stats": func(t *CardTemplate, value string) error {
+		t.Stats = nil
+		for _, stat := range strings.Split(value, "+") {
+			t.Stats = append(t.Stats, CardStat(strings.TrimSpace(stat)))
+		}
+		return nil
+	},
+	"position": func(t *CardTemplate, value string) error {
+		t.Position = CardPosition(value)
+		return nil
+	},
+	"columns": func(t *CardTemplate, value string) (err error) {
+		t.Columns, err = strconv.Atoi(value)
+		return err
+	},
+}
+
+func (t *CardTemplate) set(key string, value string) error {
+	setter, ok := cardTemplateSetters[key]
+	if !ok {
+		return fmt.Errorf("unsupported card template setting %s", key)
+	}
+
+	if err := setter(t, value); err != nil {
+		return fmt.Errorf("can't parse card template setting %s (value=%s): %v", key, value, err)
+	}
+
+	return nil
+}
+
+func (t CardTemplate) validate() error {
+	var err InvalidInputErrors
+	if !cardTemplateNamePattern.MatchString(t.Name) {
+		err.Append("card template name must only contain lowercase letters, digits and dashes")
+	}
+	t.validateStats(&err)
+	if t.Position != CardPositionTop && t.Position != CardPositionBottom {
+		err.Append("card position must be top or bottom")
+	}
+	if len(t.GridStats()) > 0 {
+		err.ValidatePositiveInt(t.Columns, "card columns must be greater than 0")
+	}
+
+	if !err.IsEmpty() {
+		return &err
+	}
+
+	return nil
+}
+
+func (t CardTemplate) validateStats(err *InvalidInputErrors) {
+	if len(t.Stats) == 0 {
+		err.Append("card template must show at least one stat")
+	}
+	for _, stat := range t.Stats {
+		if !cardStats[stat] {
+			err.Append(fmt.Sprintf("unsupported card stat %s", stat))
+		}
+	}
+}
+
+// HeadingStats returns the stats drawn on their own line, in order
+func (t CardTemplate) HeadingStats() []CardStat {
+	var stats []CardStat
+	for _, stat := range t.Stats {
+		if stat.IsHeading() {
+			stats = append(stats, stat)
+		}
+	}
+
+	return stats
+}
+
+// GridStats returns the stats drawn in the grid, in order
+func (t CardTemplate) GridStats() []CardStat {
+	var stats []CardStat
+	for _, stat := range t.Stats {
+		if !stat.IsHeading() {
+			stats = append(stats, stat)
+		}
+	}
+
+	return stats
+}
+
+// CardTemplates represents the cards generated for each activity. The first one is the shareable map of the activity.
+type CardTemplates []CardTemplate
+
+// NewCardTemplates builds the templates from settings prefixed by the template name
+// (e.g. "story:format=story,stats=title+distance"). Default templates are used when there are no settings.
+func NewCardTemplates(specs []string) (CardTemplates, error) {
+	if len(specs) == 0 {
+		return DefaultCardTemplates(), nil
+	}
+
+	templates := make(CardTemplates, 0, len(specs))
+	names := make(map[string]bool)
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("card template must be formatted as <name>:<settings> (template=%s)", spec)
+		}
+
+		name := strings.TrimSpace(parts[0])
+		if names[name] {
+			return nil, fmt.Errorf("card template %s is defined twice", name)
+		}
+		names[name] = true
+
+		template, err := ParseCardTemplate(name, parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("can't parse %s card template: %v", name, err)
+		}
+
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+// Cards returns the cards generated from the templates, stored in the folder
+func (t CardTemplates) Cards(folder string) []ShareableCard {
+	cards := make([]ShareableCard, len(t))
+	for i, template := range t {
+		cards[i] = ShareableCard{
+			Template: template.Name,
+			Path:     ShareableMapFilePath(path.Join(folder, fmt.Sprintf("card-%s.png", template.Name))),
+			Width:    template.Format.Width,
+			Height:   template.Format.Height,
+		}
+	}
+
+	return cards
+}
+
+// ShareableCard represents a card generated from a template, with its dimensions in pixels
+type ShareableCard struct {
+	Template string
+	Path     ShareableMapFilePath
+	Width    int
+	Height   int
+}
@@ -0,0 +1,94 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestParseCardTemplateSuccess(t *testing.T) {
+	template, err := domain.ParseCardTemplate("story", "format=story, stats=title+date+distance+pace+elevation,position=top,columns=2")
+
+	testutils.AssertNoError(t, err, "can't parse card template")
+	testutils.AssertEqualString(t, "story", template.Name, "unexpected name")
+	testutils.AssertEqualInt(t, 1080, template.Format.Width, "unexpected width")
+	testutils.AssertEqualInt(t, 1920, template.Format.Height, "unexpected height")
+	testutils.AssertEqualString(t, "top", string(template.Position), "unexpected position")
+	testutils.AssertEqualInt(t, 2, template.Columns, "unexpected columns")
+	testutils.AssertEqualInt(t, 2, len(template.HeadingStats()), "unexpected number of headings")
+	testutils.AssertEqualInt(t, 3, len(template.GridStats()), "unexpected number of grid stats")
+}
+
+func TestParseCardTemplateDefaults(t *testing.T) {
+	template, err := domain.ParseCardTemplate("simple", "stats=title+distance+duration")
+
+	testutils.AssertNoError(t, err, "can't parse card template")
+	testutils.AssertEqualString(t, "square", template.Format.Name, "unexpected format")
+	testutils.AssertEqualString(t, "bottom", string(template.Position), "unexpected position")
+	testutils.AssertEqualInt(t, 2, template.Columns, "columns should default to the number of grid stats")
+}
+
+func TestParseCardTemplateErrors(t *testing.T) {
+	tcs := map[string]struct {
+		name string
+		spec string
+	}{
+		"unknownKey":      {name: "card", spec: "font=serif"},
+		"missingValue":    {name: "card", spec: "stats"},
+		"unknownFormat":   {name: "card", spec: "format=banner,stats=distance"},
+		"unknownStat":     {name: "card", spec: "stats=distance+heart-rate"},
+		"noStats":         {name: "card", spec: "format=story"},
+		"unknownPosition": {name: "card", spec: "stats=distance,position=middle"},
+		"invalidColumns":  {name: "card", spec: "stats=distance,columns=-1"},
+		"invalidName":     {name: "../card", spec: "stats=distance"},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			_, err := domain.ParseCardTemplate(tc.name, tc.spec)
+
+			testutils.AssertHasError(t, err, "expected an error")
+		})
+	}
+}
+
+func TestNewCardTemplatesDefaults(t *testing.T) {
+	templates, err := domain.NewCardTemplates(nil)
+
+	testutils.AssertNoError(t, err, "can't build card templates")
+	testutils.RequireEqualInt(t, 3, len(templates), "unexpected number of templates")
+	testutils.AssertEqualString(t, "opengraph", templates[0].Format.Name, "the first card should be the open graph one")
+	testutils.AssertEqualString(t, "square", templates[1].Format.Name, "unexpected format")
+	testutils.AssertEqualString(t, "story", templates[2].Format.Name, "unexpected format")
+}
+
+func TestNewCardTemplates(t *testing.T) {
+	templates, err := domain.NewCardTemplates([]string{"og:format=opengraph,stats=distance", "story:format=story,stats=title+pace"})
+
+	testutils.AssertNoError(t, err, "can't build card templates")
+	testutils.RequireEqualInt(t, 2, len(templates), "unexpected number of templates")
+
+	cards := templates.Cards("runs/2022-04-21.09h00")
+	testutils.RequireEqualInt(t, 2, len(cards), "unexpected number of cards")
+	testutils.AssertEqualString(t, "og", cards[0].Template, "unexpected template")
+	testutils.AssertEqualString(t, "runs/2022-04-21.09h00/card-og.png", cards[0].Path.String(), "unexpected path")
+	testutils.AssertEqualInt(t, 1200, cards[0].Width, "unexpected width")
+	testutils.AssertEqualInt(t, 630, cards[0].Height, "unexpected height")
+}
+
+func TestNewCardTemplatesErrors(t *testing.T) {
+	tcs := map[string][]string{
+		"missingName":   {"format=story"},
+		"duplicateName": {"card:stats=distance", "card:stats=pace"},
+		"invalidStat":   {"card:stats=heart-rate"},
+	}
+
+	for name, specs := range tcs {
+		t.Run(name, func(t *testing.T) {
+			_, err := domain.NewCardTemplates(specs)
+
+			testutils.AssertHasError(t, err, "expected an error")
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package domain
+
+import "sort"
+
+// ChartKind represents what a chart shows along the distance of an activity
+type ChartKind string
+
+const (
+	// ChartKindElevation charts the elevation, in meters
+	ChartKindElevation ChartKind = "elevation"
+	// ChartKindPace charts the pace, in seconds per kilometer
+	ChartKindPace ChartKind = "pace"
+)
+
+// String implements Stringer interface
+func (k ChartKind) String() string {
+	return string(k)
+}
+
+const (
+	// maxChartPoints caps the number of points of a chart, long tracks are sampled evenly
+	maxChartPoints = 500
+	// maxChartTicks caps the number of distance ticks of a chart
+	maxChartTicks = 10
+	// slowestChartPace caps, in seconds per kilometer, the pace of stops
+	slowestChartPace = 20 * 60
+)
+
+// ChartPoint represents the value of a chart at a distance, in meters, from the start
+type ChartPoint struct {
+	Distance float64
+	Value    float64
+}
+
+// Chart represents values of an activity along its distance
+type Chart struct {
+	Kind   ChartKind
+	Points []ChartPoint
+}
+
+// NewElevationChart returns the elevation profile of the points
+func NewElevationChart(points GPXPoints) Chart {
+	values := make([]float64, len(points))
+	for i, point := range points {
+		values[i] = point.Elevation
+	}
+
+	return newChart(ChartKindElevation, cumulativeDistances(points), values)
+}
+
+// NewPaceChart returns the pace of the points, averaged like the pace coloring of maps. Points without timestamps
+// have no pace so their chart is empty.
+func NewPaceChart(points GPXPoints) Chart {
+	if len(points) == 0 || !isTimed(points) {
+		return Chart{Kind: ChartKindPace}
+	}
+
+	distances := cumulativeDistances(points)
+	values := make([]float64, len(points))
+	for i, speed := range smoothedSpeeds(points, distances) {
+		values[i] = slowestChartPace
+		if speed > 1000.0/slowestChartPace {
+			values[i] = 1000 / speed
+		}
+	}
+
+	return newChart(ChartKindPace, distances, values)
+}
+
+func newChart(kind ChartKind, distances []float64, values []float64) Chart {
+	step := 1
+	if len(values) > maxChartPoints {
+		step = (len(values) + maxChartPoints - 1) / maxChartPoints
+	}
+
+	chart := Chart{Kind: kind}
+	for i := 0; i < len(values); i += step {
+		chart.Points = append(chart.Points, ChartPoint{Distance: distances[i], Value: values[i]})
+	}
+
+	if last := len(values) - 1; last%step != 0 {
+		chart.Points = append(chart.Points, ChartPoint{Distance: distances[last], Value: values[last]})
+	}
+
+	return chart
+}
+
+// IsEmpty returns whether the chart has too few points to draw a line
+func (c Chart) IsEmpty() bool {
+	return len(c.Points) < 2
+}
+
+// Distance returns the distance, in meters, covered by the chart
+func (c Chart) Distance() float64 {
+	if len(c.Points) == 0 {
+		return 0
+	}
+
+	return c.Points[len(c.Points)-1].Distance
+}
+
+// Range returns the lowest and the highest values of the chart. Pace charts ignore the 5% slowest and fastest paces,
+// which are usually stops and GPS glitches.
+func (c Chart) Range() (float64, float64) {
+	if len(c.Points) == 0 {
+		return 0, 0
+	}
+
+	values := make([]float64, len(c.Points))
+	for i, point := range c.Points {
+		values[i] = point.Value
+	}
+	sort.Float64s(values)
+
+	outliers := 0
+	if c.Kind == ChartKindPace {
+		outliers = len(values) * paceOutliersPercent / 100
+	}
+
+	return values[outliers], values[len(values)-1-outliers]
+}
+
+// DistanceTicks returns the distances, in meters, labelled on the chart every 1, 2, 5, 10... kilometers
+func (c Chart) DistanceTicks() []float64 {
+	total := c.Distance()
+	step := markerStep(total, maxChartTicks)
+
+	var ticks []float64
+	for tick := 0.0; tick <= total; tick += step {
+		ticks = append(ticks, tick)
+	}
+
+	return ticks
+}
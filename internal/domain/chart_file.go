@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"bytes"
+	"io"
+)
+
+// ChartFile represents a chart rendered both as a PNG and an SVG image
+type ChartFile struct {
+	png []byte
+	svg []byte
+}
+
+func NewChartFile(png []byte, svg []byte) ChartFile {
+	return ChartFile{png: png, svg: svg}
+}
+
+func (f ChartFile) PNG() io.Reader {
+	return bytes.NewBuffer(f.png)
+}
+
+func (f ChartFile) SVG() io.Reader {
+	return bytes.NewBuffer(f.svg)
+}
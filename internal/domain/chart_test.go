@@ -0,0 +1,81 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestNewElevationChart(t *testing.T) {
+	points := straightTrack(400, func(int) time.Duration { return time.Second })
+	for i := range points {
+		points[i].Elevation = 100 + float64(i)/10
+	}
+
+	chart := domain.NewElevationChart(points)
+
+	testutils.AssertEqualString(t, "elevation", chart.Kind.String(), "unexpected kind")
+	testutils.RequireEqualInt(t, 401, len(chart.Points), "unexpected number of points")
+	testutils.AssertEqualFloat64(t, 200, chart.Points[200].Distance, "unexpected distance")
+	testutils.AssertEqualFloat64(t, 120, chart.Points[200].Value, "unexpected elevation")
+
+	low, high := chart.Range()
+	testutils.AssertEqualFloat64(t, 100, low, "unexpected lowest elevation")
+	testutils.AssertEqualFloat64(t, 140, high, "unexpected highest elevation")
+}
+
+func TestNewElevationChartSampling(t *testing.T) {
+	chart := domain.NewElevationChart(straightTrack(3000, func(int) time.Duration { return time.Second }))
+
+	testutils.AssertEqualBool(t, true, len(chart.Points) <= 501, "too many points: %d", len(chart.Points))
+	testutils.AssertEqualFloat64(t, 0, chart.Points[0].Distance, "unexpected first distance")
+	testutils.AssertEqualFloat64(t, 3000, chart.Distance(), "unexpected chart distance")
+}
+
+func TestNewPaceChart(t *testing.T) {
+	// 5min/km the first kilometer, 4min/km the second one
+	chart := domain.NewPaceChart(straightTrack(2000, func(i int) time.Duration {
+		if i <= 1000 {
+			return 300 * time.Millisecond
+		}
+		return 240 * time.Millisecond
+	}))
+
+	testutils.AssertEqualString(t, "pace", chart.Kind.String(), "unexpected kind")
+	testutils.AssertEqualBool(t, false, chart.IsEmpty(), "chart shouldn't be empty")
+
+	low, high := chart.Range()
+	testutils.AssertEqualInt(t, 240, int(low+0.5), "unexpected fastest pace")
+	testutils.AssertEqualInt(t, 300, int(high+0.5), "unexpected slowest pace")
+}
+
+func TestNewPaceChartCapsStops(t *testing.T) {
+	chart := domain.NewPaceChart(straightTrack(1000, func(i int) time.Duration {
+		if i == 500 {
+			return time.Hour
+		}
+		return 300 * time.Millisecond
+	}))
+
+	for _, point := range chart.Points {
+		testutils.AssertEqualBool(t, true, point.Value <= 20*60, "pace should be capped: %f", point.Value)
+	}
+}
+
+func TestNewPaceChartWithoutTimestamps(t *testing.T) {
+	chart := domain.NewPaceChart(straightTrack(1000, func(int) time.Duration { return 0 }))
+
+	testutils.AssertEqualBool(t, true, chart.IsEmpty(), "chart should be empty")
+}
+
+func TestChartDistanceTicks(t *testing.T) {
+	chart := domain.NewElevationChart(straightTrack(25000, func(int) time.Duration { return time.Second }))
+
+	ticks := chart.DistanceTicks()
+
+	testutils.RequireEqualInt(t, 6, len(ticks), "unexpected number of ticks")
+	testutils.AssertEqualFloat64(t, 0, ticks[0], "unexpected first tick")
+	testutils.AssertEqualFloat64(t, 5000, ticks[1], "unexpected second tick")
+}
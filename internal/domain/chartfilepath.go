@@ -0,0 +1,37 @@
+package domain
+
+// ElevationChartFilePath represents the path, without extension, to the elevation profile images
+type ElevationChartFilePath string
+
+// String implements Stringer interface
+func (e ElevationChartFilePath) String() string {
+	return string(e)
+}
+
+// PNG returns the path to the PNG image
+func (e ElevationChartFilePath) PNG() string {
+	return string(e) + ".png"
+}
+
+// SVG returns the path to the SVG image
+func (e ElevationChartFilePath) SVG() string {
+	return string(e) + ".svg"
+}
+
+// PaceChartFilePath represents the path, without extension, to the pace chart images
+type PaceChartFilePath string
+
+// String implements Stringer interface
+func (p PaceChartFilePath) String() string {
+	return string(p)
+}
+
+// PNG returns the path to the PNG image
+func (p PaceChartFilePath) PNG() string {
+	return string(p) + ".png"
+}
+
+// SVG returns the path to the SVG image
+func (p PaceChartFilePath) SVG() string {
+	return string(p) + ".svg"
+}
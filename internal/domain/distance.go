@@ -25,3 +25,8 @@ func (d Distance) Meters() int {
 func (d Distance) Kilometers() float64 {
 	return math.Round(float64(d.meters)/10) / 100.0
 }
+
+// Miles converts the distance from meters to miles
+func (d Distance) Miles() float64 {
+	return math.Round(float64(d.meters)/metersPerMile*100) / 100.0
+}
@@ -17,4 +17,112 @@ func AssertEqualRunningActivity(t *testing.T, want domain.RunningActivity, got d
 	testutils.AssertEqualString(t, want.GPXPath.String(), got.GPXPath.String(), format, args...)
 	testutils.AssertEqualString(t, want.MapPath.String(), got.MapPath.String(), format, args...)
 	testutils.AssertEqualString(t, want.ShareableMapPath.String(), got.ShareableMapPath.String(), format, args...)
+	AssertEqualShareableCards(t, want.Cards, got.Cards, format, args...)
+	testutils.AssertEqualString(t, want.ElevationChartPath.String(), got.ElevationChartPath.String(), format, args...)
+	testutils.AssertEqualString(t, want.PaceChartPath.String(), got.PaceChartPath.String(), format, args...)
+	testutils.AssertEqualString(t, want.Title, got.Title, format, args...)
+	testutils.AssertEqualString(t, want.Description, got.Description, format, args...)
+	testutils.AssertEqualString(t, want.Type.String(), got.Type.String(), format, args...)
+	AssertEqualMapStyle(t, want.MapStyle, got.MapStyle, format, args...)
+	testutils.AssertEqualString(t, want.MapStatus.String(), got.MapStatus.String(), format, args...)
+	testutils.AssertEqualString(t, want.MapError, got.MapError, format, args...)
+	testutils.AssertEqualString(t, want.Username, got.Username, format, args...)
+	testutils.AssertEqualString(t, want.Visibility.String(), got.Visibility.String(), format, args...)
+}
+
+func AssertEqualMapStyle(t *testing.T, want domain.MapStyle, got domain.MapStyle, format string, args ...interface{}) {
+	t.Helper()
+
+	testutils.AssertEqualString(t, want.Theme, got.Theme, format, args...)
+	testutils.AssertEqualString(t, want.LineColor, got.LineColor, format, args...)
+	testutils.AssertEqualInt(t, want.LineThickness, got.LineThickness, format, args...)
+	testutils.AssertEqualFloat64(t, want.LineOpacity, got.LineOpacity, format, args...)
+	testutils.AssertEqualInt(t, want.Width, got.Width, format, args...)
+	testutils.AssertEqualInt(t, want.Height, got.Height, format, args...)
+	testutils.AssertEqualInt(t, want.Padding, got.Padding, format, args...)
+	testutils.AssertEqualString(t, want.RouteColoring.String(), got.RouteColoring.String(), format, args...)
+}
+
+func AssertEqualExport(t *testing.T, want domain.Export, got domain.Export, format string, args ...interface{}) {
+	t.Helper()
+
+	testutils.AssertEqualString(t, want.ID.String(), got.ID.String(), format, args...)
+	testutils.AssertEqualString(t, want.Status.String(), got.Status.String(), format, args...)
+	testutils.AssertEqualString(t, want.ArchivePath.String(), got.ArchivePath.String(), format, args...)
+	testutils.AssertEqualTime(t, want.RequestedAt, got.RequestedAt, format, args...)
+	testutils.AssertEqualTime(t, want.CompletedAt, got.CompletedAt, format, args...)
+	testutils.AssertEqualString(t, want.Username, got.Username, format, args...)
+}
+
+func AssertEqualImport(t *testing.T, want domain.Import, got domain.Import, format string, args ...interface{}) {
+	t.Helper()
+
+	testutils.AssertEqualString(t, want.ID.String(), got.ID.String(), format, args...)
+	testutils.AssertEqualString(t, want.ArchivePath, got.ArchivePath, format, args...)
+	testutils.AssertEqualTime(t, want.CreatedAt, got.CreatedAt, format, args...)
+	testutils.AssertEqualInt(t, want.Progress.Pending, got.Progress.Pending, format, args...)
+	testutils.AssertEqualInt(t, want.Progress.Imported, got.Progress.Imported, format, args...)
+	testutils.AssertEqualInt(t, want.Progress.Skipped, got.Progress.Skipped, format, args...)
+	testutils.AssertEqualInt(t, want.Progress.Failed, got.Progress.Failed, format, args...)
+	testutils.AssertEqualString(t, want.Username, got.Username, format, args...)
+}
+
+func AssertEqualImportItem(t *testing.T, want domain.ImportItem, got domain.ImportItem, format string, args ...interface{}) {
+	t.Helper()
+
+	testutils.AssertEqualString(t, want.ImportID.String(), got.ImportID.String(), format, args...)
+	testutils.AssertEqualString(t, want.ExternalID, got.ExternalID, format, args...)
+	testutils.AssertEqualString(t, want.FileName, got.FileName, format, args...)
+	testutils.AssertEqualTime(t, want.RanAt, got.RanAt, format, args...)
+	testutils.AssertEqualString(t, want.Type, got.Type, format, args...)
+	testutils.AssertEqualString(t, want.Name, got.Name, format, args...)
+	testutils.AssertEqualString(t, want.Description, got.Description, format, args...)
+	testutils.AssertEqualString(t, want.Status.String(), got.Status.String(), format, args...)
+	testutils.AssertEqualString(t, want.Reason, got.Reason, format, args...)
+}
+
+func AssertEqualShareableCards(t *testing.T, want []domain.ShareableCard, got []domain.ShareableCard, format string, args ...interface{}) {
+	t.Helper()
+
+	testutils.RequireEqualInt(t, len(want), len(got), format, args...)
+	for i := range want {
+		testutils.AssertEqualString(t, want[i].Template, got[i].Template, format, args...)
+		testutils.AssertEqualString(t, want[i].Path.String(), got[i].Path.String(), format, args...)
+		testutils.AssertEqualInt(t, want[i].Width, got[i].Width, format, args...)
+		testutils.AssertEqualInt(t, want[i].Height, got[i].Height, format, args...)
+	}
+}
+
+func AssertEqualAPIToken(t *testing.T, want domain.APIToken, got domain.APIToken, format string, args ...interface{}) {
+	t.Helper()
+
+	testutils.AssertEqualString(t, want.ID.String(), got.ID.String(), format, args...)
+	testutils.AssertEqualString(t, want.Username, got.Username, format, args...)
+	testutils.AssertEqualString(t, want.Name, got.Name, format, args...)
+	testutils.AssertEqualString(t, want.Scope.String(), got.Scope.String(), format, args...)
+	testutils.AssertEqualString(t, want.Hash.String(), got.Hash.String(), format, args...)
+	testutils.AssertEqualTime(t, want.CreatedAt, got.CreatedAt, format, args...)
+	testutils.AssertEqualTime(t, want.LastUsedAt, got.LastUsedAt, format, args...)
+}
+
+func AssertEqualPrivacyZone(t *testing.T, want domain.PrivacyZone, got domain.PrivacyZone, format string, args ...interface{}) {
+	t.Helper()
+
+	testutils.AssertEqualString(t, want.ID.String(), got.ID.String(), format, args...)
+	testutils.AssertEqualString(t, want.Username, got.Username, format, args...)
+	testutils.AssertEqualString(t, want.Name, got.Name, format, args...)
+	testutils.AssertEqualFloat64(t, want.Latitude, got.Latitude, format, args...)
+	testutils.AssertEqualFloat64(t, want.Longitude, got.Longitude, format, args...)
+	testutils.AssertEqualInt(t, want.RadiusMeters, got.RadiusMeters, format, args...)
+	testutils.AssertEqualTime(t, want.CreatedAt, got.CreatedAt, format, args...)
+}
+
+func AssertEqualUser(t *testing.T, want domain.User, got domain.User, format string, args ...interface{}) {
+	t.Helper()
+
+	testutils.AssertEqualString(t, want.Username, got.Username, format, args...)
+	testutils.AssertEqualString(t, want.PasswordHash.String(), got.PasswordHash.String(), format, args...)
+	testutils.AssertEqualBool(t, want.IsAdmin, got.IsAdmin, format, args...)
+	testutils.AssertEqualTime(t, want.CreatedAt, got.CreatedAt, format, args...)
+	testutils.AssertEqualTime(t, want.DisabledAt, got.DisabledAt, format, args...)
 }
@@ -2,6 +2,7 @@ package domaintest
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -141,10 +142,18 @@ type RunningActivity struct {
 	duration time.Duration
 	distance domain.Distance
 	speed    domain.Speed
+	details  domain.RunningActivityDetails
+	mapStyle domain.MapStyle
+	username string
+
+	mapPending       bool
+	pendingMapReason string
 }
 
 func NewRunningActivity(t *testing.T) RunningActivity {
 	ranAt := time.Now().
+		UTC().
+		Truncate(time.Minute).
 		Add(-durationBetween(1, 24*30*12) * time.Hour)
 
 	meters := intBetween(5000, 10000)
@@ -161,6 +170,7 @@ func NewRunningActivity(t *testing.T) RunningActivity {
 		distance: distance,
 		speed:    speed,
 		duration: durationBetween(30, 60) * time.Minute,
+		mapStyle: domain.DefaultMapStyle(),
 	}
 }
 
@@ -197,6 +207,44 @@ func (r RunningActivity) WithDistanceMeters(meters int) RunningActivity {
 	return r
 }
 
+func (r RunningActivity) WithDetails(title string, description string) RunningActivity {
+	r.details.Title = title
+	r.details.Description = description
+
+	return r
+}
+
+func (r RunningActivity) WithType(activityType domain.ActivityType) RunningActivity {
+	r.details.Type = activityType
+
+	return r
+}
+
+func (r RunningActivity) WithVisibility(visibility domain.ActivityVisibility) RunningActivity {
+	r.details.Visibility = visibility
+
+	return r
+}
+
+func (r RunningActivity) WithMapStyle(style domain.MapStyle) RunningActivity {
+	r.mapStyle = style
+
+	return r
+}
+
+func (r RunningActivity) WithUsername(username string) RunningActivity {
+	r.username = username
+
+	return r
+}
+
+func (r RunningActivity) WithPendingMap(reason string) RunningActivity {
+	r.pendingMapReason = reason
+	r.mapPending = true
+
+	return r
+}
+
 func (r RunningActivity) Build() domain.RunningActivity {
 	activity, err := domain.NewRunningActivity(
 		r.ranAt,
@@ -210,6 +258,19 @@ func (r RunningActivity) Build() domain.RunningActivity {
 
 	testutils.AssertNoError(r.t, err, "can't generate activity")
 
+	activity = activity.
+		WithDetails(r.details).
+		WithMapStyle(r.mapStyle).
+		WithOwner(r.username).
+		WithCards(domain.DefaultCardTemplates().Cards(fmt.Sprintf("runs/%s", r.ranAt.Format("2006-01-02.15h04")))).
+		WithCharts(
+			domain.ElevationChartFilePath(fmt.Sprintf("runs/%s/elevation", r.ranAt.Format("2006-01-02.15h04"))),
+			domain.PaceChartFilePath(fmt.Sprintf("runs/%s/pace", r.ranAt.Format("2006-01-02.15h04"))),
+		)
+	if r.mapPending {
+		activity = activity.WithPendingMap(r.pendingMapReason)
+	}
+
 	return activity
 }
 
@@ -220,3 +281,337 @@ func (r RunningActivity) Persist(w repository.Writer) domain.RunningActivity {
 
 	return activity
 }
+
+type Export struct {
+	t      *testing.T
+	export domain.Export
+}
+
+func NewExport(t *testing.T) Export {
+	requestedAt := time.Now().
+		UTC().
+		Truncate(time.Second).
+		Add(-durationBetween(1, 24*30) * time.Hour)
+
+	return Export{t: t, export: domain.NewExport("", requestedAt)}
+}
+
+func (e Export) WithUsername(username string) Export {
+	e.export.Username = username
+
+	return e
+}
+
+func (e Export) WithRequestedAt(requestedAt time.Time) Export {
+	e.export.RequestedAt = requestedAt
+
+	return e
+}
+
+func (e Export) Ready() Export {
+	e.export = e.export.Complete(
+		domain.ExportArchivePath(fmt.Sprintf("exports/%s/sport-export.zip", e.export.ID)),
+		e.export.RequestedAt.Add(5*time.Minute),
+	)
+
+	return e
+}
+
+func (e Export) Build() domain.Export {
+	return e.export
+}
+
+func (e Export) Persist(w repository.Writer) domain.Export {
+	export := e.Build()
+	err := w.RecordExport(context.Background(), export)
+	testutils.AssertNoError(e.t, err, "can't persist export")
+
+	return export
+}
+
+type Import struct {
+	t   *testing.T
+	imp domain.Import
+}
+
+func NewImport(t *testing.T) Import {
+	createdAt := time.Now().
+		UTC().
+		Truncate(time.Second).
+		Add(-durationBetween(1, 24*30) * time.Hour)
+
+	imp := domain.NewImport("", "", createdAt)
+	imp.ArchivePath = fmt.Sprintf("uploads/imports/%s.zip", imp.ID)
+
+	return Import{t: t, imp: imp}
+}
+
+func (i Import) WithCreatedAt(createdAt time.Time) Import {
+	i.imp.CreatedAt = createdAt
+
+	return i
+}
+
+func (i Import) WithUsername(username string) Import {
+	i.imp.Username = username
+
+	return i
+}
+
+func (i Import) Build() domain.Import {
+	return i.imp
+}
+
+func (i Import) Persist(w repository.Writer) domain.Import {
+	imp := i.Build()
+	err := w.RecordImport(context.Background(), imp)
+	testutils.AssertNoError(i.t, err, "can't persist import")
+
+	return imp
+}
+
+type ImportItem struct {
+	t    *testing.T
+	item domain.ImportItem
+}
+
+func NewImportItem(t *testing.T, importID domain.ID) ImportItem {
+	ranAt := time.Now().
+		UTC().
+		Truncate(time.Second).
+		Add(-durationBetween(1, 24*365) * time.Hour)
+
+	externalID := domain.NewID().String()
+
+	return ImportItem{t: t, item: domain.ImportItem{
+		ImportID:    importID,
+		ExternalID:  externalID,
+		FileName:    fmt.Sprintf("activities/%s.gpx", externalID),
+		RanAt:       ranAt,
+		Type:        "Run",
+		Name:        "Morning Run",
+		Description: "",
+		Status:      domain.ImportItemStatusPending,
+	}}
+}
+
+func (i ImportItem) WithExternalID(externalID string) ImportItem {
+	i.item.ExternalID = externalID
+
+	return i
+}
+
+func (i ImportItem) WithFileName(fileName string) ImportItem {
+	i.item.FileName = fileName
+
+	return i
+}
+
+func (i ImportItem) WithType(kind string) ImportItem {
+	i.item.Type = kind
+
+	return i
+}
+
+func (i ImportItem) WithRawRanAt(ranAt string) ImportItem {
+	t, err := time.Parse(time.RFC3339, ranAt)
+	testutils.AssertNoError(i.t, err, "invalid ran at time in import item builder")
+	i.item.RanAt = t
+
+	return i
+}
+
+func (i ImportItem) WithStatus(status domain.ImportItemStatus) ImportItem {
+	i.item.Status = status
+
+	return i
+}
+
+func (i ImportItem) Build() domain.ImportItem {
+	return i.item
+}
+
+func (i ImportItem) Persist(w repository.Writer) domain.ImportItem {
+	item := i.Build()
+	err := w.RecordImportItems(context.Background(), []domain.ImportItem{item})
+	testutils.AssertNoError(i.t, err, "can't persist import item")
+
+	return item
+}
+
+type APIToken struct {
+	t      *testing.T
+	token  domain.APIToken
+	secret string
+}
+
+func NewAPIToken(t *testing.T) APIToken {
+	createdAt := time.Now().
+		UTC().
+		Truncate(time.Second).
+		Add(-durationBetween(1, 24*30) * time.Hour)
+
+	token, secret, err := domain.NewAPIToken("alice", fmt.Sprintf("script %d", intBetween(1, 1000)), "read", createdAt)
+	testutils.RequireNoError(t, err, "can't create api token")
+
+	return APIToken{t: t, token: token, secret: secret}
+}
+
+func (a APIToken) WithUsername(username string) APIToken {
+	a.token.Username = username
+
+	return a
+}
+
+func (a APIToken) WithScope(scope domain.APITokenScope) APIToken {
+	a.token.Scope = scope
+
+	return a
+}
+
+func (a APIToken) WithCreatedAt(createdAt time.Time) APIToken {
+	a.token.CreatedAt = createdAt
+
+	return a
+}
+
+func (a APIToken) WithLastUsedAt(lastUsedAt time.Time) APIToken {
+	a.token.LastUsedAt = lastUsedAt
+
+	return a
+}
+
+// Secret returns the secret matching the hash of the token
+func (a APIToken) Secret() string {
+	return a.secret
+}
+
+func (a APIToken) Build() domain.APIToken {
+	return a.token
+}
+
+func (a APIToken) Persist(w repository.Writer) domain.APIToken {
+	token := a.Build()
+	err := w.RecordAPIToken(context.Background(), token)
+	testutils.AssertNoError(a.t, err, "can't persist api token")
+
+	return token
+}
+
+type PrivacyZone struct {
+	t    *testing.T
+	zone domain.PrivacyZone
+}
+
+// NewPrivacyZone returns a zone of alice around the first point of the files built by NewGPXFile
+func NewPrivacyZone(t *testing.T) PrivacyZone {
+	createdAt := time.Now().
+		UTC().
+		Truncate(time.Second).
+		Add(-durationBetween(1, 24*30) * time.Hour)
+
+	zone, err := domain.NewPrivacyZone("alice", fmt.Sprintf("zone %d", intBetween(1, 1000)), "38.5", "-120.2", "200", createdAt)
+	testutils.RequireNoError(t, err, "can't create privacy zone")
+
+	return PrivacyZone{t: t, zone: zone}
+}
+
+func (p PrivacyZone) WithUsername(username string) PrivacyZone {
+	p.zone.Username = username
+
+	return p
+}
+
+func (p PrivacyZone) WithCentre(latitude float64, longitude float64) PrivacyZone {
+	p.zone.Latitude = latitude
+	p.zone.Longitude = longitude
+
+	return p
+}
+
+func (p PrivacyZone) WithRadius(meters int) PrivacyZone {
+	p.zone.RadiusMeters = meters
+
+	return p
+}
+
+func (p PrivacyZone) WithCreatedAt(createdAt time.Time) PrivacyZone {
+	p.zone.CreatedAt = createdAt
+
+	return p
+}
+
+func (p PrivacyZone) Build() domain.PrivacyZone {
+	return p.zone
+}
+
+func (p PrivacyZone) Persist(w repository.Writer) domain.PrivacyZone {
+	zone := p.Build()
+	err := w.RecordPrivacyZone(context.Background(), zone)
+	testutils.AssertNoError(p.t, err, "can't persist privacy zone")
+
+	return zone
+}
+
+// UserPassword is the password of the users built by NewUser
+const UserPassword = "correct horse battery"
+
+// userPasswordHash is computed once since hashing a password is slow on purpose
+var userPasswordHash = struct {
+	once sync.Once
+	hash domain.UserPasswordHash
+	err  error
+}{}
+
+type User struct {
+	t    *testing.T
+	user domain.User
+}
+
+func NewUser(t *testing.T) User {
+	userPasswordHash.once.Do(func() {
+		userPasswordHash.hash, userPasswordHash.err = domain.HashUserPassword(UserPassword)
+	})
+	testutils.RequireNoError(t, userPasswordHash.err, "can't hash user password")
+
+	createdAt := time.Now().
+		UTC().
+		Truncate(time.Second).
+		Add(-durationBetween(1, 24*30) * time.Hour)
+
+	return User{t: t, user: domain.User{
+		Username:     fmt.Sprintf("user-%d", intBetween(1, 1000000)),
+		PasswordHash: userPasswordHash.hash,
+		CreatedAt:    createdAt,
+	}}
+}
+
+func (u User) WithUsername(username string) User {
+	u.user.Username = username
+
+	return u
+}
+
+func (u User) WithAdmin() User {
+	u.user.IsAdmin = true
+
+	return u
+}
+
+func (u User) WithDisabledAt(disabledAt time.Time) User {
+	u.user.DisabledAt = disabledAt
+
+	return u
+}
+
+func (u User) Build() domain.User {
+	return u.user
+}
+
+func (u User) Persist(w repository.Writer) domain.User {
+	user := u.Build()
+	err := w.RecordUser(context.Background(), user)
+	testutils.AssertNoError(u.t, err, "can't persist user")
+
+	return user
+}
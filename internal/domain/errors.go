@@ -58,5 +58,58 @@ var ErrDistanceTooSmall = errors.New("distance can't be less than 0 meters")
 // ErrSpeedTooSmall is returned when speed is built with a too small number
 var ErrSpeedTooSmall = errors.New("speed can't be less than 0 km/h")
 
+// ErrPaceTooFast is returned when a pace is built with a faster value than anyone can run
+var ErrPaceTooFast = errors.New("pace can't be faster than 1:00/km")
+
+// ErrPaceTooSlow is returned when a pace is built with a slower value than anyone can keep moving
+var ErrPaceTooSlow = errors.New("pace can't be slower than 24 hours per km")
+
 // ErrCantGetRunningSession is returned when a GetRunningSession usecase can't retrieve an activity
 var ErrCantGetRunningSession = errors.New("running session not found")
+
+// ErrRunningActivityAssetNotFound is returned when an activity doesn't have the requested file
+var ErrRunningActivityAssetNotFound = errors.New("running activity asset not found")
+
+// ErrRunningActivityForbidden is returned when a user edits or deletes an activity they don't own
+var ErrRunningActivityForbidden = errors.New("running activity belongs to another athlete")
+
+// ErrRunningActivityAlreadyExists is returned when an activity is recorded at the same minute as another one, which
+// would share its slug and files
+var ErrRunningActivityAlreadyExists = errors.New("running activity already exists at this time")
+
+// ErrInvalidActivityFile is returned when an activity file can't be parsed or describes an impossible activity,
+// retrying won't help
+var ErrInvalidActivityFile = errors.New("invalid activity file")
+
+// ErrExportNotFound is returned when an export can't be retrieved
+var ErrExportNotFound = errors.New("export not found")
+
+// ErrImportNotFound is returned when an import or one of its files can't be retrieved
+var ErrImportNotFound = errors.New("import not found")
+
+// ErrUnsupportedActivityFormat is returned when an imported activity file can't be converted to GPX
+var ErrUnsupportedActivityFormat = errors.New("unsupported activity file format")
+
+// ErrMapProviderUnavailable is returned when a map can't be generated for now but may be later
+var ErrMapProviderUnavailable = errors.New("map provider is temporarily unavailable")
+
+// ErrUserPreferencesNotFound is returned when a user never saved their preferences
+var ErrUserPreferencesNotFound = errors.New("user preferences not found")
+
+// ErrMapRejected is returned when the map provider refuses to generate a map, retrying won't help
+var ErrMapRejected = errors.New("map provider rejected the map")
+
+// ErrAPITokenNotFound is returned when a personal access token doesn't exist or was revoked
+var ErrAPITokenNotFound = errors.New("api token not found")
+
+// ErrPrivacyZoneNotFound is returned when a privacy zone doesn't exist or belongs to another user
+var ErrPrivacyZoneNotFound = errors.New("privacy zone not found")
+
+// ErrUserNotFound is returned when a user doesn't exist
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrUserAlreadyExists is returned when a user is created with the username of another one
+var ErrUserAlreadyExists = errors.New("user already exists")
+
+// ErrInvalidCredentials is returned when a username and password don't match an enabled user
+var ErrInvalidCredentials = errors.New("invalid credentials")
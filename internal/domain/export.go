@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// ExportStatus represents the progress of an export
+type ExportStatus string
+
+const (
+	// ExportStatusPending is the status of an export waiting for its archive to be built
+	ExportStatusPending ExportStatus = "pending"
+	// ExportStatusReady is the status of an export whose archive can be downloaded
+	ExportStatusReady ExportStatus = "ready"
+)
+
+// String implements Stringer interface
+func (s ExportStatus) String() string {
+	return string(s)
+}
+
+// ExportArchivePath represents the path to an export archive
+type ExportArchivePath string
+
+// String implements Stringer interface
+func (p ExportArchivePath) String() string {
+	return string(p)
+}
+
+// Export represents a request to download all the recorded activities
+type Export struct {
+	ID          ID
+	Status      ExportStatus
+	ArchivePath ExportArchivePath
+	RequestedAt time.Time
+	CompletedAt time.Time
+	// Username is the athlete who requested the export and whose activities it archives
+	Username string
+}
+
+// NewExport initializes a pending export requested by username
+func NewExport(username string, requestedAt time.Time) Export {
+	return Export{
+		ID:          NewID(),
+		Status:      ExportStatusPending,
+		RequestedAt: requestedAt,
+		Username:    username,
+	}
+}
+
+// IsOwnedBy returns whether the export was requested by the user
+func (e Export) IsOwnedBy(username string) bool {
+	return username != "" && e.Username == username
+}
+
+// IsReady returns whether the archive can be downloaded
+func (e Export) IsReady() bool {
+	return e.Status == ExportStatusReady
+}
+
+// Complete returns the export marked as ready with its archive
+func (e Export) Complete(archivePath ExportArchivePath, completedAt time.Time) Export {
+	e.Status = ExportStatusReady
+	e.ArchivePath = archivePath
+	e.CompletedAt = completedAt
+
+	return e
+}
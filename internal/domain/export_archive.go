@@ -0,0 +1,21 @@
+package domain
+
+import "io"
+
+// ExportArchive represents an archive containing all the recorded activities
+type ExportArchive struct {
+	content io.ReadCloser
+}
+
+func NewExportArchive(content io.ReadCloser) ExportArchive {
+	return ExportArchive{content: content}
+}
+
+func (a ExportArchive) File() io.Reader {
+	return a.content
+}
+
+// Close releases the resources held by the archive
+func (a ExportArchive) Close() error {
+	return a.content.Close()
+}
@@ -0,0 +1,51 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestNewExport(t *testing.T) {
+	requestedAt := time.Date(2022, 4, 17, 9, 0, 0, 0, time.UTC)
+	export := domain.NewExport("alice", requestedAt)
+
+	testutils.AssertEqualString(t, "pending", export.Status.String(), "unexpected status")
+	testutils.AssertEqualString(t, "alice", export.Username, "unexpected username")
+	testutils.AssertEqualBool(t, false, export.IsReady(), "export shouldn't be ready")
+	testutils.AssertEqualTime(t, requestedAt, export.RequestedAt, "unexpected requested at")
+}
+
+func TestExportComplete(t *testing.T) {
+	completedAt := time.Date(2022, 4, 17, 9, 5, 0, 0, time.UTC)
+	export := domain.NewExport("alice", completedAt.Add(-5*time.Minute))
+
+	completed := export.Complete(domain.ExportArchivePath("exports/archive.zip"), completedAt)
+
+	testutils.AssertEqualBool(t, true, completed.IsReady(), "export should be ready")
+	testutils.AssertEqualString(t, export.ID.String(), completed.ID.String(), "unexpected id")
+	testutils.AssertEqualString(t, "exports/archive.zip", completed.ArchivePath.String(), "unexpected archive path")
+	testutils.AssertEqualTime(t, completedAt, completed.CompletedAt, "unexpected completed at")
+	testutils.AssertEqualBool(t, false, export.IsReady(), "original export shouldn't be modified")
+}
+
+func TestExportIsOwnedBy(t *testing.T) {
+	export := domain.NewExport("alice", time.Now())
+
+	testutils.AssertEqualBool(t, true, export.IsOwnedBy("alice"), "requester should own the export")
+	testutils.AssertEqualBool(t, false, export.IsOwnedBy("bob"), "other users shouldn't own the export")
+	testutils.AssertEqualBool(t, false, domain.NewExport("", time.Now()).IsOwnedBy(""), "nobody should own an orphan export")
+}
+
+func TestParseID(t *testing.T) {
+	id := domain.NewID()
+
+	parsed, err := domain.ParseID(id.String())
+	testutils.AssertNoError(t, err, "can't parse id")
+	testutils.AssertEqualString(t, id.String(), parsed.String(), "unexpected id")
+
+	_, err = domain.ParseID("not-an-id")
+	testutils.AssertErrorContains(t, "invalid identifier", err, "unexpected error")
+}
@@ -14,10 +14,35 @@ type GPXPoint struct {
 	Distance  float64
 	Elevation float64
 	Speed     float64
+	// HeartRate is in beats per minute, 0 when it wasn't recorded
+	HeartRate int
 }
 
 type GPXPoints []GPXPoint
 
+// elevationNoiseMeters is the smallest climb counted in the elevation gain, to ignore GPS noise
+const elevationNoiseMeters = 2
+
+// ElevationGain returns the cumulated climb, in meters, of the points
+func (p GPXPoints) ElevationGain() float64 {
+	if len(p) == 0 {
+		return 0
+	}
+
+	var gain float64
+	reference := p[0].Elevation
+	for _, point := range p[1:] {
+		if climb := point.Elevation - reference; climb >= elevationNoiseMeters {
+			gain += climb
+			reference = point.Elevation
+		} else if climb < 0 {
+			reference = point.Elevation
+		}
+	}
+
+	return gain
+}
+
 type GPXFile struct {
 	Distance Distance
 	Duration time.Duration
@@ -41,3 +66,13 @@ func NewGPXFile(content []byte, distance Distance, duration time.Duration, speed
 func (g GPXFile) File() io.Reader {
 	return bytes.NewBuffer(g.content)
 }
+
+// WithoutPointsIn returns the file whose points inside the zones are hidden, to draw its map. Distance, duration and
+// speed still are the ones of the full track. Its content is dropped since it would reveal the hidden points.
+func (g GPXFile) WithoutPointsIn(zones PrivacyZones) GPXFile {
+	if len(zones) == 0 {
+		return g
+	}
+
+	return NewGPXFile(nil, g.Distance, g.Duration, g.Speed, zones.Mask(g.Points))
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
 
 // ID represents an internal identifier
 type ID uuid.UUID
@@ -14,3 +18,13 @@ func NewID() ID {
 func (id ID) String() string {
 	return uuid.UUID(id).String()
 }
+
+// ParseID parses the string representation of an identifier
+func ParseID(raw string) (ID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return ID{}, fmt.Errorf("invalid identifier '%s': %v", raw, err)
+	}
+
+	return ID(id), nil
+}
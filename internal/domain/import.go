@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// ImportItemStatus represents the progress of a file being imported
+type ImportItemStatus string
+
+const (
+	// ImportItemStatusPending is the status of a file waiting to be imported
+	ImportItemStatusPending ImportItemStatus = "pending"
+	// ImportItemStatusImported is the status of a file recorded as a running activity
+	ImportItemStatusImported ImportItemStatus = "imported"
+	// ImportItemStatusSkipped is the status of a file which is not meant to be imported
+	ImportItemStatusSkipped ImportItemStatus = "skipped"
+	// ImportItemStatusFailed is the status of a file which couldn't be imported
+	ImportItemStatusFailed ImportItemStatus = "failed"
+)
+
+// String implements Stringer interface
+func (s ImportItemStatus) String() string {
+	return string(s)
+}
+
+// ImportProgress represents the number of files of an import in each status
+type ImportProgress struct {
+	Pending  int
+	Imported int
+	Skipped  int
+	Failed   int
+}
+
+// Total returns the number of files in the import
+func (p ImportProgress) Total() int {
+	return p.Pending + p.Imported + p.Skipped + p.Failed
+}
+
+// Import represents the import of an archive exported from another platform
+type Import struct {
+	ID          ID
+	ArchivePath string
+	CreatedAt   time.Time
+	Progress    ImportProgress
+	// Username is the athlete who uploaded the archive and owns its activities
+	Username string
+}
+
+// NewImport initializes an import of the archive stored at archivePath, uploaded by username
+func NewImport(username string, archivePath string, createdAt time.Time) Import {
+	return Import{
+		ID:          NewID(),
+		ArchivePath: archivePath,
+		CreatedAt:   createdAt,
+		Username:    username,
+	}
+}
+
+// CanBeViewedBy returns whether the user can follow or resume the import, that is when they uploaded the archive or
+// are an administrator
+func (i Import) CanBeViewedBy(user User) bool {
+	return user.IsAdmin || (i.Username != "" && i.Username == user.Username)
+}
+
+// IsDone returns whether all the files of the import have been processed
+func (i Import) IsDone() bool {
+	return i.Progress.Total() > 0 && i.Progress.Pending == 0
+}
+
+// ImportItem represents an activity listed in an import archive
+type ImportItem struct {
+	ImportID    ID
+	ExternalID  string
+	FileName    string
+	RanAt       time.Time
+	Type        string
+	Name        string
+	Description string
+	Status      ImportItemStatus
+	Reason      string
+}
+
+// IsRun returns whether the activity is a kind of run
+func (i ImportItem) IsRun() bool {
+	return strings.Contains(strings.ToLower(i.Type), "run")
+}
+
+// IsPending returns whether the item still has to be imported
+func (i ImportItem) IsPending() bool {
+	return i.Status == ImportItemStatusPending
+}
+
+// Details returns the information describing the activity
+func (i ImportItem) Details() RunningActivityDetails {
+	return RunningActivityDetails{Title: i.Name, Description: i.Description, Type: activityTypeFromStrava(i.Type)}
+}
+
+// Imported returns the item marked as imported
+func (i ImportItem) Imported() ImportItem {
+	i.Status = ImportItemStatusImported
+	i.Reason = ""
+
+	return i
+}
+
+// Skip returns the item marked as skipped for the reason
+func (i ImportItem) Skip(reason string) ImportItem {
+	i.Status = ImportItemStatusSkipped
+	i.Reason = reason
+
+	return i
+}
+
+// Fail returns the item marked as failed for the reason
+func (i ImportItem) Fail(reason string) ImportItem {
+	i.Status = ImportItemStatusFailed
+	i.Reason = reason
+
+	return i
+}
@@ -0,0 +1,75 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestImportIsDone(t *testing.T) {
+	imp := domain.NewImport("alice", "imports/export.zip", time.Now())
+	testutils.AssertEqualBool(t, false, imp.IsDone(), "empty import shouldn't be done")
+
+	imp.Progress = domain.ImportProgress{Pending: 1, Imported: 2}
+	testutils.AssertEqualBool(t, false, imp.IsDone(), "import with pending files shouldn't be done")
+
+	imp.Progress = domain.ImportProgress{Imported: 2, Skipped: 1, Failed: 1}
+	testutils.AssertEqualBool(t, true, imp.IsDone(), "import should be done")
+	testutils.AssertEqualInt(t, 4, imp.Progress.Total(), "unexpected total")
+}
+
+func TestImportCanBeViewedBy(t *testing.T) {
+	imp := domain.NewImport("alice", "imports/export.zip", time.Now())
+
+	testutils.AssertEqualBool(t, true, imp.CanBeViewedBy(domain.User{Username: "alice"}), "owner should view the import")
+	testutils.AssertEqualBool(t, true, imp.CanBeViewedBy(domain.User{Username: "bob", IsAdmin: true}), "admin should view the import")
+	testutils.AssertEqualBool(t, false, imp.CanBeViewedBy(domain.User{Username: "bob"}), "other athlete shouldn't view the import")
+	testutils.AssertEqualBool(t, false, domain.Import{}.CanBeViewedBy(domain.User{}), "orphan import shouldn't be viewed by a user without name")
+}
+
+func TestImportItemIsRun(t *testing.T) {
+	tcs := map[string]bool{
+		"Run":         true,
+		"Trail Run":   true,
+		"Virtual Run": true,
+		"Ride":        false,
+		"Walk":        false,
+	}
+
+	for activityType, expected := range tcs {
+		item := domain.ImportItem{Type: activityType}
+		testutils.AssertEqualBool(t, expected, item.IsRun(), "unexpected result for type %s", activityType)
+	}
+}
+
+func TestImportItemDetailsType(t *testing.T) {
+	tcs := map[string]domain.ActivityType{
+		"Run":         domain.ActivityTypeRun,
+		"Virtual Run": domain.ActivityTypeRun,
+		"Trail Run":   domain.ActivityTypeTrailRun,
+		"Hike":        domain.ActivityTypeHike,
+	}
+
+	for activityType, expected := range tcs {
+		item := domain.ImportItem{Type: activityType}
+		testutils.AssertEqualString(t, expected.String(), item.Details().Type.String(), "unexpected type for %s", activityType)
+	}
+}
+
+func TestImportItemTransitions(t *testing.T) {
+	item := domain.ImportItem{Status: domain.ImportItemStatusPending}
+	testutils.AssertEqualBool(t, true, item.IsPending(), "item should be pending")
+
+	skipped := item.Skip("not a run")
+	testutils.AssertEqualString(t, "skipped", skipped.Status.String(), "unexpected status")
+	testutils.AssertEqualString(t, "not a run", skipped.Reason, "unexpected reason")
+
+	failed := item.Fail("boom")
+	testutils.AssertEqualString(t, "failed", failed.Status.String(), "unexpected status")
+
+	imported := failed.Imported()
+	testutils.AssertEqualString(t, "imported", imported.Status.String(), "unexpected status")
+	testutils.AssertEqualString(t, "", imported.Reason, "reason should be cleared")
+}
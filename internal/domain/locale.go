@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale represents the language texts, numbers and dates are displayed in
+type Locale string
+
+const (
+	LocaleEnglish Locale = "en"
+	LocaleFrench  Locale = "fr"
+)
+
+// Locales lists every supported locale
+var Locales = []Locale{LocaleEnglish, LocaleFrench}
+
+type localeSettings struct {
+	label            string
+	openGraph        string
+	decimalSeparator string
+	dateLayout       string
+	dateTimeLayout   string
+	messages         map[string]string
+}
+
+var locales = map[Locale]localeSettings{
+	LocaleEnglish: {
+		label:            "English",
+		openGraph:        "en_US",
+		decimalSeparator: ".",
+		dateLayout:       "2006/01/02",
+		dateTimeLayout:   "2006/01/02 15:04",
+	},
+	LocaleFrench: {
+		label:            "Français",
+		openGraph:        "fr_FR",
+		decimalSeparator: ",",
+		dateLayout:       "02/01/2006",
+		dateTimeLayout:   "02/01/2006 15:04",
+		messages:         frenchMessages,
+	},
+}
+
+// ParseLocale returns the locale matching the value
+func ParseLocale(value string) (Locale, error) {
+	locale := Locale(value)
+	if _, ok := locales[locale]; !ok {
+		return "", fmt.Errorf("unsupported locale %s", value)
+	}
+
+	return locale, nil
+}
+
+func (l Locale) String() string {
+	return string(l)
+}
+
+// Label returns the name of the locale, in its own language
+func (l Locale) Label() string {
+	return l.settings().label
+}
+
+// OpenGraph returns the locale formatted for og:locale meta tags (e.g. en_US)
+func (l Locale) OpenGraph() string {
+	return l.settings().openGraph
+}
+
+// Translate returns the translation of the english message, or the message itself when it isn't translated
+func (l Locale) Translate(message string) string {
+	if translation, ok := l.settings().messages[message]; ok {
+		return translation
+	}
+
+	return message
+}
+
+// FormatNumber returns the value with the number of decimals and the decimal separator of the locale
+func (l Locale) FormatNumber(value float64, decimals int) string {
+	return strings.Replace(strconv.FormatFloat(value, 'f', decimals, 64), ".", l.settings().decimalSeparator, 1)
+}
+
+// FormatDate returns the day of the time, ordered as the locale does
+func (l Locale) FormatDate(t time.Time) string {
+	return t.Format(l.settings().dateLayout)
+}
+
+// FormatDateTime returns the day and the minute of the time, ordered as the locale does
+func (l Locale) FormatDateTime(t time.Time) string {
+	return t.Format(l.settings().dateTimeLayout)
+}
+
+// settings returns the settings of the locale, falling back to english for unknown locales
+func (l Locale) settings() localeSettings {
+	if settings, ok := locales[l]; ok {
+		return settings
+	}
+
+	return locales[LocaleEnglish]
+}
@@ -0,0 +1,158 @@
+package domain
+
+// frenchMessages translates the english messages of the templates, labels and cards
+var frenchMessages = map[string]string{
+	// navigation and errors
+	"Activities":      "Activités",
+	"Upload activity": "Ajouter une activité",
+	"Export":          "Exporter",
+	"Import":          "Importer",
+	"Pending maps":    "Cartes en attente",
+	"Settings":        "Préférences",
+	"Users":           "Utilisateurs",
+	"Profile":         "Profil",
+	"The page you are looking for does not exist": "La page demandée n'existe pas",
+	"Authentication required to access this area": "Vous devez être connecté pour accéder à cette page",
+	"Something wrong happened.":                   "Une erreur est survenue.",
+	"This form expired or was sent from another site. Go back, reload the page and submit it again.": "Ce formulaire a expiré ou a été envoyé depuis un autre site. Revenez en arrière, rechargez la page et envoyez-le à nouveau.",
+
+	// login
+	"Username:": "Identifiant :",
+	"Password:": "Mot de passe :",
+	"Login":     "Se connecter",
+
+	// activities
+	"Activity":                   "Activité",
+	"Distance":                   "Distance",
+	"Duration":                   "Durée",
+	"Time":                       "Temps",
+	"Pace":                       "Allure",
+	"Speed":                      "Vitesse",
+	"Elevation":                  "Altitude",
+	"Elevation gain":             "Dénivelé positif",
+	"Elevation profile":          "Profil d'altitude",
+	"Pace over distance":         "Allure selon la distance",
+	"Run":                        "Course",
+	"Trail run":                  "Trail",
+	"Hike":                       "Randonnée",
+	"Copy link":                  "Copier le lien",
+	"Delete":                     "Supprimer",
+	"Cancel":                     "Annuler",
+	"I confirm":                  "Je confirme",
+	"The map is being generated": "La carte est en cours de génération",
+	"Do you confirm the deletion of the activity %s?": "Confirmez-vous la suppression de l'activité du %s ?",
+
+	// upload
+	"When did you run?": "Quand avez-vous couru ?",
+	"Date:":             "Date :",
+	"Activity:":         "Activité :",
+	"Title:":            "Titre :",
+	"Description:":      "Description :",
+	"GPX file:":         "Fichier GPX :",
+	"Select a GPX file": "Choisir un fichier GPX",
+	"Submit":            "Envoyer",
+
+	// exports
+	"Download an archive with every activity: the original GPX files, the generated maps and a JSON/CSV manifest.": "Téléchargez une archive de toutes les activités : les fichiers GPX d'origine, les cartes générées et un index JSON/CSV.",
+	"Export everything": "Tout exporter",
+	"Requested at":      "Demandé le",
+	"Status":            "Statut",
+	"Ready":             "Prêt",
+	"Download":          "Télécharger",
+	"Being prepared":    "En préparation",
+
+	// imports
+	"Import the runs of a Strava account export. The archive can be requested from the Strava account settings.": "Importez les courses d'un export de compte Strava. L'archive se demande depuis les paramètres du compte Strava.",
+	"Select a Strava archive": "Choisir une archive Strava",
+	"Started at":              "Commencé le",
+	"Imported":                "Importées",
+	"Skipped":                 "Ignorées",
+	"Failed":                  "En échec",
+	"Pending":                 "En attente",
+	"Resume":                  "Reprendre",
+	"Import of %s":            "Import du %s",
+	"Date":                    "Date",
+	"Name":                    "Nom",
+	"Type":                    "Type",
+	"Reason":                  "Raison",
+	"%d imported, %d skipped, %d failed, %d pending out of %d activities.": "%d importées, %d ignorées, %d en échec, %d en attente sur %d activités.",
+
+	// pending maps
+	"These activities were recorded while their map couldn't be generated. They are retried periodically in the background.": "Ces activités ont été enregistrées alors que leur carte ne pouvait pas être générée. Elles sont régulièrement retentées en arrière-plan.",
+	"Retry now":                     "Réessayer maintenant",
+	"Last error":                    "Dernière erreur",
+	"Every map has been generated.": "Toutes les cartes ont été générées.",
+
+	// settings
+	"Display":            "Affichage",
+	"Language:":          "Langue :",
+	"Units:":             "Unités :",
+	"Show the speed as:": "Afficher la vitesse en :",
+	"Metric (km, m)":     "Métrique (km, m)",
+	"Imperial (mi, ft)":  "Impérial (mi, ft)",
+	"Save":               "Enregistrer",
+
+	// personal access tokens
+	"Manage personal access tokens": "Gérer les jetons d'accès personnels",
+	"Personal access tokens":        "Jetons d'accès personnels",
+	"Scripts send a token as a bearer token to call the API on your behalf.": "Les scripts envoient un jeton comme bearer token pour appeler l'API en votre nom.",
+	"Copy the secret of this token now, it won't be shown again:":            "Copiez le secret de ce jeton maintenant, il ne sera plus affiché :",
+	"Name:":          "Nom :",
+	"Scope:":         "Portée :",
+	"Read only":      "Lecture seule",
+	"Read and write": "Lecture et écriture",
+	"Create token":   "Créer le jeton",
+	"Scope":          "Portée",
+	"Created at":     "Créé le",
+	"Last used at":   "Dernière utilisation",
+	"Never":          "Jamais",
+	"Revoke":         "Révoquer",
+
+	// profile
+	"Member since %s":           "Membre depuis le %s",
+	"Change password":           "Changer de mot de passe",
+	"Current password:":         "Mot de passe actuel :",
+	"New password:":             "Nouveau mot de passe :",
+	"Confirm the new password:": "Confirmer le nouveau mot de passe :",
+
+	// users
+	"This area is reserved to administrators.":                   "Cette page est réservée aux administrateurs.",
+	"Give this new password to %s now, it won't be shown again:": "Donnez ce nouveau mot de passe à %s maintenant, il ne sera plus affiché :",
+	"Add a user":        "Ajouter un utilisateur",
+	"Administrator":     "Administrateur",
+	"Add user":          "Ajouter",
+	"Username":          "Identifiant",
+	"Role":              "Rôle",
+	"User":              "Utilisateur",
+	"Disabled since %s": "Désactivé depuis le %s",
+	"Active":            "Actif",
+	"Reset password":    "Réinitialiser le mot de passe",
+	"Enable":            "Réactiver",
+	"Disable":           "Désactiver",
+
+	// athletes
+	"Athlete":                   "Athlète",
+	"No activity uploaded yet.": "Aucune activité envoyée pour l'instant.",
+
+	// visibility
+	"Visibility":        "Visibilité",
+	"Visibility:":       "Visibilité :",
+	"Public":            "Publique",
+	"Unlisted":          "Non répertoriée",
+	"Private":           "Privée",
+	"Change visibility": "Modifier la visibilité",
+
+	// privacy zones
+	"Manage privacy zones": "Gérer les zones de confidentialité",
+	"Privacy zones":        "Zones de confidentialité",
+	"Points inside a privacy zone are hidden from the maps and from the GPX files downloaded by other users.": "Les points situés dans une zone de confidentialité sont masqués sur les cartes et dans les fichiers GPX téléchargés par les autres utilisateurs.",
+	"Latitude:":           "Latitude :",
+	"Longitude:":          "Longitude :",
+	"Radius (meters):":    "Rayon (mètres) :",
+	"Create privacy zone": "Créer la zone de confidentialité",
+	"Centre":              "Centre",
+	"Radius":              "Rayon",
+
+	// track downloads
+	"Download the track:": "Télécharger le tracé :",
+}
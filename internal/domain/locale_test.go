@@ -0,0 +1,28 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestParseLocale(t *testing.T) {
+	locale, err := domain.ParseLocale("fr")
+	testutils.RequireNoError(t, err, "unexpected locale error")
+	testutils.AssertEqualString(t, "fr_FR", locale.OpenGraph(), "unexpected open graph locale")
+
+	_, err = domain.ParseLocale("de")
+	testutils.AssertErrorContains(t, "unsupported locale", err, "unexpected locale error")
+}
+
+func TestLocaleTranslate(t *testing.T) {
+	testutils.AssertEqualString(t, "Allure", domain.LocaleFrench.Translate("Pace"), "unexpected french translation")
+	testutils.AssertEqualString(t, "Pace", domain.LocaleEnglish.Translate("Pace"), "english messages shouldn't be translated")
+	testutils.AssertEqualString(t, "Unknown message", domain.LocaleFrench.Translate("Unknown message"), "missing translations should fall back to english")
+}
+
+func TestLocaleFormatNumber(t *testing.T) {
+	testutils.AssertEqualString(t, "5.25", domain.LocaleEnglish.FormatNumber(5.249, 2), "unexpected english number")
+	testutils.AssertEqualString(t, "5,2", domain.LocaleFrench.FormatNumber(5.249, 1), "unexpected french number")
+}
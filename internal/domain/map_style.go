@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hexColorPattern = regexp.MustCompile(`^([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// MapPixelDensity is the number of pixels per point of generated maps
+const MapPixelDensity = 2
+
+// MapStyle represents how the map of an activity is drawn.
+//
+// Sizes are expressed in points, the generated images having MapPixelDensity times as many pixels.
+type MapStyle struct {
+	Theme         string
+	LineColor     string
+	LineThickness int
+	LineOpacity   float64
+	Width         int
+	Height        int
+	Padding       int
+	RouteColoring RouteColoring
+}
+
+// DefaultMapStyle returns the style used when nothing is configured
+func DefaultMapStyle() MapStyle {
+	return MapStyle{
+		Theme:         "mapbox/outdoors-v11",
+		LineColor:     "f44",
+		LineThickness: 3,
+		LineOpacity:   0.8,
+		Width:         800,
+		Height:        800,
+		Padding:       100,
+		RouteColoring: RouteColoringPace,
+	}
+}
+
+// ParseMapStyle returns the base style overridden by a comma-separated list of key=value settings
+// (e.g. "theme=mapbox/satellite-v9,line-color=ff0,size=600x400"). Supported keys are theme, line-color,
+// line-thickness, line-opacity, size, padding and route-coloring.
+func ParseMapStyle(base MapStyle, spec string) (MapStyle, error) {
+	style := base
+	for _, setting := range strings.Split(spec, ",") {
+		setting = strings.TrimSpace(setting)
+		if setting == "" {
+			continue
+		}
+
+		parts := strings.SplitN(setting, "=", 2)
+		if len(parts) != 2 {
+			return MapStyle{}, fmt.Errorf("map style setting must be formatted as key=value (setting=%s)", setting)
+		}
+
+		if err := style.set(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])); err != nil {
+			return MapStyle{}, err
+		}
+	}
+
+	if err := style.validate(); err != nil {
+		return MapStyle{}, err
+	}
+
+	return style, nil
+}
+
+// PixelWidth returns the width of generated maps in pixels
+func (s MapStyle) PixelWidth() int {
+	return s.Width * MapPixelDensity
+}
+
+// PixelHeight returns the height of generated maps in pixels
+func (s MapStyle) PixelHeight() int {
+	return s.Height * MapPixelDensity
+}
+
+// mapStyleSetters parses the value of each setting into the style
+var mapStyleSetters = map[string]func(s *MapStyle, value string) error{
+	"theme": func(s *MapStyle, value string) error {
+		s.Theme = value
+		return nil
+	},
+	"line-color": func(s *MapStyle, value string) error {
+		s.LineColor = value
+		return nil
+	},
+	"line-thickness": func(s *MapStyle, value string) (err error) {
+		s.LineThickness, err = strconv.Atoi(value)
+		return err
+	},
+	"line-opacity": func(s *MapStyle, value string) (err error) {
+		s.LineOpacity, err = strconv.ParseFloat(value, 64)
+		return err
+	},
+	"size": func(s *MapStyle, value string) (err error) {
+		s.Width, s.Height, err = parseMapSize(value)
+		return err
+	},
+	"padding": func(s *MapStyle, value string) (err error) {
+		s.Padding, err = strconv.Atoi(value)
+		return err
+	},
+	"route-coloring": func(s *MapStyle, value string) error {
+		s.RouteColoring = RouteColoring(value)
+		return nil
+	},
+}
+
+func (s *MapStyle) set(key string, value string) error {
+	setter, ok := mapStyleSetters[key]
+	if !ok {
+		return fmt.Errorf("unsupported map style setting %s", key)
+	}
+
+	if err := setter(s, value); err != nil {
+		return fmt.Errorf("can't parse map style setting %s (value=%s): %v", key, value, err)
+	}
+
+	return nil
+}
+
+func (s MapStyle) validate() error {
+	var err InvalidInputErrors
+	err.ValidateRequiredString(s.Theme, "map theme is required")
+	s.validateLine(&err)
+	s.validateLayout(&err)
+
+	if !err.IsEmpty() {
+		return &err
+	}
+
+	return nil
+}
+
+func (s MapStyle) validateLine(err *InvalidInputErrors) {
+	if !hexColorPattern.MatchString(s.LineColor) {
+		err.Append("map line color must be a 3 or 6 digits hexadecimal color")
+	}
+	err.ValidatePositiveInt(s.LineThickness, "map line thickness must be greater than 0")
+	if s.LineOpacity <= 0 || s.LineOpacity > 1 {
+		err.Append("map line opacity must be between 0 and 1")
+	}
+	if s.RouteColoring != RouteColoringPlain && s.RouteColoring != RouteColoringPace {
+		err.Append("map route coloring must be plain or pace")
+	}
+}
+
+func (s MapStyle) validateLayout(err *InvalidInputErrors) {
+	err.ValidatePositiveInt(s.Width, "map width must be greater than 0")
+	err.ValidatePositiveInt(s.Height, "map height must be greater than 0")
+	if s.Padding < 0 || 2*s.Padding >= s.Width || 2*s.Padding >= s.Height {
+		err.Append("map padding must leave room for the track")
+	}
+}
+
+func parseMapSize(value string) (int, int, error) {
+	parts := strings.SplitN(value, "x", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("size must be formatted as <width>x<height>")
+	}
+
+	width, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("can't parse width: %v", err)
+	}
+
+	height, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("can't parse height: %v", err)
+	}
+
+	return width, height, nil
+}
+
+// MapStyles represents the map style of each activity type
+type MapStyles struct {
+	Default    MapStyle
+	activities map[ActivityType]MapStyle
+}
+
+// NewMapStyles builds the default style from its settings, and the style of activity types from settings prefixed
+// by the type (e.g. "hike:theme=mapbox/satellite-v9"). Activity types inherit the default style settings.
+func NewMapStyles(defaultSpec string, activitySpecs []string) (MapStyles, error) {
+	defaultStyle, err := ParseMapStyle(DefaultMapStyle(), defaultSpec)
+	if err != nil {
+		return MapStyles{}, fmt.Errorf("can't parse default map style: %v", err)
+	}
+
+	styles := MapStyles{Default: defaultStyle, activities: make(map[ActivityType]MapStyle)}
+	for _, activitySpec := range activitySpecs {
+		parts := strings.SplitN(activitySpec, ":", 2)
+		if len(parts) != 2 {
+			return MapStyles{}, fmt.Errorf("activity map style must be formatted as <type>:<settings> (style=%s)", activitySpec)
+		}
+
+		activityType, err := ParseActivityType(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return MapStyles{}, fmt.Errorf("can't parse activity map style: %v", err)
+		}
+
+		style, err := ParseMapStyle(defaultStyle, parts[1])
+		if err != nil {
+			return MapStyles{}, fmt.Errorf("can't parse %s map style: %v", activityType, err)
+		}
+
+		styles.activities[activityType] = style
+	}
+
+	return styles, nil
+}
+
+// For returns the style of the activity type
+func (s MapStyles) For(activityType ActivityType) MapStyle {
+	if style, ok := s.activities[activityType]; ok {
+		return style
+	}
+
+	return s.Default
+}
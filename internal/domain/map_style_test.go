@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestParseMapStyleSuccess(t *testing.T) {
+	style, err := domain.ParseMapStyle(
+		domain.DefaultMapStyle(),
+		"theme=mapbox/satellite-v9, line-color=00ff00,line-thickness=5,line-opacity=0.5,size=600x400,padding=50,route-coloring=plain",
+	)
+
+	testutils.AssertNoError(t, err, "can't parse map style")
+	testutils.AssertEqualString(t, "mapbox/satellite-v9", style.Theme, "unexpected theme")
+	testutils.AssertEqualString(t, "00ff00", style.LineColor, "unexpected line color")
+	testutils.AssertEqualInt(t, 5, style.LineThickness, "unexpected line thickness")
+	testutils.AssertEqualFloat64(t, 0.5, style.LineOpacity, "unexpected line opacity")
+	testutils.AssertEqualInt(t, 600, style.Width, "unexpected width")
+	testutils.AssertEqualInt(t, 400, style.Height, "unexpected height")
+	testutils.AssertEqualInt(t, 50, style.Padding, "unexpected padding")
+	testutils.AssertEqualString(t, "plain", style.RouteColoring.String(), "unexpected route coloring")
+}
+
+func TestParseMapStyleKeepsBaseSettings(t *testing.T) {
+	style, err := domain.ParseMapStyle(domain.DefaultMapStyle(), "line-color=0f0")
+
+	testutils.AssertNoError(t, err, "can't parse map style")
+	testutils.AssertEqualString(t, "0f0", style.LineColor, "unexpected line color")
+	testutils.AssertEqualString(t, domain.DefaultMapStyle().Theme, style.Theme, "unexpected theme")
+	testutils.AssertEqualInt(t, domain.DefaultMapStyle().Padding, style.Padding, "unexpected padding")
+}
+
+func TestParseMapStyleErrors(t *testing.T) {
+	tcs := map[string]string{
+		"unknownKey":      "font=serif",
+		"missingValue":    "theme",
+		"invalidColor":    "line-color=red",
+		"invalidOpacity":  "line-opacity=2",
+		"invalidSize":     "size=800",
+		"nonNumericWidth": "size=wx800",
+		"paddingTooLarge": "padding=400",
+		"emptyTheme":      "theme=",
+		"unknownColoring": "route-coloring=heart-rate",
+	}
+
+	for name, spec := range tcs {
+		t.Run(name, func(t *testing.T) {
+			_, err := domain.ParseMapStyle(domain.DefaultMapStyle(), spec)
+
+			testutils.AssertHasError(t, err, "expected an error")
+		})
+	}
+}
+
+func TestNewMapStyles(t *testing.T) {
+	styles, err := domain.NewMapStyles("line-color=00f", []string{"hike:theme=mapbox/satellite-v9"})
+	testutils.AssertNoError(t, err, "can't build map styles")
+
+	run := styles.For(domain.ActivityTypeRun)
+	testutils.AssertEqualString(t, domain.DefaultMapStyle().Theme, run.Theme, "unexpected run theme")
+	testutils.AssertEqualString(t, "00f", run.LineColor, "unexpected run line color")
+
+	hike := styles.For(domain.ActivityTypeHike)
+	testutils.AssertEqualString(t, "mapbox/satellite-v9", hike.Theme, "unexpected hike theme")
+	testutils.AssertEqualString(t, "00f", hike.LineColor, "hike should inherit the default line color")
+}
+
+func TestNewMapStylesErrors(t *testing.T) {
+	tcs := map[string][]string{
+		"missingType":     {"theme=mapbox/satellite-v9"},
+		"unknownType":     {"swim:theme=mapbox/satellite-v9"},
+		"invalidSettings": {"hike:line-color=red"},
+	}
+
+	for name, specs := range tcs {
+		t.Run(name, func(t *testing.T) {
+			_, err := domain.NewMapStyles("", specs)
+
+			testutils.AssertHasError(t, err, "expected an error")
+		})
+	}
+}
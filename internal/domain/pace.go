@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// fastestPace is faster than any human can run a kilometer
+	fastestPace = time.Minute
+	// slowestPace is slower than anyone can keep moving for a kilometer
+	slowestPace = 24 * time.Hour
+)
+
+// Pace represents the time spent to travel a kilometer. The zero value represents an unknown pace, such as the one
+// of an activity without movement.
+type Pace struct {
+	perKilometer time.Duration
+}
+
+// NewPacePerKilometer builds a pace from the time spent per kilometer and validate the value is in a possible range
+func NewPacePerKilometer(d time.Duration) (Pace, error) {
+	if d < fastestPace {
+		return Pace{}, ErrPaceTooFast
+	}
+
+	if d > slowestPace {
+		return Pace{}, ErrPaceTooSlow
+	}
+
+	return Pace{perKilometer: d}, nil
+}
+
+// NewPacePerMile builds a pace from the time spent per mile and validate the value is in a possible range
+func NewPacePerMile(d time.Duration) (Pace, error) {
+	return NewPacePerKilometer(time.Duration(float64(d) * 1000 / metersPerMile))
+}
+
+// IsZero returns whether the pace is unknown
+func (p Pace) IsZero() bool {
+	return p.perKilometer == 0
+}
+
+// PerKilometer returns the time spent per kilometer, rounded to the second
+func (p Pace) PerKilometer() time.Duration {
+	return p.perKilometer.Round(time.Second)
+}
+
+// PerMile returns the time spent per mile, rounded to the second
+func (p Pace) PerMile() time.Duration {
+	return time.Duration(float64(p.perKilometer) * metersPerMile / 1000).Round(time.Second)
+}
+
+// String returns the time spent per kilometer as minutes and seconds (e.g. 5:59/km)
+func (p Pace) String() string {
+	if p.IsZero() {
+		return "-"
+	}
+
+	return formatMinutesSeconds(p.PerKilometer()) + "/km"
+}
+
+// formatMinutesSeconds returns the duration as minutes and seconds (e.g. 5:59)
+func formatMinutesSeconds(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -0,0 +1,49 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestNewPacePerKilometerSuccess(t *testing.T) {
+	pace, err := domain.NewPacePerKilometer(5*time.Minute + 59*time.Second)
+	testutils.RequireNoError(t, err, "can't build pace")
+
+	testutils.AssertEqualDuration(t, 5*time.Minute+59*time.Second, pace.PerKilometer(), "unexpected pace per kilometer")
+	testutils.AssertEqualDuration(t, 9*time.Minute+38*time.Second, pace.PerMile(), "unexpected pace per mile")
+	testutils.AssertEqualString(t, "5:59/km", pace.String(), "unexpected formatted pace")
+}
+
+func TestNewPacePerKilometerErrors(t *testing.T) {
+	tcs := map[string]struct {
+		Duration time.Duration
+		Err      error
+	}{
+		"negative": {Duration: -5 * time.Minute, Err: domain.ErrPaceTooFast},
+		"zero":     {Duration: 0, Err: domain.ErrPaceTooFast},
+		"tooFast":  {Duration: 59 * time.Second, Err: domain.ErrPaceTooFast},
+		"tooSlow":  {Duration: 25 * time.Hour, Err: domain.ErrPaceTooSlow},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			_, err := domain.NewPacePerKilometer(tc.Duration)
+			testutils.AssertErrorIs(t, tc.Err, err, "unexpected pace error")
+		})
+	}
+}
+
+func TestNewPacePerMileSuccess(t *testing.T) {
+	pace, err := domain.NewPacePerMile(8 * time.Minute)
+	testutils.RequireNoError(t, err, "can't build pace")
+
+	testutils.AssertEqualDuration(t, 4*time.Minute+58*time.Second, pace.PerKilometer(), "unexpected pace per kilometer")
+	testutils.AssertEqualDuration(t, 8*time.Minute, pace.PerMile(), "unexpected pace per mile")
+}
+
+func TestPaceStringUnknown(t *testing.T) {
+	testutils.AssertEqualString(t, "-", domain.Pace{}.String(), "unexpected formatted pace")
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// privacyZoneNameMaxLength is the maximum number of characters of a zone name
+	privacyZoneNameMaxLength = 100
+	// privacyZoneMinRadiusMeters is the smallest radius, GPS being too noisy to hide anything in a smaller zone
+	privacyZoneMinRadiusMeters = 100
+	// privacyZoneMaxRadiusMeters is the largest radius, bigger zones would hide most of the activities
+	privacyZoneMaxRadiusMeters = 5000
+	// earthRadiusMeters is the mean radius of the earth used to compute distances between coordinates
+	earthRadiusMeters = 6371000
+)
+
+// PrivacyZone represents a circle, usually around home, whose points are hidden from the maps and the GPX files
+// shared with other users
+type PrivacyZone struct {
+	ID           ID
+	Username     string
+	Name         string
+	Latitude     float64
+	Longitude    float64
+	RadiusMeters int
+	CreatedAt    time.Time
+}
+
+// NewPrivacyZone builds a zone of the user from the values of the settings form and validates them
+func NewPrivacyZone(username string, name string, latitude string, longitude string, radius string, createdAt time.Time) (PrivacyZone, error) {
+	var errs InvalidInputErrors
+	errs.ValidateRequiredString(name, "name is required")
+	if utf8.RuneCountInString(name) > privacyZoneNameMaxLength {
+		errs.Append(fmt.Sprintf("name can't be longer than %d characters", privacyZoneNameMaxLength))
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latitude), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		errs.Append("latitude must be a number between -90 and 90")
+	}
+
+	lon, err := strconv.ParseFloat(strings.TrimSpace(longitude), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		errs.Append("longitude must be a number between -180 and 180")
+	}
+
+	meters, err := strconv.Atoi(strings.TrimSpace(radius))
+	if err != nil || meters < privacyZoneMinRadiusMeters || meters > privacyZoneMaxRadiusMeters {
+		errs.Append(fmt.Sprintf("radius must be between %d and %d meters", privacyZoneMinRadiusMeters, privacyZoneMaxRadiusMeters))
+	}
+
+	if !errs.IsEmpty() {
+		return PrivacyZone{}, &errs
+	}
+
+	return PrivacyZone{
+		ID:           NewID(),
+		Username:     username,
+		Name:         name,
+		Latitude:     lat,
+		Longitude:    lon,
+		RadiusMeters: meters,
+		CreatedAt:    createdAt,
+	}, nil
+}
+
+// Contains returns whether the point is inside the zone
+func (z PrivacyZone) Contains(point GPXPoint) bool {
+	return haversineMeters(z.Latitude, z.Longitude, point.Latitude, point.Longitude) <= float64(z.RadiusMeters)
+}
+
+// IsCrossedBy returns whether any of the points is inside the zone
+func (z PrivacyZone) IsCrossedBy(points GPXPoints) bool {
+	for _, point := range points {
+		if z.Contains(point) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PrivacyZones represents all the zones of a user
+type PrivacyZones []PrivacyZone
+
+// Contains returns whether the point is inside any of the zones
+func (zs PrivacyZones) Contains(point GPXPoint) bool {
+	for _, zone := range zs {
+		if zone.Contains(point) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Mask returns the points outside every zone. Other values of the points, such as their distance to the previous
+// point, are kept so stats of the track stay the same.
+func (zs PrivacyZones) Mask(points GPXPoints) GPXPoints {
+	if len(zs) == 0 {
+		return points
+	}
+
+	masked := make(GPXPoints, 0, len(points))
+	for _, point := range points {
+		if !zs.Contains(point) {
+			masked = append(masked, point)
+		}
+	}
+
+	return masked
+}
+
+// haversineMeters returns the distance between two coordinates, on earth
+func haversineMeters(fromLatitude float64, fromLongitude float64, toLatitude float64, toLongitude float64) float64 {
+	lat1, lat2 := fromLatitude*math.Pi/180, toLatitude*math.Pi/180
+	deltaLat := lat2 - lat1
+	deltaLon := (toLongitude - fromLongitude) * math.Pi / 180
+
+	a := math.Pow(math.Sin(deltaLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(deltaLon/2), 2)
+
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,104 @@
+package domain_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestNewPrivacyZone(t *testing.T) {
+	createdAt := time.Date(2022, 4, 29, 9, 0, 0, 0, time.UTC)
+
+	zone, err := domain.NewPrivacyZone("alice", "Home", "48.8566", " 2.3522", "250", createdAt)
+	testutils.RequireNoError(t, err, "can't create privacy zone")
+
+	testutils.AssertEqualString(t, "alice", zone.Username, "unexpected username")
+	testutils.AssertEqualString(t, "Home", zone.Name, "unexpected name")
+	testutils.AssertEqualFloat64(t, 48.8566, zone.Latitude, "unexpected latitude")
+	testutils.AssertEqualFloat64(t, 2.3522, zone.Longitude, "unexpected longitude")
+	testutils.AssertEqualInt(t, 250, zone.RadiusMeters, "unexpected radius")
+	testutils.AssertEqualTime(t, createdAt, zone.CreatedAt, "unexpected created at")
+}
+
+func TestNewPrivacyZoneInvalid(t *testing.T) {
+	_, err := domain.NewPrivacyZone("alice", "", "91", "east", "20", time.Now())
+
+	var invalidErr *domain.InvalidInputErrors
+	testutils.RequireErrorAs(t, &invalidErr, err, "expected invalid input")
+	testutils.AssertEqualStrings(t, []string{
+		"name is required",
+		"latitude must be a number between -90 and 90",
+		"longitude must be a number between -180 and 180",
+		"radius must be between 100 and 5000 meters",
+	}, invalidErr.Detail(), "unexpected errors")
+
+	_, err = domain.NewPrivacyZone("alice", strings.Repeat("a", 101), "48.8566", "2.3522", "5001", time.Now())
+	testutils.RequireErrorAs(t, &invalidErr, err, "expected invalid input")
+	testutils.AssertEqualStrings(t, []string{
+		"name can't be longer than 100 characters",
+		"radius must be between 100 and 5000 meters",
+	}, invalidErr.Detail(), "unexpected errors")
+}
+
+func TestPrivacyZoneContains(t *testing.T) {
+	zone := domain.PrivacyZone{Latitude: 48.8566, Longitude: 2.3522, RadiusMeters: 200}
+
+	// 0.001 degree of latitude is about 111 meters
+	testutils.AssertEqualBool(t, true, zone.Contains(domain.GPXPoint{Latitude: 48.8566, Longitude: 2.3522}), "centre should be inside")
+	testutils.AssertEqualBool(t, true, zone.Contains(domain.GPXPoint{Latitude: 48.8583, Longitude: 2.3522}), "point at 190 meters should be inside")
+	testutils.AssertEqualBool(t, false, zone.Contains(domain.GPXPoint{Latitude: 48.8586, Longitude: 2.3522}), "point at 220 meters should be outside")
+}
+
+func TestPrivacyZoneIsCrossedBy(t *testing.T) {
+	zone := domain.PrivacyZone{Latitude: 48.8566, Longitude: 2.3522, RadiusMeters: 200}
+
+	crossing := domain.GPXPoints{{Latitude: 48.87, Longitude: 2.3522}, {Latitude: 48.8567, Longitude: 2.3522}}
+	testutils.AssertEqualBool(t, true, zone.IsCrossedBy(crossing), "track should cross the zone")
+
+	away := domain.GPXPoints{{Latitude: 48.87, Longitude: 2.3522}, {Latitude: 48.88, Longitude: 2.3522}}
+	testutils.AssertEqualBool(t, false, zone.IsCrossedBy(away), "track shouldn't cross the zone")
+}
+
+func TestPrivacyZonesMask(t *testing.T) {
+	zones := domain.PrivacyZones{
+		{Latitude: 48.8566, Longitude: 2.3522, RadiusMeters: 200},
+		{Latitude: 48.87, Longitude: 2.3522, RadiusMeters: 200},
+	}
+	points := domain.GPXPoints{
+		{Latitude: 48.8566, Longitude: 2.3522, Distance: 0},
+		{Latitude: 48.86, Longitude: 2.3522, Distance: 378},
+		{Latitude: 48.865, Longitude: 2.3522, Distance: 556},
+		{Latitude: 48.87, Longitude: 2.3522, Distance: 556},
+	}
+
+	masked := zones.Mask(points)
+
+	testutils.RequireEqualInt(t, 2, len(masked), "unexpected number of points")
+	testutils.AssertEqualFloat64(t, 48.86, masked[0].Latitude, "unexpected first point")
+	testutils.AssertEqualFloat64(t, 378, masked[0].Distance, "distance of points should be kept")
+	testutils.AssertEqualFloat64(t, 48.865, masked[1].Latitude, "unexpected last point")
+
+	testutils.AssertEqualInt(t, 4, len(domain.PrivacyZones(nil).Mask(points)), "points shouldn't be masked without zones")
+}
+
+func TestGPXFileWithoutPointsIn(t *testing.T) {
+	distance, err := domain.NewDistanceFromMeters(1490)
+	testutils.RequireNoError(t, err, "can't build distance")
+	points := domain.GPXPoints{{Latitude: 48.8566, Longitude: 2.3522}, {Latitude: 48.86, Longitude: 2.3522}}
+	speed, err := domain.NewSpeedFromKmh(1.49)
+	testutils.RequireNoError(t, err, "can't build speed")
+	gpx := domain.NewGPXFile([]byte("<gpx></gpx>"), distance, time.Hour, speed, points)
+
+	masked := gpx.WithoutPointsIn(domain.PrivacyZones{{Latitude: 48.8566, Longitude: 2.3522, RadiusMeters: 200}})
+
+	testutils.AssertEqualInt(t, 1, len(masked.Points), "unexpected number of points")
+	testutils.AssertEqualInt(t, 1490, masked.Distance.Meters(), "distance should be the one of the full track")
+	testutils.AssertEqualDuration(t, time.Hour, masked.Duration, "duration should be the one of the full track")
+	content, err := ioutil.ReadAll(masked.File())
+	testutils.RequireNoError(t, err, "can't read gpx content")
+	testutils.AssertEqualInt(t, 0, len(content), "content shouldn't reveal hidden points")
+}
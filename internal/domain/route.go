@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+)
+
+// RouteColoring represents how the route of an activity is colored on its map
+type RouteColoring string
+
+const (
+	// RouteColoringPlain draws the whole route with the line color of the style
+	RouteColoringPlain RouteColoring = "plain"
+	// RouteColoringPace draws each section of the route with the color of its pace band
+	RouteColoringPace RouteColoring = "pace"
+)
+
+func (c RouteColoring) String() string {
+	return string(c)
+}
+
+// PaceColors lists the colors of the pace bands, from the slowest to the fastest
+var PaceColors = []string{"2b83ba", "abdda4", "e6d855", "fdae61", "d7191c"}
+
+const (
+	// paceWindowMeters is the distance over which the pace of a point is averaged, to smooth GPS noise
+	paceWindowMeters = 200
+	// paceOutliersPercent is the share of the slowest and of the fastest paces ignored to compute pace bands
+	paceOutliersPercent = 5
+	// minSectionMeters is the shortest section drawn with a different color than its predecessor
+	minSectionMeters = 200
+	// maxSections caps the number of sections of long routes
+	maxSections = 50
+	// maxDistanceMarkers caps the number of distance markers of long routes
+	maxDistanceMarkers = 25
+)
+
+// RouteMarkerKind represents the kind of point of interest shown on a route
+type RouteMarkerKind string
+
+const (
+	RouteMarkerStart    RouteMarkerKind = "start"
+	RouteMarkerFinish   RouteMarkerKind = "finish"
+	RouteMarkerDistance RouteMarkerKind = "distance"
+)
+
+// RouteMarker represents a point of interest of the route
+type RouteMarker struct {
+	Kind      RouteMarkerKind
+	Label     string
+	Latitude  float64
+	Longitude float64
+}
+
+// RouteSection represents consecutive points drawn with the same color. Sections share their boundary point so the
+// route has no gap.
+type RouteSection struct {
+	Points GPXPoints
+	Color  string
+}
+
+// Route represents what is drawn on the map of an activity
+type Route struct {
+	Sections []RouteSection
+	Markers  []RouteMarker
+}
+
+// NewRoute splits the points in colored sections according to the style, and places start, finish and kilometer
+// markers. Routes without timestamps are drawn with the line color of the style. Markers are placed every 1, 2, 5,
+// 10... kilometers so long routes don't get cluttered.
+func NewRoute(points GPXPoints, style MapStyle) Route {
+	if len(points) == 0 {
+		return Route{}
+	}
+
+	distances := cumulativeDistances(points)
+
+	var sections []RouteSection
+	if style.RouteColoring == RouteColoringPace && isTimed(points) {
+		sections = paceSections(points, distances)
+	} else {
+		sections = []RouteSection{{Points: points, Color: style.LineColor}}
+	}
+
+	first, last := points[0], points[len(points)-1]
+	markers := []RouteMarker{{Kind: RouteMarkerStart, Latitude: first.Latitude, Longitude: first.Longitude}}
+	markers = append(markers, distanceMarkers(points, distances)...)
+	markers = append(markers, RouteMarker{Kind: RouteMarkerFinish, Latitude: last.Latitude, Longitude: last.Longitude})
+
+	return Route{Sections: sections, Markers: markers}
+}
+
+// isTimed reports whether points have timestamps to compute paces from
+func isTimed(points GPXPoints) bool {
+	return points[len(points)-1].Time.After(points[0].Time)
+}
+
+func cumulativeDistances(points GPXPoints) []float64 {
+	distances := make([]float64, len(points))
+	for i := 1; i < len(points); i++ {
+		distances[i] = distances[i-1] + points[i].Distance
+	}
+
+	return distances
+}
+
+// paceBands returns the pace band of each point. Bands split evenly the range between the slowest and the fastest
+// paces of the activity, ignoring the 5% most extreme ones which are usually stops and GPS glitches.
+func paceBands(points GPXPoints, distances []float64) []int {
+	speeds := smoothedSpeeds(points, distances)
+
+	sorted := append([]float64(nil), speeds...)
+	sort.Float64s(sorted)
+	slowest := sorted[len(sorted)*paceOutliersPercent/100]
+	fastest := sorted[len(sorted)-1-len(sorted)*paceOutliersPercent/100]
+
+	bands := make([]int, len(points))
+	for i, speed := range speeds {
+		bands[i] = len(PaceColors) / 2
+		if fastest > slowest {
+			bands[i] = int((speed - slowest) / (fastest - slowest) * float64(len(PaceColors)))
+		}
+
+		if bands[i] < 0 {
+			bands[i] = 0
+		} else if bands[i] >= len(PaceColors) {
+			bands[i] = len(PaceColors) - 1
+		}
+	}
+
+	return bands
+}
+
+// smoothedSpeeds returns the speed of each point, in meters per second, averaged over the previous paceWindowMeters
+func smoothedSpeeds(points GPXPoints, distances []float64) []float64 {
+	speeds := make([]float64, len(points))
+	start := 0
+	for i := range points {
+		for start < i && distances[i]-distances[start+1] >= paceWindowMeters {
+			start++
+		}
+
+		if elapsed := points[i].Time.Sub(points[start].Time).Seconds(); elapsed > 0 {
+			speeds[i] = (distances[i] - distances[start]) / elapsed
+		} else if i > 0 {
+			speeds[i] = speeds[i-1]
+		}
+	}
+
+	// the first point has no elapsed time, it shares the speed of the next one
+	if len(speeds) > 1 {
+		speeds[0] = speeds[1]
+	}
+
+	return speeds
+}
+
+func paceSections(points GPXPoints, distances []float64) []RouteSection {
+	bands := paceBands(points, distances)
+	minLength := distances[len(distances)-1] / maxSections
+	if minLength < minSectionMeters {
+		minLength = minSectionMeters
+	}
+
+	var sections []RouteSection
+	start, band := 0, bands[0]
+	for i := 1; i < len(points); i++ {
+		if bands[i] == band || distances[i]-distances[start] < minLength {
+			continue
+		}
+
+		sections = append(sections, RouteSection{Points: points[start : i+1], Color: PaceColors[band]})
+		start, band = i, bands[i]
+	}
+
+	return append(sections, RouteSection{Points: points[start:], Color: PaceColors[band]})
+}
+
+func distanceMarkers(points GPXPoints, distances []float64) []RouteMarker {
+	total := distances[len(distances)-1]
+	step := markerStep(total, maxDistanceMarkers)
+
+	var markers []RouteMarker
+	next := step
+	for i := 1; i < len(points) && next <= total; i++ {
+		for next <= distances[i] {
+			ratio := (next - distances[i-1]) / (distances[i] - distances[i-1])
+			markers = append(markers, RouteMarker{
+				Kind:      RouteMarkerDistance,
+				Label:     strconv.Itoa(int(next / 1000)),
+				Latitude:  points[i-1].Latitude + ratio*(points[i].Latitude-points[i-1].Latitude),
+				Longitude: points[i-1].Longitude + ratio*(points[i].Longitude-points[i-1].Longitude),
+			})
+			next += step
+		}
+	}
+
+	return markers
+}
+
+// markerStep returns the smallest 1, 2 or 5 multiple of a power of ten kilometers keeping the number of markers
+// under maxMarkers
+func markerStep(total float64, maxMarkers int) float64 {
+	for magnitude := 1000.0; ; magnitude *= 10 {
+		for _, factor := range []float64{1, 2, 5} {
+			if total/(factor*magnitude) <= float64(maxMarkers) {
+				return factor * magnitude
+			}
+		}
+	}
+}
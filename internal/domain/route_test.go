@@ -0,0 +1,100 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestNewRoutePlain(t *testing.T) {
+	style := domain.DefaultMapStyle()
+	style.RouteColoring = domain.RouteColoringPlain
+
+	route := domain.NewRoute(straightTrack(3000, func(int) time.Duration { return time.Second }), style)
+
+	testutils.AssertEqualInt(t, 1, len(route.Sections), "unexpected number of sections")
+	testutils.AssertEqualString(t, style.LineColor, route.Sections[0].Color, "unexpected section color")
+}
+
+func TestNewRouteWithoutTimestamps(t *testing.T) {
+	points := straightTrack(3000, func(int) time.Duration { return 0 })
+
+	route := domain.NewRoute(points, domain.DefaultMapStyle())
+
+	testutils.AssertEqualInt(t, 1, len(route.Sections), "unexpected number of sections")
+	testutils.AssertEqualString(t, domain.DefaultMapStyle().LineColor, route.Sections[0].Color, "unexpected section color")
+}
+
+func TestNewRoutePaceSections(t *testing.T) {
+	// runs the first half of the track at 2m/s and the second half at 5m/s
+	points := straightTrack(4000, func(i int) time.Duration {
+		if i < 2000 {
+			return 5 * time.Second
+		}
+
+		return 2 * time.Second
+	})
+
+	route := domain.NewRoute(points, domain.DefaultMapStyle())
+
+	if len(route.Sections) < 2 {
+		t.Fatalf("expected several sections. got: %d", len(route.Sections))
+	}
+
+	first, last := route.Sections[0], route.Sections[len(route.Sections)-1]
+	testutils.AssertEqualString(t, domain.PaceColors[0], first.Color, "slow start should use the slowest color")
+	testutils.AssertEqualString(t, domain.PaceColors[len(domain.PaceColors)-1], last.Color, "fast finish should use the fastest color")
+
+	for i := 1; i < len(route.Sections); i++ {
+		previous := route.Sections[i-1].Points
+		if previous[len(previous)-1] != route.Sections[i].Points[0] {
+			t.Errorf("section %d doesn't start where the previous one ends", i)
+		}
+	}
+}
+
+func TestNewRouteMarkers(t *testing.T) {
+	points := straightTrack(3500, func(int) time.Duration { return time.Second })
+
+	route := domain.NewRoute(points, domain.DefaultMapStyle())
+
+	testutils.AssertEqualInt(t, 5, len(route.Markers), "unexpected number of markers")
+	testutils.AssertEqualString(t, string(domain.RouteMarkerStart), string(route.Markers[0].Kind), "unexpected first marker")
+	testutils.AssertEqualFloat64(t, points[0].Longitude, route.Markers[0].Longitude, "unexpected start position")
+	for i, label := range []string{"1", "2", "3"} {
+		marker := route.Markers[i+1]
+		testutils.AssertEqualString(t, string(domain.RouteMarkerDistance), string(marker.Kind), "unexpected marker %d", i)
+		testutils.AssertEqualString(t, label, marker.Label, "unexpected label of marker %d", i)
+	}
+	testutils.AssertEqualString(t, string(domain.RouteMarkerFinish), string(route.Markers[4].Kind), "unexpected last marker")
+	testutils.AssertEqualFloat64(t, points[len(points)-1].Longitude, route.Markers[4].Longitude, "unexpected finish position")
+}
+
+func TestNewRouteMarkersLongTrack(t *testing.T) {
+	route := domain.NewRoute(straightTrack(100000, func(int) time.Duration { return time.Second }), domain.DefaultMapStyle())
+
+	// a 100km track gets a marker every 5km
+	testutils.AssertEqualInt(t, 22, len(route.Markers), "unexpected number of markers")
+	testutils.AssertEqualString(t, "5", route.Markers[1].Label, "unexpected first distance marker")
+}
+
+// straightTrack builds a track heading east with a point every meter, interval returning the time to the i-th point
+func straightTrack(meters int, interval func(int) time.Duration) domain.GPXPoints {
+	const metersPerDegree = 111320.0
+
+	start := time.Date(2022, time.April, 20, 9, 0, 0, 0, time.UTC)
+	points := make(domain.GPXPoints, meters+1)
+	points[0] = domain.GPXPoint{Time: start, Latitude: 0, Longitude: 0}
+	for i := 1; i <= meters; i++ {
+		points[i] = domain.GPXPoint{
+			Time:      points[i-1].Time.Add(interval(i)),
+			Latitude:  0,
+			Longitude: float64(i) / metersPerDegree,
+			Distance:  1,
+		}
+	}
+
+	return points
+}
@@ -2,9 +2,26 @@ package domain
 
 import (
 	"fmt"
+	"path"
+	"strings"
 	"time"
 )
 
+// MapStatus represents whether the map of an activity has been generated
+type MapStatus string
+
+const (
+	// MapStatusReady is the status of an activity whose map and shareable card are stored
+	MapStatusReady MapStatus = "ready"
+	// MapStatusPending is the status of an activity whose map couldn't be generated yet
+	MapStatusPending MapStatus = "pending"
+)
+
+// String implements Stringer interface
+func (s MapStatus) String() string {
+	return string(s)
+}
+
 // RunningActivity represents a running session
 type RunningActivity struct {
 	Slug             RunningActivitySlug
@@ -15,6 +32,215 @@ type RunningActivity struct {
 	GPXPath          GPXFilePath
 	MapPath          MapFilePath
 	ShareableMapPath ShareableMapFilePath
+	// Cards are empty for activities recorded before card templates, whose only card is their shareable map
+	Cards []ShareableCard
+	// ElevationChartPath and PaceChartPath are empty for activities recorded before charts were generated
+	ElevationChartPath ElevationChartFilePath
+	PaceChartPath      PaceChartFilePath
+	Title              string
+	Description        string
+	Type               ActivityType
+	MapStyle           MapStyle
+	MapStatus          MapStatus
+	// MapError is the reason of the last failed map generation of a pending map
+	MapError string
+	// Username is the athlete who uploaded the activity
+	Username   string
+	Visibility ActivityVisibility
+}
+
+// RunningActivityDetails represents the optional information describing an activity
+type RunningActivityDetails struct {
+	Title       string
+	Description string
+	Type        ActivityType
+	Visibility  ActivityVisibility
+}
+
+// WithDetails returns the activity described by the details
+func (r RunningActivity) WithDetails(details RunningActivityDetails) RunningActivity {
+	r.Title = details.Title
+	r.Description = details.Description
+	if details.Type != "" {
+		r.Type = details.Type
+	}
+	if details.Visibility != "" {
+		r.Visibility = details.Visibility
+	}
+
+	return r
+}
+
+// WithOwner returns the activity uploaded by the athlete
+func (r RunningActivity) WithOwner(username string) RunningActivity {
+	r.Username = username
+
+	return r
+}
+
+// CanBeManagedBy returns whether the user is allowed to edit or delete the activity
+func (r RunningActivity) CanBeManagedBy(user User) bool {
+	return user.IsAdmin || r.isOwnedBy(user)
+}
+
+// WithVisibility returns the activity restricted to the visibility
+func (r RunningActivity) WithVisibility(visibility ActivityVisibility) RunningActivity {
+	r.Visibility = visibility
+
+	return r
+}
+
+// CanBeSeenBy returns whether the user is allowed to read the activity: private ones are only readable by their owner
+func (r RunningActivity) CanBeSeenBy(user User) bool {
+	return r.Visibility != ActivityVisibilityPrivate || r.isOwnedBy(user)
+}
+
+// IsListedFor returns whether the activity appears in the lists browsed by the user: only public ones are listed to
+// other users
+func (r RunningActivity) IsListedFor(user User) bool {
+	return r.Visibility == ActivityVisibilityPublic || r.isOwnedBy(user)
+}
+
+// IsPrivate returns whether the activity is only readable by its owner
+func (r RunningActivity) IsPrivate() bool {
+	return r.Visibility == ActivityVisibilityPrivate
+}
+
+// RevealsTrackTo returns whether the user gets the full track of the activity. Points inside the privacy zones of the
+// owner are hidden to the other users, administrators included.
+func (r RunningActivity) RevealsTrackTo(user User) bool {
+	return r.isOwnedBy(user)
+}
+
+// HasPublicAsset returns whether the file can be served as is to everyone. Files of private activities, and GPX files
+// which may cross the privacy zones of the owner, must be served by the application.
+func (r RunningActivity) HasPublicAsset(assetPath string) bool {
+	return !r.IsPrivate() && assetPath != r.GPXPath.String()
+}
+
+func (r RunningActivity) isOwnedBy(user User) bool {
+	return r.Username != "" && r.Username == user.Username
+}
+
+// WithMapStyle returns the activity whose map is drawn with the style
+func (r RunningActivity) WithMapStyle(style MapStyle) RunningActivity {
+	r.MapStyle = style
+
+	return r
+}
+
+// WithCards returns the activity shared with the cards, the first one being its shareable map
+func (r RunningActivity) WithCards(cards []ShareableCard) RunningActivity {
+	r.Cards = cards
+	if len(cards) > 0 {
+		r.ShareableMapPath = cards[0].Path
+	}
+
+	return r
+}
+
+// ShareableCards returns the cards of the activity, falling back to its shareable map for activities recorded
+// before card templates
+func (r RunningActivity) ShareableCards() []ShareableCard {
+	if len(r.Cards) > 0 {
+		return r.Cards
+	}
+
+	return []ShareableCard{{
+		Path:   r.ShareableMapPath,
+		Width:  r.MapStyle.PixelWidth(),
+		Height: r.MapStyle.PixelHeight(),
+	}}
+}
+
+// WithCharts returns the activity whose charts are stored at the paths
+func (r RunningActivity) WithCharts(elevationPath ElevationChartFilePath, pacePath PaceChartFilePath) RunningActivity {
+	r.ElevationChartPath = elevationPath
+	r.PaceChartPath = pacePath
+
+	return r
+}
+
+// HasCharts returns whether the activity has an elevation profile and a pace chart
+func (r RunningActivity) HasCharts() bool {
+	return r.ElevationChartPath != "" && r.PaceChartPath != ""
+}
+
+// AssetPaths returns the paths of every file stored for the activity
+func (r RunningActivity) AssetPaths() []string {
+	paths := []string{r.GPXPath.String(), r.MapPath.String()}
+	for _, card := range r.ShareableCards() {
+		paths = append(paths, card.Path.String())
+	}
+
+	if r.HasCharts() {
+		paths = append(paths, r.ElevationChartPath.PNG(), r.ElevationChartPath.SVG(), r.PaceChartPath.PNG(), r.PaceChartPath.SVG())
+	}
+
+	return paths
+}
+
+// HasMisplacedAssets returns whether the files of the activity aren't stored in the folder matching its visibility,
+// such as the files of a public activity made private
+func (r RunningActivity) HasMisplacedAssets() bool {
+	storedPrivately := strings.HasPrefix(r.GPXPath.String(), privateAssetsFolder+"/")
+
+	return r.IsPrivate() != storedPrivately
+}
+
+// WithAssetsFolder returns the activity whose files are stored in the folder, keeping their names
+func (r RunningActivity) WithAssetsFolder(folder string) RunningActivity {
+	rebase := func(assetPath string) string { return path.Join(folder, path.Base(assetPath)) }
+
+	r.GPXPath = GPXFilePath(rebase(r.GPXPath.String()))
+	r.MapPath = MapFilePath(rebase(r.MapPath.String()))
+	r.ShareableMapPath = ShareableMapFilePath(rebase(r.ShareableMapPath.String()))
+
+	var cards []ShareableCard
+	for _, card := range r.Cards {
+		card.Path = ShareableMapFilePath(rebase(card.Path.String()))
+		cards = append(cards, card)
+	}
+	r.Cards = cards
+
+	if r.HasCharts() {
+		r.ElevationChartPath = ElevationChartFilePath(rebase(r.ElevationChartPath.String()))
+		r.PaceChartPath = PaceChartFilePath(rebase(r.PaceChartPath.String()))
+	}
+
+	return r
+}
+
+// AssetPath returns the path of the file of the activity whose name is the last element, such as map.png
+func (r RunningActivity) AssetPath(name string) (string, bool) {
+	for _, assetPath := range r.AssetPaths() {
+		if path.Base(assetPath) == name {
+			return assetPath, true
+		}
+	}
+
+	return "", false
+}
+
+// IsMapPending returns whether the map and shareable card are still to be generated
+func (r RunningActivity) IsMapPending() bool {
+	return r.MapStatus == MapStatusPending
+}
+
+// WithPendingMap returns the activity waiting for its map, because of the reason
+func (r RunningActivity) WithPendingMap(reason string) RunningActivity {
+	r.MapStatus = MapStatusPending
+	r.MapError = reason
+
+	return r
+}
+
+// WithReadyMap returns the activity whose map and shareable card are stored
+func (r RunningActivity) WithReadyMap() RunningActivity {
+	r.MapStatus = MapStatusReady
+	r.MapError = ""
+
+	return r
 }
 
 func NewRunningActivity(when time.Time, duration time.Duration, distance Distance, speed Speed, gpxPath GPXFilePath, mapPath MapFilePath, shareableMapPath ShareableMapFilePath) (RunningActivity, error) {
@@ -44,5 +270,9 @@ func NewRunningActivity(when time.Time, duration time.Duration, distance Distanc
 		GPXPath:          gpxPath,
 		MapPath:          mapPath,
 		ShareableMapPath: shareableMapPath,
+		Type:             ActivityTypeRun,
+		MapStyle:         DefaultMapStyle(),
+		MapStatus:        MapStatusReady,
+		Visibility:       ActivityVisibilityPublic,
 	}, nil
 }
@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -36,3 +37,162 @@ func TestNewRunnginActivityErrors(t *testing.T) {
 	testutils.AssertEqualString(t, "map path is required", errorMessages[4], "wrong map path error")
 	testutils.AssertEqualString(t, "shareable map path is required", errorMessages[5], "wrong shareable map path error")
 }
+
+func TestRunningActivityPendingMap(t *testing.T) {
+	activity := domain.RunningActivity{MapStatus: domain.MapStatusReady}
+
+	pending := activity.WithPendingMap("mapbox is down")
+	testutils.AssertEqualBool(t, true, pending.IsMapPending(), "map should be pending")
+	testutils.AssertEqualString(t, "mapbox is down", pending.MapError, "unexpected map error")
+
+	ready := pending.WithReadyMap()
+	testutils.AssertEqualBool(t, false, ready.IsMapPending(), "map should be ready")
+	testutils.AssertEqualString(t, "", ready.MapError, "map error should be cleared")
+}
+
+func TestRunningActivityCharts(t *testing.T) {
+	activity := domain.RunningActivity{}
+	testutils.AssertEqualBool(t, false, activity.HasCharts(), "activity shouldn't have charts")
+
+	activity = activity.WithCharts("runs/2022-04-21.09h00/elevation", "runs/2022-04-21.09h00/pace")
+	testutils.AssertEqualBool(t, true, activity.HasCharts(), "activity should have charts")
+	testutils.AssertEqualString(t, "runs/2022-04-21.09h00/elevation.svg", activity.ElevationChartPath.SVG(), "unexpected elevation chart path")
+	testutils.AssertEqualString(t, "runs/2022-04-21.09h00/pace.png", activity.PaceChartPath.PNG(), "unexpected pace chart path")
+}
+
+func TestRunningActivityCards(t *testing.T) {
+	activity := domain.RunningActivity{ShareableMapPath: "runs/2022-04-21.09h00/share-map.png", MapStyle: domain.DefaultMapStyle()}
+
+	legacyCards := activity.ShareableCards()
+	testutils.RequireEqualInt(t, 1, len(legacyCards), "unexpected number of legacy cards")
+	testutils.AssertEqualString(t, "runs/2022-04-21.09h00/share-map.png", legacyCards[0].Path.String(), "unexpected legacy card")
+	testutils.AssertEqualInt(t, 1600, legacyCards[0].Width, "unexpected legacy card width")
+
+	activity = activity.WithCards(domain.DefaultCardTemplates().Cards("runs/2022-04-21.09h00"))
+	testutils.AssertEqualInt(t, 3, len(activity.ShareableCards()), "unexpected number of cards")
+	testutils.AssertEqualString(t, "runs/2022-04-21.09h00/card-opengraph.png", activity.ShareableMapPath.String(), "unexpected shareable map")
+}
+
+func TestRunningActivityCanBeManagedBy(t *testing.T) {
+	activity := domain.RunningActivity{}.WithOwner("alice")
+
+	testutils.AssertEqualBool(t, true, activity.CanBeManagedBy(domain.User{Username: "alice"}), "owner should manage the activity")
+	testutils.AssertEqualBool(t, true, activity.CanBeManagedBy(domain.User{Username: "bob", IsAdmin: true}), "admin should manage the activity")
+	testutils.AssertEqualBool(t, false, activity.CanBeManagedBy(domain.User{Username: "bob"}), "other athlete shouldn't manage the activity")
+	testutils.AssertEqualBool(t, false, domain.RunningActivity{}.CanBeManagedBy(domain.User{}), "orphan activity shouldn't be managed by a user without name")
+}
+
+func TestRunningActivityCanBeSeenBy(t *testing.T) {
+	owner := domain.User{Username: "alice"}
+	other := domain.User{Username: "bob"}
+	visitor := domain.User{}
+
+	tcs := map[domain.ActivityVisibility][3]bool{
+		domain.ActivityVisibilityPublic:   {true, true, true},
+		domain.ActivityVisibilityUnlisted: {true, true, true},
+		domain.ActivityVisibilityPrivate:  {true, false, false},
+	}
+
+	for visibility, expected := range tcs {
+		activity := domain.RunningActivity{}.WithOwner("alice").WithVisibility(visibility)
+
+		testutils.AssertEqualBool(t, expected[0], activity.CanBeSeenBy(owner), "unexpected owner access to %s activity", visibility)
+		testutils.AssertEqualBool(t, expected[1], activity.CanBeSeenBy(other), "unexpected athlete access to %s activity", visibility)
+		testutils.AssertEqualBool(t, expected[2], activity.CanBeSeenBy(visitor), "unexpected visitor access to %s activity", visibility)
+	}
+}
+
+func TestRunningActivityIsListedFor(t *testing.T) {
+	owner := domain.User{Username: "alice"}
+	other := domain.User{Username: "bob"}
+	visitor := domain.User{}
+
+	tcs := map[domain.ActivityVisibility][3]bool{
+		domain.ActivityVisibilityPublic:   {true, true, true},
+		domain.ActivityVisibilityUnlisted: {true, false, false},
+		domain.ActivityVisibilityPrivate:  {true, false, false},
+	}
+
+	for visibility, expected := range tcs {
+		activity := domain.RunningActivity{}.WithOwner("alice").WithVisibility(visibility)
+
+		testutils.AssertEqualBool(t, expected[0], activity.IsListedFor(owner), "unexpected owner listing of %s activity", visibility)
+		testutils.AssertEqualBool(t, expected[1], activity.IsListedFor(other), "unexpected athlete listing of %s activity", visibility)
+		testutils.AssertEqualBool(t, expected[2], activity.IsListedFor(visitor), "unexpected visitor listing of %s activity", visibility)
+	}
+}
+
+func TestRunningActivityAssetPath(t *testing.T) {
+	activity := domain.RunningActivity{
+		GPXPath:            "runs/2022-04-21.09h00/run.gpx",
+		MapPath:            "runs/2022-04-21.09h00/map.png",
+		ShareableMapPath:   "runs/2022-04-21.09h00/share-map.png",
+		ElevationChartPath: "runs/2022-04-21.09h00/elevation",
+		PaceChartPath:      "runs/2022-04-21.09h00/pace",
+	}
+
+	tcs := map[string]string{
+		"run.gpx":       "runs/2022-04-21.09h00/run.gpx",
+		"map.png":       "runs/2022-04-21.09h00/map.png",
+		"share-map.png": "runs/2022-04-21.09h00/share-map.png",
+		"elevation.svg": "runs/2022-04-21.09h00/elevation.svg",
+		"pace.png":      "runs/2022-04-21.09h00/pace.png",
+	}
+
+	for name, expected := range tcs {
+		actual, ok := activity.AssetPath(name)
+		testutils.AssertEqualBool(t, true, ok, "asset %s should be found", name)
+		testutils.AssertEqualString(t, expected, actual, "unexpected path of asset %s", name)
+	}
+
+	_, ok := activity.AssetPath("passwords.txt")
+	testutils.AssertEqualBool(t, false, ok, "unknown asset shouldn't be found")
+}
+
+func TestRunningActivityHasPublicAsset(t *testing.T) {
+	activity := domain.RunningActivity{
+		GPXPath: "runs/2022-04-21.09h00/run.gpx",
+		MapPath: "runs/2022-04-21.09h00/map.png",
+	}
+
+	testutils.AssertEqualBool(t, true, activity.HasPublicAsset(activity.MapPath.String()), "map of public activity should be public")
+	testutils.AssertEqualBool(t, false, activity.HasPublicAsset(activity.GPXPath.String()), "gpx file may cross privacy zones")
+
+	activity = activity.WithVisibility(domain.ActivityVisibilityPrivate)
+	testutils.AssertEqualBool(t, false, activity.HasPublicAsset(activity.MapPath.String()), "map of private activity shouldn't be public")
+}
+
+func TestRunningActivityRevealsTrackTo(t *testing.T) {
+	activity := domain.RunningActivity{}.WithOwner("alice")
+
+	testutils.AssertEqualBool(t, true, activity.RevealsTrackTo(domain.User{Username: "alice"}), "owner should get the full track")
+	testutils.AssertEqualBool(t, false, activity.RevealsTrackTo(domain.User{Username: "bob", IsAdmin: true}), "other users shouldn't get the full track")
+	testutils.AssertEqualBool(t, false, activity.RevealsTrackTo(domain.User{}), "visitors shouldn't get the full track")
+}
+
+func TestRunningActivityHasMisplacedAssets(t *testing.T) {
+	public := domain.RunningActivity{GPXPath: "runs/2022-04-21.09h00/run.gpx"}
+	private := domain.RunningActivity{GPXPath: "private/0123/2022-04-21.09h00/run.gpx"}
+
+	testutils.AssertEqualBool(t, false, public.HasMisplacedAssets(), "public activity stored publicly")
+	testutils.AssertEqualBool(t, true, public.WithVisibility(domain.ActivityVisibilityPrivate).HasMisplacedAssets(), "private activity stored publicly")
+	testutils.AssertEqualBool(t, false, private.WithVisibility(domain.ActivityVisibilityPrivate).HasMisplacedAssets(), "private activity stored privately")
+	testutils.AssertEqualBool(t, true, private.WithVisibility(domain.ActivityVisibilityUnlisted).HasMisplacedAssets(), "unlisted activity stored privately")
+}
+
+func TestRunningActivityWithAssetsFolder(t *testing.T) {
+	activity := domain.RunningActivity{
+		GPXPath:            "runs/2022-04-21.09h00/run.gpx",
+		MapPath:            "runs/2022-04-21.09h00/map.png",
+		ElevationChartPath: "runs/2022-04-21.09h00/elevation",
+		PaceChartPath:      "runs/2022-04-21.09h00/pace",
+	}.WithCards(domain.DefaultCardTemplates().Cards("runs/2022-04-21.09h00"))
+
+	moved := activity.WithAssetsFolder("private/0123/2022-04-21.09h00")
+
+	for _, assetPath := range moved.AssetPaths() {
+		testutils.AssertEqualBool(t, true, strings.HasPrefix(assetPath, "private/0123/2022-04-21.09h00/"), "unexpected path %s", assetPath)
+	}
+	testutils.AssertEqualString(t, "private/0123/2022-04-21.09h00/card-opengraph.png", moved.ShareableMapPath.String(), "unexpected shareable map path")
+	testutils.AssertEqualString(t, "runs/2022-04-21.09h00/card-opengraph.png", activity.Cards[0].Path.String(), "original cards shouldn't change")
+}
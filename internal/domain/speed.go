@@ -1,6 +1,6 @@
 package domain
 
-import "math"
+import "time"
 
 // Speed represents the average speed for an activity in km/h
 type Speed struct {
@@ -22,7 +22,22 @@ func (s Speed) KilometersPerHour() float64 {
 	return s.kilometersPerHour
 }
 
-// MinutesPerKilometer converts speed from km/h to min/km
-func (s Speed) MinutesPerKilometer() float64 {
-	return math.Round(60/s.kilometersPerHour*100) / 100
+// Pace converts speed from km/h to the time spent per kilometer. The pace is unknown when there is no speed or when
+// the speed is out of the range of a possible pace.
+func (s Speed) Pace() Pace {
+	if s.kilometersPerHour <= 0 {
+		return Pace{}
+	}
+
+	pace, err := NewPacePerKilometer(time.Duration(float64(time.Hour) / s.kilometersPerHour))
+	if err != nil {
+		return Pace{}
+	}
+
+	return pace
+}
+
+// MilesPerHour converts speed from km/h to mph
+func (s Speed) MilesPerHour() float64 {
+	return s.kilometersPerHour * 1000 / metersPerMile
 }
@@ -3,6 +3,7 @@ package domain_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/lonepeon/golib/testutils"
 	"github.com/lonepeon/sport/internal/domain"
@@ -21,17 +22,31 @@ func TestNewSpeedFromKmhNegativeError(t *testing.T) {
 	testutils.AssertErrorIs(t, domain.ErrSpeedTooSmall, err, "unexpected speed error")
 }
 
-func TestSpeedMinutesPerKilometer(t *testing.T) {
-	tcs := map[float64]float64{
-		10:    6,
-		12.45: 4.82,
+func TestSpeedPace(t *testing.T) {
+	tcs := map[float64]time.Duration{
+		10:    6 * time.Minute,
+		12.45: 4*time.Minute + 49*time.Second,
 	}
 
 	for kmh, expected := range tcs {
-		t.Run(fmt.Sprintf("%vkm/h -> %vm/km", kmh, expected), func(t *testing.T) {
+		t.Run(fmt.Sprintf("%vkm/h -> %v/km", kmh, expected), func(t *testing.T) {
 			speed, err := domain.NewSpeedFromKmh(kmh)
 			testutils.AssertNoError(t, err, "can't build speed of %f km/h", kmh)
-			testutils.AssertEqualFloat64(t, expected, speed.MinutesPerKilometer(), "invalid conversion")
+			testutils.AssertEqualDuration(t, expected, speed.Pace().PerKilometer(), "invalid conversion")
+		})
+	}
+}
+
+func TestSpeedPaceWithoutSpeed(t *testing.T) {
+	testutils.AssertEqualBool(t, true, domain.Speed{}.Pace().IsZero(), "pace should be unknown")
+}
+
+func TestSpeedPaceOutOfRange(t *testing.T) {
+	for _, kmh := range []float64{0.01, 100} {
+		t.Run(fmt.Sprintf("%vkm/h", kmh), func(t *testing.T) {
+			speed, err := domain.NewSpeedFromKmh(kmh)
+			testutils.AssertNoError(t, err, "can't build speed of %f km/h", kmh)
+			testutils.AssertEqualBool(t, true, speed.Pace().IsZero(), "pace should be unknown")
 		})
 	}
 }
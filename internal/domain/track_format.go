@@ -0,0 +1,45 @@
+package domain
+
+import "fmt"
+
+// TrackFormat represents a file format the track of an activity can be downloaded as
+type TrackFormat string
+
+const (
+	// TrackFormatGPX files hold the points, their time, elevation and heart rate
+	TrackFormatGPX TrackFormat = "gpx"
+	// TrackFormatGeoJSON files hold the line of the track and each point with its time, elevation, speed and heart rate
+	TrackFormatGeoJSON TrackFormat = "geojson"
+	// TrackFormatKML files hold the points and their time and elevation, as read by Google Earth
+	TrackFormatKML TrackFormat = "kml"
+)
+
+// TrackFormats lists every supported format
+var TrackFormats = []TrackFormat{TrackFormatGPX, TrackFormatGeoJSON, TrackFormatKML}
+
+// ParseTrackFormat returns the format matching the value, the extension of its files
+func ParseTrackFormat(value string) (TrackFormat, error) {
+	for _, format := range TrackFormats {
+		if string(format) == value {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported track format %s", value)
+}
+
+func (f TrackFormat) String() string {
+	return string(f)
+}
+
+// Label returns the usual name of the format
+func (f TrackFormat) Label() string {
+	switch f {
+	case TrackFormatGeoJSON:
+		return "GeoJSON"
+	case TrackFormatKML:
+		return "KML"
+	default:
+		return "GPX"
+	}
+}
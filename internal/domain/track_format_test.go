@@ -0,0 +1,36 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestParseTrackFormatSuccess(t *testing.T) {
+	tcs := map[string]domain.TrackFormat{
+		"gpx":     domain.TrackFormatGPX,
+		"geojson": domain.TrackFormatGeoJSON,
+		"kml":     domain.TrackFormatKML,
+	}
+
+	for value, expected := range tcs {
+		actual, err := domain.ParseTrackFormat(value)
+		testutils.AssertNoError(t, err, "can't parse track format %s", value)
+		testutils.AssertEqualString(t, expected.String(), actual.String(), "unexpected track format")
+	}
+}
+
+func TestParseTrackFormatError(t *testing.T) {
+	for _, value := range []string{"", "tcx", "GPX"} {
+		_, err := domain.ParseTrackFormat(value)
+
+		testutils.AssertHasError(t, err, "expected an error for %s", value)
+	}
+}
+
+func TestTrackFormatLabel(t *testing.T) {
+	testutils.AssertEqualString(t, "GPX", domain.TrackFormatGPX.Label(), "unexpected gpx label")
+	testutils.AssertEqualString(t, "GeoJSON", domain.TrackFormatGeoJSON.Label(), "unexpected geojson label")
+	testutils.AssertEqualString(t, "KML", domain.TrackFormatKML.Label(), "unexpected kml label")
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// UnitSystem represents the units distances, speeds, paces and elevations are displayed in
+type UnitSystem string
+
+const (
+	UnitSystemMetric   UnitSystem = "metric"
+	UnitSystemImperial UnitSystem = "imperial"
+)
+
+// UnitSystems lists every supported unit system
+var UnitSystems = []UnitSystem{UnitSystemMetric, UnitSystemImperial}
+
+const (
+	metersPerMile = 1609.344
+	feetPerMeter  = 3.28084
+)
+
+// ParseUnitSystem returns the unit system matching the value
+func ParseUnitSystem(value string) (UnitSystem, error) {
+	for _, units := range UnitSystems {
+		if string(units) == value {
+			return units, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported unit system %s", value)
+}
+
+func (u UnitSystem) String() string {
+	return string(u)
+}
+
+// Label returns the human readable name of the unit system
+func (u UnitSystem) Label() string {
+	if u == UnitSystemImperial {
+		return "Imperial (mi, ft)"
+	}
+
+	return "Metric (km, m)"
+}
+
+// DistanceUnit returns the abbreviation of the unit distances are displayed in
+func (u UnitSystem) DistanceUnit() string {
+	if u == UnitSystemImperial {
+		return "mi"
+	}
+
+	return "km"
+}
+
+// Distance returns the distance in the unit of the system
+func (u UnitSystem) Distance(d Distance) float64 {
+	if u == UnitSystemImperial {
+		return d.Miles()
+	}
+
+	return d.Kilometers()
+}
+
+// Speed returns the speed in the distance unit of the system per hour
+func (u UnitSystem) Speed(s Speed) float64 {
+	if u == UnitSystemImperial {
+		return s.MilesPerHour()
+	}
+
+	return s.KilometersPerHour()
+}
+
+// Pace returns the time spent per distance unit of the system
+func (u UnitSystem) Pace(p Pace) time.Duration {
+	if u == UnitSystemImperial {
+		return p.PerMile()
+	}
+
+	return p.PerKilometer()
+}
+
+// Elevation returns the meters in the elevation unit of the system, feet for the imperial system
+func (u UnitSystem) Elevation(meters float64) float64 {
+	if u == UnitSystemImperial {
+		return meters * feetPerMeter
+	}
+
+	return meters
+}
+
+// ElevationUnit returns the abbreviation of the unit elevations are displayed in
+func (u UnitSystem) ElevationUnit() string {
+	if u == UnitSystemImperial {
+		return "ft"
+	}
+
+	return "m"
+}
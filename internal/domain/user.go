@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// userPasswordMinLength is the minimum number of characters of a password
+	userPasswordMinLength = 8
+	// userPasswordMaxLength is the maximum number of bytes of a password, bcrypt ignores the next ones
+	userPasswordMaxLength = 72
+	// userGeneratedPasswordSize is the number of random bytes of a generated password
+	userGeneratedPasswordSize = 12
+)
+
+// UserPasswordHash represents the salted hash of a password, the password itself is never stored
+type UserPasswordHash string
+
+// HashUserPassword returns the salted hash of the password
+func HashUserPassword(password string) (UserPasswordHash, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("can't hash password: %v", err)
+	}
+
+	return UserPasswordHash(hash), nil
+}
+
+// String implements Stringer interface
+func (h UserPasswordHash) String() string {
+	return string(h)
+}
+
+// User represents an account allowed to log in. The username identifies the user and never changes.
+type User struct {
+	Username     string
+	PasswordHash UserPasswordHash
+	IsAdmin      bool
+	CreatedAt    time.Time
+	DisabledAt   time.Time
+}
+
+// NewUser initializes a user and hashes the password
+func NewUser(username string, password string, isAdmin bool, createdAt time.Time) (User, error) {
+	var errs InvalidInputErrors
+	if _, err := NewSlug(username); err != nil {
+		errs.Append("username must only contain lower case alphanumeric characters, - or ., and be shorter than 63 characters")
+	}
+	validateUserPassword(&errs, password)
+
+	if !errs.IsEmpty() {
+		return User{}, &errs
+	}
+
+	hash, err := HashUserPassword(password)
+	if err != nil {
+		return User{}, err
+	}
+
+	return User{Username: username, PasswordHash: hash, IsAdmin: isAdmin, CreatedAt: createdAt}, nil
+}
+
+// NewLegacyUser initializes an administrator declared in SPORT_USERS before accounts were persisted. The username and
+// password rules of NewUser didn't exist back then and aren't enforced, so existing installs keep their logins.
+func NewLegacyUser(username string, password string, createdAt time.Time) (User, error) {
+	if username == "" {
+		var errs InvalidInputErrors
+		errs.Append("username can't be empty")
+		return User{}, &errs
+	}
+
+	hash, err := HashUserPassword(password)
+	if err != nil {
+		return User{}, err
+	}
+
+	return User{Username: username, PasswordHash: hash, IsAdmin: true, CreatedAt: createdAt}, nil
+}
+
+// GenerateUserPassword returns a random password, given to users whose password was reset
+func GenerateUserPassword() (string, error) {
+	password := make([]byte, userGeneratedPasswordSize)
+	if _, err := rand.Read(password); err != nil {
+		return "", fmt.Errorf("can't generate password: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(password), nil
+}
+
+// IsDisabled returns whether the user is no longer allowed to log in
+func (u User) IsDisabled() bool {
+	return !u.DisabledAt.IsZero()
+}
+
+// VerifyPassword returns whether the password is the one of the user
+func (u User) VerifyPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// ChangePassword replaces the password of the user
+func (u *User) ChangePassword(password string) error {
+	var errs InvalidInputErrors
+	validateUserPassword(&errs, password)
+	if !errs.IsEmpty() {
+		return &errs
+	}
+
+	hash, err := HashUserPassword(password)
+	if err != nil {
+		return err
+	}
+
+	u.PasswordHash = hash
+
+	return nil
+}
+
+func validateUserPassword(errs *InvalidInputErrors, password string) {
+	if len([]rune(password)) < userPasswordMinLength {
+		errs.Append(fmt.Sprintf("password must be at least %d characters long", userPasswordMinLength))
+	}
+
+	if len(password) > userPasswordMaxLength {
+		errs.Append(fmt.Sprintf("password can't be longer than %d bytes", userPasswordMaxLength))
+	}
+}
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// SpeedDisplay represents whether the speed of activities is displayed as a pace (time per distance) or as a speed
+type SpeedDisplay string
+
+const (
+	SpeedDisplayPace  SpeedDisplay = "pace"
+	SpeedDisplaySpeed SpeedDisplay = "speed"
+)
+
+// SpeedDisplays lists every supported speed display
+var SpeedDisplays = []SpeedDisplay{SpeedDisplayPace, SpeedDisplaySpeed}
+
+// ParseSpeedDisplay returns the speed display matching the value
+func ParseSpeedDisplay(value string) (SpeedDisplay, error) {
+	for _, display := range SpeedDisplays {
+		if string(display) == value {
+			return display, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported speed display %s", value)
+}
+
+func (s SpeedDisplay) String() string {
+	return string(s)
+}
+
+// Label returns the human readable name of the speed display
+func (s SpeedDisplay) Label() string {
+	if s == SpeedDisplaySpeed {
+		return "Speed"
+	}
+
+	return "Pace"
+}
+
+// UserPreferences represents how a user wants activities to be displayed
+type UserPreferences struct {
+	Locale       Locale
+	Units        UnitSystem
+	SpeedDisplay SpeedDisplay
+}
+
+// DefaultUserPreferences returns the preferences of users who didn't choose any
+func DefaultUserPreferences() UserPreferences {
+	return UserPreferences{Locale: LocaleEnglish, Units: UnitSystemMetric, SpeedDisplay: SpeedDisplayPace}
+}
+
+// NewUserPreferences builds preferences and validates each value is supported
+func NewUserPreferences(locale string, units string, speedDisplay string) (UserPreferences, error) {
+	var errs InvalidInputErrors
+
+	var prefs UserPreferences
+	var err error
+	if prefs.Locale, err = ParseLocale(locale); err != nil {
+		errs.Append("locale must be en or fr")
+	}
+	if prefs.Units, err = ParseUnitSystem(units); err != nil {
+		errs.Append("units must be metric or imperial")
+	}
+	if prefs.SpeedDisplay, err = ParseSpeedDisplay(speedDisplay); err != nil {
+		errs.Append("speed display must be pace or speed")
+	}
+
+	if !errs.IsEmpty() {
+		return UserPreferences{}, &errs
+	}
+
+	return prefs, nil
+}
+
+// Translate returns the english message in the locale of the user
+func (p UserPreferences) Translate(message string) string {
+	return p.Locale.Translate(message)
+}
+
+// FormatDistance returns the distance with 2 decimals (e.g. 10.24km or 6.36mi)
+func (p UserPreferences) FormatDistance(d Distance) string {
+	return p.Locale.FormatNumber(p.Units.Distance(d), 2) + p.Units.DistanceUnit()
+}
+
+// FormatSpeed returns the speed with 1 decimal (e.g. 10.2km/h or 6.4mph)
+func (p UserPreferences) FormatSpeed(s Speed) string {
+	if p.Units == UnitSystemImperial {
+		return p.Locale.FormatNumber(p.Units.Speed(s), 1) + "mph"
+	}
+
+	return p.Locale.FormatNumber(p.Units.Speed(s), 1) + "km/h"
+}
+
+// FormatPace returns the time spent per distance unit (e.g. 5:59/km or 9:38/mi)
+func (p UserPreferences) FormatPace(pace Pace) string {
+	if pace.IsZero() {
+		return "-"
+	}
+
+	return formatMinutesSeconds(p.Units.Pace(pace)) + "/" + p.Units.DistanceUnit()
+}
+
+// FormatPreferredSpeed returns the speed formatted as a pace or a speed, as the user prefers
+func (p UserPreferences) FormatPreferredSpeed(s Speed) string {
+	if p.SpeedDisplay == SpeedDisplaySpeed {
+		return p.FormatSpeed(s)
+	}
+
+	return p.FormatPace(s.Pace())
+}
+
+// FormatElevation returns the meters rounded in the elevation unit (e.g. 120m or 394ft)
+func (p UserPreferences) FormatElevation(meters float64) string {
+	return p.Locale.FormatNumber(p.Units.Elevation(meters), 0) + p.Units.ElevationUnit()
+}
+
+// FormatDuration returns the duration as minutes and seconds, with hours for long ones (e.g. 45:12 or 1:02:03)
+func (p UserPreferences) FormatDuration(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+	}
+
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// FormatDate returns the day of the time in the locale of the user
+func (p UserPreferences) FormatDate(t time.Time) string {
+	return p.Locale.FormatDate(t)
+}
+
+// FormatDateTime returns the day and minute of the time in the locale of the user
+func (p UserPreferences) FormatDateTime(t time.Time) string {
+	return p.Locale.FormatDateTime(t)
+}
@@ -0,0 +1,83 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestNewUserPreferencesSuccess(t *testing.T) {
+	prefs, err := domain.NewUserPreferences("fr", "imperial", "speed")
+	testutils.RequireNoError(t, err, "unexpected preferences error")
+
+	testutils.AssertEqualString(t, "fr", prefs.Locale.String(), "unexpected locale")
+	testutils.AssertEqualString(t, "imperial", prefs.Units.String(), "unexpected units")
+	testutils.AssertEqualString(t, "speed", prefs.SpeedDisplay.String(), "unexpected speed display")
+}
+
+func TestNewUserPreferencesInvalid(t *testing.T) {
+	_, err := domain.NewUserPreferences("de", "nautical", "both")
+
+	var errs *domain.InvalidInputErrors
+	testutils.RequireErrorAs(t, &errs, err, "unexpected preferences error")
+	testutils.AssertEqualInt(t, 3, len(errs.Detail()), "unexpected number of errors")
+}
+
+func TestUserPreferencesFormat(t *testing.T) {
+	distance, err := domain.NewDistanceFromMeters(10000)
+	testutils.RequireNoError(t, err, "can't build distance")
+	speed, err := domain.NewSpeedFromKmh(10.04)
+	testutils.RequireNoError(t, err, "can't build speed")
+
+	type Formatted struct {
+		Distance  string
+		Speed     string
+		Pace      string
+		Preferred string
+		Elevation string
+		DateTime  string
+	}
+
+	tcs := map[string]struct {
+		Preferences domain.UserPreferences
+		Expected    Formatted
+	}{
+		"englishMetric": {
+			Preferences: domain.UserPreferences{Locale: domain.LocaleEnglish, Units: domain.UnitSystemMetric, SpeedDisplay: domain.SpeedDisplayPace},
+			Expected:    Formatted{"10.00km", "10.0km/h", "5:59/km", "5:59/km", "120m", "2022/04/21 09:05"},
+		},
+		"frenchMetric": {
+			Preferences: domain.UserPreferences{Locale: domain.LocaleFrench, Units: domain.UnitSystemMetric, SpeedDisplay: domain.SpeedDisplaySpeed},
+			Expected:    Formatted{"10,00km", "10,0km/h", "5:59/km", "10,0km/h", "120m", "21/04/2022 09:05"},
+		},
+		"englishImperial": {
+			Preferences: domain.UserPreferences{Locale: domain.LocaleEnglish, Units: domain.UnitSystemImperial, SpeedDisplay: domain.SpeedDisplayPace},
+			Expected:    Formatted{"6.21mi", "6.2mph", "9:37/mi", "9:37/mi", "394ft", "2022/04/21 09:05"},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			prefs := tc.Preferences
+			testutils.AssertEqualString(t, tc.Expected.Distance, prefs.FormatDistance(distance), "unexpected distance")
+			testutils.AssertEqualString(t, tc.Expected.Speed, prefs.FormatSpeed(speed), "unexpected speed")
+			testutils.AssertEqualString(t, tc.Expected.Pace, prefs.FormatPace(speed.Pace()), "unexpected pace")
+			testutils.AssertEqualString(t, tc.Expected.Preferred, prefs.FormatPreferredSpeed(speed), "unexpected preferred speed")
+			testutils.AssertEqualString(t, tc.Expected.Elevation, prefs.FormatElevation(120), "unexpected elevation")
+			testutils.AssertEqualString(t, tc.Expected.DateTime, prefs.FormatDateTime(time.Date(2022, time.April, 21, 9, 5, 0, 0, time.UTC)), "unexpected date")
+		})
+	}
+}
+
+func TestUserPreferencesFormatUnknownPace(t *testing.T) {
+	testutils.AssertEqualString(t, "-", domain.DefaultUserPreferences().FormatPace(domain.Pace{}), "unexpected pace")
+}
+
+func TestUserPreferencesFormatDuration(t *testing.T) {
+	prefs := domain.DefaultUserPreferences()
+
+	testutils.AssertEqualString(t, "52:30", prefs.FormatDuration(52*time.Minute+30*time.Second), "unexpected short duration")
+	testutils.AssertEqualString(t, "2:05:07", prefs.FormatDuration(2*time.Hour+5*time.Minute+7*time.Second), "unexpected long duration")
+}
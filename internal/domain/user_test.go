@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func TestNewUser(t *testing.T) {
+	createdAt := time.Date(2022, 4, 26, 9, 0, 0, 0, time.UTC)
+
+	user, err := domain.NewUser("alice", "correct horse", true, createdAt)
+	testutils.RequireNoError(t, err, "can't create user")
+
+	testutils.AssertEqualString(t, "alice", user.Username, "unexpected username")
+	testutils.AssertEqualBool(t, true, user.IsAdmin, "unexpected admin")
+	testutils.AssertEqualTime(t, createdAt, user.CreatedAt, "unexpected created at")
+	testutils.AssertEqualBool(t, false, user.IsDisabled(), "user shouldn't be disabled")
+	testutils.AssertEqualBool(t, false, strings.Contains(user.PasswordHash.String(), "correct horse"), "hash shouldn't contain the password")
+	testutils.AssertEqualBool(t, true, user.VerifyPassword("correct horse"), "password should be verified")
+	testutils.AssertEqualBool(t, false, user.VerifyPassword("wrong horse"), "wrong password shouldn't be verified")
+}
+
+func TestNewUserInvalid(t *testing.T) {
+	_, err := domain.NewUser("Alice Smith", "short", false, time.Now())
+
+	var invalidErr *domain.InvalidInputErrors
+	testutils.RequireErrorAs(t, &invalidErr, err, "expected invalid input")
+	testutils.AssertEqualStrings(t, []string{
+		"username must only contain lower case alphanumeric characters, - or ., and be shorter than 63 characters",
+		"password must be at least 8 characters long",
+	}, invalidErr.Detail(), "unexpected errors")
+
+	_, err = domain.NewUser("alice", strings.Repeat("a", 73), false, time.Now())
+	testutils.RequireErrorAs(t, &invalidErr, err, "expected invalid input")
+	testutils.AssertEqualStrings(t, []string{"password can't be longer than 72 bytes"}, invalidErr.Detail(), "unexpected errors")
+}
+
+func TestNewLegacyUser(t *testing.T) {
+	createdAt := time.Date(2022, 4, 26, 9, 0, 0, 0, time.UTC)
+
+	user, err := domain.NewLegacyUser("Alice Smith", "short", createdAt)
+	testutils.RequireNoError(t, err, "can't create legacy user")
+
+	testutils.AssertEqualString(t, "Alice Smith", user.Username, "unexpected username")
+	testutils.AssertEqualBool(t, true, user.IsAdmin, "legacy user should be an admin")
+	testutils.AssertEqualTime(t, createdAt, user.CreatedAt, "unexpected created at")
+	testutils.AssertEqualBool(t, true, user.VerifyPassword("short"), "password should be verified")
+
+	_, err = domain.NewLegacyUser("", "correct horse", createdAt)
+	var invalidErr *domain.InvalidInputErrors
+	testutils.RequireErrorAs(t, &invalidErr, err, "expected invalid input")
+	testutils.AssertEqualStrings(t, []string{"username can't be empty"}, invalidErr.Detail(), "unexpected errors")
+}
+
+func TestUserChangePassword(t *testing.T) {
+	user, err := domain.NewUser("alice", "correct horse", false, time.Now())
+	testutils.RequireNoError(t, err, "can't create user")
+
+	testutils.RequireNoError(t, user.ChangePassword("battery staple"), "can't change password")
+	testutils.AssertEqualBool(t, true, user.VerifyPassword("battery staple"), "new password should be verified")
+	testutils.AssertEqualBool(t, false, user.VerifyPassword("correct horse"), "old password shouldn't be verified")
+
+	err = user.ChangePassword("short")
+	var invalidErr *domain.InvalidInputErrors
+	testutils.RequireErrorAs(t, &invalidErr, err, "expected invalid input")
+	testutils.AssertEqualBool(t, true, user.VerifyPassword("battery staple"), "password shouldn't change")
+}
+
+func TestGenerateUserPassword(t *testing.T) {
+	password, err := domain.GenerateUserPassword()
+	testutils.RequireNoError(t, err, "can't generate password")
+
+	other, err := domain.GenerateUserPassword()
+	testutils.RequireNoError(t, err, "can't generate password")
+
+	testutils.AssertEqualBool(t, false, password == other, "passwords should be random")
+
+	user, err := domain.NewUser("alice", password, false, time.Now())
+	testutils.RequireNoError(t, err, "generated password should be valid")
+	testutils.AssertEqualBool(t, true, user.VerifyPassword(password), "generated password should be verified")
+}
@@ -13,6 +13,7 @@ import (
 	_ "embed"
 
 	"github.com/lonepeon/sport/internal/domain"
+	xdraw "golang.org/x/image/draw"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/opentype"
 	"golang.org/x/image/math/fixed"
@@ -31,48 +32,207 @@ func init() {
 	montSerratRegularFont = font
 }
 
+// cardReferenceWidth is the card width, in pixels, the sizes below are expressed for. Sizes scale with the card.
+const cardReferenceWidth = 1080.0
+
+const (
+	cardPadding     = 40
+	cardRowGap      = 20
+	cardTitleSize   = 64
+	cardDateSize    = 36
+	cardLabelSize   = 30
+	cardValueSize   = 72
+	cardLineSpacing = 1.25
+)
+
+var cardBandColor = color.RGBA{A: 0x99}
+
 type Annotation struct {
 }
 
-func (a Annotation) AnnotateMapWithStats(ctx context.Context, file domain.MapFile, distance domain.Distance, speed domain.Speed) (domain.ShareableMapFile, error) {
+// DrawCard draws the stats chosen by the template over the map, which is cropped to fill the card format. Stats are
+// formatted and labelled as the user prefers.
+func (a Annotation) DrawCard(ctx context.Context, file domain.MapFile, template domain.CardTemplate, stats domain.CardStats, prefs domain.UserPreferences) (domain.ShareableMapFile, error) {
 	src, err := png.Decode(file.File())
 	if err != nil {
 		return domain.ShareableMapFile{}, fmt.Errorf("can't decode image from png: %v", err)
 	}
 
-	overlayedImage := image.NewRGBA(src.Bounds())
-	draw.Draw(overlayedImage, overlayedImage.Bounds(), src, image.Pt(0, 0), draw.Src)
-	bg := &image.Uniform{color.RGBA{0xFF, 0xFF, 0xFF, 0x77}}
-	draw.Draw(overlayedImage, image.Rect(0, src.Bounds().Max.Y-200, src.Bounds().Max.X, src.Bounds().Max.Y), bg, image.Pt(0, 0), draw.Over)
+	card := image.NewRGBA(image.Rect(0, 0, template.Format.Width, template.Format.Height))
+	xdraw.CatmullRom.Scale(card, card.Bounds(), src, coverRect(src.Bounds(), card.Bounds()), draw.Src, nil)
+
+	layout, err := newCardLayout(template, stats, prefs)
+	if err != nil {
+		return domain.ShareableMapFile{}, err
+	}
+	layout.draw(card)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, card); err != nil {
+		return domain.ShareableMapFile{}, fmt.Errorf("can't encode image to png: %v", err)
+	}
+
+	return domain.NewSharableMapFile(buf.Bytes()), nil
+}
+
+// coverRect returns the largest centered part of src with the aspect ratio of dst
+func coverRect(src image.Rectangle, dst image.Rectangle) image.Rectangle {
+	width, height := src.Dx(), src.Dy()
+	if width*dst.Dy() > height*dst.Dx() {
+		width = height * dst.Dx() / dst.Dy()
+	} else {
+		height = width * dst.Dy() / dst.Dx()
+	}
+
+	min := src.Min.Add(image.Pt((src.Dx()-width)/2, (src.Dy()-height)/2))
+
+	return image.Rectangle{Min: min, Max: min.Add(image.Pt(width, height))}
+}
+
+type cardText struct {
+	Face font.Face
+	Text string
+	X    int
+	// Baseline is the vertical position of the text baseline, from the top of the band
+	Baseline int
+}
+
+type cardLayout struct {
+	template domain.CardTemplate
+	prefs    domain.UserPreferences
+	texts    []cardText
+	height   int
+}
+
+func newCardLayout(template domain.CardTemplate, stats domain.CardStats, prefs domain.UserPreferences) (cardLayout, error) {
+	scale := float64(template.Format.Width) / cardReferenceWidth
+	padding := int(cardPadding * scale)
+	contentWidth := template.Format.Width - 2*padding
+	layout := cardLayout{template: template, prefs: prefs, height: padding}
+
+	headingSizes := map[domain.CardStat]float64{domain.CardStatTitle: cardTitleSize, domain.CardStatDate: cardDateSize}
+	for _, stat := range template.HeadingStats() {
+		face, err := newFace(headingSizes[stat] * scale)
+		if err != nil {
+			return cardLayout{}, err
+		}
+
+		layout.addLine(face, fitString(face, stats.Value(stat, prefs), contentWidth), padding)
+	}
+
+	if err := layout.addGrid(stats, scale, padding, contentWidth); err != nil {
+		return cardLayout{}, err
+	}
+
+	layout.height += padding
+
+	return layout, nil
+}
+
+// addLine adds the text below the previous ones
+func (l *cardLayout) addLine(face font.Face, text string, x int) {
+	lineHeight := int(float64(face.Metrics().Height.Round()) * cardLineSpacing)
+	l.texts = append(l.texts, cardText{Face: face, Text: text, X: x, Baseline: l.height + face.Metrics().Ascent.Round()})
+	l.height += lineHeight
+}
 
+// addGrid adds the grid stats in rows of template columns, each stat being a label above its value
+func (l *cardLayout) addGrid(stats domain.CardStats, scale float64, padding int, contentWidth int) error {
+	gridStats := l.template.GridStats()
+	if len(gridStats) == 0 {
+		return nil
+	}
+
+	columnWidth := contentWidth / l.template.Columns
+	labelFace, err := newFace(cardLabelSize * scale)
+	if err != nil {
+		return err
+	}
+
+	// values are shrunk so they fit narrow columns
+	valueFace, err := newFace(minFloat64(cardValueSize*scale, float64(columnWidth)/5))
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(gridStats); start += l.template.Columns {
+		end := start + l.template.Columns
+		if end > len(gridStats) {
+			end = len(gridStats)
+		}
+
+		if len(l.texts) > 0 {
+			l.height += int(cardRowGap * scale)
+		}
+
+		l.addGridRow(stats, gridStats[start:end], labelFace, valueFace, padding, columnWidth)
+	}
+
+	return nil
+}
+
+func (l *cardLayout) addGridRow(stats domain.CardStats, row []domain.CardStat, labelFace font.Face, valueFace font.Face, padding int, columnWidth int) {
+	rowTop := l.height
+	for column, stat := range row {
+		x := padding + column*columnWidth
+
+		l.height = rowTop
+		l.addLine(labelFace, fitString(labelFace, stats.Label(stat, l.prefs), columnWidth), x)
+		l.addLine(valueFace, fitString(valueFace, stats.Value(stat, l.prefs), columnWidth), x)
+	}
+}
+
+// draw draws the band and its texts on the edge of the card chosen by the template
+func (l cardLayout) draw(card draw.Image) {
+	top := 0
+	if l.template.Position == domain.CardPositionBottom {
+		top = card.Bounds().Dy() - l.height
+	}
+
+	band := image.Rect(0, top, card.Bounds().Dx(), top+l.height)
+	draw.Draw(card, band, image.NewUniform(cardBandColor), image.Point{}, draw.Over)
+
+	for _, text := range l.texts {
+		drawer := font.Drawer{Dst: card, Src: image.White, Face: text.Face, Dot: fixed.P(text.X, top+text.Baseline)}
+		drawer.DrawString(text.Text)
+	}
+}
+
+func newFace(size float64) (font.Face, error) {
 	face, err := opentype.NewFace(montSerratRegularFont, &opentype.FaceOptions{
-		Size:    100,
+		Size:    size,
 		DPI:     72,
 		Hinting: font.HintingNone,
 	})
-
 	if err != nil {
-		return domain.ShareableMapFile{}, fmt.Errorf("can't setup font: %v", err)
+		return nil, fmt.Errorf("can't setup font: %v", err)
 	}
 
-	hpadding := 30
-	drawing := font.Drawer{
-		Dst:  overlayedImage,
-		Src:  image.White,
-		Face: face,
-		Dot:  fixed.P(hpadding, src.Bounds().Max.Y-60),
+	return face, nil
+}
+
+// fitString shortens the text with an ellipsis until it fits the width
+func fitString(face font.Face, text string, width int) string {
+	if font.MeasureString(face, text).Round() <= width {
+		return text
 	}
 
-	drawing.DrawString(fmt.Sprintf("%.2fkm", distance.Kilometers()))
-	speedLabel := fmt.Sprintf("%.2fkm/h", speed.KilometersPerHour())
-	length := font.MeasureString(face, speedLabel)
-	drawing.Dot = fixed.P(src.Bounds().Max.X-length.Round()-hpadding, drawing.Dot.Y.Round())
-	drawing.DrawString(speedLabel)
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		shortened := string(runes) + "..."
+		if font.MeasureString(face, shortened).Round() <= width {
+			return shortened
+		}
+	}
 
-	var buf bytes.Buffer
-	if err := png.Encode(&buf, overlayedImage); err != nil {
-		return domain.ShareableMapFile{}, fmt.Errorf("can't encode image to png: %v", err)
+	return ""
+}
+
+func minFloat64(a float64, b float64) float64 {
+	if a < b {
+		return a
 	}
 
-	return domain.NewSharableMapFile(buf.Bytes()), nil
+	return b
 }
@@ -0,0 +1,231 @@
+package annotation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+const (
+	chartWidth        = 1200
+	chartHeight       = 360
+	chartMarginLeft   = 140
+	chartMarginRight  = 40
+	chartMarginTop    = 20
+	chartMarginBottom = 60
+	chartFontSize     = 24
+	chartValueTicks   = 3
+)
+
+var (
+	chartColors = map[domain.ChartKind]color.RGBA{
+		domain.ChartKindElevation: {R: 0x3f, G: 0x8f, B: 0x5f, A: 0xff},
+		domain.ChartKindPace:      {R: 0x2b, G: 0x83, B: 0xba, A: 0xff},
+	}
+	// chartMinSpans is the smallest range of values drawn, so flat charts don't look hilly
+	chartMinSpans = map[domain.ChartKind]float64{
+		domain.ChartKindElevation: 20,
+		domain.ChartKindPace:      30,
+	}
+	chartGridColor = color.RGBA{R: 0xdd, G: 0xdd, B: 0xdd, A: 0xff}
+	chartTextColor = color.RGBA{R: 0x55, G: 0x55, B: 0x55, A: 0xff}
+)
+
+// DrawChart renders the chart as a PNG and an SVG image sharing the same layout
+func (a Annotation) DrawChart(ctx context.Context, chart domain.Chart) (domain.ChartFile, error) {
+	layout := newChartLayout(chart)
+
+	pngContent, err := layout.png()
+	if err != nil {
+		return domain.ChartFile{}, fmt.Errorf("can't draw %s chart: %v", chart.Kind, err)
+	}
+
+	return domain.NewChartFile(pngContent, layout.svg()), nil
+}
+
+type chartTick struct {
+	Position float64
+	Label    string
+}
+
+type chartLayout struct {
+	chart domain.Chart
+	low   float64
+	high  float64
+}
+
+func newChartLayout(chart domain.Chart) chartLayout {
+	low, high := chart.Range()
+	if span := chartMinSpans[chart.Kind]; high-low < span {
+		center := (low + high) / 2
+		low, high = center-span/2, center+span/2
+	}
+
+	// leave room at the bottom so the lowest values don't look like missing data
+	if chart.Kind == domain.ChartKindPace {
+		high += (high - low) / 10
+	} else {
+		low -= (high - low) / 10
+	}
+
+	return chartLayout{chart: chart, low: low, high: high}
+}
+
+func (l chartLayout) x(distance float64) float64 {
+	total := l.chart.Distance()
+	if total == 0 {
+		return chartMarginLeft
+	}
+
+	return chartMarginLeft + distance/total*(chartWidth-chartMarginLeft-chartMarginRight)
+}
+
+// y returns the vertical position of the value, faster paces being drawn higher
+func (l chartLayout) y(value float64) float64 {
+	ratio := math.Max(0, math.Min(1, (value-l.low)/(l.high-l.low)))
+	if l.chart.Kind == domain.ChartKindPace {
+		ratio = 1 - ratio
+	}
+
+	return chartMarginTop + (1-ratio)*(chartHeight-chartMarginTop-chartMarginBottom)
+}
+
+// area returns the polygon between the line of the chart and its bottom
+func (l chartLayout) area() [][2]float64 {
+	bottom := float64(chartHeight - chartMarginBottom)
+	polygon := [][2]float64{{l.x(0), bottom}}
+	for _, point := range l.chart.Points {
+		polygon = append(polygon, [2]float64{l.x(point.Distance), l.y(point.Value)})
+	}
+
+	return append(polygon, [2]float64{l.x(l.chart.Distance()), bottom})
+}
+
+func (l chartLayout) valueTicks() []chartTick {
+	ticks := make([]chartTick, chartValueTicks)
+	for i := range ticks {
+		value := l.low + (l.high-l.low)*float64(i)/(chartValueTicks-1)
+		ticks[i] = chartTick{Position: l.y(value), Label: formatChartValue(l.chart.Kind, value)}
+	}
+
+	return ticks
+}
+
+func (l chartLayout) distanceTicks() []chartTick {
+	var ticks []chartTick
+	for _, distance := range l.chart.DistanceTicks() {
+		ticks = append(ticks, chartTick{Position: l.x(distance), Label: fmt.Sprintf("%gkm", distance/1000)})
+	}
+
+	return ticks
+}
+
+// formatChartValue returns the label of the value, impossible paces having none
+func formatChartValue(kind domain.ChartKind, value float64) string {
+	if kind == domain.ChartKindPace {
+		pace, err := domain.NewPacePerKilometer(time.Duration(value * float64(time.Second)))
+		if err != nil {
+			return ""
+		}
+
+		return pace.String()
+	}
+
+	return fmt.Sprintf("%.0fm", value)
+}
+
+func (l chartLayout) png() ([]byte, error) {
+	face, err := newFace(chartFontSize)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	drawer := font.Drawer{Dst: img, Src: image.NewUniform(chartTextColor), Face: face}
+	for _, tick := range l.valueTicks() {
+		line := image.Rect(chartMarginLeft, int(tick.Position)-1, chartWidth-chartMarginRight, int(tick.Position)+1)
+		draw.Draw(img, line, image.NewUniform(chartGridColor), image.Point{}, draw.Src)
+
+		drawer.Dot = fixed.P(chartMarginLeft-15-drawer.MeasureString(tick.Label).Round(), int(tick.Position)+chartFontSize/3)
+		drawer.DrawString(tick.Label)
+	}
+
+	for _, tick := range l.distanceTicks() {
+		drawer.Dot = fixed.P(int(tick.Position)-drawer.MeasureString(tick.Label).Round()/2, chartHeight-20)
+		drawer.DrawString(tick.Label)
+	}
+
+	if !l.chart.IsEmpty() {
+		fillArea(img, l.area(), chartColors[l.chart.Kind])
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("can't encode image to png: %v", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func fillArea(dst draw.Image, polygon [][2]float64, c color.Color) {
+	r := vector.NewRasterizer(dst.Bounds().Dx(), dst.Bounds().Dy())
+	r.MoveTo(float32(polygon[0][0]), float32(polygon[0][1]))
+	for _, p := range polygon[1:] {
+		r.LineTo(float32(p[0]), float32(p[1]))
+	}
+	r.ClosePath()
+	r.Draw(dst, dst.Bounds(), image.NewUniform(c), image.Point{})
+}
+
+func (l chartLayout) svg() []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" `+
+		`font-family="Montserrat, sans-serif" font-size="%d" fill="%s">`, chartWidth, chartHeight, chartWidth, chartHeight,
+		chartFontSize, hexColor(chartTextColor))
+	fmt.Fprint(&buf, `<rect width="100%" height="100%" fill="#fff"/>`)
+
+	for _, tick := range l.valueTicks() {
+		fmt.Fprintf(&buf, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="%s" stroke-width="2"/>`,
+			chartMarginLeft, tick.Position, chartWidth-chartMarginRight, tick.Position, hexColor(chartGridColor))
+		fmt.Fprintf(&buf, `<text x="%d" y="%.1f" text-anchor="end">%s</text>`,
+			chartMarginLeft-15, tick.Position+chartFontSize/3, tick.Label)
+	}
+
+	for _, tick := range l.distanceTicks() {
+		fmt.Fprintf(&buf, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`, tick.Position, chartHeight-20, tick.Label)
+	}
+
+	if !l.chart.IsEmpty() {
+		fmt.Fprint(&buf, `<path d="`)
+		for i, p := range l.area() {
+			command := "L"
+			if i == 0 {
+				command = "M"
+			}
+			fmt.Fprintf(&buf, "%s%.1f %.1f ", command, p[0], p[1])
+		}
+		fmt.Fprintf(&buf, `Z" fill="%s"/>`, hexColor(chartColors[l.chart.Kind]))
+	}
+
+	fmt.Fprint(&buf, `</svg>`)
+
+	return buf.Bytes()
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
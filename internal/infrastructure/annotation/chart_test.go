@@ -0,0 +1,70 @@
+package annotation_test
+
+import (
+	"context"
+	"encoding/xml"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/annotation"
+)
+
+func TestDrawChartPNG(t *testing.T) {
+	chart := domain.Chart{Kind: domain.ChartKindElevation, Points: []domain.ChartPoint{
+		{Distance: 0, Value: 100},
+		{Distance: 2500, Value: 150},
+		{Distance: 5000, Value: 120},
+	}}
+
+	chartFile, err := annotation.Annotation{}.DrawChart(context.Background(), chart)
+	testutils.RequireNoError(t, err, "can't draw chart")
+
+	img, err := png.Decode(chartFile.PNG())
+	testutils.RequireNoError(t, err, "can't decode png")
+	testutils.AssertEqualInt(t, 1200, img.Bounds().Dx(), "unexpected width")
+	testutils.AssertEqualInt(t, 360, img.Bounds().Dy(), "unexpected height")
+
+	r, g, b, _ := img.At(img.Bounds().Dx()/2, img.Bounds().Dy()-70).RGBA()
+	testutils.AssertEqualInt(t, 0x3f, int(r>>8), "unexpected red under the elevation line")
+	testutils.AssertEqualInt(t, 0x8f, int(g>>8), "unexpected green under the elevation line")
+	testutils.AssertEqualInt(t, 0x5f, int(b>>8), "unexpected blue under the elevation line")
+}
+
+func TestDrawChartSVG(t *testing.T) {
+	chart := domain.Chart{Kind: domain.ChartKindPace, Points: []domain.ChartPoint{
+		{Distance: 0, Value: 300},
+		{Distance: 1000, Value: 240},
+	}}
+
+	chartFile, err := annotation.Annotation{}.DrawChart(context.Background(), chart)
+	testutils.RequireNoError(t, err, "can't draw chart")
+
+	content, err := io.ReadAll(chartFile.SVG())
+	testutils.RequireNoError(t, err, "can't read svg")
+
+	decoder := xml.NewDecoder(strings.NewReader(string(content)))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		testutils.RequireNoError(t, err, "invalid svg")
+	}
+
+	testutils.AssertContainsString(t, "<path d=\"M", string(content), "missing pace area")
+	testutils.AssertContainsString(t, "4:00/km", string(content), "missing fastest pace label")
+	testutils.AssertContainsString(t, "1km", string(content), "missing distance label")
+}
+
+func TestDrawChartEmpty(t *testing.T) {
+	chartFile, err := annotation.Annotation{}.DrawChart(context.Background(), domain.Chart{Kind: domain.ChartKindPace})
+	testutils.RequireNoError(t, err, "can't draw chart")
+
+	content, err := io.ReadAll(chartFile.SVG())
+	testutils.RequireNoError(t, err, "can't read svg")
+	testutils.AssertEqualBool(t, false, strings.Contains(string(content), "<path"), "empty chart shouldn't have an area")
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+// ActivitiesAsset serves a file of the activity, such as map.png, when the token owner is allowed to see it
+func ActivitiesAsset(app application.Application) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		vars := ctx.Vars(r)
+
+		slug, err := domain.NewRunnningActivitySlugFromString(vars["slug"])
+		if err != nil {
+			return notFoundResponse(w, fmt.Sprintf("can't parse activity slug (slug=%s): %v", vars["slug"], err))
+		}
+
+		content, err := app.GetRunningSessionAsset(ctx.StdCtx(), CurrentUser(r), slug, vars["name"])
+		if err != nil {
+			return failureResponse(w, err, "can't get file of activity (slug=%s, name=%s)", vars["slug"], vars["name"])
+		}
+		defer content.Close()
+
+		body, err := ioutil.ReadAll(content)
+		if err != nil {
+			return failureResponse(w, err, "can't read file of activity (slug=%s, name=%s)", vars["slug"], vars["name"])
+		}
+
+		return www.AssetResponse(w, vars["name"], body, "file sent")
+	}
+}
@@ -0,0 +1,72 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+)
+
+func TestActivitiesAssetNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities/{slug}/assets/{name}", nil)
+
+	ctx.EXPECT().StdCtx()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900", "name": "secrets.txt"})
+	app.EXPECT().
+		GetRunningSessionAsset(gomock.Any(), "", domaintest.MatchRunningActivitySlug("202204170900"), "secrets.txt").
+		Return(nil, domain.ErrRunningActivityAssetNotFound)
+
+	response := api.ActivitiesAsset(app)(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusNotFound, api.ErrorCodeNotFound, response)
+}
+
+func TestActivitiesAssetUnexpectedError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities/{slug}/assets/{name}", nil)
+
+	ctx.EXPECT().StdCtx()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900", "name": "map.png"})
+	app.EXPECT().GetRunningSessionAsset(gomock.Any(), "", gomock.Any(), "map.png").Return(nil, errors.New("boom"))
+
+	response := api.ActivitiesAsset(app)(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusInternalServerError, api.ErrorCodeInternal, response)
+}
+
+func TestActivitiesAssetSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities/{slug}/assets/{name}", nil)
+
+	ctx.EXPECT().StdCtx()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900", "name": "run.gpx"})
+	app.EXPECT().
+		GetRunningSessionAsset(gomock.Any(), "", domaintest.MatchRunningActivitySlug("202204170900"), "run.gpx").
+		Return(ioutil.NopCloser(strings.NewReader("<gpx></gpx>")), nil)
+
+	response := api.ActivitiesAsset(app)(ctx, w, r)
+
+	testutils.AssertEqualInt(t, http.StatusOK, response.HTTPCode, "unexpected http code")
+	testutils.AssertEqualString(t, "<gpx></gpx>", response.Data.(string), "unexpected content")
+	testutils.AssertEqualString(t, "application/gpx+xml", w.Header().Get("Content-Type"), "unexpected content type")
+}
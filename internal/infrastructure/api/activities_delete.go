@@ -0,0 +1,34 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+)
+
+// ActivitiesDelete deletes the activity and its assets in the background, when the token owner may manage it
+func ActivitiesDelete(app application.Application, enqueuer job.Enqueuer) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		vars := ctx.Vars(r)
+
+		slug, err := domain.NewRunnningActivitySlugFromString(vars["slug"])
+		if err != nil {
+			return notFoundResponse(w, fmt.Sprintf("can't parse activity slug (slug=%s): %v", vars["slug"], err))
+		}
+
+		if _, err := app.GetManageableRunningSession(ctx.StdCtx(), CurrentUser(r), slug); err != nil {
+			return failureResponse(w, err, "can't find activity (slug=%s)", vars["slug"])
+		}
+
+		input := job.DeleteRunningSessionJobInput{Slug: slug.String()}
+		if err := job.EnqueueDeleteRunningSessionJob(enqueuer, input); err != nil {
+			return failureResponse(w, err, "can't enqueue running session deletion job")
+		}
+
+		return jsonResponse(w, http.StatusAccepted, Job{Slug: slug.String(), Status: "deleting"})
+	}
+}
@@ -0,0 +1,95 @@
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+)
+
+func TestActivitiesDeleteNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("DELETE", "/api/v1/activities/{slug}", nil)
+
+	ctx.EXPECT().StdCtx()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900"})
+	app.EXPECT().GetManageableRunningSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.RunningActivity{}, domain.ErrCantGetRunningSession)
+
+	response := api.ActivitiesDelete(app, nil)(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusNotFound, api.ErrorCodeNotFound, response)
+}
+
+func TestActivitiesDeleteOfAnotherAthlete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("DELETE", "/api/v1/activities/{slug}", nil)
+
+	ctx.EXPECT().Vars(gomock.Any()).Return(map[string]string{"slug": "202204170900"})
+	app.EXPECT().
+		GetManageableRunningSession(gomock.Any(), "bob", domaintest.MatchRunningActivitySlug("202204170900")).
+		Return(domain.RunningActivity{}, fmt.Errorf("wrapped: %w", domain.ErrRunningActivityForbidden))
+
+	response := authenticated(ctx, "bob", api.ActivitiesDelete(app, nil))(w, r)
+
+	assertErrorResponse(t, http.StatusForbidden, api.ErrorCodeForbidden, response)
+}
+
+func TestActivitiesDeleteCannotEnqueueJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("DELETE", "/api/v1/activities/{slug}", nil)
+
+	ctx.EXPECT().StdCtx()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900"})
+	app.EXPECT().GetManageableRunningSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(domaintest.NewRunningActivity(t).Build(), nil)
+	enqueuer.EXPECT().Enqueue(gomock.Any()).Return(errors.New("boom"))
+
+	response := api.ActivitiesDelete(app, enqueuer)(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusInternalServerError, api.ErrorCodeInternal, response)
+}
+
+func TestActivitiesDeleteSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("DELETE", "/api/v1/activities/{slug}", nil)
+
+	ctx.EXPECT().StdCtx()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900"})
+	app.EXPECT().
+		GetManageableRunningSession(gomock.Any(), gomock.Any(), domaintest.MatchRunningActivitySlug("202204170900")).
+		Return(domaintest.NewRunningActivity(t).Build(), nil)
+	enqueuer.EXPECT().Enqueue(jobtest.NewJobMatcher(
+		"delete-running-session-job",
+		&job.DeleteRunningSessionJobInput{},
+		func(arg interface{}) bool {
+			return arg.(*job.DeleteRunningSessionJobInput).Slug == "202204170900"
+		},
+	)).Return(nil)
+
+	response := api.ActivitiesDelete(app, enqueuer)(ctx, w, r)
+
+	assertJSONResponse(t, http.StatusAccepted, `{"slug": "202204170900", "status": "deleting"}`, response)
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl"
+)
+
+// ActivityList is a page of activities
+type ActivityList struct {
+	Activities []Activity `json:"activities"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// ActivitiesIndex lists the activities listed to the token owner, most recent first, one page at a time
+func ActivitiesIndex(app application.Application, urls asseturl.Builder) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		pagination, err := parsePagination(r)
+		if err != nil {
+			return failureResponse(w, err, "can't parse pagination")
+		}
+
+		activities, err := app.ListRunningSessions(ctx.StdCtx(), CurrentUser(r))
+		if err != nil {
+			return failureResponse(w, err, "can't list activities")
+		}
+
+		pagination.Total = len(activities)
+		start, end := pagination.bounds()
+
+		page := ActivityList{Activities: make([]Activity, 0, end-start), Pagination: pagination}
+		for _, activity := range activities[start:end] {
+			representation, err := NewActivity(activity, urls)
+			if err != nil {
+				return failureResponse(w, err, "can't represent activity")
+			}
+
+			page.Activities = append(page.Activities, representation)
+		}
+
+		return jsonResponse(w, http.StatusOK, page)
+	}
+}
@@ -0,0 +1,98 @@
+package api_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+)
+
+func TestActivitiesIndexInvalidPagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities?page=0&per_page=500", nil)
+
+	response := api.ActivitiesIndex(nil, assetURLs)(ctx, w, r)
+
+	apiErr := assertErrorResponse(t, http.StatusUnprocessableEntity, api.ErrorCodeInvalidInput, response)
+	testutils.AssertEqualInt(t, 2, len(apiErr.Details), "unexpected number of invalid inputs")
+}
+
+func TestActivitiesIndexCannotListActivities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities", nil)
+
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ListRunningSessions(gomock.Any(), "").Return(nil, errors.New("boom"))
+
+	response := api.ActivitiesIndex(app, assetURLs)(ctx, w, r)
+
+	apiErr := assertErrorResponse(t, http.StatusInternalServerError, api.ErrorCodeInternal, response)
+	testutils.AssertEqualString(t, "something wrong happened", apiErr.Message, "unexpected error message")
+}
+
+func TestActivitiesIndexSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities?page=2&per_page=2", nil)
+	activities := []domain.RunningActivity{
+		domaintest.NewRunningActivity(t).WithRawSlug("202204170900").Build(),
+		domaintest.NewRunningActivity(t).WithRawSlug("202204160900").Build(),
+		domaintest.NewRunningActivity(t).WithRawSlug("202204150900").Build(),
+	}
+
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ListRunningSessions(gomock.Any(), "").Return(activities, nil)
+
+	response := api.ActivitiesIndex(app, assetURLs)(ctx, w, r)
+
+	testutils.AssertEqualString(t, "application/json", w.Header().Get("Content-Type"), "unexpected content type")
+	assertJSONResponse(t, http.StatusOK, mustEncodeJSON(t, map[string]interface{}{
+		"activities": []api.Activity{mustNewActivity(t, activities[2])},
+		"pagination": api.Pagination{Page: 2, PerPage: 2, Total: 3},
+	}), response)
+}
+
+func TestActivitiesIndexPageAfterTheLastOne(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities?page=3", nil)
+
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ListRunningSessions(gomock.Any(), "").Return([]domain.RunningActivity{domaintest.NewRunningActivity(t).Build()}, nil)
+
+	response := api.ActivitiesIndex(app, assetURLs)(ctx, w, r)
+
+	assertJSONResponse(t, http.StatusOK, `{"activities": [], "pagination": {"page": 3, "per_page": 20, "total": 1}}`, response)
+}
+
+func TestActivitiesIndexPageTooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities?page=100000000000000000&per_page=100", nil)
+
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ListRunningSessions(gomock.Any(), "").Return([]domain.RunningActivity{domaintest.NewRunningActivity(t).Build()}, nil)
+
+	response := api.ActivitiesIndex(app, assetURLs)(ctx, w, r)
+
+	assertJSONResponse(t, http.StatusOK, `{"activities": [], "pagination": {"page": 100000000000000000, "per_page": 100, "total": 1}}`, response)
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+const (
+	// UploadDateLayout is the layout of the date field, the same as the upload form
+	UploadDateLayout = "2006-01-02T15:04"
+	// MaxUploadBodySize is the biggest request accepted by ActivitiesPost, a GPX file and the fields of the form
+	MaxUploadBodySize = www.MaxGPXFileSize + 1024*1024
+)
+
+// Job is the status of an activity processed in the background
+type Job struct {
+	Slug   string `json:"slug"`
+	Status string `json:"status"`
+}
+
+type upload struct {
+	When    time.Time
+	Details domain.RunningActivityDetails
+	GPX     *multipart.FileHeader
+}
+
+// ActivitiesPost records the activity of the GPX file in the background, with the same fields as the upload form
+func ActivitiesPost(enqueuer job.Enqueuer, uploadFolder string) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		if err := r.ParseMultipartForm(www.MaxGPXFileSize); err != nil {
+			return errorResponse(w, http.StatusBadRequest, Error{Code: ErrorCodeBadRequest, Message: "request must be a multipart form"},
+				fmt.Sprintf("can't parse form: %v", err))
+		}
+
+		upload, err := parseUpload(r)
+		if err != nil {
+			return failureResponse(w, err, "can't parse upload")
+		}
+
+		slug, err := domain.NewRunnningActivitySlugFromTime(upload.When)
+		if err != nil {
+			return failureResponse(w, err, "can't build activity slug")
+		}
+
+		filepath, err := saveUpload(upload.GPX, uploadFolder)
+		if err != nil {
+			return failureResponse(w, err, "can't save gpx file")
+		}
+
+		input := job.TrackRunningSessionJobInput{
+			When:        upload.When,
+			GPXFilepath: filepath,
+			Title:       upload.Details.Title,
+			Description: upload.Details.Description,
+			Type:        upload.Details.Type,
+			Visibility:  upload.Details.Visibility,
+			Username:    CurrentUser(r),
+		}
+		if err := job.EnqueueTrackRunningSessionJob(enqueuer, input); err != nil {
+			return failureResponse(w, err, "can't enqueue running session job")
+		}
+
+		w.Header().Set("Location", "/api/v1/activities/"+slug.String())
+		return jsonResponse(w, http.StatusAccepted, Job{Slug: slug.String(), Status: "processing"})
+	}
+}
+
+func parseUpload(r *http.Request) (upload, error) {
+	var errs domain.InvalidInputErrors
+
+	when, err := time.Parse(UploadDateLayout, r.FormValue("date"))
+	if err != nil {
+		errs.Append(fmt.Sprintf("date format is expected to follow %s", UploadDateLayout))
+	}
+
+	activityType, err := domain.ParseActivityType(r.FormValue("type"))
+	if err != nil {
+		errs.Append("activity type is not supported")
+	}
+
+	visibility, err := domain.ParseActivityVisibility(r.FormValue("visibility"))
+	if err != nil {
+		errs.Append("activity visibility is not supported")
+	}
+
+	gpxFiles := r.MultipartForm.File["gpx"]
+	if len(gpxFiles) == 0 {
+		errs.Append("gpx file must be sent")
+	} else if gpxFiles[0].Size > www.MaxGPXFileSize {
+		errs.Append("gpx file must be smaller than 5Mb")
+	}
+
+	if !errs.IsEmpty() {
+		return upload{}, &errs
+	}
+
+	details := domain.RunningActivityDetails{
+		Title:       r.FormValue("title"),
+		Description: r.FormValue("description"),
+		Type:        activityType,
+		Visibility:  visibility,
+	}
+
+	return upload{When: when, Details: details, GPX: gpxFiles[0]}, nil
+}
+
+// saveUpload copies the uploaded GPX file to a file of its own in the upload folder, so uploads of the same minute
+// don't overwrite each other before their job runs
+func saveUpload(header *multipart.FileHeader, uploadFolder string) (string, error) {
+	f, err := header.Open()
+	if err != nil {
+		return "", fmt.Errorf("can't open uploaded gpx file: %v", err)
+	}
+	defer f.Close()
+
+	dest, err := os.CreateTemp(uploadFolder, "activity-*.gpx")
+	if err != nil {
+		return "", fmt.Errorf("can't create temporary gpx file in upload folder: %v", err)
+	}
+	defer dest.Close()
+
+	if _, err := io.Copy(dest, f); err != nil {
+		return "", fmt.Errorf("can't copy uploaded file to upload folder (path=%s): %v", dest.Name(), err)
+	}
+
+	return dest.Name(), nil
+}
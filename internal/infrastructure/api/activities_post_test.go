@@ -0,0 +1,146 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+)
+
+func newUploadRequest(t *testing.T, fields map[string]string, withGPX bool) *http.Request {
+	var body bytes.Buffer
+	bodyWriter := multipart.NewWriter(&body)
+	for name, value := range fields {
+		testutils.RequireNoError(t, bodyWriter.WriteField(name, value), "can't write %s to form", name)
+	}
+
+	if withGPX {
+		gpxFile, err := bodyWriter.CreateFormFile("gpx", "run.gpx")
+		testutils.RequireNoError(t, err, "can't create form file")
+		_, err = gpxFile.Write([]byte("<gpx></gpx>"))
+		testutils.RequireNoError(t, err, "can't write form file")
+	}
+	testutils.RequireNoError(t, bodyWriter.Close(), "can't close form")
+
+	r := httptest.NewRequest("POST", "/api/v1/activities", &body)
+	r.Header.Set("Content-Type", bodyWriter.FormDataContentType())
+
+	return r
+}
+
+func TestActivitiesPostNotMultipart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/api/v1/activities", strings.NewReader(`{"date": "2022-04-17T09:00"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	response := api.ActivitiesPost(nil, "")(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusBadRequest, api.ErrorCodeBadRequest, response)
+}
+
+func TestActivitiesPostInvalidInputs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := newUploadRequest(t, map[string]string{"date": "yesterday", "type": "swim", "visibility": "friends"}, false)
+
+	response := api.ActivitiesPost(nil, "")(ctx, w, r)
+
+	apiErr := assertErrorResponse(t, http.StatusUnprocessableEntity, api.ErrorCodeInvalidInput, response)
+	testutils.AssertEqualStrings(t, []string{
+		"date format is expected to follow 2006-01-02T15:04",
+		"activity type is not supported",
+		"activity visibility is not supported",
+		"gpx file must be sent",
+	}, apiErr.Details, "unexpected invalid inputs")
+}
+
+func TestActivitiesPostCannotEnqueueJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	w := httptest.NewRecorder()
+	r := newUploadRequest(t, map[string]string{"date": "2022-04-17T09:00"}, true)
+	uploadFolder, err := os.MkdirTemp("", "api-upload")
+	testutils.RequireNoError(t, err, "can't create temp folder")
+	defer os.RemoveAll(uploadFolder)
+
+	enqueuer.EXPECT().Enqueue(gomock.Any()).Return(errors.New("boom"))
+
+	response := api.ActivitiesPost(enqueuer, uploadFolder)(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusInternalServerError, api.ErrorCodeInternal, response)
+}
+
+func TestActivitiesPostSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	w := httptest.NewRecorder()
+	r := newUploadRequest(t, map[string]string{"date": "2022-04-17T09:00", "type": "hike", "title": "Hills"}, true)
+	uploadFolder, err := os.MkdirTemp("", "api-upload")
+	testutils.RequireNoError(t, err, "can't create temp folder")
+	defer os.RemoveAll(uploadFolder)
+
+	enqueuer.EXPECT().Enqueue(jobtest.NewJobMatcher(
+		"track-running-session-job",
+		&job.TrackRunningSessionJobInput{},
+		func(arg interface{}) bool {
+			input := arg.(*job.TrackRunningSessionJobInput)
+
+			return strings.HasPrefix(input.GPXFilepath, uploadFolder) &&
+				input.When.Format("2006-01-02T15:04") == "2022-04-17T09:00" &&
+				input.Type == domain.ActivityTypeHike &&
+				input.Title == "Hills" &&
+				input.Username == "alice"
+		},
+	)).Return(nil)
+
+	response := authenticated(ctx, "alice", api.ActivitiesPost(enqueuer, uploadFolder))(w, r)
+
+	assertJSONResponse(t, http.StatusAccepted, `{"slug": "202204170900", "status": "processing"}`, response)
+	testutils.AssertEqualString(t, "/api/v1/activities/202204170900", w.Header().Get("Location"), "unexpected location")
+}
+
+func TestActivitiesPostSameMinute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	uploadFolder, err := os.MkdirTemp("", "api-upload")
+	testutils.RequireNoError(t, err, "can't create temp folder")
+	defer os.RemoveAll(uploadFolder)
+
+	var paths []string
+	enqueuer.EXPECT().Enqueue(jobtest.NewJobMatcher(
+		"track-running-session-job",
+		&job.TrackRunningSessionJobInput{},
+		func(arg interface{}) bool {
+			paths = append(paths, arg.(*job.TrackRunningSessionJobInput).GPXFilepath)
+
+			return true
+		},
+	)).Return(nil).Times(2)
+
+	for _, username := range []string{"alice", "bob"} {
+		r := newUploadRequest(t, map[string]string{"date": "2022-04-17T09:00", "type": "hike"}, true)
+		response := authenticated(ctx, username, api.ActivitiesPost(enqueuer, uploadFolder))(httptest.NewRecorder(), r)
+		testutils.AssertEqualInt(t, http.StatusAccepted, response.HTTPCode, "unexpected status code")
+	}
+
+	testutils.RequireEqualInt(t, 2, len(paths), "unexpected number of enqueued jobs")
+	testutils.AssertEqualBool(t, true, paths[0] != paths[1], "uploads of the same minute must use different files (path=%s)", paths[0])
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+)
+
+// ActivitiesRegenerate generates the map, cards and charts of the activity again in the background, when the token owner
+// may manage it. Cards follow the preferences of the token owner.
+func ActivitiesRegenerate(app application.Application, enqueuer job.Enqueuer) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		vars := ctx.Vars(r)
+
+		slug, err := domain.NewRunnningActivitySlugFromString(vars["slug"])
+		if err != nil {
+			return notFoundResponse(w, fmt.Sprintf("can't parse activity slug (slug=%s): %v", vars["slug"], err))
+		}
+
+		if _, err := app.GetManageableRunningSession(ctx.StdCtx(), CurrentUser(r), slug); err != nil {
+			return failureResponse(w, err, "can't find activity (slug=%s)", vars["slug"])
+		}
+
+		input := job.RegenerateRunningSessionJobInput{Slug: slug.String()}
+		if err := job.EnqueueRegenerateRunningSessionJob(enqueuer, input); err != nil {
+			return failureResponse(w, err, "can't enqueue running session regeneration job")
+		}
+
+		w.Header().Set("Location", "/api/v1/activities/"+slug.String())
+		return jsonResponse(w, http.StatusAccepted, Job{Slug: slug.String(), Status: "regenerating"})
+	}
+}
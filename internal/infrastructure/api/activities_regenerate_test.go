@@ -0,0 +1,91 @@
+package api_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+)
+
+func TestActivitiesRegenerateInvalidSlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/api/v1/activities/{slug}/regenerate", nil)
+
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "invalid slug"})
+
+	response := api.ActivitiesRegenerate(nil, nil)(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusNotFound, api.ErrorCodeNotFound, response)
+}
+
+func TestActivitiesRegenerateNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/api/v1/activities/{slug}/regenerate", nil)
+
+	ctx.EXPECT().StdCtx()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900"})
+	app.EXPECT().GetManageableRunningSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.RunningActivity{}, domain.ErrCantGetRunningSession)
+
+	response := api.ActivitiesRegenerate(app, nil)(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusNotFound, api.ErrorCodeNotFound, response)
+}
+
+func TestActivitiesRegenerateCannotEnqueueJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/api/v1/activities/{slug}/regenerate", nil)
+
+	ctx.EXPECT().StdCtx()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900"})
+	app.EXPECT().GetManageableRunningSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(domaintest.NewRunningActivity(t).Build(), nil)
+	enqueuer.EXPECT().Enqueue(gomock.Any()).Return(errors.New("boom"))
+
+	response := api.ActivitiesRegenerate(app, enqueuer)(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusInternalServerError, api.ErrorCodeInternal, response)
+}
+
+func TestActivitiesRegenerateSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/api/v1/activities/{slug}/regenerate", nil)
+
+	ctx.EXPECT().Vars(gomock.Any()).Return(map[string]string{"slug": "202204170900"})
+	app.EXPECT().
+		GetManageableRunningSession(gomock.Any(), "alice", domaintest.MatchRunningActivitySlug("202204170900")).
+		Return(domaintest.NewRunningActivity(t).Build(), nil)
+	enqueuer.EXPECT().Enqueue(jobtest.NewJobMatcher(
+		"regenerate-running-session-job",
+		&job.RegenerateRunningSessionJobInput{},
+		func(arg interface{}) bool {
+			input := arg.(*job.RegenerateRunningSessionJobInput)
+
+			return input.Slug == "202204170900"
+		},
+	)).Return(nil)
+
+	response := authenticated(ctx, "alice", api.ActivitiesRegenerate(app, enqueuer))(w, r)
+
+	assertJSONResponse(t, http.StatusAccepted, `{"slug": "202204170900", "status": "regenerating"}`, response)
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl"
+)
+
+func ActivitiesShow(app application.Application, urls asseturl.Builder) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		vars := ctx.Vars(r)
+
+		slug, err := domain.NewRunnningActivitySlugFromString(vars["slug"])
+		if err != nil {
+			return notFoundResponse(w, fmt.Sprintf("can't parse activity slug (slug=%s): %v", vars["slug"], err))
+		}
+
+		activity, err := app.GetRunningSession(ctx.StdCtx(), CurrentUser(r), slug)
+		if err != nil {
+			return failureResponse(w, err, "can't find activity (slug=%s)", vars["slug"])
+		}
+
+		representation, err := NewActivity(activity, urls)
+		if err != nil {
+			return failureResponse(w, err, "can't represent activity (slug=%s)", vars["slug"])
+		}
+
+		return jsonResponse(w, http.StatusOK, representation)
+	}
+}
@@ -0,0 +1,81 @@
+package api_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+)
+
+func TestActivitiesShowInvalidSlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities/{slug}", nil)
+
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "invalid slug"})
+
+	response := api.ActivitiesShow(nil, assetURLs)(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusNotFound, api.ErrorCodeNotFound, response)
+}
+
+func TestActivitiesShowNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities/{slug}", nil)
+
+	ctx.EXPECT().StdCtx()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900"})
+	app.EXPECT().
+		GetRunningSession(gomock.Any(), "", domaintest.MatchRunningActivitySlug("202204170900")).
+		Return(domain.RunningActivity{}, domain.ErrCantGetRunningSession)
+
+	response := api.ActivitiesShow(app, assetURLs)(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusNotFound, api.ErrorCodeNotFound, response)
+}
+
+func TestActivitiesShowUnexpectedError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities/{slug}", nil)
+
+	ctx.EXPECT().StdCtx()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900"})
+	app.EXPECT().GetRunningSession(gomock.Any(), "", gomock.Any()).Return(domain.RunningActivity{}, errors.New("boom"))
+
+	response := api.ActivitiesShow(app, assetURLs)(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusInternalServerError, api.ErrorCodeInternal, response)
+}
+
+func TestActivitiesShowSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities/{slug}", nil)
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202204170900").Build()
+
+	ctx.EXPECT().StdCtx()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900"})
+	app.EXPECT().
+		GetRunningSession(gomock.Any(), "", domaintest.MatchRunningActivitySlug("202204170900")).
+		Return(activity, nil)
+
+	response := api.ActivitiesShow(app, assetURLs)(ctx, w, r)
+
+	assertJSONResponse(t, http.StatusOK, mustEncodeJSON(t, mustNewActivity(t, activity)), response)
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl"
+)
+
+// Activity is the JSON representation of a domain.RunningActivity
+type Activity struct {
+	Slug        string    `json:"slug"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Type        string    `json:"type"`
+	RanAt       time.Time `json:"ran_at"`
+	// Athlete is the username of the uploader, empty for activities recorded before they had an owner
+	Athlete string `json:"athlete"`
+	// Visibility is public, unlisted or private. Files of private activities are linked through
+	// presigned URLs instead of the CDN.
+	Visibility string `json:"visibility"`
+	// DurationSeconds, DistanceMeters and SpeedKmh are the raw values, PaceSecondsPerKm is null when the pace is unknown
+	DurationSeconds  int     `json:"duration_seconds"`
+	DistanceMeters   int     `json:"distance_meters"`
+	SpeedKmh         float64 `json:"speed_kmh"`
+	PaceSecondsPerKm *int    `json:"pace_seconds_per_km"`
+	MapStatus        string  `json:"map_status"`
+	MapError         string  `json:"map_error,omitempty"`
+	Assets           Assets  `json:"assets"`
+}
+
+// Assets holds the URLs of the files generated for an activity
+type Assets struct {
+	GPX   string `json:"gpx"`
+	Map   string `json:"map"`
+	Cards []Card `json:"cards"`
+	// ElevationChart and PaceChart are missing for activities recorded before charts were generated
+	ElevationChart *Chart `json:"elevation_chart,omitempty"`
+	PaceChart      *Chart `json:"pace_chart,omitempty"`
+}
+
+// Card is a shareable card, with its dimensions in pixels
+type Card struct {
+	Template string `json:"template,omitempty"`
+	URL      string `json:"url"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+}
+
+// Chart holds the URLs of the images of a chart
+type Chart struct {
+	PNG string `json:"png"`
+	SVG string `json:"svg"`
+}
+
+// NewActivity returns the representation of the activity, whose assets are served from the CDN, presigned URLs or
+// ActivitiesAsset depending on who can see them
+func NewActivity(activity domain.RunningActivity, urls asseturl.Builder) (Activity, error) {
+	assets, err := newAssets(activity, urls)
+	if err != nil {
+		return Activity{}, fmt.Errorf("can't build asset urls of activity %s: %v", activity.Slug, err)
+	}
+
+	representation := Activity{
+		Slug:            activity.Slug.String(),
+		Title:           activity.Title,
+		Description:     activity.Description,
+		Type:            activity.Type.String(),
+		RanAt:           activity.RanAt,
+		Athlete:         activity.Username,
+		Visibility:      activity.Visibility.String(),
+		DurationSeconds: int(activity.Duration.Round(time.Second).Seconds()),
+		DistanceMeters:  activity.Distance.Meters(),
+		SpeedKmh:        activity.Speed.KilometersPerHour(),
+		MapStatus:       activity.MapStatus.String(),
+		MapError:        activity.MapError,
+		Assets:          assets,
+	}
+
+	if pace := activity.Speed.Pace(); !pace.IsZero() {
+		seconds := int(pace.PerKilometer().Seconds())
+		representation.PaceSecondsPerKm = &seconds
+	}
+
+	return representation, nil
+}
+
+func newAssets(activity domain.RunningActivity, urls asseturl.Builder) (Assets, error) {
+	var urlErr error
+	assetURL := func(assetPath string) string {
+		url, err := AssetURL(urls, activity, assetPath)
+		if err != nil {
+			urlErr = err
+		}
+
+		return url
+	}
+
+	assets := Assets{
+		GPX: assetURL(activity.GPXPath.String()),
+		Map: assetURL(activity.MapPath.String()),
+	}
+
+	for _, card := range activity.ShareableCards() {
+		assets.Cards = append(assets.Cards, Card{
+			Template: card.Template,
+			URL:      assetURL(card.Path.String()),
+			Width:    card.Width,
+			Height:   card.Height,
+		})
+	}
+
+	if activity.HasCharts() {
+		assets.ElevationChart = &Chart{
+			PNG: assetURL(activity.ElevationChartPath.PNG()),
+			SVG: assetURL(activity.ElevationChartPath.SVG()),
+		}
+		assets.PaceChart = &Chart{
+			PNG: assetURL(activity.PaceChartPath.PNG()),
+			SVG: assetURL(activity.PaceChartPath.SVG()),
+		}
+	}
+
+	return assets, urlErr
+}
+
+// AssetURL returns the URL of a file of the activity. GPX files are served by ActivitiesAsset, relatively to the API
+// host, so privacy zones are hidden. Files of private activities are linked through presigned URLs, the other ones
+// through the CDN.
+func AssetURL(urls asseturl.Builder, activity domain.RunningActivity, assetPath string) (string, error) {
+	if assetPath == activity.GPXPath.String() {
+		return "/api/v1/activities/" + activity.Slug.String() + "/assets/" + path.Base(assetPath), nil
+	}
+
+	if activity.HasPublicAsset(assetPath) {
+		return urls.PublicURL(assetPath)
+	}
+
+	return urls.PresignedURL(assetPath)
+}
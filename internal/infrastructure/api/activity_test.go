@@ -0,0 +1,73 @@
+package api_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl/asseturltest"
+)
+
+func TestNewActivity(t *testing.T) {
+	activity := domaintest.NewRunningActivity(t).
+		WithRawSlug("202204170900").
+		WithDetails("Morning run", "Along the river").
+		WithType(domain.ActivityTypeTrailRun).
+		WithSpeedKmh(10).
+		WithDistanceMeters(10000).
+		WithDuration(time.Hour + time.Second).
+		WithUsername("alice").
+		Build()
+	activity = activity.WithCards(domain.DefaultCardTemplates().Cards("runs/2022-04-17.09h00"))
+
+	representation := mustNewActivity(t, activity)
+
+	testutils.AssertEqualString(t, "202204170900", representation.Slug, "unexpected slug")
+	testutils.AssertEqualString(t, "Morning run", representation.Title, "unexpected title")
+	testutils.AssertEqualString(t, "trail-run", representation.Type, "unexpected type")
+	testutils.AssertEqualString(t, "alice", representation.Athlete, "unexpected athlete")
+	testutils.AssertEqualInt(t, 3601, representation.DurationSeconds, "unexpected duration")
+	testutils.AssertEqualInt(t, 10000, representation.DistanceMeters, "unexpected distance")
+	testutils.AssertEqualInt(t, 360, *representation.PaceSecondsPerKm, "unexpected pace")
+	testutils.AssertEqualString(t, "/api/v1/activities/202204170900/assets/run.gpx", representation.Assets.GPX, "unexpected gpx url")
+	testutils.AssertEqualInt(t, 3, len(representation.Assets.Cards), "unexpected number of cards")
+	testutils.AssertEqualString(t, "https://cdn.example.com/runs/2022-04-17.09h00/card-square.png", representation.Assets.Cards[1].URL, "unexpected card url")
+	testutils.AssertEqualString(t, "https://cdn.example.com/"+activity.PaceChartPath.SVG(), representation.Assets.PaceChart.SVG, "unexpected chart url")
+}
+
+func TestNewActivityUnknownPace(t *testing.T) {
+	activity := domaintest.NewRunningActivity(t).Build()
+	activity.Speed = domain.Speed{}
+
+	representation := mustNewActivity(t, activity)
+
+	testutils.AssertEqualBool(t, true, representation.PaceSecondsPerKm == nil, "pace should be unknown")
+}
+
+func TestNewActivityPrivate(t *testing.T) {
+	activity := domaintest.NewRunningActivity(t).
+		WithRawSlug("202204170900").
+		WithVisibility(domain.ActivityVisibilityPrivate).
+		Build()
+
+	representation := mustNewActivity(t, activity)
+
+	testutils.AssertEqualString(t, "private", representation.Visibility, "unexpected visibility")
+	testutils.AssertEqualString(t, "/api/v1/activities/202204170900/assets/run.gpx", representation.Assets.GPX, "unexpected gpx url")
+	testutils.AssertEqualString(t, "https://bucket.example.com/"+activity.MapPath.String()+"?expires=3600", representation.Assets.Map, "unexpected map url")
+	testutils.AssertEqualString(t, "https://bucket.example.com/"+activity.PaceChartPath.SVG()+"?expires=3600", representation.Assets.PaceChart.SVG, "unexpected chart url")
+}
+
+func TestNewActivityPresignError(t *testing.T) {
+	activity := domaintest.NewRunningActivity(t).WithVisibility(domain.ActivityVisibilityPrivate).Build()
+	urls := asseturl.NewBuilder("https://cdn.example.com", asseturltest.Presigner{Err: errors.New("boom")}, time.Hour)
+
+	_, err := api.NewActivity(activity, urls)
+
+	testutils.AssertErrorContains(t, "boom", err, "expected presigner error")
+}
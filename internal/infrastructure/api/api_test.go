@@ -0,0 +1,91 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl/asseturltest"
+)
+
+// assetURLs links the public files through the CDN and signs fake URLs for the other ones
+var assetURLs = asseturl.NewBuilder("https://cdn.example.com", asseturltest.Presigner{}, time.Hour)
+
+func mustNewActivity(t *testing.T, activity domain.RunningActivity) api.Activity {
+	t.Helper()
+
+	representation, err := api.NewActivity(activity, assetURLs)
+	testutils.RequireNoError(t, err, "can't represent activity")
+
+	return representation
+}
+
+func assertJSONResponse(t *testing.T, wantCode int, wantBody string, got web.Response) {
+	t.Helper()
+
+	testutils.AssertEqualString(t, api.ResponseTemplate, got.Template, "unexpected response template")
+	testutils.AssertEqualInt(t, wantCode, got.HTTPCode, "unexpected response http code")
+
+	var want, actual interface{}
+	testutils.RequireNoError(t, json.Unmarshal([]byte(wantBody), &want), "can't decode expected body")
+	testutils.RequireNoError(t, json.Unmarshal([]byte(got.Data.(string)), &actual), "can't decode response body")
+
+	if !reflect.DeepEqual(want, actual) {
+		t.Errorf("unexpected response body\nwant:\n%s\ngot:\n%s", wantBody, got.Data)
+	}
+}
+
+// staticTokens authenticates the personal access tokens indexed by their secret
+type staticTokens map[string]domain.APIToken
+
+func (s staticTokens) AuthenticateAPIToken(ctx context.Context, secret string) (domain.APIToken, error) {
+	token, ok := s[secret]
+	if !ok {
+		return domain.APIToken{}, domain.ErrAPITokenNotFound
+	}
+
+	return token, nil
+}
+
+// authenticated calls the handler as if the user sent a valid bearer token allowed to read and write
+func authenticated(ctx *webtest.MockContext, username string, h web.HandlerFunc) func(http.ResponseWriter, *http.Request) web.Response {
+	return func(w http.ResponseWriter, r *http.Request) web.Response {
+		ctx.EXPECT().StdCtx().Return(context.Background()).AnyTimes()
+		r.Header.Set("Authorization", "Bearer secret")
+
+		tokens := staticTokens{"secret": {Username: username, Scope: domain.APITokenScopeWrite}}
+		return api.Authenticate(tokens, domain.APITokenScopeWrite, h)(ctx, w, r)
+	}
+}
+
+func mustEncodeJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+
+	content, err := json.Marshal(v)
+	testutils.RequireNoError(t, err, "can't encode expected body")
+
+	return string(content)
+}
+
+func assertErrorResponse(t *testing.T, wantCode int, wantErrorCode api.ErrorCode, got web.Response) api.Error {
+	t.Helper()
+
+	testutils.AssertEqualInt(t, wantCode, got.HTTPCode, "unexpected response http code")
+
+	var body struct {
+		Error api.Error `json:"error"`
+	}
+	testutils.RequireNoError(t, json.Unmarshal([]byte(got.Data.(string)), &body), "can't decode response body")
+	testutils.AssertEqualString(t, string(wantErrorCode), string(body.Error.Code), "unexpected error code")
+
+	return body.Error
+}
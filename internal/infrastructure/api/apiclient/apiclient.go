@@ -0,0 +1,144 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+)
+
+// Client calls the API on behalf of the owner of a personal access token
+type Client struct {
+	HTTPClient *http.Client
+	// EndpointURL is the URL the application is served from, without the /api/v1 prefix
+	EndpointURL string
+	// RequestTimeout bounds each request sent to the API
+	RequestTimeout time.Duration
+	token          string
+}
+
+func New(endpointURL string, token string) *Client {
+	return &Client{
+		HTTPClient:     http.DefaultClient,
+		EndpointURL:    strings.TrimSuffix(endpointURL, "/"),
+		RequestTimeout: time.Minute,
+		token:          token,
+	}
+}
+
+// Upload is an activity to record from a GPX file
+type Upload struct {
+	// RanAt is sent to the minute and without its time zone, as the upload form does
+	RanAt       time.Time
+	Type        domain.ActivityType
+	Title       string
+	Description string
+	// Visibility defaults to public when empty
+	Visibility domain.ActivityVisibility
+	GPX        io.Reader
+}
+
+// ListActivities returns a page of activities, most recent first. Pages start at 1.
+func (c *Client) ListActivities(ctx context.Context, page int, perPage int) (api.ActivityList, error) {
+	query := url.Values{"page": {strconv.Itoa(page)}, "per_page": {strconv.Itoa(perPage)}}
+
+	var activities api.ActivityList
+	err := c.do(ctx, http.MethodGet, "/api/v1/activities?"+query.Encode(), "", nil, &activities)
+
+	return activities, err
+}
+
+// GetActivity returns the activity identified by the slug
+func (c *Client) GetActivity(ctx context.Context, slug string) (api.Activity, error) {
+	var activity api.Activity
+	err := c.do(ctx, http.MethodGet, "/api/v1/activities/"+url.PathEscape(slug), "", nil, &activity)
+
+	return activity, err
+}
+
+// UploadActivity sends the GPX file. The activity is recorded in the background, it can be fetched with the slug of
+// the returned job once done.
+func (c *Client) UploadActivity(ctx context.Context, upload Upload) (api.Job, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+
+	fields := map[string]string{
+		"date":        upload.RanAt.Format(api.UploadDateLayout),
+		"type":        upload.Type.String(),
+		"title":       upload.Title,
+		"description": upload.Description,
+		"visibility":  upload.Visibility.String(),
+	}
+	for name, value := range fields {
+		if err := form.WriteField(name, value); err != nil {
+			return api.Job{}, fmt.Errorf("can't write %s field: %v", name, err)
+		}
+	}
+
+	file, err := form.CreateFormFile("gpx", "activity.gpx")
+	if err != nil {
+		return api.Job{}, fmt.Errorf("can't create gpx field: %v", err)
+	}
+
+	if _, err := io.Copy(file, upload.GPX); err != nil {
+		return api.Job{}, fmt.Errorf("can't copy gpx file: %v", err)
+	}
+
+	if err := form.Close(); err != nil {
+		return api.Job{}, fmt.Errorf("can't close multipart form: %v", err)
+	}
+
+	var job api.Job
+	err = c.do(ctx, http.MethodPost, "/api/v1/activities", form.FormDataContentType(), &body, &job)
+
+	return job, err
+}
+
+// do sends the request and decodes the JSON response in result. Error responses are returned as *Error.
+func (c *Client) do(ctx context.Context, method string, path string, contentType string, body io.Reader, result interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, c.RequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, c.EndpointURL+path, body)
+	if err != nil {
+		return fmt.Errorf("can't build request: %v", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't send %s %s request: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("can't read response (status=%d): %v", resp.StatusCode, err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newError(resp.StatusCode, content)
+	}
+
+	if err := json.Unmarshal(content, result); err != nil {
+		return fmt.Errorf("can't decode response (status=%d): %v", resp.StatusCode, err)
+	}
+
+	return nil
+}
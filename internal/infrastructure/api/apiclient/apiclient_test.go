@@ -0,0 +1,249 @@
+package apiclient_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+	"github.com/lonepeon/sport/internal/infrastructure/api/apiclient"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl/asseturltest"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+)
+
+const (
+	readToken  = "read-secret"
+	writeToken = "write-secret"
+)
+
+func TestListActivitiesSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	activities := []domain.RunningActivity{
+		domaintest.NewRunningActivity(t).Build(),
+		domaintest.NewRunningActivity(t).Build(),
+		domaintest.NewRunningActivity(t).Build(),
+	}
+
+	app.EXPECT().ListRunningSessions(gomock.Any(), "alice").Return(activities, nil)
+
+	server := newServer(t, ctrl, app, nil)
+	defer server.Close()
+
+	list, err := apiclient.New(server.URL, readToken).ListActivities(context.Background(), 2, 2)
+	testutils.RequireNoError(t, err, "can't list activities")
+
+	testutils.AssertEqualInt(t, 3, list.Pagination.Total, "unexpected total")
+	testutils.AssertEqualInt(t, 2, list.Pagination.Page, "unexpected page")
+	testutils.RequireEqualInt(t, 1, len(list.Activities), "unexpected number of activities")
+	testutils.AssertEqualString(t, activities[2].Slug.String(), list.Activities[0].Slug, "unexpected activity")
+}
+
+func TestListActivitiesInvalidPagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	server := newServer(t, ctrl, nil, nil)
+	defer server.Close()
+
+	_, err := apiclient.New(server.URL, readToken).ListActivities(context.Background(), 0, 20)
+
+	var apiErr *apiclient.Error
+	testutils.RequireErrorAs(t, &apiErr, err, "expected an api error")
+	testutils.AssertEqualInt(t, http.StatusUnprocessableEntity, apiErr.StatusCode, "unexpected status")
+	testutils.AssertEqualString(t, string(api.ErrorCodeInvalidInput), string(apiErr.Code), "unexpected code")
+	testutils.AssertEqualStrings(t, []string{"page must be a number greater than 0"}, apiErr.Details, "unexpected details")
+}
+
+func TestGetActivitySuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	activity := domaintest.NewRunningActivity(t).Build()
+
+	app.EXPECT().GetRunningSession(gomock.Any(), "alice", activity.Slug).Return(activity, nil)
+
+	server := newServer(t, ctrl, app, nil)
+	defer server.Close()
+
+	actual, err := apiclient.New(server.URL+"/", readToken).GetActivity(context.Background(), activity.Slug.String())
+	testutils.RequireNoError(t, err, "can't get activity")
+
+	testutils.AssertEqualString(t, activity.Slug.String(), actual.Slug, "unexpected slug")
+	testutils.AssertEqualString(t, "https://cdn.example.com/"+activity.MapPath.String(), actual.Assets.Map, "unexpected map url")
+	testutils.AssertEqualString(t, "/api/v1/activities/"+activity.Slug.String()+"/assets/run.gpx", actual.Assets.GPX, "unexpected gpx url")
+}
+
+func TestGetActivityNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+
+	app.EXPECT().GetRunningSession(gomock.Any(), "alice", gomock.Any()).Return(domain.RunningActivity{}, domain.ErrCantGetRunningSession)
+
+	server := newServer(t, ctrl, app, nil)
+	defer server.Close()
+
+	_, err := apiclient.New(server.URL, readToken).GetActivity(context.Background(), "202204250900")
+
+	var apiErr *apiclient.Error
+	testutils.RequireErrorAs(t, &apiErr, err, "expected an api error")
+	testutils.AssertEqualInt(t, http.StatusNotFound, apiErr.StatusCode, "unexpected status")
+	testutils.AssertEqualString(t, string(api.ErrorCodeNotFound), string(apiErr.Code), "unexpected code")
+}
+
+func TestUploadActivitySuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ranAt := time.Date(2022, 4, 25, 9, 30, 0, 0, time.UTC)
+
+	enqueuer.EXPECT().Enqueue(jobtest.NewJobMatcher("track-running-session-job", &job.TrackRunningSessionJobInput{}, func(v interface{}) bool {
+		input := v.(*job.TrackRunningSessionJobInput)
+		content, err := ioutil.ReadFile(input.GPXFilepath)
+		testutils.RequireNoError(t, err, "can't read uploaded file")
+
+		return input.When.Equal(ranAt) && input.Title == "Morning run" && input.Type == domain.ActivityTypeTrailRun &&
+			input.Visibility == domain.ActivityVisibilityUnlisted && input.Username == "alice" && string(content) == "<gpx></gpx>"
+	})).Return(nil)
+
+	server := newServer(t, ctrl, nil, enqueuer)
+	defer server.Close()
+
+	job, err := apiclient.New(server.URL, writeToken).UploadActivity(context.Background(), apiclient.Upload{
+		RanAt:      ranAt,
+		Type:       domain.ActivityTypeTrailRun,
+		Title:      "Morning run",
+		Visibility: domain.ActivityVisibilityUnlisted,
+		GPX:        strings.NewReader("<gpx></gpx>"),
+	})
+	testutils.RequireNoError(t, err, "can't upload activity")
+
+	testutils.AssertEqualString(t, "202204250930", job.Slug, "unexpected slug")
+	testutils.AssertEqualString(t, "processing", job.Status, "unexpected status")
+}
+
+func TestUploadActivityInsufficientScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	server := newServer(t, ctrl, nil, nil)
+	defer server.Close()
+
+	_, err := apiclient.New(server.URL, readToken).UploadActivity(context.Background(), apiclient.Upload{
+		RanAt: time.Now(),
+		Type:  domain.ActivityTypeRun,
+		GPX:   strings.NewReader("<gpx></gpx>"),
+	})
+
+	var apiErr *apiclient.Error
+	testutils.RequireErrorAs(t, &apiErr, err, "expected an api error")
+	testutils.AssertEqualInt(t, http.StatusForbidden, apiErr.StatusCode, "unexpected status")
+	testutils.AssertEqualString(t, string(api.ErrorCodeForbidden), string(apiErr.Code), "unexpected code")
+}
+
+func TestInvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	server := newServer(t, ctrl, nil, nil)
+	defer server.Close()
+
+	_, err := apiclient.New(server.URL, "unknown").ListActivities(context.Background(), 1, 20)
+
+	var apiErr *apiclient.Error
+	testutils.RequireErrorAs(t, &apiErr, err, "expected an api error")
+	testutils.AssertEqualInt(t, http.StatusUnauthorized, apiErr.StatusCode, "unexpected status")
+	testutils.AssertContainsString(t, "bearer token is invalid", apiErr.Error(), "unexpected message")
+}
+
+func TestResponseWithoutErrorBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := apiclient.New(server.URL, readToken).ListActivities(context.Background(), 1, 20)
+
+	var apiErr *apiclient.Error
+	testutils.RequireErrorAs(t, &apiErr, err, "expected an api error")
+	testutils.AssertEqualInt(t, http.StatusBadGateway, apiErr.StatusCode, "unexpected status")
+	testutils.AssertEqualString(t, "Bad Gateway", apiErr.Message, "unexpected message")
+}
+
+func TestRequestError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	_, err := apiclient.New(server.URL, readToken).ListActivities(context.Background(), 1, 20)
+
+	var apiErr *apiclient.Error
+	testutils.AssertEqualBool(t, false, errors.As(err, &apiErr), "unexpected api error")
+	testutils.AssertHasError(t, err, "expected an error")
+}
+
+// newServer serves the routes of the API, authenticating a read and a write token of alice
+func newServer(t *testing.T, ctrl *gomock.Controller, app *applicationtest.MockApplication, enqueuer *jobtest.MockEnqueuer) *httptest.Server {
+	tokens := applicationtest.NewMockApplication(ctrl)
+	tokens.EXPECT().AuthenticateAPIToken(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, secret string) (domain.APIToken, error) {
+		switch secret {
+		case readToken:
+			return domain.APIToken{Username: "alice", Scope: domain.APITokenScopeRead}, nil
+		case writeToken:
+			return domain.APIToken{Username: "alice", Scope: domain.APITokenScopeWrite}, nil
+		default:
+			return domain.APIToken{}, domain.ErrAPITokenNotFound
+		}
+	}).AnyTimes()
+
+	urls := asseturl.NewBuilder("https://cdn.example.com", asseturltest.Presigner{}, time.Hour)
+	routes := api.Routes(app, tokens, enqueuer, urls, t.TempDir())
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, route := range routes {
+			vars, ok := matchRoute(route, r)
+			if !ok {
+				continue
+			}
+
+			ctx := webtest.NewMockContext(ctrl)
+			ctx.EXPECT().StdCtx().Return(context.Background()).AnyTimes()
+			ctx.EXPECT().Vars(gomock.Any()).Return(vars).AnyTimes()
+
+			response := route.Handler(ctx, w, r)
+			w.WriteHeader(response.HTTPCode)
+			_, _ = io.WriteString(w, response.Data.(string))
+			return
+		}
+
+		http.NotFound(w, r)
+	}))
+}
+
+// matchRoute returns the variables of the route path, such as {slug}, when the request matches the route
+func matchRoute(route api.Route, r *http.Request) (map[string]string, bool) {
+	patternParts := strings.Split(route.Path, "/")
+	pathParts := strings.Split(r.URL.Path, "/")
+	if route.Method != r.Method || len(patternParts) != len(pathParts) {
+		return nil, false
+	}
+
+	vars := make(map[string]string)
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "{") {
+			vars[strings.Trim(part, "{}")] = pathParts[i]
+		} else if part != pathParts[i] {
+			return nil, false
+		}
+	}
+
+	return vars, true
+}
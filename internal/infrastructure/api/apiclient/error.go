@@ -0,0 +1,40 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+)
+
+// Error is returned when the API answers with an error status
+type Error struct {
+	StatusCode int
+	Code       api.ErrorCode
+	Message    string
+	// Details lists every invalid input
+	Details []string
+}
+
+func newError(statusCode int, body []byte) *Error {
+	var response struct {
+		Error api.Error `json:"error"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil || response.Error.Code == "" {
+		// responses not sent by the API itself, such as the ones of a proxy, have no error body
+		return &Error{StatusCode: statusCode, Code: api.ErrorCodeInternal, Message: http.StatusText(statusCode)}
+	}
+
+	return &Error{StatusCode: statusCode, Code: response.Error.Code, Message: response.Error.Message, Details: response.Error.Details}
+}
+
+func (e *Error) Error() string {
+	if len(e.Details) == 0 {
+		return fmt.Sprintf("%s (status=%d): %s", e.Code, e.StatusCode, e.Message)
+	}
+
+	return fmt.Sprintf("%s (status=%d): %s: %s", e.Code, e.StatusCode, e.Message, strings.Join(e.Details, "; "))
+}
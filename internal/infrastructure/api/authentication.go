@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// TokenAuthenticator returns the personal access token matching the secret, or domain.ErrAPITokenNotFound
+type TokenAuthenticator interface {
+	AuthenticateAPIToken(ctx context.Context, secret string) (domain.APIToken, error)
+}
+
+type usernameContextKey struct{}
+
+// Authenticate rejects the requests without a valid bearer token, or whose token scope doesn't allow the required
+// one. The handler gets the token owner through CurrentUser.
+func Authenticate(tokens TokenAuthenticator, required domain.APITokenScope, h web.HandlerFunc) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		secret := bearerToken(r)
+		if secret == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="sport"`)
+			return errorResponse(w, http.StatusUnauthorized, Error{Code: ErrorCodeUnauthorized, Message: "bearer token is required"},
+				"missing bearer token")
+		}
+
+		token, err := tokens.AuthenticateAPIToken(ctx.StdCtx(), secret)
+		if errors.Is(err, domain.ErrAPITokenNotFound) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="sport", error="invalid_token"`)
+			return errorResponse(w, http.StatusUnauthorized, Error{Code: ErrorCodeUnauthorized, Message: "bearer token is invalid"},
+				fmt.Sprintf("can't authenticate token: %v", err))
+		}
+		if err != nil {
+			return failureResponse(w, err, "can't authenticate token")
+		}
+
+		if !token.Scope.Allows(required) {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="sport", error="insufficient_scope", scope="%s"`, required))
+			apiErr := Error{Code: ErrorCodeForbidden, Message: fmt.Sprintf("bearer token requires the %s scope", required)}
+			return errorResponse(w, http.StatusForbidden, apiErr, fmt.Sprintf("token %s lacks the %s scope", token.ID, required))
+		}
+
+		return h(ctx, w, r.WithContext(context.WithValue(r.Context(), usernameContextKey{}, token.Username)))
+	}
+}
+
+// CurrentUser returns the username of the token owner, or an empty string on routes without authentication
+func CurrentUser(r *http.Request) string {
+	username, _ := r.Context().Value(usernameContextKey{}).(string)
+
+	return username
+}
+
+func bearerToken(r *http.Request) string {
+	parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return ""
+	}
+
+	return strings.TrimSpace(parts[1])
+}
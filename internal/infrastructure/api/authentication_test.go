@@ -0,0 +1,105 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+)
+
+func TestAuthenticateMissingToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities", nil)
+
+	response := api.Authenticate(staticTokens{}, domain.APITokenScopeRead, unreachableHandler(t))(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusUnauthorized, api.ErrorCodeUnauthorized, response)
+	testutils.AssertEqualString(t, `Bearer realm="sport"`, w.Header().Get("WWW-Authenticate"), "unexpected challenge")
+}
+
+func TestAuthenticateInvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities", nil)
+	r.Header.Set("Authorization", "Bearer unknown")
+
+	ctx.EXPECT().StdCtx().Return(context.Background())
+
+	tokens := staticTokens{"secret": {Username: "alice", Scope: domain.APITokenScopeRead}}
+	response := api.Authenticate(tokens, domain.APITokenScopeRead, unreachableHandler(t))(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusUnauthorized, api.ErrorCodeUnauthorized, response)
+	testutils.AssertContainsString(t, "invalid_token", w.Header().Get("WWW-Authenticate"), "unexpected challenge")
+}
+
+func TestAuthenticateAuthenticatorError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	app := applicationtest.NewMockApplication(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities", nil)
+	r.Header.Set("Authorization", "Bearer secret")
+
+	ctx.EXPECT().StdCtx().Return(context.Background())
+	app.EXPECT().AuthenticateAPIToken(gomock.Any(), "secret").Return(domain.APIToken{}, errors.New("boom"))
+
+	response := api.Authenticate(app, domain.APITokenScopeRead, unreachableHandler(t))(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusInternalServerError, api.ErrorCodeInternal, response)
+}
+
+func TestAuthenticateInsufficientScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("DELETE", "/api/v1/activities/morning-run", nil)
+	r.Header.Set("Authorization", "Bearer secret")
+
+	ctx.EXPECT().StdCtx().Return(context.Background())
+
+	tokens := staticTokens{"secret": {Username: "alice", Scope: domain.APITokenScopeRead}}
+	response := api.Authenticate(tokens, domain.APITokenScopeWrite, unreachableHandler(t))(ctx, w, r)
+
+	assertErrorResponse(t, http.StatusForbidden, api.ErrorCodeForbidden, response)
+	testutils.AssertContainsString(t, `error="insufficient_scope"`, w.Header().Get("WWW-Authenticate"), "unexpected challenge")
+}
+
+func TestAuthenticateSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/activities", nil)
+	r.Header.Set("Authorization", "bearer secret")
+
+	ctx.EXPECT().StdCtx().Return(context.Background())
+
+	expectedResponse := webtest.MockedResponse("activities")
+	handler := func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		testutils.AssertEqualString(t, "alice", api.CurrentUser(r), "unexpected current user")
+		return expectedResponse
+	}
+
+	tokens := staticTokens{"secret": {Username: "alice", Scope: domain.APITokenScopeWrite}}
+	response := api.Authenticate(tokens, domain.APITokenScopeRead, handler)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, response, "unexpected response")
+}
+
+func unreachableHandler(t *testing.T) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		t.Fatalf("handler must not be called")
+		return web.Response{}
+	}
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	// embed is used to store the OpenAPI document
+	_ "embed"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+)
+
+// OpenAPISpec is the OpenAPI 3 document describing the routes. Tests check it matches Routes and the JSON types.
+//
+//go:embed openapi.json
+var OpenAPISpec []byte
+
+// OpenAPI serves the OpenAPI document, without authentication
+func OpenAPI() web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		return writeJSON(w, http.StatusOK, OpenAPISpec, "openapi document sent")
+	}
+}
@@ -0,0 +1,205 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+)
+
+// openAPIPrefix is the URL of the server declared by the document, which paths are relative to
+const openAPIPrefix = "/api/v1"
+
+type openAPIDocument struct {
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPIOperation struct {
+	OperationID string                       `json:"operationId"`
+	Scope       domain.APITokenScope         `json:"x-scope"`
+	Security    *[]map[string][]string       `json:"security"`
+	Responses   map[string]json.RawMessage   `json:"responses"`
+	Parameters  []map[string]json.RawMessage `json:"parameters"`
+}
+
+type openAPISchema struct {
+	Required   []string                 `json:"required"`
+	Properties map[string]openAPISchema `json:"properties"`
+	Enum       []string                 `json:"enum"`
+}
+
+func TestOpenAPIServesDocument(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/v1/openapi.json", nil)
+
+	response := api.OpenAPI()(ctx, w, r)
+
+	testutils.AssertEqualInt(t, http.StatusOK, response.HTTPCode, "unexpected http code")
+	testutils.AssertEqualString(t, "application/json", w.Header().Get("Content-Type"), "unexpected content type")
+	testutils.AssertEqualString(t, string(api.OpenAPISpec), response.Data.(string), "unexpected document")
+}
+
+func TestOpenAPIDocumentsEveryRoute(t *testing.T) {
+	document := parseOpenAPIDocument(t)
+
+	documented := make(map[string]openAPIOperation)
+	for path, item := range document.Paths {
+		for method, raw := range item {
+			if method == "parameters" {
+				continue
+			}
+
+			var operation openAPIOperation
+			testutils.RequireNoError(t, json.Unmarshal(raw, &operation), "can't decode operation %s %s", method, path)
+			documented[strings.ToUpper(method)+" "+openAPIPrefix+path] = operation
+		}
+	}
+
+	for _, route := range api.Routes(nil, staticTokens{}, nil, assetURLs, "") {
+		key := route.Method + " " + route.Path
+		operation, ok := documented[key]
+		if !ok {
+			t.Errorf("route %s isn't documented", key)
+			continue
+		}
+		delete(documented, key)
+
+		testutils.AssertEqualString(t, route.Scope.String(), operation.Scope.String(), "unexpected scope of %s", key)
+		isPublic := operation.Security != nil && len(*operation.Security) == 0
+		testutils.AssertEqualBool(t, route.Scope == "", isPublic, "unexpected security of %s", key)
+		if route.Scope != "" {
+			assertResponsesDocumented(t, key, operation, "401", "403")
+		}
+	}
+
+	for key := range documented {
+		t.Errorf("documented operation %s isn't served", key)
+	}
+}
+
+func TestOpenAPIDocumentsJSONTypes(t *testing.T) {
+	document := parseOpenAPIDocument(t)
+
+	types := map[string]interface{}{
+		"Activity":     api.Activity{},
+		"Assets":       api.Assets{},
+		"Card":         api.Card{},
+		"Chart":        api.Chart{},
+		"ActivityList": api.ActivityList{},
+		"Pagination":   api.Pagination{},
+		"Job":          api.Job{},
+		"Error":        api.Error{},
+	}
+
+	for name, value := range types {
+		schema, ok := document.Components.Schemas[name]
+		if !ok {
+			t.Errorf("schema %s isn't documented", name)
+			continue
+		}
+
+		properties, required := jsonFields(reflect.TypeOf(value))
+		testutils.AssertEqualStrings(t, properties, sortedKeys(schema.Properties), "unexpected properties of %s", name)
+		testutils.AssertEqualStrings(t, required, sortedStrings(schema.Required), "unexpected required properties of %s", name)
+	}
+}
+
+func TestOpenAPIDocumentsActivityTypes(t *testing.T) {
+	document := parseOpenAPIDocument(t)
+
+	var expected []string
+	for _, activityType := range domain.ActivityTypes {
+		expected = append(expected, activityType.String())
+	}
+
+	for _, schema := range []string{"Activity", "Upload"} {
+		actual := document.Components.Schemas[schema].Properties["type"].Enum
+		testutils.AssertEqualStrings(t, expected, actual, "unexpected activity types of %s", schema)
+	}
+}
+
+func TestOpenAPIDocumentsActivityVisibilities(t *testing.T) {
+	document := parseOpenAPIDocument(t)
+
+	var expected []string
+	for _, visibility := range domain.ActivityVisibilities {
+		expected = append(expected, visibility.String())
+	}
+
+	for _, schema := range []string{"Activity", "Upload"} {
+		actual := document.Components.Schemas[schema].Properties["visibility"].Enum
+		testutils.AssertEqualStrings(t, expected, actual, "unexpected activity visibilities of %s", schema)
+	}
+}
+
+func parseOpenAPIDocument(t *testing.T) openAPIDocument {
+	t.Helper()
+
+	var document openAPIDocument
+	testutils.RequireNoError(t, json.Unmarshal(api.OpenAPISpec, &document), "can't decode openapi document")
+	testutils.RequireEqualInt(t, 1, len(document.Servers), "unexpected number of servers")
+	testutils.RequireEqualString(t, openAPIPrefix, document.Servers[0].URL, "unexpected server url")
+
+	return document
+}
+
+func assertResponsesDocumented(t *testing.T, key string, operation openAPIOperation, codes ...string) {
+	t.Helper()
+
+	for _, code := range codes {
+		if _, ok := operation.Responses[code]; !ok {
+			t.Errorf("response %s of %s isn't documented", code, key)
+		}
+	}
+}
+
+// jsonFields returns the sorted names of the JSON fields of the struct, and the ones which are always encoded
+func jsonFields(structType reflect.Type) ([]string, []string) {
+	var names, required []string
+	for i := 0; i < structType.NumField(); i++ {
+		parts := strings.Split(structType.Field(i).Tag.Get("json"), ",")
+		if parts[0] == "" || parts[0] == "-" {
+			continue
+		}
+
+		names = append(names, parts[0])
+		if len(parts) == 1 || parts[1] != "omitempty" {
+			required = append(required, parts[0])
+		}
+	}
+
+	return sortedStrings(names), sortedStrings(required)
+}
+
+func sortedKeys(properties map[string]openAPISchema) []string {
+	var keys []string
+	for key := range properties {
+		keys = append(keys, key)
+	}
+
+	return sortedStrings(keys)
+}
+
+func sortedStrings(values []string) []string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+
+	return sorted
+}
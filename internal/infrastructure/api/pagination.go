@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+)
+
+// Pagination describes the page of a list, pages starting at 1
+type Pagination struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+	Total   int `json:"total"`
+}
+
+// parsePagination reads the page and per_page query parameters
+func parsePagination(r *http.Request) (Pagination, error) {
+	pagination := Pagination{Page: 1, PerPage: defaultPerPage}
+
+	var errs domain.InvalidInputErrors
+	if value := r.URL.Query().Get("page"); value != "" {
+		page, err := strconv.Atoi(value)
+		if err != nil || page < 1 {
+			errs.Append("page must be a number greater than 0")
+		}
+		pagination.Page = page
+	}
+
+	if value := r.URL.Query().Get("per_page"); value != "" {
+		perPage, err := strconv.Atoi(value)
+		if err != nil || perPage < 1 || perPage > maxPerPage {
+			errs.Append("per_page must be a number between 1 and 100")
+		}
+		pagination.PerPage = perPage
+	}
+
+	if !errs.IsEmpty() {
+		return Pagination{}, &errs
+	}
+
+	return pagination, nil
+}
+
+// bounds returns the indexes of the first and after the last items of the page. Pages after the last one are empty,
+// without computing their offset which could overflow.
+func (p Pagination) bounds() (int, int) {
+	if p.Page-1 > p.Total/p.PerPage {
+		return p.Total, p.Total
+	}
+
+	start := (p.Page - 1) * p.PerPage
+	if start > p.Total {
+		start = p.Total
+	}
+
+	end := start + p.PerPage
+	if end > p.Total {
+		end = p.Total
+	}
+
+	return start, end
+}
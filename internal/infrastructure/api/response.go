@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// ResponseTemplate renders the JSON body encoded by the handlers as is
+const ResponseTemplate = "templates/api/response.json.tmpl"
+
+// ErrorCode identifies the kind of error returned by the API
+type ErrorCode string
+
+const (
+	ErrorCodeBadRequest   ErrorCode = "bad_request"
+	ErrorCodeInvalidInput ErrorCode = "invalid_input"
+	ErrorCodeNotFound     ErrorCode = "not_found"
+	ErrorCodeUnauthorized ErrorCode = "unauthorized"
+	ErrorCodeForbidden    ErrorCode = "forbidden"
+	ErrorCodeInternal     ErrorCode = "internal_error"
+)
+
+// Error is the body of every error response
+type Error struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	// Details lists every invalid input
+	Details []string `json:"details,omitempty"`
+}
+
+type errorBody struct {
+	Error Error `json:"error"`
+}
+
+func jsonResponse(w http.ResponseWriter, httpCode int, body interface{}) web.Response {
+	content, err := json.Marshal(body)
+	if err != nil {
+		return errorResponse(w, http.StatusInternalServerError, Error{Code: ErrorCodeInternal, Message: "something wrong happened"},
+			fmt.Sprintf("can't encode response: %v", err))
+	}
+
+	return writeJSON(w, httpCode, content, "response sent")
+}
+
+func errorResponse(w http.ResponseWriter, httpCode int, apiErr Error, logMessage string) web.Response {
+	// an error body is always encodable
+	content, _ := json.Marshal(errorBody{Error: apiErr})
+
+	return writeJSON(w, httpCode, content, logMessage)
+}
+
+// failureResponse maps the error to its HTTP code: invalid inputs are unprocessable, missing activities and files are
+// not found and activities of other athletes are forbidden. The message of unexpected errors is only logged.
+func failureResponse(w http.ResponseWriter, err error, format string, args ...interface{}) web.Response {
+	logMessage := fmt.Sprintf("%s: %v", fmt.Sprintf(format, args...), err)
+
+	var invalidInput *domain.InvalidInputErrors
+	if errors.As(err, &invalidInput) {
+		apiErr := Error{Code: ErrorCodeInvalidInput, Message: "request contains invalid inputs", Details: invalidInput.Detail()}
+		return errorResponse(w, http.StatusUnprocessableEntity, apiErr, logMessage)
+	}
+
+	if errors.Is(err, domain.ErrCantGetRunningSession) || errors.Is(err, domain.ErrRunningActivityAssetNotFound) {
+		return notFoundResponse(w, logMessage)
+	}
+
+	if errors.Is(err, domain.ErrRunningActivityForbidden) {
+		apiErr := Error{Code: ErrorCodeForbidden, Message: "activity belongs to another athlete"}
+		return errorResponse(w, http.StatusForbidden, apiErr, logMessage)
+	}
+
+	return errorResponse(w, http.StatusInternalServerError, Error{Code: ErrorCodeInternal, Message: "something wrong happened"}, logMessage)
+}
+
+func notFoundResponse(w http.ResponseWriter, logMessage string) web.Response {
+	return errorResponse(w, http.StatusNotFound, Error{Code: ErrorCodeNotFound, Message: "activity not found"}, logMessage)
+}
+
+func writeJSON(w http.ResponseWriter, httpCode int, content []byte, logMessage string) web.Response {
+	w.Header().Set("Content-Type", "application/json")
+
+	return web.Response{
+		HTTPCode:   httpCode,
+		Template:   ResponseTemplate,
+		Data:       string(content),
+		LogMessage: logMessage,
+	}
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+// Route is an endpoint of the API. Scope is the one its token requires, public routes have none.
+type Route struct {
+	Method  string
+	Path    string
+	Scope   domain.APITokenScope
+	Handler web.HandlerFunc
+}
+
+// Routes returns every endpoint of the API, the authenticated ones wrapped by Authenticate
+func Routes(app application.Application, tokens TokenAuthenticator, enqueuer job.Enqueuer, urls asseturl.Builder, uploadFolder string) []Route {
+	routes := []Route{
+		{Method: "GET", Path: "/api/v1/activities", Scope: domain.APITokenScopeRead, Handler: ActivitiesIndex(app, urls)},
+		{Method: "POST", Path: "/api/v1/activities", Scope: domain.APITokenScopeWrite, Handler: www.LimitBodySize(MaxUploadBodySize, ActivitiesPost(enqueuer, uploadFolder))},
+		{Method: "GET", Path: "/api/v1/activities/{slug}", Scope: domain.APITokenScopeRead, Handler: ActivitiesShow(app, urls)},
+		{Method: "GET", Path: "/api/v1/activities/{slug}/assets/{name}", Scope: domain.APITokenScopeRead, Handler: ActivitiesAsset(app)},
+		{Method: "DELETE", Path: "/api/v1/activities/{slug}", Scope: domain.APITokenScopeWrite, Handler: ActivitiesDelete(app, enqueuer)},
+		{Method: "POST", Path: "/api/v1/activities/{slug}/regenerate", Scope: domain.APITokenScopeWrite, Handler: ActivitiesRegenerate(app, enqueuer)},
+		{Method: "GET", Path: "/api/v1/openapi.json", Handler: OpenAPI()},
+	}
+
+	for i, route := range routes {
+		if route.Scope != "" {
+			routes[i].Handler = Authenticate(tokens, route.Scope, route.Handler)
+		}
+	}
+
+	return routes
+}
@@ -0,0 +1,260 @@
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// AssetFetcher represents the storage holding the files of the activities
+type AssetFetcher interface {
+	FetchAsset(fileName string) (io.ReadCloser, error)
+}
+
+// Archive builds zip archives out of the recorded activities
+type Archive struct {
+	assets AssetFetcher
+}
+
+// New initializes an archive builder fetching activity files from assets
+func New(assets AssetFetcher) Archive {
+	return Archive{assets: assets}
+}
+
+type manifestEntry struct {
+	Slug             string    `json:"slug"`
+	RanAt            time.Time `json:"ran_at"`
+	Title            string    `json:"title"`
+	Description      string    `json:"description"`
+	DurationMs       int64     `json:"duration_ms"`
+	DistanceMeters   int       `json:"distance_meters"`
+	SpeedKmh         float64   `json:"speed_kmh"`
+	GPXFile          string    `json:"gpx_file"`
+	MapFile          string    `json:"map_file"`
+	ShareableMapFile string    `json:"shareable_map_file"`
+	Type             string    `json:"type"`
+	// Display holds the values formatted with the preferences of the user requesting the export
+	Display manifestDisplay `json:"display"`
+}
+
+type manifestDisplay struct {
+	Date     string `json:"date"`
+	Distance string `json:"distance"`
+	Duration string `json:"duration"`
+	Pace     string `json:"pace"`
+	Speed    string `json:"speed"`
+}
+
+var manifestCSVHeader = []string{
+	"slug", "ran_at", "title", "description", "duration_ms", "distance_meters", "speed_kmh", "gpx_file", "map_file",
+	"shareable_map_file", "type", "date", "distance", "duration", "pace", "speed",
+}
+
+func (e manifestEntry) csvRecord() []string {
+	return []string{
+		e.Slug,
+		e.RanAt.Format(time.RFC3339),
+		e.Title,
+		e.Description,
+		strconv.FormatInt(e.DurationMs, 10),
+		strconv.Itoa(e.DistanceMeters),
+		strconv.FormatFloat(e.SpeedKmh, 'f', -1, 64),
+		e.GPXFile,
+		e.MapFile,
+		e.ShareableMapFile,
+		e.Type,
+		e.Display.Date,
+		e.Display.Distance,
+		e.Display.Duration,
+		e.Display.Pace,
+		e.Display.Speed,
+	}
+}
+
+// BuildExportArchive builds a zip containing the files of every activity, a manifest.json and a manifest.csv. Besides
+// raw values, manifests hold the values formatted as the user prefers.
+//
+// The archive is written in a temporary file removed when the returned archive is closed.
+func (a Archive) BuildExportArchive(ctx context.Context, activities []domain.RunningActivity, prefs domain.UserPreferences) (domain.ExportArchive, error) {
+	file, err := os.CreateTemp("", "sport-export-*.zip")
+	if err != nil {
+		return domain.ExportArchive{}, fmt.Errorf("can't create temporary archive: %v", err)
+	}
+
+	archive := temporaryFile{File: file}
+	if err := a.writeArchive(ctx, file, activities, prefs); err != nil {
+		archive.Close()
+		return domain.ExportArchive{}, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		archive.Close()
+		return domain.ExportArchive{}, fmt.Errorf("can't rewind archive: %v", err)
+	}
+
+	return domain.NewExportArchive(archive), nil
+}
+
+func (a Archive) writeArchive(ctx context.Context, w io.Writer, activities []domain.RunningActivity, prefs domain.UserPreferences) error {
+	zipWriter := zip.NewWriter(w)
+
+	entries := make([]manifestEntry, 0, len(activities))
+	for _, activity := range activities {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		entry, err := a.addActivity(zipWriter, activity, prefs)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := writeJSONManifest(zipWriter, entries); err != nil {
+		return err
+	}
+
+	if err := writeCSVManifest(zipWriter, entries); err != nil {
+		return err
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return fmt.Errorf("can't finalize archive: %v", err)
+	}
+
+	return nil
+}
+
+func (a Archive) addActivity(zipWriter *zip.Writer, activity domain.RunningActivity, prefs domain.UserPreferences) (manifestEntry, error) {
+	folder := path.Join("activities", activity.Slug.String())
+
+	entry := manifestEntry{
+		Slug:           activity.Slug.String(),
+		RanAt:          activity.RanAt,
+		Title:          activity.Title,
+		Description:    activity.Description,
+		DurationMs:     activity.Duration.Milliseconds(),
+		DistanceMeters: activity.Distance.Meters(),
+		SpeedKmh:       activity.Speed.KilometersPerHour(),
+		Type:           activity.Type.String(),
+		Display: manifestDisplay{
+			Date:     prefs.FormatDateTime(activity.RanAt),
+			Distance: prefs.FormatDistance(activity.Distance),
+			Duration: prefs.FormatDuration(activity.Duration),
+			Pace:     prefs.FormatPace(activity.Speed.Pace()),
+			Speed:    prefs.FormatSpeed(activity.Speed),
+		},
+	}
+
+	mapPath, shareableMapPath := activity.MapPath.String(), activity.ShareableMapPath.String()
+	if activity.IsMapPending() {
+		// the maps of a pending activity aren't stored yet
+		mapPath, shareableMapPath = "", ""
+	}
+
+	files := []struct {
+		assetPath   string
+		archivePath *string
+	}{
+		{assetPath: activity.GPXPath.String(), archivePath: &entry.GPXFile},
+		{assetPath: mapPath, archivePath: &entry.MapFile},
+		{assetPath: shareableMapPath, archivePath: &entry.ShareableMapFile},
+	}
+
+	for _, file := range files {
+		if file.assetPath == "" {
+			continue
+		}
+
+		archivePath := path.Join(folder, path.Base(file.assetPath))
+		if err := a.copyAsset(zipWriter, file.assetPath, archivePath); err != nil {
+			return manifestEntry{}, err
+		}
+
+		*file.archivePath = archivePath
+	}
+
+	return entry, nil
+}
+
+func (a Archive) copyAsset(zipWriter *zip.Writer, assetPath string, archivePath string) error {
+	content, err := a.assets.FetchAsset(assetPath)
+	if err != nil {
+		return fmt.Errorf("can't fetch asset (path=%s): %v", assetPath, err)
+	}
+	defer content.Close()
+
+	w, err := zipWriter.Create(archivePath)
+	if err != nil {
+		return fmt.Errorf("can't create archive entry (path=%s): %v", archivePath, err)
+	}
+
+	if _, err := io.Copy(w, content); err != nil {
+		return fmt.Errorf("can't copy asset to archive (path=%s): %v", assetPath, err)
+	}
+
+	return nil
+}
+
+func writeJSONManifest(zipWriter *zip.Writer, entries []manifestEntry) error {
+	w, err := zipWriter.Create("manifest.json")
+	if err != nil {
+		return fmt.Errorf("can't create json manifest: %v", err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(entries); err != nil {
+		return fmt.Errorf("can't write json manifest: %v", err)
+	}
+
+	return nil
+}
+
+func writeCSVManifest(zipWriter *zip.Writer, entries []manifestEntry) error {
+	w, err := zipWriter.Create("manifest.csv")
+	if err != nil {
+		return fmt.Errorf("can't create csv manifest: %v", err)
+	}
+
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(manifestCSVHeader); err != nil {
+		return fmt.Errorf("can't write csv manifest header: %v", err)
+	}
+
+	for _, entry := range entries {
+		if err := csvWriter.Write(entry.csvRecord()); err != nil {
+			return fmt.Errorf("can't write csv manifest entry (slug=%s): %v", entry.Slug, err)
+		}
+	}
+
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("can't flush csv manifest: %v", err)
+	}
+
+	return nil
+}
+
+type temporaryFile struct {
+	*os.File
+}
+
+// Close closes and removes the file
+func (f temporaryFile) Close() error {
+	err := f.File.Close()
+	os.Remove(f.Name())
+
+	return err
+}
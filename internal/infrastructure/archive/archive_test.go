@@ -0,0 +1,129 @@
+package archive_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/archive"
+)
+
+type assets map[string]string
+
+func (a assets) FetchAsset(name string) (io.ReadCloser, error) {
+	content, ok := a[name]
+	if !ok {
+		return nil, fmt.Errorf("file not found")
+	}
+
+	return io.NopCloser(bytes.NewBufferString(content)), nil
+}
+
+func TestBuildExportArchiveSuccess(t *testing.T) {
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202204170900").WithDetails("Morning run", "Along the river").Build()
+	store := assets{
+		activity.GPXPath.String():          "gpx content",
+		activity.MapPath.String():          "map content",
+		activity.ShareableMapPath.String(): "shareable map content",
+	}
+
+	prefs := domain.UserPreferences{Locale: domain.LocaleFrench, Units: domain.UnitSystemMetric, SpeedDisplay: domain.SpeedDisplayPace}
+
+	exportArchive, err := archive.New(store).BuildExportArchive(context.Background(), []domain.RunningActivity{activity}, prefs)
+	testutils.RequireNoError(t, err, "can't build archive")
+	defer exportArchive.Close()
+
+	files := readArchive(t, exportArchive)
+
+	testutils.AssertEqualString(t, "gpx content", files["activities/202204170900/run.gpx"], "unexpected gpx file")
+	testutils.AssertEqualString(t, "map content", files["activities/202204170900/map.png"], "unexpected map file")
+	testutils.AssertEqualString(t, "shareable map content", files["activities/202204170900/card-opengraph.png"], "unexpected shareable map file")
+
+	var manifest []map[string]interface{}
+	err = json.Unmarshal([]byte(files["manifest.json"]), &manifest)
+	testutils.RequireNoError(t, err, "can't parse json manifest")
+	testutils.RequireEqualInt(t, 1, len(manifest), "unexpected number of json manifest entries")
+	testutils.AssertEqualString(t, "202204170900", manifest[0]["slug"].(string), "unexpected slug")
+	testutils.AssertEqualString(t, "2022-04-17T09:00:00Z", manifest[0]["ran_at"].(string), "unexpected ran at")
+	testutils.AssertEqualString(t, "Morning run", manifest[0]["title"].(string), "unexpected title")
+	testutils.AssertEqualString(t, "run", manifest[0]["type"].(string), "unexpected type")
+	testutils.AssertEqualFloat64(t, float64(activity.Distance.Meters()), manifest[0]["distance_meters"].(float64), "unexpected distance")
+	testutils.AssertEqualString(t, "activities/202204170900/run.gpx", manifest[0]["gpx_file"].(string), "unexpected gpx file")
+	display := manifest[0]["display"].(map[string]interface{})
+	testutils.AssertEqualString(t, prefs.FormatDistance(activity.Distance), display["distance"].(string), "unexpected formatted distance")
+	testutils.AssertEqualString(t, "17/04/2022 09:00", display["date"].(string), "unexpected formatted date")
+
+	records, err := csv.NewReader(bytes.NewBufferString(files["manifest.csv"])).ReadAll()
+	testutils.RequireNoError(t, err, "can't parse csv manifest")
+	testutils.RequireEqualInt(t, 2, len(records), "unexpected number of csv manifest lines")
+	testutils.AssertEqualString(t, "slug", records[0][0], "unexpected csv header")
+	testutils.AssertEqualString(t, "202204170900", records[1][0], "unexpected slug")
+	testutils.AssertEqualString(t, "activities/202204170900/card-opengraph.png", records[1][9], "unexpected shareable map file")
+	testutils.AssertEqualString(t, "run", records[1][10], "unexpected type")
+	testutils.AssertEqualString(t, "17/04/2022 09:00", records[1][11], "unexpected formatted date")
+	testutils.AssertEqualString(t, prefs.FormatPace(activity.Speed.Pace()), records[1][14], "unexpected formatted pace")
+}
+
+func TestBuildExportArchivePendingMap(t *testing.T) {
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202204170900").WithPendingMap("mapbox is down").Build()
+	store := assets{activity.GPXPath.String(): "gpx content"}
+
+	exportArchive, err := archive.New(store).BuildExportArchive(context.Background(), []domain.RunningActivity{activity}, domain.DefaultUserPreferences())
+	testutils.RequireNoError(t, err, "can't build archive")
+	defer exportArchive.Close()
+
+	files := readArchive(t, exportArchive)
+
+	testutils.AssertEqualString(t, "gpx content", files["activities/202204170900/run.gpx"], "unexpected gpx file")
+	_, hasMap := files["activities/202204170900/map.png"]
+	testutils.AssertEqualBool(t, false, hasMap, "pending map shouldn't be archived")
+}
+
+func TestBuildExportArchiveMissingAsset(t *testing.T) {
+	activity := domaintest.NewRunningActivity(t).Build()
+
+	_, err := archive.New(assets{}).BuildExportArchive(context.Background(), []domain.RunningActivity{activity}, domain.DefaultUserPreferences())
+
+	testutils.AssertErrorContains(t, "can't fetch asset", err, "unexpected error")
+}
+
+func TestBuildExportArchiveNoActivities(t *testing.T) {
+	exportArchive, err := archive.New(assets{}).BuildExportArchive(context.Background(), nil, domain.DefaultUserPreferences())
+	testutils.RequireNoError(t, err, "can't build archive")
+	defer exportArchive.Close()
+
+	files := readArchive(t, exportArchive)
+
+	testutils.AssertEqualInt(t, 2, len(files), "unexpected number of files")
+	testutils.AssertEqualString(t, "[]\n", files["manifest.json"], "unexpected json manifest")
+}
+
+func readArchive(t *testing.T, exportArchive domain.ExportArchive) map[string]string {
+	content, err := io.ReadAll(exportArchive.File())
+	testutils.RequireNoError(t, err, "can't read archive")
+
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	testutils.RequireNoError(t, err, "can't open archive")
+
+	files := make(map[string]string)
+	for _, file := range reader.File {
+		f, err := file.Open()
+		testutils.RequireNoError(t, err, "can't open archive entry %s", file.Name)
+
+		data, err := io.ReadAll(f)
+		testutils.RequireNoError(t, err, "can't read archive entry %s", file.Name)
+		f.Close()
+
+		files[file.Name] = string(data)
+	}
+
+	return files
+}
@@ -0,0 +1,45 @@
+// Package asseturl builds the URLs browsers and API clients use to download the stored files
+package asseturl
+
+import (
+	"fmt"
+	"time"
+)
+
+// Presigner signs temporary URLs giving access to a file of a private bucket
+type Presigner interface {
+	PresignAsset(fileName string, expiry time.Duration) (string, error)
+}
+
+// Builder links the public files through the CDN, when there is one, and the other ones through presigned URLs
+// expiring after a while, so the bucket itself can stay private
+type Builder struct {
+	cdnURL    string
+	presigner Presigner
+	expiry    time.Duration
+}
+
+// NewBuilder returns a builder signing URLs valid for expiry. Without CDN URL, every file is linked through a presigned
+// URL.
+func NewBuilder(cdnURL string, presigner Presigner, expiry time.Duration) Builder {
+	return Builder{cdnURL: cdnURL, presigner: presigner, expiry: expiry}
+}
+
+// PublicURL returns the URL of a file anyone can see
+func (b Builder) PublicURL(fileName string) (string, error) {
+	if b.cdnURL == "" {
+		return b.PresignedURL(fileName)
+	}
+
+	return b.cdnURL + "/" + fileName, nil
+}
+
+// PresignedURL returns a URL giving access to the file until it expires
+func (b Builder) PresignedURL(fileName string) (string, error) {
+	url, err := b.presigner.PresignAsset(fileName, b.expiry)
+	if err != nil {
+		return "", fmt.Errorf("can't presign url of file %s: %v", fileName, err)
+	}
+
+	return url, nil
+}
@@ -0,0 +1,45 @@
+package asseturl_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl/asseturltest"
+)
+
+func TestPublicURLWithCDN(t *testing.T) {
+	urls := asseturl.NewBuilder("https://cdn.example.com", asseturltest.Presigner{}, time.Hour)
+
+	url, err := urls.PublicURL("runs/2022-04-17.09h00/map.png")
+	testutils.RequireNoError(t, err, "can't build url")
+
+	testutils.AssertEqualString(t, "https://cdn.example.com/runs/2022-04-17.09h00/map.png", url, "unexpected url")
+}
+
+func TestPublicURLWithoutCDN(t *testing.T) {
+	urls := asseturl.NewBuilder("", asseturltest.Presigner{}, time.Hour)
+
+	url, err := urls.PublicURL("runs/2022-04-17.09h00/map.png")
+	testutils.RequireNoError(t, err, "can't build url")
+
+	testutils.AssertEqualString(t, "https://bucket.example.com/runs/2022-04-17.09h00/map.png?expires=3600", url, "unexpected url")
+}
+
+func TestPresignedURLSuccess(t *testing.T) {
+	urls := asseturl.NewBuilder("https://cdn.example.com", asseturltest.Presigner{}, 15*time.Minute)
+
+	url, err := urls.PresignedURL("runs/2022-04-17.09h00/map.png")
+	testutils.RequireNoError(t, err, "can't build url")
+
+	testutils.AssertEqualString(t, "https://bucket.example.com/runs/2022-04-17.09h00/map.png?expires=900", url, "unexpected url")
+}
+
+func TestPresignedURLError(t *testing.T) {
+	urls := asseturl.NewBuilder("https://cdn.example.com", asseturltest.Presigner{Err: errors.New("boom")}, time.Hour)
+
+	_, err := urls.PresignedURL("runs/2022-04-17.09h00/map.png")
+	testutils.AssertErrorContains(t, "boom", err, "expected presigner error")
+}
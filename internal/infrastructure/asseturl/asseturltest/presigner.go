@@ -0,0 +1,19 @@
+package asseturltest
+
+import (
+	"fmt"
+	"time"
+)
+
+// Presigner signs fake URLs on bucket.example.com, or fails with Err when it's set
+type Presigner struct {
+	Err error
+}
+
+func (p Presigner) PresignAsset(fileName string, expiry time.Duration) (string, error) {
+	if p.Err != nil {
+		return "", p.Err
+	}
+
+	return fmt.Sprintf("https://bucket.example.com/%s?expires=%d", fileName, int(expiry.Seconds())), nil
+}
@@ -0,0 +1,273 @@
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	snapshotTimeLayout = "20060102T150405Z"
+	snapshotExtension  = ".sqlite.gz"
+)
+
+var (
+	ErrBackupNotFound = errors.New("backup not found")
+)
+
+// Snapshotter represents a database able to write a consistent copy of itself in a file
+type Snapshotter interface {
+	Snapshot(ctx context.Context, dest string) error
+}
+
+// AssetStore represents the storage where compressed snapshots are uploaded
+type AssetStore interface {
+	StoreAsset(content io.Reader, fileName string) error
+	DeleteAsset(fileName string) error
+	FetchAsset(fileName string) (io.ReadCloser, error)
+	ListAssets(prefix string) ([]string, error)
+}
+
+// Backup takes compressed snapshots of a database and stores them in an asset store
+type Backup struct {
+	snapshotter Snapshotter
+	store       AssetStore
+
+	// Folder is the prefix of all the snapshots in the asset store
+	Folder string
+	// Retention is the number of snapshots to keep. Older ones are deleted after each backup. 0 keeps everything.
+	Retention int
+	Now       func() time.Time
+}
+
+// New initializes a backup keeping the last retention snapshots
+func New(snapshotter Snapshotter, store AssetStore, retention int) Backup {
+	return Backup{
+		snapshotter: snapshotter,
+		store:       store,
+		Folder:      "backups/sqlite",
+		Retention:   retention,
+		Now:         time.Now,
+	}
+}
+
+// BackupDatabase uploads a new compressed snapshot, applies the retention policy and returns the snapshot name
+func (b Backup) BackupDatabase(ctx context.Context) (string, error) {
+	dir, err := os.MkdirTemp("", "sport-backup")
+	if err != nil {
+		return "", fmt.Errorf("can't create temporary folder: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	snapshotPath := filepath.Join(dir, "snapshot.sqlite")
+	if err := b.snapshotter.Snapshot(ctx, snapshotPath); err != nil {
+		return "", err
+	}
+
+	compressedPath := snapshotPath + ".gz"
+	if err := compressFile(snapshotPath, compressedPath); err != nil {
+		return "", err
+	}
+
+	compressed, err := os.Open(compressedPath)
+	if err != nil {
+		return "", fmt.Errorf("can't open compressed snapshot: %v", err)
+	}
+	defer compressed.Close()
+
+	name := path.Join(b.Folder, b.Now().UTC().Format(snapshotTimeLayout)+snapshotExtension)
+	if err := b.store.StoreAsset(compressed, name); err != nil {
+		return "", fmt.Errorf("can't upload snapshot (name=%s): %v", name, err)
+	}
+
+	if err := b.applyRetention(); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// ListBackups returns the name of all the stored snapshots, from the oldest to the newest
+func (b Backup) ListBackups() ([]string, error) {
+	names, err := b.store.ListAssets(b.Folder + "/")
+	if err != nil {
+		return nil, fmt.Errorf("can't list snapshots: %v", err)
+	}
+
+	var snapshots []string
+	for _, name := range names {
+		if strings.HasSuffix(name, snapshotExtension) {
+			snapshots = append(snapshots, name)
+		}
+	}
+
+	sort.Strings(snapshots)
+
+	return snapshots, nil
+}
+
+// FindBackup returns the name of the most recent snapshot taken at or before the given time
+func (b Backup) FindBackup(at time.Time) (string, error) {
+	snapshots, err := b.ListBackups()
+	if err != nil {
+		return "", err
+	}
+
+	var found string
+	for _, name := range snapshots {
+		takenAt, err := snapshotTakenAt(name)
+		if err != nil || takenAt.After(at) {
+			continue
+		}
+
+		found = name
+	}
+
+	if found == "" {
+		return "", fmt.Errorf("%w: no snapshot taken before %s", ErrBackupNotFound, at.Format(time.RFC3339))
+	}
+
+	return found, nil
+}
+
+// IsDue returns whether a snapshot should be taken now, that is when the most recent one is older than the interval
+// or when there is none
+func (b Backup) IsDue(interval time.Duration) (bool, error) {
+	now := b.Now()
+
+	name, err := b.FindBackup(now)
+	if errors.Is(err, ErrBackupNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	takenAt, err := snapshotTakenAt(name)
+	if err != nil {
+		return false, err
+	}
+
+	return now.Sub(takenAt) >= interval, nil
+}
+
+// RestoreDatabase downloads a snapshot, verifies it and swaps it with the database file at dest.
+//
+// The previous database file is kept next to it with a .bak suffix.
+func (b Backup) RestoreDatabase(name string, dest string, verify func(path string) error) error {
+	content, err := b.store.FetchAsset(name)
+	if err != nil {
+		return fmt.Errorf("%w: can't fetch snapshot (name=%s): %v", ErrBackupNotFound, name, err)
+	}
+	defer content.Close()
+
+	restoringPath := dest + ".restoring"
+	if err := decompressFile(content, restoringPath); err != nil {
+		os.Remove(restoringPath)
+		return err
+	}
+
+	if err := verify(restoringPath); err != nil {
+		os.Remove(restoringPath)
+		return fmt.Errorf("can't verify snapshot (name=%s): %v", name, err)
+	}
+
+	if _, err := os.Stat(dest); err == nil {
+		backupPath := fmt.Sprintf("%s.%s.bak", dest, b.Now().UTC().Format(snapshotTimeLayout))
+		if err := os.Rename(dest, backupPath); err != nil {
+			os.Remove(restoringPath)
+			return fmt.Errorf("can't move current database away (path=%s): %v", dest, err)
+		}
+	}
+
+	if err := os.Rename(restoringPath, dest); err != nil {
+		return fmt.Errorf("can't swap database with snapshot (path=%s): %v", dest, err)
+	}
+
+	return nil
+}
+
+func (b Backup) applyRetention() error {
+	if b.Retention <= 0 {
+		return nil
+	}
+
+	snapshots, err := b.ListBackups()
+	if err != nil {
+		return err
+	}
+
+	if len(snapshots) <= b.Retention {
+		return nil
+	}
+
+	for _, name := range snapshots[:len(snapshots)-b.Retention] {
+		if err := b.store.DeleteAsset(name); err != nil {
+			return fmt.Errorf("can't delete expired snapshot (name=%s): %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+func snapshotTakenAt(name string) (time.Time, error) {
+	takenAt, err := time.Parse(snapshotTimeLayout, strings.TrimSuffix(path.Base(name), snapshotExtension))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("can't parse snapshot time (name=%s): %v", name, err)
+	}
+
+	return takenAt, nil
+}
+
+func compressFile(src string, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("can't open snapshot: %v", err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("can't create compressed snapshot: %v", err)
+	}
+	defer out.Close()
+
+	w := gzip.NewWriter(out)
+	if _, err := io.Copy(w, in); err != nil {
+		return fmt.Errorf("can't compress snapshot: %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("can't flush compressed snapshot: %v", err)
+	}
+
+	return nil
+}
+
+func decompressFile(src io.Reader, dest string) error {
+	r, err := gzip.NewReader(src)
+	if err != nil {
+		return fmt.Errorf("can't read compressed snapshot: %v", err)
+	}
+	defer r.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("can't create restored database: %v", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, r); err != nil {
+		return fmt.Errorf("can't decompress snapshot: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package backup_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/infrastructure/backup"
+)
+
+type snapshotter struct {
+	content string
+}
+
+func (s snapshotter) Snapshot(_ context.Context, dest string) error {
+	return os.WriteFile(dest, []byte(s.content), 0600)
+}
+
+type assetStore struct {
+	files map[string][]byte
+}
+
+func newAssetStore() *assetStore {
+	return &assetStore{files: make(map[string][]byte)}
+}
+
+func (s *assetStore) StoreAsset(content io.Reader, name string) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	s.files[name] = data
+	return nil
+}
+
+func (s *assetStore) DeleteAsset(name string) error {
+	delete(s.files, name)
+	return nil
+}
+
+func (s *assetStore) FetchAsset(name string) (io.ReadCloser, error) {
+	data, ok := s.files[name]
+	if !ok {
+		return nil, fmt.Errorf("file not found")
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *assetStore) ListAssets(prefix string) ([]string, error) {
+	var names []string
+	for name := range s.files {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+func fixedClock(raw string) func() time.Time {
+	return func() time.Time {
+		t, _ := time.Parse(time.RFC3339, raw)
+		return t
+	}
+}
+
+func TestBackupDatabaseSuccess(t *testing.T) {
+	store := newAssetStore()
+	b := backup.New(snapshotter{content: "database content"}, store, 3)
+	b.Now = fixedClock("2022-04-10T12:30:00Z")
+
+	name, err := b.BackupDatabase(context.Background())
+	testutils.AssertNoError(t, err, "can't backup database")
+	testutils.AssertEqualString(t, "backups/sqlite/20220410T123000Z.sqlite.gz", name, "unexpected snapshot name")
+
+	r, err := gzip.NewReader(bytes.NewReader(store.files[name]))
+	testutils.AssertNoError(t, err, "snapshot is not compressed")
+	content, err := io.ReadAll(r)
+	testutils.AssertNoError(t, err, "can't decompress snapshot")
+	testutils.AssertEqualString(t, "database content", string(content), "unexpected snapshot content")
+}
+
+func TestBackupDatabaseRetention(t *testing.T) {
+	store := newAssetStore()
+	b := backup.New(snapshotter{content: "database content"}, store, 2)
+
+	for _, now := range []string{"2022-04-10T12:00:00Z", "2022-04-11T12:00:00Z", "2022-04-12T12:00:00Z"} {
+		b.Now = fixedClock(now)
+		_, err := b.BackupDatabase(context.Background())
+		testutils.AssertNoError(t, err, "can't backup database")
+	}
+
+	snapshots, err := b.ListBackups()
+	testutils.AssertNoError(t, err, "can't list snapshots")
+	testutils.AssertEqualInt(t, 2, len(snapshots), "unexpected number of snapshots: %v", snapshots)
+	testutils.AssertEqualString(t, "backups/sqlite/20220411T120000Z.sqlite.gz", snapshots[0], "unexpected oldest snapshot")
+	testutils.AssertEqualString(t, "backups/sqlite/20220412T120000Z.sqlite.gz", snapshots[1], "unexpected newest snapshot")
+}
+
+func TestFindBackup(t *testing.T) {
+	store := newAssetStore()
+	b := backup.New(snapshotter{content: "database content"}, store, 0)
+
+	for _, now := range []string{"2022-04-10T12:00:00Z", "2022-04-11T12:00:00Z"} {
+		b.Now = fixedClock(now)
+		_, err := b.BackupDatabase(context.Background())
+		testutils.AssertNoError(t, err, "can't backup database")
+	}
+
+	name, err := b.FindBackup(fixedClock("2022-04-11T08:00:00Z")())
+	testutils.AssertNoError(t, err, "can't find snapshot")
+	testutils.AssertEqualString(t, "backups/sqlite/20220410T120000Z.sqlite.gz", name, "unexpected snapshot")
+
+	_, err = b.FindBackup(fixedClock("2022-04-01T08:00:00Z")())
+	testutils.AssertErrorIs(t, backup.ErrBackupNotFound, err, "unexpected error")
+}
+
+func TestIsDue(t *testing.T) {
+	store := newAssetStore()
+	b := backup.New(snapshotter{content: "database content"}, store, 0)
+
+	due, err := b.IsDue(24 * time.Hour)
+	testutils.AssertNoError(t, err, "can't check backup is due")
+	testutils.AssertEqualBool(t, true, due, "backup should be due without snapshot")
+
+	b.Now = fixedClock("2022-04-10T12:00:00Z")
+	_, err = b.BackupDatabase(context.Background())
+	testutils.AssertNoError(t, err, "can't backup database")
+
+	b.Now = fixedClock("2022-04-11T11:59:00Z")
+	due, err = b.IsDue(24 * time.Hour)
+	testutils.AssertNoError(t, err, "can't check backup is due")
+	testutils.AssertEqualBool(t, false, due, "backup shouldn't be due before the interval")
+
+	b.Now = fixedClock("2022-04-11T12:00:00Z")
+	due, err = b.IsDue(24 * time.Hour)
+	testutils.AssertNoError(t, err, "can't check backup is due")
+	testutils.AssertEqualBool(t, true, due, "backup should be due after the interval")
+}
+
+func TestRestoreDatabaseSuccess(t *testing.T) {
+	store := newAssetStore()
+	b := backup.New(snapshotter{content: "snapshot content"}, store, 0)
+	b.Now = fixedClock("2022-04-10T12:30:00Z")
+
+	name, err := b.BackupDatabase(context.Background())
+	testutils.AssertNoError(t, err, "can't backup database")
+
+	dest := filepath.Join(t.TempDir(), "sport.sqlite")
+	testutils.AssertNoError(t, os.WriteFile(dest, []byte("current content"), 0600), "can't write current database")
+
+	var verifiedContent []byte
+	err = b.RestoreDatabase(name, dest, func(path string) error {
+		verifiedContent, err = os.ReadFile(path)
+		return err
+	})
+	testutils.AssertNoError(t, err, "can't restore database")
+	testutils.AssertEqualString(t, "snapshot content", string(verifiedContent), "unexpected verified content")
+
+	content, err := os.ReadFile(dest)
+	testutils.AssertNoError(t, err, "can't read restored database")
+	testutils.AssertEqualString(t, "snapshot content", string(content), "unexpected restored content")
+
+	previous, err := os.ReadFile(dest + ".20220410T123000Z.bak")
+	testutils.AssertNoError(t, err, "can't read previous database")
+	testutils.AssertEqualString(t, "current content", string(previous), "unexpected previous content")
+}
+
+func TestRestoreDatabaseVerificationFailure(t *testing.T) {
+	store := newAssetStore()
+	b := backup.New(snapshotter{content: "snapshot content"}, store, 0)
+
+	name, err := b.BackupDatabase(context.Background())
+	testutils.AssertNoError(t, err, "can't backup database")
+
+	dest := filepath.Join(t.TempDir(), "sport.sqlite")
+	testutils.AssertNoError(t, os.WriteFile(dest, []byte("current content"), 0600), "can't write current database")
+
+	err = b.RestoreDatabase(name, dest, func(string) error { return errors.New("corrupted") })
+	testutils.AssertErrorContains(t, "can't verify snapshot", err, "unexpected error")
+
+	content, err := os.ReadFile(dest)
+	testutils.AssertNoError(t, err, "can't read current database")
+	testutils.AssertEqualString(t, "current content", string(content), "database shouldn't have been replaced")
+
+	_, err = os.Stat(dest + ".restoring")
+	testutils.AssertEqualBool(t, true, os.IsNotExist(err), "temporary file should have been removed")
+}
+
+func TestRestoreDatabaseUnknownSnapshot(t *testing.T) {
+	b := backup.New(snapshotter{}, newAssetStore(), 0)
+
+	err := b.RestoreDatabase("backups/sqlite/nope.sqlite.gz", filepath.Join(t.TempDir(), "sport.sqlite"), func(string) error { return nil })
+	testutils.AssertErrorIs(t, backup.ErrBackupNotFound, err, "unexpected error")
+}
@@ -0,0 +1,79 @@
+package gpx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+type GeoJSONFeature struct {
+	Type       string          `json:"type"`
+	Geometry   GeoJSONGeometry `json:"geometry"`
+	Properties interface{}     `json:"properties"`
+}
+
+type GeoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// GeoJSONPointProperties are the values recorded at a point of the track. The speed is missing when it can't be
+// computed, such as on the first point, and the heart rate when it wasn't recorded.
+type GeoJSONPointProperties struct {
+	Time      time.Time `json:"time"`
+	Elevation float64   `json:"elevation"`
+	SpeedKmh  *float64  `json:"speed_kmh,omitempty"`
+	HeartRate int       `json:"heart_rate,omitempty"`
+}
+
+// WriteGeoJSONFile returns a GeoJSON feature collection made of a line string of the whole track followed by a point
+// for each point of the track, holding its time, elevation, speed and heart rate
+func (GPX) WriteGeoJSONFile(ctx context.Context, points domain.GPXPoints) (io.Reader, error) {
+	line := make([][3]float64, len(points))
+	features := make([]GeoJSONFeature, 0, len(points)+1)
+	features = append(features, GeoJSONFeature{
+		Type:       "Feature",
+		Geometry:   GeoJSONGeometry{Type: "LineString", Coordinates: line},
+		Properties: struct{}{},
+	})
+
+	for i, point := range points {
+		line[i] = [3]float64{point.Longitude, point.Latitude, point.Elevation}
+		features = append(features, GeoJSONFeature{
+			Type:     "Feature",
+			Geometry: GeoJSONGeometry{Type: "Point", Coordinates: line[i]},
+			Properties: GeoJSONPointProperties{
+				Time:      point.Time,
+				Elevation: point.Elevation,
+				SpeedKmh:  knownSpeed(point.Speed),
+				HeartRate: point.HeartRate,
+			},
+		})
+	}
+
+	content, err := json.Marshal(GeoJSONFeatureCollection{Type: "FeatureCollection", Features: features})
+	if err != nil {
+		return nil, fmt.Errorf("can't build geojson file: %v", err)
+	}
+
+	return bytes.NewReader(content), nil
+}
+
+func knownSpeed(speed float64) *float64 {
+	if math.IsNaN(speed) || math.IsInf(speed, 0) {
+		return nil
+	}
+
+	return &speed
+}
@@ -1,6 +1,7 @@
 package gpx
 
 import (
+	"bytes"
 	"context"
 	"encoding/xml"
 	"fmt"
@@ -42,6 +43,26 @@ func (GPX) CleanGPXFile(ctx context.Context, r io.Reader) (domain.GPXFile, error
 	), nil
 }
 
+// WriteGPXFile returns a GPX file of a single track segment made of the points, as written by CleanGPXFile
+func (GPX) WriteGPXFile(ctx context.Context, points domain.GPXPoints) (io.Reader, error) {
+	segment := TrackSegment{Points: make([]TrackPoint, len(points))}
+	for i := range points {
+		segment.Points[i] = TrackPoint{
+			Time:       points[i].Time,
+			Coordinate: Coordinate{Latitude: points[i].Latitude, Longitude: points[i].Longitude},
+			Elevation:  points[i].Elevation,
+			HeartRate:  points[i].HeartRate,
+		}
+	}
+
+	content, err := xml.Marshal(segment)
+	if err != nil {
+		return nil, fmt.Errorf("can't build gpx file: %v", err)
+	}
+
+	return bytes.NewReader(content), nil
+}
+
 func gpxPointsToDomainPoints(gpxPoints []TrackPoint) []domain.GPXPoint {
 	domainPoints := make([]domain.GPXPoint, len(gpxPoints))
 	for i := range gpxPoints {
@@ -53,6 +74,7 @@ func gpxPointsToDomainPoints(gpxPoints []TrackPoint) []domain.GPXPoint {
 			Distance:  gpxPoints[i].Distance,
 			Elevation: gpxPoints[i].Elevation,
 			Speed:     gpxPoints[i].Speed,
+			HeartRate: gpxPoints[i].HeartRate,
 		}
 	}
 
@@ -0,0 +1,83 @@
+package gpx_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/gpx"
+)
+
+func TestWriteGPXFile(t *testing.T) {
+	file, err := os.Open("testdata/valid.gpx")
+	testutils.RequireNoError(t, err, "can't open test file")
+	defer file.Close()
+
+	cleaned, err := gpx.GPX{}.CleanGPXFile(context.Background(), file)
+	testutils.RequireNoError(t, err, "can't clean gpx file")
+
+	written, err := gpx.GPX{}.WriteGPXFile(context.Background(), cleaned.Points)
+	testutils.RequireNoError(t, err, "can't write gpx file")
+
+	result, err := ioutil.ReadAll(written)
+	testutils.RequireNoError(t, err, "can't read written gpx file")
+
+	expected, err := ioutil.ReadFile("testdata/golden.gpx")
+	testutils.RequireNoError(t, err, "can't load golden file")
+
+	testutils.AssertEqualString(t, strings.TrimSpace(string(expected)), string(result), "unexpected gpx result")
+}
+
+func TestWriteGPXFileHeartRate(t *testing.T) {
+	file, err := os.Open("testdata/heart-rate.gpx")
+	testutils.RequireNoError(t, err, "can't open test file")
+	defer file.Close()
+
+	cleaned, err := gpx.GPX{}.CleanGPXFile(context.Background(), file)
+	testutils.RequireNoError(t, err, "can't clean gpx file")
+
+	written, err := gpx.GPX{}.WriteGPXFile(context.Background(), cleaned.Points)
+	testutils.RequireNoError(t, err, "can't write gpx file")
+
+	reparsed, err := gpx.ParseTrackSegment(written)
+	testutils.RequireNoError(t, err, "can't parse written gpx file")
+
+	testutils.RequireEqualInt(t, 3, len(reparsed.Points), "unexpected number of points")
+	testutils.AssertEqualInt(t, 121, reparsed.Points[0].HeartRate, "unexpected heart rate of first point")
+	testutils.AssertEqualInt(t, 0, reparsed.Points[1].HeartRate, "unexpected heart rate of point without extensions")
+}
+
+func TestWriteGeoJSONFile(t *testing.T) {
+	assertWrittenFile(t, gpx.GPX{}.WriteGeoJSONFile, "testdata/heart-rate.geojson")
+}
+
+func TestWriteKMLFile(t *testing.T) {
+	assertWrittenFile(t, gpx.GPX{}.WriteKMLFile, "testdata/heart-rate.kml")
+}
+
+func assertWrittenFile(t *testing.T, write func(context.Context, domain.GPXPoints) (io.Reader, error), goldenFile string) {
+	t.Helper()
+
+	file, err := os.Open("testdata/heart-rate.gpx")
+	testutils.RequireNoError(t, err, "can't open test file")
+	defer file.Close()
+
+	cleaned, err := gpx.GPX{}.CleanGPXFile(context.Background(), file)
+	testutils.RequireNoError(t, err, "can't clean gpx file")
+
+	written, err := write(context.Background(), cleaned.Points)
+	testutils.RequireNoError(t, err, "can't write file")
+
+	result, err := ioutil.ReadAll(written)
+	testutils.RequireNoError(t, err, "can't read written file")
+
+	expected, err := ioutil.ReadFile(goldenFile)
+	testutils.RequireNoError(t, err, "can't load golden file")
+
+	testutils.AssertEqualString(t, string(expected), string(result), "unexpected file content")
+}
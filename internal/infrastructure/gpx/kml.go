@@ -0,0 +1,54 @@
+package gpx
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+const (
+	kmlNamespace          = "http://www.opengis.net/kml/2.2"
+	kmlExtensionNamespace = "http://www.google.com/kml/ext/2.2"
+)
+
+type XMLKML struct {
+	XMLName            xml.Name        `xml:"kml"`
+	Namespace          string          `xml:"xmlns,attr"`
+	ExtensionNamespace string          `xml:"xmlns:gx,attr"`
+	Placemark          XMLKMLPlacemark `xml:"Document>Placemark"`
+}
+
+type XMLKMLPlacemark struct {
+	Track XMLKMLTrack `xml:"gx:Track"`
+}
+
+// XMLKMLTrack lists the times of the points followed by their coordinates, as "longitude latitude elevation"
+type XMLKMLTrack struct {
+	When  []string `xml:"when"`
+	Coord []string `xml:"gx:coord"`
+}
+
+// WriteKMLFile returns a KML document with a single track made of the points and their time
+func (GPX) WriteKMLFile(ctx context.Context, points domain.GPXPoints) (io.Reader, error) {
+	track := XMLKMLTrack{When: make([]string, len(points)), Coord: make([]string, len(points))}
+	for i, point := range points {
+		track.When[i] = point.Time.UTC().Format(time.RFC3339)
+		track.Coord[i] = fmt.Sprintf("%f %f %f", point.Longitude, point.Latitude, point.Elevation)
+	}
+
+	content, err := xml.Marshal(XMLKML{
+		Namespace:          kmlNamespace,
+		ExtensionNamespace: kmlExtensionNamespace,
+		Placemark:          XMLKMLPlacemark{Track: track},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't build kml file: %v", err)
+	}
+
+	return io.MultiReader(bytes.NewBufferString(xml.Header), bytes.NewReader(content)), nil
+}
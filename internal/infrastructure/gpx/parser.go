@@ -39,6 +39,8 @@ type TrackPoint struct {
 	Distance   float64
 	Elevation  float64
 	Speed      float64
+	// HeartRate is in beats per minute, 0 when it wasn't recorded
+	HeartRate int
 }
 
 // ParseTrackSegment reads a GPX XML file and expect to find a track with one and only one segment.
@@ -80,6 +82,7 @@ func ParseTrackSegment(r io.Reader) (TrackSegment, error) {
 			Time:       trkpts[i].Time,
 			Elevation:  trkpts[i].Elevation,
 			Speed:      math.KilometerPerHour(distance, duration),
+			HeartRate:  trkpts[i].HeartRate(),
 		}
 
 		previousCoordinate = points[i].Coordinate
@@ -99,10 +102,11 @@ func (s TrackSegment) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	segment.Points = make([]XMLTrackPoint, len(s.Points))
 	for i := range s.Points {
 		segment.Points[i] = XMLTrackPoint{
-			Latitude:  s.Points[i].Coordinate.Latitude,
-			Longitude: s.Points[i].Coordinate.Longitude,
-			Time:      s.Points[i].Time,
-			Elevation: s.Points[i].Elevation,
+			Latitude:   s.Points[i].Coordinate.Latitude,
+			Longitude:  s.Points[i].Coordinate.Longitude,
+			Time:       s.Points[i].Time,
+			Elevation:  s.Points[i].Elevation,
+			Extensions: newXMLTrackPointExtensions(s.Points[i].HeartRate),
 		}
 	}
 
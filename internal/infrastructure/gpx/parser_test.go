@@ -26,6 +26,20 @@ func TestParseFile(t *testing.T) {
 	testutils.AssertEqualFloat64(t, 10.03, segment.Speed, "unexpected average speed")
 }
 
+func TestParseFileHeartRate(t *testing.T) {
+	fname := "testdata/heart-rate.gpx"
+	file, err := os.Open(fname)
+	testutils.AssertNoError(t, err, "can't open test file: %v", err)
+
+	segment, err := gpx.ParseTrackSegment(file)
+	testutils.AssertNoError(t, err, "can't parse gpx file (file=%s): %v", fname, err)
+
+	testutils.RequireEqualInt(t, 3, len(segment.Points), "unexpected number of points")
+	testutils.AssertEqualInt(t, 121, segment.Points[0].HeartRate, "unexpected heart rate of first point")
+	testutils.AssertEqualInt(t, 0, segment.Points[1].HeartRate, "unexpected heart rate of point without extensions")
+	testutils.AssertEqualInt(t, 135, segment.Points[2].HeartRate, "unexpected heart rate of last point")
+}
+
 func TestMarshalFile(t *testing.T) {
 	fname := "testdata/valid.gpx"
 	file, err := os.Open(fname)
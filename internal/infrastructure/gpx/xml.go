@@ -15,8 +15,31 @@ type XMLTrackSegment struct {
 }
 
 type XMLTrackPoint struct {
-	Latitude  float64   `xml:"lat,attr"`
-	Longitude float64   `xml:"lon,attr"`
-	Time      time.Time `xml:"time"`
-	Elevation float64   `xml:"ele"`
+	Latitude   float64                  `xml:"lat,attr"`
+	Longitude  float64                  `xml:"lon,attr"`
+	Time       time.Time                `xml:"time"`
+	Elevation  float64                  `xml:"ele"`
+	Extensions *XMLTrackPointExtensions `xml:"extensions,omitempty"`
+}
+
+// XMLTrackPointExtensions holds the values recorded by watches in the Garmin TrackPointExtension schema
+type XMLTrackPointExtensions struct {
+	HeartRate int `xml:"TrackPointExtension>hr"`
+}
+
+// HeartRate returns the heart rate of the point in beats per minute, 0 when it wasn't recorded
+func (p XMLTrackPoint) HeartRate() int {
+	if p.Extensions == nil {
+		return 0
+	}
+
+	return p.Extensions.HeartRate
+}
+
+func newXMLTrackPointExtensions(heartRate int) *XMLTrackPointExtensions {
+	if heartRate == 0 {
+		return nil
+	}
+
+	return &XMLTrackPointExtensions{HeartRate: heartRate}
 }
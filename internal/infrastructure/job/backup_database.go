@@ -0,0 +1,52 @@
+package job
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/golib/job"
+)
+
+const backupDatabaseJobName = "backup-database-job"
+
+// EnqueueBackupDatabaseJob enqueues a new job
+func EnqueueBackupDatabaseJob(client Enqueuer) error {
+	j, err := job.NewJob(backupDatabaseJobName, struct{}{})
+	if err != nil {
+		return fmt.Errorf("can't build a new job (name=%s): %v", backupDatabaseJobName, err)
+	}
+
+	if err := client.Enqueue(j); err != nil {
+		return fmt.Errorf("can't enqueue job (name=%s): %v", backupDatabaseJobName, err)
+	}
+
+	return nil
+}
+
+// DatabaseBackuper represents a service able to take and store a snapshot of the database
+type DatabaseBackuper interface {
+	BackupDatabase(context.Context) (string, error)
+}
+
+// BackupDatabaseJob represents a worker in charge of backing up the database
+type BackupDatabaseJob struct {
+	backuper DatabaseBackuper
+}
+
+// NewBackupDatabaseJob initializes a database backup job handler
+func NewBackupDatabaseJob(backuper DatabaseBackuper) *BackupDatabaseJob {
+	return &BackupDatabaseJob{backuper: backuper}
+}
+
+func (j *BackupDatabaseJob) Name() string {
+	return backupDatabaseJobName
+}
+
+// Handle implements job.Handler
+func (j *BackupDatabaseJob) Handle(ctx context.Context, _ []byte) error {
+	if _, err := j.backuper.BackupDatabase(ctx); err != nil {
+		return fmt.Errorf("can't backup database: %v", err)
+	}
+
+	return nil
+}
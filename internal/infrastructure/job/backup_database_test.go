@@ -0,0 +1,47 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+)
+
+func TestBackupDatabaseHandleFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backuper := jobtest.NewMockDatabaseBackuper(ctrl)
+
+	backuper.EXPECT().BackupDatabase(gomock.Any()).Return("", errors.New("boom"))
+
+	err := job.NewBackupDatabaseJob(backuper).Handle(context.Background(), []byte(`{}`))
+
+	testutils.AssertErrorContains(t, "can't backup database", err, "unexpected error")
+}
+
+func TestBackupDatabaseHandleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backuper := jobtest.NewMockDatabaseBackuper(ctrl)
+
+	backuper.EXPECT().BackupDatabase(gomock.Any()).Return("backups/sqlite/20220410T123000Z.sqlite.gz", nil)
+
+	err := job.NewBackupDatabaseJob(backuper).Handle(context.Background(), []byte(`{}`))
+
+	testutils.AssertNoError(t, err, "unexpected error")
+}
+
+func TestEnqueueBackupDatabaseJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+
+	enqueuer.EXPECT().
+		Enqueue(jobtest.NewJobMatcher("backup-database-job", map[string]interface{}{}, func(interface{}) bool { return true })).
+		Return(nil)
+
+	err := job.EnqueueBackupDatabaseJob(enqueuer)
+
+	testutils.AssertNoError(t, err, "unexpected error")
+}
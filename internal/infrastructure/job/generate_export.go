@@ -0,0 +1,68 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonepeon/golib/job"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+const generateExportJobName = "generate-export-job"
+
+func EnqueueGenerateExportJob(client Enqueuer, input GenerateExportJobInput) error {
+	j, err := job.NewJob(generateExportJobName, input)
+	if err != nil {
+		return fmt.Errorf("can't build a new job (name=%s): %v", generateExportJobName, err)
+	}
+
+	if err := client.Enqueue(j); err != nil {
+		return fmt.Errorf("can't enqueue job (name=%s): %v", generateExportJobName, err)
+	}
+
+	return nil
+}
+
+type GenerateExportJobInput struct {
+	ID string `json:"id"`
+	// Username is the requester, whose preferences are used to format the manifests
+	Username string `json:"username,omitempty"`
+}
+
+// GenerateExportJob represents a worker in charge of building export archives
+type GenerateExportJob struct {
+	application application.Application
+}
+
+func NewGenerateExportJob(app application.Application) *GenerateExportJob {
+	return &GenerateExportJob{application: app}
+}
+
+func (j *GenerateExportJob) Name() string {
+	return generateExportJobName
+}
+
+func (j *GenerateExportJob) Handle(ctx context.Context, payload []byte) error {
+	var input GenerateExportJobInput
+	if err := json.Unmarshal(payload, &input); err != nil {
+		return fmt.Errorf("can't parse input: %v", err)
+	}
+
+	id, err := domain.ParseID(input.ID)
+	if err != nil {
+		return fmt.Errorf("can't parse export id: %v", err)
+	}
+
+	prefs, err := j.application.GetUserPreferences(ctx, input.Username)
+	if err != nil {
+		return fmt.Errorf("can't get user preferences: %v", err)
+	}
+
+	if err := j.application.GenerateExport(ctx, id, prefs); err != nil {
+		return fmt.Errorf("can't generate export: %v", err)
+	}
+
+	return nil
+}
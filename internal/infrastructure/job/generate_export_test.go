@@ -0,0 +1,80 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+)
+
+func TestGenerateExportHandleInvalidPayload(t *testing.T) {
+	err := job.NewGenerateExportJob(nil).
+		Handle(context.Background(), []byte(`{this is not a json}`))
+
+	testutils.AssertErrorContains(t, "can't parse input", err, "unexpected error")
+}
+
+func TestGenerateExportHandleInvalidID(t *testing.T) {
+	err := job.NewGenerateExportJob(nil).
+		Handle(context.Background(), []byte(`{"id": "invalid id"}`))
+
+	testutils.AssertErrorContains(t, "can't parse export id", err, "unexpected error")
+}
+
+func TestGenerateExportHandleFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+	id := domain.NewID()
+
+	application.EXPECT().
+		GetUserPreferences(gomock.Any(), gomock.Eq("")).
+		Return(domain.DefaultUserPreferences(), nil)
+	application.EXPECT().
+		GenerateExport(gomock.Any(), gomock.Eq(id), gomock.Any()).
+		Return(errors.New("boom"))
+
+	err := job.NewGenerateExportJob(application).
+		Handle(context.Background(), []byte(fmt.Sprintf(`{"id": "%s"}`, id)))
+
+	testutils.AssertErrorContains(t, "can't generate export", err, "unexpected error")
+}
+
+func TestGenerateExportHandlePreferencesFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+	id := domain.NewID()
+
+	application.EXPECT().
+		GetUserPreferences(gomock.Any(), gomock.Eq("alice")).
+		Return(domain.UserPreferences{}, errors.New("boom"))
+
+	err := job.NewGenerateExportJob(application).
+		Handle(context.Background(), []byte(fmt.Sprintf(`{"id": "%s", "username": "alice"}`, id)))
+
+	testutils.AssertErrorContains(t, "can't get user preferences", err, "unexpected error")
+}
+
+func TestGenerateExportHandleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+	id := domain.NewID()
+	prefs := domain.UserPreferences{Locale: domain.LocaleFrench, Units: domain.UnitSystemMetric, SpeedDisplay: domain.SpeedDisplayPace}
+
+	application.EXPECT().
+		GetUserPreferences(gomock.Any(), gomock.Eq("alice")).
+		Return(prefs, nil)
+	application.EXPECT().
+		GenerateExport(gomock.Any(), gomock.Eq(id), gomock.Eq(prefs)).
+		Return(nil)
+
+	err := job.NewGenerateExportJob(application).
+		Handle(context.Background(), []byte(fmt.Sprintf(`{"id": "%s", "username": "alice"}`, id)))
+
+	testutils.AssertNoError(t, err, "unexpected error")
+}
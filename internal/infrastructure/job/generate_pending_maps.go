@@ -0,0 +1,48 @@
+package job
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonepeon/golib/job"
+	"github.com/lonepeon/sport/internal/application"
+)
+
+const generatePendingMapsJobName = "generate-pending-maps-job"
+
+// EnqueueGeneratePendingMapsJob enqueues a new job
+func EnqueueGeneratePendingMapsJob(client Enqueuer) error {
+	j, err := job.NewJob(generatePendingMapsJobName, struct{}{})
+	if err != nil {
+		return fmt.Errorf("can't build a new job (name=%s): %v", generatePendingMapsJobName, err)
+	}
+
+	if err := client.Enqueue(j); err != nil {
+		return fmt.Errorf("can't enqueue job (name=%s): %v", generatePendingMapsJobName, err)
+	}
+
+	return nil
+}
+
+// GeneratePendingMapsJob represents a worker in charge of generating the maps of activities recorded without them
+type GeneratePendingMapsJob struct {
+	application application.Application
+}
+
+// NewGeneratePendingMapsJob initializes a pending maps job handler
+func NewGeneratePendingMapsJob(app application.Application) *GeneratePendingMapsJob {
+	return &GeneratePendingMapsJob{application: app}
+}
+
+func (j *GeneratePendingMapsJob) Name() string {
+	return generatePendingMapsJobName
+}
+
+// Handle implements job.Handler
+func (j *GeneratePendingMapsJob) Handle(ctx context.Context, _ []byte) error {
+	if err := j.application.GeneratePendingMaps(ctx); err != nil {
+		return fmt.Errorf("can't generate pending maps: %v", err)
+	}
+
+	return nil
+}
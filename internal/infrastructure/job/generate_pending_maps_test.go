@@ -0,0 +1,48 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+)
+
+func TestGeneratePendingMapsHandleFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+
+	application.EXPECT().GeneratePendingMaps(gomock.Any()).Return(errors.New("boom"))
+
+	err := job.NewGeneratePendingMapsJob(application).Handle(context.Background(), []byte(`{}`))
+
+	testutils.AssertErrorContains(t, "can't generate pending maps", err, "unexpected error")
+}
+
+func TestGeneratePendingMapsHandleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+
+	application.EXPECT().GeneratePendingMaps(gomock.Any()).Return(nil)
+
+	err := job.NewGeneratePendingMapsJob(application).Handle(context.Background(), []byte(`{}`))
+
+	testutils.AssertNoError(t, err, "unexpected error")
+}
+
+func TestEnqueueGeneratePendingMapsJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+
+	enqueuer.EXPECT().
+		Enqueue(jobtest.NewJobMatcher("generate-pending-maps-job", map[string]interface{}{}, func(interface{}) bool { return true })).
+		Return(nil)
+
+	err := job.EnqueueGeneratePendingMapsJob(enqueuer)
+
+	testutils.AssertNoError(t, err, "unexpected error")
+}
@@ -0,0 +1,62 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonepeon/golib/job"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+const importActivityJobName = "import-activity-job"
+
+func EnqueueImportActivityJob(client Enqueuer, input ImportActivityJobInput) error {
+	j, err := job.NewJob(importActivityJobName, input)
+	if err != nil {
+		return fmt.Errorf("can't build a new job (name=%s): %v", importActivityJobName, err)
+	}
+
+	if err := client.Enqueue(j); err != nil {
+		return fmt.Errorf("can't enqueue job (name=%s): %v", importActivityJobName, err)
+	}
+
+	return nil
+}
+
+type ImportActivityJobInput struct {
+	ImportID   string `json:"import_id"`
+	ExternalID string `json:"external_id"`
+}
+
+// ImportActivityJob represents a worker in charge of recording one activity of an import
+type ImportActivityJob struct {
+	application application.Application
+}
+
+func NewImportActivityJob(app application.Application) *ImportActivityJob {
+	return &ImportActivityJob{application: app}
+}
+
+func (j *ImportActivityJob) Name() string {
+	return importActivityJobName
+}
+
+func (j *ImportActivityJob) Handle(ctx context.Context, payload []byte) error {
+	var input ImportActivityJobInput
+	if err := json.Unmarshal(payload, &input); err != nil {
+		return fmt.Errorf("can't parse input: %v", err)
+	}
+
+	id, err := domain.ParseID(input.ImportID)
+	if err != nil {
+		return fmt.Errorf("can't parse import id: %v", err)
+	}
+
+	if err := j.application.ImportActivity(ctx, id, input.ExternalID); err != nil {
+		return fmt.Errorf("can't import activity: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+)
+
+func TestImportActivityHandleInvalidPayload(t *testing.T) {
+	err := job.NewImportActivityJob(nil).
+		Handle(context.Background(), []byte(`{this is not a json}`))
+
+	testutils.AssertErrorContains(t, "can't parse input", err, "unexpected error")
+}
+
+func TestImportActivityHandleInvalidID(t *testing.T) {
+	err := job.NewImportActivityJob(nil).
+		Handle(context.Background(), []byte(`{"import_id": "invalid id", "external_id": "1001"}`))
+
+	testutils.AssertErrorContains(t, "can't parse import id", err, "unexpected error")
+}
+
+func TestImportActivityHandleFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+	id := domain.NewID()
+
+	application.EXPECT().
+		ImportActivity(gomock.Any(), gomock.Eq(id), "1001").
+		Return(errors.New("boom"))
+
+	err := job.NewImportActivityJob(application).
+		Handle(context.Background(), []byte(fmt.Sprintf(`{"import_id": "%s", "external_id": "1001"}`, id)))
+
+	testutils.AssertErrorContains(t, "can't import activity", err, "unexpected error")
+}
+
+func TestImportActivityHandleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+	id := domain.NewID()
+
+	application.EXPECT().
+		ImportActivity(gomock.Any(), gomock.Eq(id), "1001").
+		Return(nil)
+
+	err := job.NewImportActivityJob(application).
+		Handle(context.Background(), []byte(fmt.Sprintf(`{"import_id": "%s", "external_id": "1001"}`, id)))
+
+	testutils.AssertNoError(t, err, "unexpected error")
+}
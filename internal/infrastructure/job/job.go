@@ -6,3 +6,8 @@ import "github.com/lonepeon/golib/job"
 type Enqueuer interface {
 	Enqueue(job.Job) error
 }
+
+// Logging represents a logger reporting what the jobs skip
+type Logging interface {
+	Infof(string, ...interface{})
+}
@@ -1,10 +1,11 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/lonepeon/sport/internal/infrastructure/job (interfaces: Enqueuer)
+// Source: github.com/lonepeon/sport/internal/infrastructure/job (interfaces: Enqueuer,DatabaseBackuper,Logging)
 
 // Package jobtest is a generated GoMock package.
 package jobtest
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -47,3 +48,81 @@ func (mr *MockEnqueuerMockRecorder) Enqueue(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockEnqueuer)(nil).Enqueue), arg0)
 }
+
+// MockDatabaseBackuper is a mock of DatabaseBackuper interface.
+type MockDatabaseBackuper struct {
+	ctrl     *gomock.Controller
+	recorder *MockDatabaseBackuperMockRecorder
+}
+
+// MockDatabaseBackuperMockRecorder is the mock recorder for MockDatabaseBackuper.
+type MockDatabaseBackuperMockRecorder struct {
+	mock *MockDatabaseBackuper
+}
+
+// NewMockDatabaseBackuper creates a new mock instance.
+func NewMockDatabaseBackuper(ctrl *gomock.Controller) *MockDatabaseBackuper {
+	mock := &MockDatabaseBackuper{ctrl: ctrl}
+	mock.recorder = &MockDatabaseBackuperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDatabaseBackuper) EXPECT() *MockDatabaseBackuperMockRecorder {
+	return m.recorder
+}
+
+// BackupDatabase mocks base method.
+func (m *MockDatabaseBackuper) BackupDatabase(arg0 context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackupDatabase", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackupDatabase indicates an expected call of BackupDatabase.
+func (mr *MockDatabaseBackuperMockRecorder) BackupDatabase(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupDatabase", reflect.TypeOf((*MockDatabaseBackuper)(nil).BackupDatabase), arg0)
+}
+
+// MockLogging is a mock of Logging interface.
+type MockLogging struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoggingMockRecorder
+}
+
+// MockLoggingMockRecorder is the mock recorder for MockLogging.
+type MockLoggingMockRecorder struct {
+	mock *MockLogging
+}
+
+// NewMockLogging creates a new mock instance.
+func NewMockLogging(ctrl *gomock.Controller) *MockLogging {
+	mock := &MockLogging{ctrl: ctrl}
+	mock.recorder = &MockLoggingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogging) EXPECT() *MockLoggingMockRecorder {
+	return m.recorder
+}
+
+// Infof mocks base method.
+func (m *MockLogging) Infof(arg0 string, arg1 ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Infof", varargs...)
+}
+
+// Infof indicates an expected call of Infof.
+func (mr *MockLoggingMockRecorder) Infof(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Infof", reflect.TypeOf((*MockLogging)(nil).Infof), varargs...)
+}
@@ -0,0 +1,70 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonepeon/golib/job"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+const prepareImportJobName = "prepare-import-job"
+
+func EnqueuePrepareImportJob(client Enqueuer, input PrepareImportJobInput) error {
+	j, err := job.NewJob(prepareImportJobName, input)
+	if err != nil {
+		return fmt.Errorf("can't build a new job (name=%s): %v", prepareImportJobName, err)
+	}
+
+	if err := client.Enqueue(j); err != nil {
+		return fmt.Errorf("can't enqueue job (name=%s): %v", prepareImportJobName, err)
+	}
+
+	return nil
+}
+
+type PrepareImportJobInput struct {
+	ID string `json:"id"`
+}
+
+// PrepareImportJob represents a worker in charge of extracting import archives and enqueuing one job per activity
+type PrepareImportJob struct {
+	application application.Application
+	client      Enqueuer
+}
+
+func NewPrepareImportJob(app application.Application, client Enqueuer) *PrepareImportJob {
+	return &PrepareImportJob{application: app, client: client}
+}
+
+func (j *PrepareImportJob) Name() string {
+	return prepareImportJobName
+}
+
+func (j *PrepareImportJob) Handle(ctx context.Context, payload []byte) error {
+	var input PrepareImportJobInput
+	if err := json.Unmarshal(payload, &input); err != nil {
+		return fmt.Errorf("can't parse input: %v", err)
+	}
+
+	id, err := domain.ParseID(input.ID)
+	if err != nil {
+		return fmt.Errorf("can't parse import id: %v", err)
+	}
+
+	items, err := j.application.PrepareImport(ctx, id)
+	if err != nil {
+		return fmt.Errorf("can't prepare import: %v", err)
+	}
+
+	for _, item := range items {
+		err := EnqueueImportActivityJob(j.client, ImportActivityJobInput{ImportID: input.ID, ExternalID: item.ExternalID})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
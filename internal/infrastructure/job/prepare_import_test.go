@@ -0,0 +1,73 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+)
+
+func TestPrepareImportHandleInvalidPayload(t *testing.T) {
+	err := job.NewPrepareImportJob(nil, nil).
+		Handle(context.Background(), []byte(`{this is not a json}`))
+
+	testutils.AssertErrorContains(t, "can't parse input", err, "unexpected error")
+}
+
+func TestPrepareImportHandleInvalidID(t *testing.T) {
+	err := job.NewPrepareImportJob(nil, nil).
+		Handle(context.Background(), []byte(`{"id": "invalid id"}`))
+
+	testutils.AssertErrorContains(t, "can't parse import id", err, "unexpected error")
+}
+
+func TestPrepareImportHandleFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+	id := domain.NewID()
+
+	application.EXPECT().
+		PrepareImport(gomock.Any(), gomock.Eq(id)).
+		Return(nil, errors.New("boom"))
+
+	err := job.NewPrepareImportJob(application, nil).
+		Handle(context.Background(), []byte(fmt.Sprintf(`{"id": "%s"}`, id)))
+
+	testutils.AssertErrorContains(t, "can't prepare import", err, "unexpected error")
+}
+
+func TestPrepareImportHandleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+	client := jobtest.NewMockEnqueuer(ctrl)
+	id := domain.NewID()
+
+	application.EXPECT().
+		PrepareImport(gomock.Any(), gomock.Eq(id)).
+		Return([]domain.ImportItem{{ImportID: id, ExternalID: "1001"}, {ImportID: id, ExternalID: "1002"}}, nil)
+
+	for _, externalID := range []string{"1001", "1002"} {
+		externalID := externalID
+		client.EXPECT().Enqueue(jobtest.NewJobMatcher(
+			"import-activity-job",
+			&job.ImportActivityJobInput{},
+			func(arg interface{}) bool {
+				input := arg.(*job.ImportActivityJobInput)
+
+				return input.ImportID == id.String() && input.ExternalID == externalID
+			},
+		)).Return(nil)
+	}
+
+	err := job.NewPrepareImportJob(application, client).
+		Handle(context.Background(), []byte(fmt.Sprintf(`{"id": "%s"}`, id)))
+
+	testutils.AssertNoError(t, err, "unexpected error")
+}
@@ -0,0 +1,88 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonepeon/golib/job"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+const regeneratePrivacyZoneActivitiesJobName = "regenerate-privacy-zone-activities-job"
+
+func EnqueueRegeneratePrivacyZoneActivitiesJob(client Enqueuer, input RegeneratePrivacyZoneActivitiesJobInput) error {
+	j, err := job.NewJob(regeneratePrivacyZoneActivitiesJobName, input)
+	if err != nil {
+		return fmt.Errorf("can't build a new job (name=%s): %v", regeneratePrivacyZoneActivitiesJobName, err)
+	}
+
+	if err := client.Enqueue(j); err != nil {
+		return fmt.Errorf("can't enqueue job (name=%s): %v", regeneratePrivacyZoneActivitiesJobName, err)
+	}
+
+	return nil
+}
+
+// RegeneratePrivacyZoneActivitiesJobInput describes a zone which was created or deleted. The zone itself may not exist
+// anymore so its circle is copied.
+type RegeneratePrivacyZoneActivitiesJobInput struct {
+	Username     string  `json:"username"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	RadiusMeters int     `json:"radius_meters"`
+}
+
+// NewRegeneratePrivacyZoneActivitiesJobInput returns the input of the job regenerating the activities crossing the zone
+func NewRegeneratePrivacyZoneActivitiesJobInput(zone domain.PrivacyZone) RegeneratePrivacyZoneActivitiesJobInput {
+	return RegeneratePrivacyZoneActivitiesJobInput{
+		Username:     zone.Username,
+		Latitude:     zone.Latitude,
+		Longitude:    zone.Longitude,
+		RadiusMeters: zone.RadiusMeters,
+	}
+}
+
+// RegeneratePrivacyZoneActivitiesJob represents a worker in charge of finding the activities crossing a privacy zone
+// and enqueuing one regeneration job per activity, whose cards are drawn with the preferences of their owner
+type RegeneratePrivacyZoneActivitiesJob struct {
+	application application.Application
+	client      Enqueuer
+}
+
+func NewRegeneratePrivacyZoneActivitiesJob(app application.Application, client Enqueuer) *RegeneratePrivacyZoneActivitiesJob {
+	return &RegeneratePrivacyZoneActivitiesJob{application: app, client: client}
+}
+
+func (j *RegeneratePrivacyZoneActivitiesJob) Name() string {
+	return regeneratePrivacyZoneActivitiesJobName
+}
+
+func (j *RegeneratePrivacyZoneActivitiesJob) Handle(ctx context.Context, payload []byte) error {
+	var input RegeneratePrivacyZoneActivitiesJobInput
+	if err := json.Unmarshal(payload, &input); err != nil {
+		return fmt.Errorf("can't parse input: %v", err)
+	}
+
+	zone := domain.PrivacyZone{
+		Username:     input.Username,
+		Latitude:     input.Latitude,
+		Longitude:    input.Longitude,
+		RadiusMeters: input.RadiusMeters,
+	}
+
+	activities, err := j.application.ListPrivacyZoneRunningSessions(ctx, zone)
+	if err != nil {
+		return fmt.Errorf("can't list activities crossing the privacy zone: %v", err)
+	}
+
+	for _, activity := range activities {
+		err := EnqueueRegenerateRunningSessionJob(j.client, RegenerateRunningSessionJobInput{Slug: activity.Slug.String()})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+)
+
+func TestRegeneratePrivacyZoneActivitiesHandleInvalidPayload(t *testing.T) {
+	err := job.NewRegeneratePrivacyZoneActivitiesJob(nil, nil).
+		Handle(context.Background(), []byte(`{this is not a json}`))
+
+	testutils.AssertErrorContains(t, "can't parse input", err, "unexpected error")
+}
+
+func TestRegeneratePrivacyZoneActivitiesHandleFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+
+	application.EXPECT().
+		ListPrivacyZoneRunningSessions(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("boom"))
+
+	err := job.NewRegeneratePrivacyZoneActivitiesJob(application, nil).
+		Handle(context.Background(), []byte(`{"username": "alice", "latitude": 48.8566, "longitude": 2.3522, "radius_meters": 200}`))
+
+	testutils.AssertErrorContains(t, "can't list activities crossing the privacy zone", err, "unexpected error")
+}
+
+func TestRegeneratePrivacyZoneActivitiesHandleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+	client := jobtest.NewMockEnqueuer(ctrl)
+	activity := domaintest.NewRunningActivity(t).WithUsername("alice").Build()
+
+	application.EXPECT().
+		ListPrivacyZoneRunningSessions(gomock.Any(), gomock.Eq(domain.PrivacyZone{Username: "alice", Latitude: 48.8566, Longitude: 2.3522, RadiusMeters: 200})).
+		Return([]domain.RunningActivity{activity}, nil)
+
+	client.EXPECT().Enqueue(jobtest.NewJobMatcher(
+		"regenerate-running-session-job",
+		&job.RegenerateRunningSessionJobInput{},
+		func(arg interface{}) bool {
+			input := arg.(*job.RegenerateRunningSessionJobInput)
+
+			return input.Slug == activity.Slug.String()
+		},
+	)).Return(nil)
+
+	err := job.NewRegeneratePrivacyZoneActivitiesJob(application, client).
+		Handle(context.Background(), []byte(`{"username": "alice", "latitude": 48.8566, "longitude": 2.3522, "radius_meters": 200}`))
+
+	testutils.AssertNoError(t, err, "unexpected error")
+}
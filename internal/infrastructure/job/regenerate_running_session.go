@@ -0,0 +1,60 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonepeon/golib/job"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+const regenerateRunningSessionJobName = "regenerate-running-session-job"
+
+func EnqueueRegenerateRunningSessionJob(client Enqueuer, input RegenerateRunningSessionJobInput) error {
+	j, err := job.NewJob(regenerateRunningSessionJobName, input)
+	if err != nil {
+		return fmt.Errorf("can't build a new job (name=%s): %v", regenerateRunningSessionJobName, err)
+	}
+
+	if err := client.Enqueue(j); err != nil {
+		return fmt.Errorf("can't enqueue job (name=%s): %v", regenerateRunningSessionJobName, err)
+	}
+
+	return nil
+}
+
+type RegenerateRunningSessionJobInput struct {
+	Slug string `json:"slug"`
+}
+
+type RegenerateRunningSessionJob struct {
+	application application.Application
+}
+
+func NewRegenerateRunningSessionJob(app application.Application) *RegenerateRunningSessionJob {
+	return &RegenerateRunningSessionJob{application: app}
+}
+
+func (j *RegenerateRunningSessionJob) Name() string {
+	return regenerateRunningSessionJobName
+}
+
+func (j *RegenerateRunningSessionJob) Handle(ctx context.Context, payload []byte) error {
+	var input RegenerateRunningSessionJobInput
+	if err := json.Unmarshal(payload, &input); err != nil {
+		return fmt.Errorf("can't parse input: %v", err)
+	}
+
+	slug, err := domain.NewRunnningActivitySlugFromString(input.Slug)
+	if err != nil {
+		return fmt.Errorf("can't parse slug: %v", err)
+	}
+
+	if err := j.application.RegenerateRunningSession(ctx, slug); err != nil {
+		return fmt.Errorf("can't regenerate running activity: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+)
+
+func TestRegenerateRunningSessionHandleInvalidPayload(t *testing.T) {
+	err := job.NewRegenerateRunningSessionJob(nil).
+		Handle(context.Background(), []byte(`{this is not a json}`))
+
+	testutils.AssertErrorContains(t, "can't parse input", err, "unexpected error")
+}
+
+func TestRegenerateRunningSessionHandleInvalidSlug(t *testing.T) {
+	err := job.NewRegenerateRunningSessionJob(nil).
+		Handle(context.Background(), []byte(`{"slug": "invalid slug"}`))
+
+	testutils.AssertErrorContains(t, "can't parse slug", err, "unexpected error")
+}
+
+func TestRegenerateRunningSessionHandleFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+
+	application.EXPECT().
+		RegenerateRunningSession(gomock.Any(), domaintest.MatchRunningActivitySlug("202202231558")).
+		Return(errors.New("boom"))
+
+	err := job.NewRegenerateRunningSessionJob(application).
+		Handle(context.Background(), []byte(`{"slug": "202202231558"}`))
+
+	testutils.AssertErrorContains(t, "can't regenerate", err, "unexpected error")
+}
+
+func TestRegenerateRunningSessionHandleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+
+	application.EXPECT().
+		RegenerateRunningSession(gomock.Any(), domaintest.MatchRunningActivitySlug("202202231558")).
+		Return(nil)
+
+	err := job.NewRegenerateRunningSessionJob(application).
+		Handle(context.Background(), []byte(`{"slug": "202202231558"}`))
+
+	testutils.AssertNoError(t, err, "unexpected error")
+}
@@ -3,12 +3,14 @@ package job
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/lonepeon/golib/job"
 	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
 )
 
 // TrackRunningSessionJobName is the name of the TrackRunningSessionJob
@@ -30,25 +32,34 @@ func EnqueueTrackRunningSessionJob(client Enqueuer, input TrackRunningSessionJob
 
 // TrackRunningSessionJobInput represents a job input
 type TrackRunningSessionJobInput struct {
-	When        time.Time `json:"when"`
-	GPXFilepath string    `json:"filepath"`
+	When        time.Time           `json:"when"`
+	GPXFilepath string              `json:"filepath"`
+	Title       string              `json:"title,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Type        domain.ActivityType `json:"type,omitempty"`
+	// Visibility is empty for jobs enqueued before activities had one, which are public
+	Visibility domain.ActivityVisibility `json:"visibility,omitempty"`
+	// Username is the uploader, who owns the activity and whose preferences are used to draw the cards
+	Username string `json:"username,omitempty"`
 }
 
 // TrackRunningSessionJob represent a tracker worker in charge of parsing and storing a running session
 type TrackRunningSessionJob struct {
 	application application.Application
+	log         Logging
 }
 
 // NewTrackRunningSessionJob initializes a running session job handler
-func NewTrackRunningSessionJob(app application.Application) *TrackRunningSessionJob {
-	return &TrackRunningSessionJob{application: app}
+func NewTrackRunningSessionJob(app application.Application, log Logging) *TrackRunningSessionJob {
+	return &TrackRunningSessionJob{application: app, log: log}
 }
 
 func (j *TrackRunningSessionJob) Name() string {
 	return trackRunningSessionJobName
 }
 
-// Handle implements job.Handler
+// Handle implements job.Handler. The GPX file is removed once the activity is recorded, or when an activity already
+// starts at the same minute since retrying can't succeed.
 func (j *TrackRunningSessionJob) Handle(ctx context.Context, payload []byte) error {
 	var input TrackRunningSessionJobInput
 	if err := json.Unmarshal(payload, &input); err != nil {
@@ -61,9 +72,28 @@ func (j *TrackRunningSessionJob) Handle(ctx context.Context, payload []byte) err
 	}
 	defer f.Close()
 
-	if err := j.application.TrackRunningSession(ctx, input.When, f); err != nil {
+	prefs, err := j.application.GetUserPreferences(ctx, input.Username)
+	if err != nil {
+		return fmt.Errorf("can't get user preferences: %v", err)
+	}
+
+	details := domain.RunningActivityDetails{
+		Title:       input.Title,
+		Description: input.Description,
+		Type:        input.Type,
+		Visibility:  input.Visibility,
+	}
+
+	err = j.application.TrackRunningSession(ctx, input.Username, input.When, details, prefs, f)
+	if errors.Is(err, domain.ErrRunningActivityAlreadyExists) {
+		j.log.Infof("skipping running session (path=%s): %v", input.GPXFilepath, err)
+	} else if err != nil {
 		return fmt.Errorf("can'track running session: %v", err)
 	}
 
+	if err := os.Remove(input.GPXFilepath); err != nil {
+		j.log.Infof("can't remove gpx file (path=%s): %v", input.GPXFilepath, err)
+	}
+
 	return nil
 }
@@ -0,0 +1,107 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+)
+
+func TestTrackRunningSessionHandleInvalidPayload(t *testing.T) {
+	err := job.NewTrackRunningSessionJob(nil, nil).
+		Handle(context.Background(), []byte(`{this is not a json}`))
+
+	testutils.AssertErrorContains(t, "can't parse input", err, "unexpected error")
+}
+
+func TestTrackRunningSessionHandleFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+
+	application.EXPECT().
+		GetUserPreferences(gomock.Any(), gomock.Any()).
+		Return(domain.DefaultUserPreferences(), nil)
+	application.EXPECT().
+		TrackRunningSession(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errors.New("boom"))
+
+	_, payload := trackRunningSessionPayload(t)
+	err := job.NewTrackRunningSessionJob(application, jobtest.NewMockLogging(ctrl)).
+		Handle(context.Background(), payload)
+
+	testutils.AssertErrorContains(t, "can'track running session", err, "unexpected error")
+}
+
+func TestTrackRunningSessionHandlePreferencesFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+
+	application.EXPECT().
+		GetUserPreferences(gomock.Any(), gomock.Any()).
+		Return(domain.UserPreferences{}, errors.New("boom"))
+
+	_, payload := trackRunningSessionPayload(t)
+	err := job.NewTrackRunningSessionJob(application, jobtest.NewMockLogging(ctrl)).
+		Handle(context.Background(), payload)
+
+	testutils.AssertErrorContains(t, "can't get user preferences", err, "unexpected error")
+}
+
+func TestTrackRunningSessionHandleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+	prefs := domain.UserPreferences{Locale: domain.LocaleFrench, Units: domain.UnitSystemImperial, SpeedDisplay: domain.SpeedDisplayPace}
+
+	application.EXPECT().
+		GetUserPreferences(gomock.Any(), gomock.Eq("alice")).
+		Return(prefs, nil)
+	application.EXPECT().
+		TrackRunningSession(gomock.Any(), gomock.Eq("alice"), gomock.Any(), gomock.Any(), gomock.Eq(prefs), gomock.Any()).
+		Return(nil)
+
+	path, payload := trackRunningSessionPayload(t)
+	err := job.NewTrackRunningSessionJob(application, jobtest.NewMockLogging(ctrl)).
+		Handle(context.Background(), payload)
+
+	testutils.AssertNoError(t, err, "unexpected error")
+	_, err = os.Stat(path)
+	testutils.AssertErrorIs(t, os.ErrNotExist, err, "gpx file should have been removed")
+}
+
+func TestTrackRunningSessionHandleAlreadyExisting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+	log := jobtest.NewMockLogging(ctrl)
+
+	application.EXPECT().
+		GetUserPreferences(gomock.Any(), gomock.Any()).
+		Return(domain.DefaultUserPreferences(), nil)
+	application.EXPECT().
+		TrackRunningSession(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(fmt.Errorf("can't record activity: %w", domain.ErrRunningActivityAlreadyExists))
+	log.EXPECT().Infof(gomock.Any(), gomock.Any())
+
+	path, payload := trackRunningSessionPayload(t)
+	err := job.NewTrackRunningSessionJob(application, log).
+		Handle(context.Background(), payload)
+
+	testutils.AssertNoError(t, err, "job shouldn't be retried")
+	_, err = os.Stat(path)
+	testutils.AssertErrorIs(t, os.ErrNotExist, err, "gpx file should have been removed")
+}
+
+func trackRunningSessionPayload(t *testing.T) (string, []byte) {
+	path := filepath.Join(t.TempDir(), "run.gpx")
+	testutils.AssertNoError(t, os.WriteFile(path, []byte("<gpx></gpx>"), 0600), "can't write gpx file")
+
+	return path, []byte(fmt.Sprintf(`{"when": "2022-04-20T09:00:00Z", "filepath": %q, "username": "alice"}`, path))
+}
@@ -0,0 +1,53 @@
+package mapbox
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrGeneric      = errors.New("something wrong happened")
+	ErrURLTooLong   = errors.New("url is too long")
+	ErrRateLimited  = errors.New("too many requests")
+	ErrUnavailable  = errors.New("service unavailable")
+)
+
+// Error is returned by every failed map generation. It matches domain.ErrMapProviderUnavailable when the request may
+// succeed later and domain.ErrMapRejected otherwise.
+type Error struct {
+	// StatusCode is the HTTP status of the response, 0 when no response was received
+	StatusCode int
+	// RetryAfter is the delay asked by the API before the next request, 0 when none was given
+	RetryAfter time.Duration
+	Kind       error
+	Details    string
+}
+
+func (e *Error) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("%v: %s", e.Kind, e.Details)
+	}
+
+	return fmt.Sprintf("%v (status=%d): %s", e.Kind, e.StatusCode, e.Details)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// Temporary reports whether the same request may succeed later
+func (e *Error) Temporary() bool {
+	return e.Kind == ErrRateLimited || e.Kind == ErrUnavailable
+}
+
+func (e *Error) Is(target error) bool {
+	if e.Temporary() {
+		return target == domain.ErrMapProviderUnavailable
+	}
+
+	return target == domain.ErrMapRejected
+}
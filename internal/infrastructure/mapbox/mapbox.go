@@ -1,98 +1,208 @@
 package mapbox
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
-	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/lonepeon/sport/internal/domain"
 )
 
-var (
-	ErrInvalidToken = errors.New("invalid token")
-	ErrGeneric      = errors.New("something wrong happened")
+const (
+	// MaxURLLength is the longest URL accepted by the static images API
+	MaxURLLength = 8192
+
+	// simplificationStartTolerance is the distance, in meters, under which points are first dropped when a track
+	// doesn't fit in the URL. It grows by simplificationToleranceGrowth until the track fits.
+	simplificationStartTolerance  = 0.5
+	simplificationToleranceGrowth = 1.5
 )
 
 type Mapbox struct {
-	HTTPClient    *http.Client
-	EndpointURL   string
+	HTTPClient  *http.Client
+	EndpointURL string
+	// RequestTimeout bounds each request sent to the API
+	RequestTimeout time.Duration
+	// MaxAttempts is the number of requests sent before giving up on rate limits, server errors and timeouts
+	MaxAttempts int
+	// RetryDelay is the delay before the first retry. It doubles with each attempt, with some jitter.
+	RetryDelay time.Duration
+	// MaxRetryDelay caps the delay between two attempts, including the one asked by a Retry-After header
+	MaxRetryDelay time.Duration
 	token         string
-	theme         string
-	size          string
-	lineColor     string
-	lineThinkness int
-	lineOpacity   float64
-	padding       int
 }
 
 func New(token string) *Mapbox {
 	return &Mapbox{
-		HTTPClient:    http.DefaultClient,
-		EndpointURL:   "https://api.mapbox.com",
-		token:         token,
-		theme:         "mapbox/outdoors-v11",
-		lineThinkness: 3,
-		lineOpacity:   0.8,
-		lineColor:     "f44",
-		size:          "800x800@2x",
-		padding:       100,
+		HTTPClient:     http.DefaultClient,
+		EndpointURL:    "https://api.mapbox.com",
+		RequestTimeout: 30 * time.Second,
+		MaxAttempts:    4,
+		RetryDelay:     time.Second,
+		MaxRetryDelay:  30 * time.Second,
+		token:          token,
 	}
 }
 
-func (m *Mapbox) URL(pts Points) string {
-	line := url.QueryEscape(pts.PolylineEncode())
+func (m *Mapbox) URL(overlays Overlays, style domain.MapStyle) string {
 	return fmt.Sprintf(
-		"%s/styles/v1/%s/static/path-%d+%s-%.1f(%s)/auto/%s?logo=false&access_token=%s&padding=%d",
+		"%s/styles/v1/%s/static/%s/auto/%dx%d@%dx?logo=false&access_token=%s&padding=%d",
 		m.EndpointURL,
-		m.theme,
-		m.lineThinkness, m.lineColor, m.lineOpacity,
-		line,
-		m.size,
+		style.Theme,
+		overlays.encode(style),
+		style.Width, style.Height, domain.MapPixelDensity,
 		m.token,
-		m.padding,
+		style.Padding,
 	)
 }
 
-func (m *Mapbox) GenerateMap(ctx context.Context, gpx domain.GPXFile) (domain.MapFile, error) {
-	url := m.URL(m.mapPoints(gpx.Points))
+// FittingURL returns the URL of the map, simplifying the paths as little as possible to stay under MaxURLLength
+func (m *Mapbox) FittingURL(overlays Overlays, style domain.MapStyle) (string, error) {
+	url := m.URL(overlays, style)
+	for tolerance := simplificationStartTolerance; len(url) > MaxURLLength; tolerance *= simplificationToleranceGrowth {
+		simplified := overlays.Simplify(tolerance)
+		url = m.URL(simplified, style)
+
+		if simplified.PointCount() <= 2 && len(url) > MaxURLLength {
+			return "", &Error{Kind: ErrURLTooLong, Details: fmt.Sprintf("can't simplify track under %d characters (length=%d)", MaxURLLength, len(url))}
+		}
+	}
+
+	return url, nil
+}
+
+// GenerateMap fetches the map of the track. Rate limits, server errors and timeouts are retried up to MaxAttempts
+// times, as long as the context isn't done.
+func (m *Mapbox) GenerateMap(ctx context.Context, gpx domain.GPXFile, style domain.MapStyle) (domain.MapFile, error) {
+	url, err := m.FittingURL(NewOverlays(domain.NewRoute(gpx.Points, style)), style)
+	if err != nil {
+		return domain.MapFile{}, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		content, err := m.fetch(ctx, url)
+		if err == nil {
+			return domain.NewMapFile(content), nil
+		}
+
+		mapboxErr, retry := m.shouldRetry(err, attempt)
+		if !retry {
+			return domain.MapFile{}, err
+		}
+
+		if err := wait(ctx, m.retryDelay(attempt, mapboxErr.RetryAfter)); err != nil {
+			return domain.MapFile{}, &Error{Kind: ErrUnavailable, Details: fmt.Sprintf("gave up after %d attempts: %v (last error: %v)", attempt, err, mapboxErr)}
+		}
+	}
+}
+
+func (m *Mapbox) shouldRetry(err error, attempt int) (*Error, bool) {
+	var mapboxErr *Error
+	if !errors.As(err, &mapboxErr) {
+		return nil, false
+	}
+
+	return mapboxErr, mapboxErr.Temporary() && attempt < m.MaxAttempts
+}
+
+func (m *Mapbox) fetch(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.RequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, &Error{Kind: ErrGeneric, Details: fmt.Sprintf("can't build request: %v", err)}
+	}
+
+	resp, err := m.HTTPClient.Do(req)
+	if err != nil {
+		return nil, &Error{Kind: ErrUnavailable, Details: fmt.Sprintf("can't fetch image: %v", redactToken(err, m.token))}
+	}
+	defer resp.Body.Close()
 
-	resp, err := m.HTTPClient.Get(url)
+	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return domain.MapFile{}, fmt.Errorf("can't fetch image (url=%v): %v", url, err)
+		return nil, &Error{Kind: ErrUnavailable, StatusCode: resp.StatusCode, Details: fmt.Sprintf("can't read response: %v", err)}
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		return body, nil
+	}
+
+	return nil, &Error{
+		Kind:       statusError(resp.StatusCode),
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		Details:    fmt.Sprintf("can't fetch image (body=%s)", body),
 	}
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-	case http.StatusUnauthorized:
-		body, _ := ioutil.ReadAll(resp.Body)
-		return domain.MapFile{}, fmt.Errorf("can't fetch image (url=%v, status=%d, body=%v): %w", url, resp.StatusCode, body, ErrInvalidToken)
+// retryDelay returns the delay asked by the API or an exponential backoff with jitter, whichever is set
+func (m *Mapbox) retryDelay(attempt int, retryAfter time.Duration) time.Duration {
+	delay := retryAfter
+	if delay <= 0 {
+		backoff := m.RetryDelay << (attempt - 1)
+		delay = backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+	}
 
+	if delay > m.MaxRetryDelay {
+		return m.MaxRetryDelay
+	}
+
+	return delay
+}
+
+func statusError(status int) error {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrInvalidToken
+	case status == http.StatusRequestURITooLong:
+		return ErrURLTooLong
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case status >= http.StatusInternalServerError:
+		return ErrUnavailable
 	default:
-		body, _ := ioutil.ReadAll(resp.Body)
-		return domain.MapFile{}, fmt.Errorf("can't fetch image (url=%v, status=%d, body=%v): %w", url, resp.StatusCode, body, ErrGeneric)
+		return ErrGeneric
+	}
+}
+
+// parseRetryAfter reads a Retry-After header, either as a number of seconds or as an HTTP date
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
 	}
 
-	var buf bytes.Buffer
-	if _, err := io.Copy(&buf, resp.Body); err != nil {
-		return domain.MapFile{}, fmt.Errorf("%w: can't write map image: %v", ErrGeneric, err)
+	if at, err := http.ParseTime(header); err == nil {
+		return at.Sub(now)
 	}
 
-	return domain.NewMapFile(buf.Bytes()), nil
+	return 0
 }
 
-func (m *Mapbox) mapPoints(gpxPoints domain.GPXPoints) Points {
-	points := make(Points, len(gpxPoints))
-	for i := range gpxPoints {
-		points[i] = Point{
-			Latitude:  gpxPoints[i].Latitude,
-			Longitude: gpxPoints[i].Longitude,
-		}
+func wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// redactToken removes the access token from errors embedding the request URL
+func redactToken(err error, token string) string {
+	if token == "" {
+		return err.Error()
 	}
 
-	return points
+	return strings.ReplaceAll(err.Error(), token, "<redacted>")
 }
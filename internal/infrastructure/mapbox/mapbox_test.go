@@ -5,11 +5,16 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"math"
+	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
 	"github.com/lonepeon/sport/internal/domain/domaintest"
 	"github.com/lonepeon/sport/internal/infrastructure/mapbox"
 )
@@ -31,19 +36,115 @@ func (m MapboxAPIMock) RoundTrip(r *http.Request) (*http.Response, error) {
 	return recorder.Result(), nil
 }
 
+// MapboxAPISequence replies with its responses in order, repeating the last one
+type MapboxAPISequence struct {
+	Responses []*httptest.ResponseRecorder
+	Calls     int
+}
+
+func (m *MapboxAPISequence) RoundTrip(r *http.Request) (*http.Response, error) {
+	response := m.Responses[len(m.Responses)-1]
+	if m.Calls < len(m.Responses) {
+		response = m.Responses[m.Calls]
+	}
+	m.Calls++
+
+	return response.Result(), nil
+}
+
+func mapboxResponse(status int, body string, headers map[string]string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	for key, value := range headers {
+		recorder.Header().Set(key, value)
+	}
+	recorder.WriteHeader(status)
+	recorder.Body = bytes.NewBufferString(body)
+
+	return recorder
+}
+
+// MapboxAPIHanging never replies, until the request is canceled
+type MapboxAPIHanging struct{}
+
+func (m MapboxAPIHanging) RoundTrip(r *http.Request) (*http.Response, error) {
+	<-r.Context().Done()
+	return nil, r.Context().Err()
+}
+
 func TestURL(t *testing.T) {
 	box := mapbox.New("<token>")
 
-	url := box.URL(mapbox.Points{
+	url := box.URL(singlePath(mapbox.Points{
 		{Latitude: 38.5, Longitude: -120.2},
 		{Latitude: 40.7, Longitude: -120.95},
 		{Latitude: 43.252, Longitude: -126.453},
-	})
+	}, "f44"), domain.DefaultMapStyle())
 
 	testutils.AssertEqualString(t, "https://api.mapbox.com/styles/v1/mapbox/outdoors-v11/static/path-3+f44-0.8(_p~iF~ps%7CU_ulLnnqC_mqNvxq%60%40)/auto/800x800@2x?logo=false&access_token=<token>&padding=100", url, "wrong mapbox url")
 }
 
-func TestGenerateMapFromPointsSuccess(t *testing.T) {
+func TestURLWithStyle(t *testing.T) {
+	box := mapbox.New("<token>")
+	style := domain.MapStyle{
+		Theme:         "mapbox/satellite-v9",
+		LineColor:     "00ff00",
+		LineThickness: 5,
+		LineOpacity:   0.5,
+		Width:         600,
+		Height:        400,
+		Padding:       50,
+	}
+
+	url := box.URL(singlePath(mapbox.Points{{Latitude: 38.5, Longitude: -120.2}}, "00ff00"), style)
+
+	testutils.AssertEqualString(t, "https://api.mapbox.com/styles/v1/mapbox/satellite-v9/static/path-5+00ff00-0.5(_p~iF~ps%7CU)/auto/600x400@2x?logo=false&access_token=<token>&padding=50", url, "wrong mapbox url")
+}
+
+func TestURLWithPins(t *testing.T) {
+	box := mapbox.New("<token>")
+	overlays := mapbox.Overlays{
+		Paths: []mapbox.Path{
+			{Points: mapbox.Points{{Latitude: 38.5, Longitude: -120.2}, {Latitude: 40.7, Longitude: -120.95}}, Color: "2b83ba"},
+			{Points: mapbox.Points{{Latitude: 40.7, Longitude: -120.95}, {Latitude: 43.252, Longitude: -126.453}}, Color: "d7191c"},
+		},
+		Pins: []mapbox.Pin{
+			{Size: "l", Color: "2ea043", Latitude: 38.5, Longitude: -120.2},
+			{Size: "s", Label: "1", Color: "555", Latitude: 40.7, Longitude: -120.95},
+			{Size: "l", Color: "d1242f", Latitude: 43.252, Longitude: -126.453},
+		},
+	}
+
+	url := box.URL(overlays, domain.DefaultMapStyle())
+
+	testutils.AssertEqualString(t, "https://api.mapbox.com/styles/v1/mapbox/outdoors-v11/static/path-3+2b83ba-0.8(_p~iF~ps%7CU_ulLnnqC),path-3+d7191c-0.8(_flwFn%60faV_mqNvxq%60%40),pin-l+2ea043(-120.20000,38.50000),pin-s-1+555(-120.95000,40.70000),pin-l+d1242f(-126.45300,43.25200)/auto/800x800@2x?logo=false&access_token=<token>&padding=100", url, "wrong mapbox url")
+}
+
+func TestNewOverlays(t *testing.T) {
+	route := domain.Route{
+		Sections: []domain.RouteSection{
+			{Points: domain.GPXPoints{{Latitude: 1, Longitude: 2}, {Latitude: 3, Longitude: 4}}, Color: "2b83ba"},
+		},
+		Markers: []domain.RouteMarker{
+			{Kind: domain.RouteMarkerStart, Latitude: 1, Longitude: 2},
+			{Kind: domain.RouteMarkerDistance, Label: "5", Latitude: 2, Longitude: 3},
+			{Kind: domain.RouteMarkerDistance, Label: "100", Latitude: 2, Longitude: 3},
+			{Kind: domain.RouteMarkerFinish, Latitude: 3, Longitude: 4},
+		},
+	}
+
+	overlays := mapbox.NewOverlays(route)
+
+	testutils.AssertEqualInt(t, 1, len(overlays.Paths), "wrong number of paths")
+	testutils.AssertEqualString(t, "2b83ba", overlays.Paths[0].Color, "wrong path color")
+	testutils.AssertEqualInt(t, 2, len(overlays.Paths[0].Points), "wrong number of path points")
+	testutils.AssertEqualInt(t, 4, len(overlays.Pins), "wrong number of pins")
+	testutils.AssertEqualString(t, "2ea043", overlays.Pins[0].Color, "wrong start pin color")
+	testutils.AssertEqualString(t, "5", overlays.Pins[1].Label, "wrong distance pin label")
+	testutils.AssertEqualString(t, "", overlays.Pins[2].Label, "labels above 99 aren't supported by mapbox")
+	testutils.AssertEqualString(t, "d1242f", overlays.Pins[3].Color, "wrong finish pin color")
+}
+
+func TestFittingURLShortTrack(t *testing.T) {
 	box := mapbox.New("<token>")
 
 	pts := mapbox.Points{
@@ -52,17 +153,65 @@ func TestGenerateMapFromPointsSuccess(t *testing.T) {
 		{Latitude: 43.252, Longitude: -126.453},
 	}
 
+	url, err := box.FittingURL(singlePath(pts, "f44"), domain.DefaultMapStyle())
+	testutils.AssertNoError(t, err, "can't build url")
+	testutils.AssertEqualString(t, box.URL(singlePath(pts, "f44"), domain.DefaultMapStyle()), url, "short tracks shouldn't be simplified")
+}
+
+func TestFittingURLLongTrack(t *testing.T) {
+	box := mapbox.New("<token>")
+
+	tcs := map[string]int{
+		"oneHour":   3600,
+		"threeHour": 3 * 3600,
+		"tenHours":  10 * 3600,
+	}
+
+	for name, seconds := range tcs {
+		t.Run(name, func(t *testing.T) {
+			pts := singlePath(syntheticTrack(seconds), "f44")
+			if len(box.URL(pts, domain.DefaultMapStyle())) <= mapbox.MaxURLLength {
+				t.Fatalf("synthetic track should not fit in a URL without simplification")
+			}
+
+			url, err := box.FittingURL(pts, domain.DefaultMapStyle())
+			testutils.AssertNoError(t, err, "can't build url")
+
+			if len(url) > mapbox.MaxURLLength {
+				t.Errorf("url is too long. max: %d; got: %d", mapbox.MaxURLLength, len(url))
+			}
+
+			if len(url) < mapbox.MaxURLLength/2 {
+				t.Errorf("track is simplified too much. min: %d; got: %d", mapbox.MaxURLLength/2, len(url))
+			}
+		})
+	}
+}
+
+func TestFittingURLTokenTooLong(t *testing.T) {
+	box := mapbox.New(strings.Repeat("x", mapbox.MaxURLLength))
+
+	_, err := box.FittingURL(singlePath(syntheticTrack(3600), "f44"), domain.DefaultMapStyle())
+
+	testutils.AssertErrorIs(t, mapbox.ErrURLTooLong, err, "unexpected error")
+}
+
+func TestGenerateMapFromPointsSuccess(t *testing.T) {
+	box := mapbox.New("<token>")
+
 	gpxFile := domaintest.NewGPXFile(t).Build()
+	expectedURL, err := box.FittingURL(mapbox.NewOverlays(domain.NewRoute(gpxFile.Points, domain.DefaultMapStyle())), domain.DefaultMapStyle())
+	testutils.AssertNoError(t, err, "can't build expected url")
 
 	mock := MapboxAPIMock{
 		Status:      200,
 		Response:    []byte("the image bytes"),
-		ExpectedURL: box.URL(pts),
+		ExpectedURL: expectedURL,
 	}
 
 	box.HTTPClient = &http.Client{Transport: mock}
 
-	image, err := box.GenerateMap(context.Background(), gpxFile)
+	image, err := box.GenerateMap(context.Background(), gpxFile, domain.DefaultMapStyle())
 	testutils.AssertNoError(t, err, "can't generate image")
 	content, err := ioutil.ReadAll(image.File())
 	testutils.AssertNoError(t, err, "can't generate image content")
@@ -72,23 +221,19 @@ func TestGenerateMapFromPointsSuccess(t *testing.T) {
 func TestGenerateMapFromPointsWrongToken(t *testing.T) {
 	box := mapbox.New("<token>")
 
-	pts := mapbox.Points{
-		{Latitude: 38.5, Longitude: -120.2},
-		{Latitude: 40.7, Longitude: -120.95},
-		{Latitude: 43.252, Longitude: -126.453},
-	}
-
 	gpxFile := domaintest.NewGPXFile(t).Build()
+	expectedURL, err := box.FittingURL(mapbox.NewOverlays(domain.NewRoute(gpxFile.Points, domain.DefaultMapStyle())), domain.DefaultMapStyle())
+	testutils.AssertNoError(t, err, "can't build expected url")
 
 	mock := MapboxAPIMock{
 		Status:      401,
 		Response:    []byte(`{"message":"Not Authorized - Invalid Token"}`),
-		ExpectedURL: box.URL(pts),
+		ExpectedURL: expectedURL,
 	}
 
 	box.HTTPClient = &http.Client{Transport: mock}
 
-	image, err := box.GenerateMap(context.Background(), gpxFile)
+	image, err := box.GenerateMap(context.Background(), gpxFile, domain.DefaultMapStyle())
 	if err == nil {
 		content, _ := ioutil.ReadAll(image.File())
 		testutils.AssertHasError(t, err, "shouldn't generate image but got one. got: %v", string(content))
@@ -100,23 +245,19 @@ func TestGenerateMapFromPointsWrongToken(t *testing.T) {
 func TestGenerateMapFromPointsWrongQuery(t *testing.T) {
 	box := mapbox.New("<token>")
 
-	pts := mapbox.Points{
-		{Latitude: 38.5, Longitude: -120.2},
-		{Latitude: 40.7, Longitude: -120.95},
-		{Latitude: 43.252, Longitude: -126.453},
-	}
-
 	gpxFile := domaintest.NewGPXFile(t).Build()
+	expectedURL, err := box.FittingURL(mapbox.NewOverlays(domain.NewRoute(gpxFile.Points, domain.DefaultMapStyle())), domain.DefaultMapStyle())
+	testutils.AssertNoError(t, err, "can't build expected url")
 
 	mock := MapboxAPIMock{
 		Status:      422,
 		Response:    []byte(`{"message":"Auto extent cannot be determined when GeoJSON has no features"}%`),
-		ExpectedURL: box.URL(pts),
+		ExpectedURL: expectedURL,
 	}
 
 	box.HTTPClient = &http.Client{Transport: mock}
 
-	image, err := box.GenerateMap(context.Background(), gpxFile)
+	image, err := box.GenerateMap(context.Background(), gpxFile, domain.DefaultMapStyle())
 	if err == nil {
 		content, _ := ioutil.ReadAll(image.File())
 		testutils.AssertHasError(t, err, "shouldn't generate image but got one. got: %v", string(content))
@@ -124,3 +265,120 @@ func TestGenerateMapFromPointsWrongQuery(t *testing.T) {
 
 	testutils.AssertErrorIs(t, mapbox.ErrGeneric, err, "wrong error")
 }
+
+func singlePath(pts mapbox.Points, color string) mapbox.Overlays {
+	return mapbox.Overlays{Paths: []mapbox.Path{{Points: pts, Color: color}}}
+}
+
+// syntheticTrack simulates a winding run sampled every second at ~3m/s, with a few meters of GPS noise
+func syntheticTrack(seconds int) mapbox.Points {
+	const stepMeters = 3.0
+	const noiseMeters = 2.0
+	const metersPerDegree = 111320.0
+
+	random := rand.New(rand.NewSource(42))
+	pts := make(mapbox.Points, seconds)
+	latitude, longitude := 48.8566, 2.3522
+	for i := range pts {
+		heading := math.Sin(float64(i)/90) * math.Pi
+		latitude += stepMeters * math.Cos(heading) / metersPerDegree
+		longitude += stepMeters * math.Sin(heading) / (metersPerDegree * math.Cos(latitude*math.Pi/180))
+		pts[i] = mapbox.Point{
+			Latitude:  latitude + (random.Float64()-0.5)*noiseMeters/metersPerDegree,
+			Longitude: longitude + (random.Float64()-0.5)*noiseMeters/metersPerDegree,
+		}
+	}
+
+	return pts
+}
+
+func TestGenerateMapRetriesServerErrors(t *testing.T) {
+	box := mapbox.New("<token>")
+	box.RetryDelay = time.Millisecond
+	api := &MapboxAPISequence{Responses: []*httptest.ResponseRecorder{
+		mapboxResponse(503, "unavailable", nil),
+		mapboxResponse(500, "oops", nil),
+		mapboxResponse(200, "the image bytes", nil),
+	}}
+	box.HTTPClient = &http.Client{Transport: api}
+
+	image, err := box.GenerateMap(context.Background(), domaintest.NewGPXFile(t).Build(), domain.DefaultMapStyle())
+	testutils.AssertNoError(t, err, "can't generate image")
+	content, err := ioutil.ReadAll(image.File())
+	testutils.AssertNoError(t, err, "can't generate image content")
+	testutils.AssertEqualString(t, "the image bytes", string(content), "wrong image content")
+	testutils.AssertEqualInt(t, 3, api.Calls, "unexpected number of requests")
+}
+
+func TestGenerateMapHonoursRetryAfter(t *testing.T) {
+	box := mapbox.New("<token>")
+	api := &MapboxAPISequence{Responses: []*httptest.ResponseRecorder{
+		mapboxResponse(429, "slow down", map[string]string{"Retry-After": "1"}),
+		mapboxResponse(200, "the image bytes", nil),
+	}}
+	box.HTTPClient = &http.Client{Transport: api}
+
+	start := time.Now()
+	_, err := box.GenerateMap(context.Background(), domaintest.NewGPXFile(t).Build(), domain.DefaultMapStyle())
+	testutils.AssertNoError(t, err, "can't generate image")
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait for the Retry-After delay. got: %v", elapsed)
+	}
+}
+
+func TestGenerateMapGivesUpOnTransientErrors(t *testing.T) {
+	box := mapbox.New("<token>")
+	box.RetryDelay = time.Millisecond
+	box.MaxAttempts = 3
+	api := &MapboxAPISequence{Responses: []*httptest.ResponseRecorder{mapboxResponse(429, "slow down", nil)}}
+	box.HTTPClient = &http.Client{Transport: api}
+
+	_, err := box.GenerateMap(context.Background(), domaintest.NewGPXFile(t).Build(), domain.DefaultMapStyle())
+
+	testutils.AssertErrorIs(t, mapbox.ErrRateLimited, err, "wrong error")
+	testutils.AssertErrorIs(t, domain.ErrMapProviderUnavailable, err, "rate limits should be transient")
+	testutils.AssertEqualInt(t, 3, api.Calls, "unexpected number of requests")
+}
+
+func TestGenerateMapDoesntRetryPermanentErrors(t *testing.T) {
+	box := mapbox.New("<token>")
+	box.RetryDelay = time.Millisecond
+	api := &MapboxAPISequence{Responses: []*httptest.ResponseRecorder{mapboxResponse(401, "invalid token", nil)}}
+	box.HTTPClient = &http.Client{Transport: api}
+
+	_, err := box.GenerateMap(context.Background(), domaintest.NewGPXFile(t).Build(), domain.DefaultMapStyle())
+
+	testutils.AssertErrorIs(t, mapbox.ErrInvalidToken, err, "wrong error")
+	testutils.AssertErrorIs(t, domain.ErrMapRejected, err, "invalid tokens should be permanent")
+	testutils.AssertEqualInt(t, 1, api.Calls, "unexpected number of requests")
+}
+
+func TestGenerateMapRequestTimeout(t *testing.T) {
+	box := mapbox.New("<token>")
+	box.RequestTimeout = 10 * time.Millisecond
+	box.RetryDelay = time.Millisecond
+	box.MaxAttempts = 2
+	box.HTTPClient = &http.Client{Transport: MapboxAPIHanging{}}
+
+	_, err := box.GenerateMap(context.Background(), domaintest.NewGPXFile(t).Build(), domain.DefaultMapStyle())
+
+	testutils.AssertErrorIs(t, domain.ErrMapProviderUnavailable, err, "timeouts should be transient")
+	if strings.Contains(err.Error(), "<token>") {
+		t.Errorf("error shouldn't leak the access token: %v", err)
+	}
+}
+
+func TestGenerateMapContextCanceled(t *testing.T) {
+	box := mapbox.New("<token>")
+	box.RetryDelay = time.Hour
+	box.MaxRetryDelay = time.Hour
+	box.HTTPClient = &http.Client{Transport: &MapboxAPISequence{Responses: []*httptest.ResponseRecorder{mapboxResponse(503, "", nil)}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := box.GenerateMap(ctx, domaintest.NewGPXFile(t).Build(), domain.DefaultMapStyle())
+
+	testutils.AssertErrorIs(t, domain.ErrMapProviderUnavailable, err, "canceled requests should be transient")
+}
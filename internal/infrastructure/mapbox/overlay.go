@@ -0,0 +1,131 @@
+package mapbox
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+const (
+	pinStartColor    = "2ea043"
+	pinFinishColor   = "d1242f"
+	pinDistanceColor = "555"
+
+	// maxPinLabel is the highest number the static images API accepts as a pin label
+	maxPinLabel = 99
+)
+
+// Overlays represents everything drawn on top of the map: colored paths, then pins
+type Overlays struct {
+	Paths []Path
+	Pins  []Pin
+}
+
+// Path represents a part of the track drawn with a single color
+type Path struct {
+	Points Points
+	Color  string
+}
+
+// Pin represents a marker of the track
+type Pin struct {
+	Size      string
+	Label     string
+	Color     string
+	Latitude  float64
+	Longitude float64
+}
+
+// NewOverlays converts the sections and markers of a route to their static images API overlays
+func NewOverlays(route domain.Route) Overlays {
+	var overlays Overlays
+	for _, section := range route.Sections {
+		overlays.Paths = append(overlays.Paths, Path{Points: mapPoints(section.Points), Color: section.Color})
+	}
+
+	for _, marker := range route.Markers {
+		overlays.Pins = append(overlays.Pins, newPin(marker))
+	}
+
+	return overlays
+}
+
+func newPin(marker domain.RouteMarker) Pin {
+	pin := Pin{Size: "l", Latitude: marker.Latitude, Longitude: marker.Longitude}
+	switch marker.Kind {
+	case domain.RouteMarkerStart:
+		pin.Color = pinStartColor
+	case domain.RouteMarkerFinish:
+		pin.Color = pinFinishColor
+	default:
+		pin.Size = "s"
+		pin.Color = pinDistanceColor
+		if km, err := strconv.Atoi(marker.Label); err == nil && km <= maxPinLabel {
+			pin.Label = marker.Label
+		}
+	}
+
+	return pin
+}
+
+// Simplify simplifies the points of each path with the given tolerance, in meters
+func (o Overlays) Simplify(tolerance float64) Overlays {
+	simplified := Overlays{Paths: make([]Path, len(o.Paths)), Pins: o.Pins}
+	for i, path := range o.Paths {
+		simplified.Paths[i] = Path{Points: path.Points.Simplify(tolerance), Color: path.Color}
+	}
+
+	return simplified
+}
+
+// PointCount returns the number of points of the longest path
+func (o Overlays) PointCount() int {
+	var count int
+	for _, path := range o.Paths {
+		if len(path.Points) > count {
+			count = len(path.Points)
+		}
+	}
+
+	return count
+}
+
+func (o Overlays) encode(style domain.MapStyle) string {
+	overlays := make([]string, 0, len(o.Paths)+len(o.Pins))
+	for _, path := range o.Paths {
+		overlays = append(overlays, fmt.Sprintf(
+			"path-%d+%s-%.1f(%s)",
+			style.LineThickness, path.Color, style.LineOpacity, url.QueryEscape(path.Points.PolylineEncode()),
+		))
+	}
+
+	for _, pin := range o.Pins {
+		overlays = append(overlays, pin.encode())
+	}
+
+	return strings.Join(overlays, ",")
+}
+
+func (p Pin) encode() string {
+	name := "pin-" + p.Size
+	if p.Label != "" {
+		name += "-" + p.Label
+	}
+
+	return fmt.Sprintf("%s+%s(%.5f,%.5f)", name, p.Color, p.Longitude, p.Latitude)
+}
+
+func mapPoints(gpxPoints domain.GPXPoints) Points {
+	points := make(Points, len(gpxPoints))
+	for i := range gpxPoints {
+		points[i] = Point{
+			Latitude:  gpxPoints[i].Latitude,
+			Longitude: gpxPoints[i].Longitude,
+		}
+	}
+
+	return points
+}
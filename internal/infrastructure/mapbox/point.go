@@ -7,6 +7,8 @@ import (
 
 var polylineFactor = math.Pow10(5)
 
+const earthRadiusMeters = 6371000
+
 // Points represents a list of GPS points
 type Points []Point
 
@@ -43,6 +45,73 @@ func (pts Points) PolylineEncode() string {
 	return strings.ReplaceAll(s.String(), "\\", "\\\\")
 }
 
+// Simplify removes the points closer than tolerance meters to the line drawn by their neighbours, using the
+// Douglas-Peucker algorithm https://en.wikipedia.org/wiki/Ramer%E2%80%93Douglas%E2%80%93Peucker_algorithm
+func (pts Points) Simplify(tolerance float64) Points {
+	if len(pts) < 3 {
+		return pts
+	}
+
+	projected := pts.project()
+	keep := make([]bool, len(pts))
+	keep[0], keep[len(pts)-1] = true, true
+
+	ranges := [][2]int{{0, len(pts) - 1}}
+	for len(ranges) > 0 {
+		first, last := ranges[len(ranges)-1][0], ranges[len(ranges)-1][1]
+		ranges = ranges[:len(ranges)-1]
+
+		farthest, distance := 0, 0.0
+		for i := first + 1; i < last; i++ {
+			if d := segmentDistance(projected[i], projected[first], projected[last]); d > distance {
+				farthest, distance = i, d
+			}
+		}
+
+		if distance > tolerance {
+			keep[farthest] = true
+			ranges = append(ranges, [2]int{first, farthest}, [2]int{farthest, last})
+		}
+	}
+
+	simplified := make(Points, 0, len(pts))
+	for i := range pts {
+		if keep[i] {
+			simplified = append(simplified, pts[i])
+		}
+	}
+
+	return simplified
+}
+
+// project converts the points to meters on a plane tangent to the first point, which is accurate enough at the
+// scale of a track
+func (pts Points) project() [][2]float64 {
+	cos := math.Cos(pts[0].Latitude * math.Pi / 180)
+	projected := make([][2]float64, len(pts))
+	for i := range pts {
+		projected[i] = [2]float64{
+			pts[i].Longitude * math.Pi / 180 * cos * earthRadiusMeters,
+			pts[i].Latitude * math.Pi / 180 * earthRadiusMeters,
+		}
+	}
+
+	return projected
+}
+
+// segmentDistance returns the distance between p and the segment going from a to b
+func segmentDistance(p [2]float64, a [2]float64, b [2]float64) float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	length := dx*dx + dy*dy
+	if length == 0 {
+		return math.Hypot(p[0]-a[0], p[1]-a[1])
+	}
+
+	t := math.Max(0, math.Min(1, ((p[0]-a[0])*dx+(p[1]-a[1])*dy)/length))
+
+	return math.Hypot(p[0]-(a[0]+t*dx), p[1]-(a[1]+t*dy))
+}
+
 func toE5(n float64) int32 {
 	return int32(math.Round(n * polylineFactor))
 }
@@ -16,3 +16,39 @@ func TestPolyline(t *testing.T) {
 
 	testutils.AssertEqualString(t, "_p~iF~ps|U_ulLnnqC_mqNvxq`@", polyline.PolylineEncode(), "wrong polyline")
 }
+
+func TestSimplifyRemovesAlignedPoints(t *testing.T) {
+	pts := mapbox.Points{
+		{Latitude: 48.8566, Longitude: 2.3522},
+		{Latitude: 48.8566, Longitude: 2.3532},
+		{Latitude: 48.8566, Longitude: 2.3542},
+		{Latitude: 48.8576, Longitude: 2.3542},
+		{Latitude: 48.8586, Longitude: 2.3542},
+	}
+
+	simplified := pts.Simplify(1)
+
+	testutils.AssertEqualInt(t, 3, len(simplified), "unexpected number of points")
+	testutils.AssertEqualString(t, mapbox.Points{pts[0], pts[2], pts[4]}.PolylineEncode(), simplified.PolylineEncode(), "unexpected points")
+}
+
+func TestSimplifyKeepsPointsAboveTolerance(t *testing.T) {
+	pts := mapbox.Points{
+		{Latitude: 48.8566, Longitude: 2.3522},
+		// ~11m away from the line between its neighbours
+		{Latitude: 48.8567, Longitude: 2.3532},
+		{Latitude: 48.8566, Longitude: 2.3542},
+	}
+
+	testutils.AssertEqualInt(t, 3, len(pts.Simplify(10)), "unexpected number of points with a small tolerance")
+	testutils.AssertEqualInt(t, 2, len(pts.Simplify(12)), "unexpected number of points with a big tolerance")
+}
+
+func TestSimplifyShortTrack(t *testing.T) {
+	pts := mapbox.Points{
+		{Latitude: 48.8566, Longitude: 2.3522},
+		{Latitude: 48.8567, Longitude: 2.3532},
+	}
+
+	testutils.AssertEqualInt(t, 2, len(pts.Simplify(100)), "unexpected number of points")
+}
@@ -0,0 +1,131 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+type export struct {
+	ID          string
+	Status      string
+	ArchivePath string
+	RequestedAt time.Time
+	Username    string
+	CompletedAt sql.NullTime
+}
+
+func (e export) ToDomain() (domain.Export, error) {
+	id, err := domain.ParseID(e.ID)
+	if err != nil {
+		return domain.Export{}, fmt.Errorf("can't parse export id: %v", err)
+	}
+
+	result := domain.Export{
+		ID:          id,
+		Status:      domain.ExportStatus(e.Status),
+		ArchivePath: domain.ExportArchivePath(e.ArchivePath),
+		Username:    e.Username,
+		RequestedAt: e.RequestedAt.UTC(),
+	}
+
+	if e.CompletedAt.Valid {
+		result.CompletedAt = e.CompletedAt.Time.UTC()
+	}
+
+	return result, nil
+}
+
+// GetExport returns the export matching the identifier
+func (r PostgreSQL) GetExport(ctx context.Context, id domain.ID) (domain.Export, error) {
+	statement := `
+		SELECT id, status, archive_path, requested_at, completed_at, username
+		FROM exports
+		WHERE id = $1`
+
+	var dbExport export
+	err := r.DB.QueryRowContext(ctx, statement, id.String()).
+		Scan(&dbExport.ID, &dbExport.Status, &dbExport.ArchivePath, &dbExport.RequestedAt, &dbExport.CompletedAt, &dbExport.Username)
+	if err == sql.ErrNoRows {
+		return domain.Export{}, domain.ErrExportNotFound
+	}
+	if err != nil {
+		return domain.Export{}, fmt.Errorf("can't get export: %v", err)
+	}
+
+	return dbExport.ToDomain()
+}
+
+// ListExports returns the exports requested by the athlete, from the most recent one
+func (r PostgreSQL) ListExports(ctx context.Context, username string) ([]domain.Export, error) {
+	statement := `
+		SELECT id, status, archive_path, requested_at, completed_at, username
+		FROM exports
+		WHERE username = $1
+		ORDER BY requested_at DESC`
+
+	rows, err := r.DB.QueryContext(ctx, statement, username)
+	if err != nil {
+		return nil, fmt.Errorf("can't get exports: %v", err)
+	}
+	defer rows.Close()
+
+	var exports []domain.Export
+	for rows.Next() {
+		var dbExport export
+		err := rows.Scan(&dbExport.ID, &dbExport.Status, &dbExport.ArchivePath, &dbExport.RequestedAt, &dbExport.CompletedAt, &dbExport.Username)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan export: %v", err)
+		}
+
+		e, err := dbExport.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		exports = append(exports, e)
+	}
+
+	return exports, nil
+}
+
+// RecordExport persists the export in database
+func (r PostgreSQL) RecordExport(ctx context.Context, e domain.Export) error {
+	statement := `
+		INSERT INTO exports (id, status, archive_path, requested_at, completed_at, username)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.DB.ExecContext(ctx, statement, e.ID.String(), e.Status.String(), e.ArchivePath.String(), e.RequestedAt, nullableTime(e.CompletedAt), e.Username)
+	if err != nil {
+		return fmt.Errorf("can't insert into table: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateExport persists the status and archive of an existing export
+func (r PostgreSQL) UpdateExport(ctx context.Context, e domain.Export) error {
+	statement := `UPDATE exports SET status = $1, archive_path = $2, completed_at = $3 WHERE id = $4`
+
+	rst, err := r.DB.ExecContext(ctx, statement, e.Status.String(), e.ArchivePath.String(), nullableTime(e.CompletedAt), e.ID.String())
+	if err != nil {
+		return fmt.Errorf("can't update export: %v", err)
+	}
+
+	if count, _ := rst.RowsAffected(); count == 0 {
+		return domain.ErrExportNotFound
+	}
+
+	return nil
+}
+
+func nullableTime(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: t, Valid: true}
+}
@@ -0,0 +1,250 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// importColumns lists the columns read by scanImport, in order
+const importColumns = `
+	i.id, i.archive_path, i.created_at, i.username,
+	COUNT(CASE WHEN it.status = 'pending' THEN 1 END),
+	COUNT(CASE WHEN it.status = 'imported' THEN 1 END),
+	COUNT(CASE WHEN it.status = 'skipped' THEN 1 END),
+	COUNT(CASE WHEN it.status = 'failed' THEN 1 END)`
+
+// importItemColumns lists the columns read by scanImportItem, in order
+const importItemColumns = `import_id, external_id, file_name, ran_at, type, name, description, status, reason`
+
+func scanImport(row scanner) (domain.Import, error) {
+	var rawID string
+	var imp domain.Import
+	err := row.Scan(
+		&rawID,
+		&imp.ArchivePath,
+		&imp.CreatedAt,
+		&imp.Username,
+		&imp.Progress.Pending,
+		&imp.Progress.Imported,
+		&imp.Progress.Skipped,
+		&imp.Progress.Failed,
+	)
+	if err != nil {
+		return domain.Import{}, err
+	}
+
+	id, err := domain.ParseID(rawID)
+	if err != nil {
+		return domain.Import{}, fmt.Errorf("can't parse import id: %v", err)
+	}
+
+	imp.ID = id
+	imp.CreatedAt = imp.CreatedAt.UTC()
+
+	return imp, nil
+}
+
+func scanImportItem(row scanner) (domain.ImportItem, error) {
+	var rawID, status string
+	var item domain.ImportItem
+	err := row.Scan(
+		&rawID,
+		&item.ExternalID,
+		&item.FileName,
+		&item.RanAt,
+		&item.Type,
+		&item.Name,
+		&item.Description,
+		&status,
+		&item.Reason,
+	)
+	if err != nil {
+		return domain.ImportItem{}, err
+	}
+
+	id, err := domain.ParseID(rawID)
+	if err != nil {
+		return domain.ImportItem{}, fmt.Errorf("can't parse import id: %v", err)
+	}
+
+	item.ImportID = id
+	item.RanAt = item.RanAt.UTC()
+	item.Status = domain.ImportItemStatus(status)
+
+	return item, nil
+}
+
+// GetImport returns the import matching the identifier, with the progress of its items
+func (r PostgreSQL) GetImport(ctx context.Context, id domain.ID) (domain.Import, error) {
+	statement := `
+		SELECT ` + importColumns + `
+		FROM imports i
+		LEFT JOIN import_items it ON it.import_id = i.id
+		WHERE i.id = $1
+		GROUP BY i.id, i.archive_path, i.created_at, i.username`
+
+	imp, err := scanImport(r.DB.QueryRowContext(ctx, statement, id.String()))
+	if err == sql.ErrNoRows {
+		return domain.Import{}, domain.ErrImportNotFound
+	}
+	if err != nil {
+		return domain.Import{}, fmt.Errorf("can't get import: %v", err)
+	}
+
+	return imp, nil
+}
+
+// ListImports returns the imports started by the athlete with the progress of their items, from the most recent one
+func (r PostgreSQL) ListImports(ctx context.Context, username string) ([]domain.Import, error) {
+	statement := `
+		SELECT ` + importColumns + `
+		FROM imports i
+		LEFT JOIN import_items it ON it.import_id = i.id
+		WHERE i.username = $1
+		GROUP BY i.id, i.archive_path, i.created_at, i.username
+		ORDER BY i.created_at DESC`
+
+	rows, err := r.DB.QueryContext(ctx, statement, username)
+	if err != nil {
+		return nil, fmt.Errorf("can't get imports: %v", err)
+	}
+	defer rows.Close()
+
+	var imports []domain.Import
+	for rows.Next() {
+		imp, err := scanImport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan import: %v", err)
+		}
+
+		imports = append(imports, imp)
+	}
+
+	return imports, nil
+}
+
+// RecordImport persists the import in database
+func (r PostgreSQL) RecordImport(ctx context.Context, imp domain.Import) error {
+	statement := `INSERT INTO imports (id, archive_path, created_at, username) VALUES ($1, $2, $3, $4)`
+
+	_, err := r.DB.ExecContext(ctx, statement, imp.ID.String(), imp.ArchivePath, imp.CreatedAt, imp.Username)
+	if err != nil {
+		return fmt.Errorf("can't insert into table: %v", err)
+	}
+
+	return nil
+}
+
+// AssignOrphanImports gives the imports started before they had an owner to the athlete
+func (r PostgreSQL) AssignOrphanImports(ctx context.Context, username string) error {
+	statement := `UPDATE imports SET username = $1 WHERE username = ''`
+
+	if _, err := r.DB.ExecContext(ctx, statement, username); err != nil {
+		return fmt.Errorf("can't assign imports: %v", err)
+	}
+
+	return nil
+}
+
+// GetImportItem returns the item of the import matching the external identifier
+func (r PostgreSQL) GetImportItem(ctx context.Context, importID domain.ID, externalID string) (domain.ImportItem, error) {
+	statement := `
+		SELECT ` + importItemColumns + `
+		FROM import_items
+		WHERE import_id = $1 AND external_id = $2`
+
+	item, err := scanImportItem(r.DB.QueryRowContext(ctx, statement, importID.String(), externalID))
+	if err == sql.ErrNoRows {
+		return domain.ImportItem{}, domain.ErrImportNotFound
+	}
+	if err != nil {
+		return domain.ImportItem{}, fmt.Errorf("can't get import item: %v", err)
+	}
+
+	return item, nil
+}
+
+// ListImportItems returns all the items of the import, from the oldest activity
+func (r PostgreSQL) ListImportItems(ctx context.Context, importID domain.ID) ([]domain.ImportItem, error) {
+	statement := `
+		SELECT ` + importItemColumns + `
+		FROM import_items
+		WHERE import_id = $1
+		ORDER BY ran_at, external_id`
+
+	rows, err := r.DB.QueryContext(ctx, statement, importID.String())
+	if err != nil {
+		return nil, fmt.Errorf("can't get import items: %v", err)
+	}
+	defer rows.Close()
+
+	var items []domain.ImportItem
+	for rows.Next() {
+		item, err := scanImportItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan import item: %v", err)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// RecordImportItems persists the items in database. Items already recorded for the import are left untouched.
+func (r PostgreSQL) RecordImportItems(ctx context.Context, items []domain.ImportItem) error {
+	statement := `
+		INSERT INTO import_items (` + importItemColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (import_id, external_id) DO NOTHING`
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("can't start transaction: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, item := range items {
+		_, err := tx.ExecContext(
+			ctx,
+			statement,
+			item.ImportID.String(),
+			item.ExternalID,
+			item.FileName,
+			item.RanAt,
+			item.Type,
+			item.Name,
+			item.Description,
+			item.Status.String(),
+			item.Reason,
+		)
+		if err != nil {
+			return fmt.Errorf("can't insert import item (external_id=%s): %v", item.ExternalID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit import items: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateImportItem persists the status of an existing import item
+func (r PostgreSQL) UpdateImportItem(ctx context.Context, item domain.ImportItem) error {
+	statement := `UPDATE import_items SET status = $1, reason = $2 WHERE import_id = $3 AND external_id = $4`
+
+	rst, err := r.DB.ExecContext(ctx, statement, item.Status.String(), item.Reason, item.ImportID.String(), item.ExternalID)
+	if err != nil {
+		return fmt.Errorf("can't update import item: %v", err)
+	}
+
+	if count, _ := rst.RowsAffected(); count == 0 {
+		return domain.ErrImportNotFound
+	}
+
+	return nil
+}
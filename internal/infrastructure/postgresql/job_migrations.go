@@ -0,0 +1,26 @@
+// Code generated -fname job_migrations.go -func JobMigrations ./job_scripts DO NOT EDIT
+
+package postgresql
+
+import "github.com/lonepeon/golib/sqlutil"
+
+// JobMigrations returns an ordered list of migrations to execute
+func JobMigrations() []sqlutil.Migration {
+	return []sqlutil.Migration{
+		{
+			Version: "202204100001",
+			Script: `CREATE TABLE jobs (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL,
+  params BYTEA NOT NULL,
+  at TIMESTAMPTZ NOT NULL,
+  attempts INTEGER NOT NULL,
+  max_attempts INTEGER NOT NULL,
+  locked_until TIMESTAMPTZ,
+  failed TIMESTAMPTZ
+)
+
+`,
+		},
+	}
+}
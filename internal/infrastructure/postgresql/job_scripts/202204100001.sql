@@ -0,0 +1,10 @@
+CREATE TABLE jobs (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL,
+  params BYTEA NOT NULL,
+  at TIMESTAMPTZ NOT NULL,
+  attempts INTEGER NOT NULL,
+  max_attempts INTEGER NOT NULL,
+  locked_until TIMESTAMPTZ,
+  failed TIMESTAMPTZ
+)
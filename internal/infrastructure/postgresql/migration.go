@@ -0,0 +1,85 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/golib/sqlutil"
+)
+
+// ExecuteMigrations runs all the migrations not executed yet and returns their versions.
+//
+// It mirrors sqlutil.ExecuteMigrations which relies on SQLite placeholders PostgreSQL doesn't understand.
+func ExecuteMigrations(ctx context.Context, db *sql.DB, migrations []sqlutil.Migration) ([]string, error) {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS migrations (version TEXT PRIMARY KEY, executed_at TIMESTAMPTZ)`)
+	if err != nil {
+		return nil, fmt.Errorf("can't create migrations table: %v", err)
+	}
+
+	executedMigrations, err := loadAlreadyExecutedMigrations(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var newMigrationVersions []string
+	for _, migration := range migrations {
+		if _, ok := executedMigrations[migration.Version]; ok {
+			continue
+		}
+
+		newMigrationVersions = append(newMigrationVersions, migration.Version)
+		if err := executeNewMigration(ctx, db, migration); err != nil {
+			return nil, err
+		}
+	}
+
+	return newMigrationVersions, nil
+}
+
+func loadAlreadyExecutedMigrations(ctx context.Context, db *sql.DB) (map[string]struct{}, error) {
+	rows, err := db.QueryContext(ctx, `SELECT version FROM migrations ORDER BY executed_at ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("can't select already executed migrations: %v", err)
+	}
+	defer rows.Close()
+
+	executedMigrations := make(map[string]struct{})
+	var version string
+	for rows.Next() {
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("can't scan past migrations result from sql: %v", err)
+		}
+		executedMigrations[version] = struct{}{}
+	}
+
+	return executedMigrations, nil
+}
+
+func executeNewMigration(ctx context.Context, db *sql.DB, migration sqlutil.Migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("can't start migration transaction (version=%s): %v", migration.Version, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, migration.Script); err != nil {
+		return fmt.Errorf("can't execute migration (version=%s): %v", migration.Version, err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO migrations (version, executed_at) VALUES ($1, $2)`,
+		migration.Version, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("can't mark migration as executed (version=%s): %v", migration.Version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit migration (version=%s): %v", migration.Version, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,424 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+//go:generate go run ../../../vendor/github.com/lonepeon/golib/sqlutil/cmd/sql-migration ./scripts
+//go:generate go run ../../../vendor/github.com/lonepeon/golib/sqlutil/cmd/sql-migration -fname job_migrations.go -func JobMigrations ./job_scripts
+//go:generate go run ../../../vendor/github.com/lonepeon/golib/sqlutil/cmd/sql-migration -fname session_migrations.go -func SessionMigrations ./session_scripts
+
+// PostgreSQL represents a repository interacting with a PostgreSQL database
+type PostgreSQL struct {
+	DB *sql.DB
+}
+
+// New initializes a new PostgreSQL repository
+func New(db *sql.DB) PostgreSQL {
+	return PostgreSQL{
+		DB: db,
+	}
+}
+
+type runningActivity struct {
+	ID                 string
+	RanAt              time.Time
+	Duration           int64
+	Distance           int
+	Speed              float64
+	GPXPath            string
+	MapPath            string
+	ShareableMapPath   string
+	ElevationChartPath string
+	PaceChartPath      string
+	Cards              string
+	Title              string
+	Description        string
+	Type               string
+	MapStyle           domain.MapStyle
+	MapStatus          string
+	MapError           string
+	Username           string
+	Visibility         string
+}
+
+// runningActivityColumns lists the columns read by scanRunningActivity, in order
+const runningActivityColumns = `id, ran_at, duration, distance, speed, gpx_path, map_path, shareable_map_path, title, description, ` +
+	`activity_type, map_theme, map_line_color, map_line_thickness, map_line_opacity, map_width, map_height, map_padding, map_route_coloring, map_status, map_error, ` +
+	`elevation_chart_path, pace_chart_path, cards, username, visibility`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRunningActivity(row scanner) (runningActivity, error) {
+	var activity runningActivity
+	err := row.Scan(
+		&activity.ID,
+		&activity.RanAt,
+		&activity.Duration,
+		&activity.Distance,
+		&activity.Speed,
+		&activity.GPXPath,
+		&activity.MapPath,
+		&activity.ShareableMapPath,
+		&activity.Title,
+		&activity.Description,
+		&activity.Type,
+		&activity.MapStyle.Theme,
+		&activity.MapStyle.LineColor,
+		&activity.MapStyle.LineThickness,
+		&activity.MapStyle.LineOpacity,
+		&activity.MapStyle.Width,
+		&activity.MapStyle.Height,
+		&activity.MapStyle.Padding,
+		&activity.MapStyle.RouteColoring,
+		&activity.MapStatus,
+		&activity.MapError,
+		&activity.ElevationChartPath,
+		&activity.PaceChartPath,
+		&activity.Cards,
+		&activity.Username,
+		&activity.Visibility,
+	)
+
+	return activity, err
+}
+
+func (r runningActivity) ToDomain() (domain.RunningActivity, error) {
+	var activity domain.RunningActivity
+
+	activity.GPXPath = domain.GPXFilePath(r.GPXPath)
+	activity.MapPath = domain.MapFilePath(r.MapPath)
+	activity.ShareableMapPath = domain.ShareableMapFilePath(r.ShareableMapPath)
+	activity.ElevationChartPath = domain.ElevationChartFilePath(r.ElevationChartPath)
+	activity.PaceChartPath = domain.PaceChartFilePath(r.PaceChartPath)
+
+	cards, err := decodeCards(r.Cards)
+	if err != nil {
+		return domain.RunningActivity{}, fmt.Errorf("can't parse cards for activity (id=%s): %v", r.ID, err)
+	}
+	activity.Cards = cards
+	activity.Title = r.Title
+	activity.Description = r.Description
+	activity.MapStyle = r.MapStyle
+	activity.MapStatus = domain.MapStatus(r.MapStatus)
+	activity.MapError = r.MapError
+	activity.Username = r.Username
+	activity.RanAt = r.RanAt.UTC()
+	activity.Duration = time.Duration(r.Duration) * time.Millisecond
+
+	slug, err := domain.NewRunnningActivitySlugFromTime(activity.RanAt)
+	if err != nil {
+		return domain.RunningActivity{}, fmt.Errorf("can't build slug from ranAt for activity (id=%s): %v", r.ID, err)
+	}
+	activity.Slug = slug
+
+	speed, err := domain.NewSpeedFromKmh(r.Speed)
+	if err != nil {
+		return domain.RunningActivity{}, fmt.Errorf("can't parse speed for activity (id=%s): %v", r.ID, err)
+	}
+	activity.Speed = speed
+
+	distance, err := domain.NewDistanceFromMeters(r.Distance)
+	if err != nil {
+		return domain.RunningActivity{}, fmt.Errorf("can't parse distance for activity (id=%s): %v", r.ID, err)
+	}
+	activity.Distance = distance
+
+	activityType, err := domain.ParseActivityType(r.Type)
+	if err != nil {
+		return domain.RunningActivity{}, fmt.Errorf("can't parse type for activity (id=%s): %v", r.ID, err)
+	}
+	activity.Type = activityType
+
+	visibility, err := domain.ParseActivityVisibility(r.Visibility)
+	if err != nil {
+		return domain.RunningActivity{}, fmt.Errorf("can't parse visibility for activity (id=%s): %v", r.ID, err)
+	}
+	activity.Visibility = visibility
+
+	return activity, nil
+}
+
+// GetRunningActivity returns the running activity matching the slug
+func (r PostgreSQL) GetRunningActivity(ctx context.Context, slug domain.RunningActivitySlug) (domain.RunningActivity, error) {
+	statement := `
+		SELECT ` + runningActivityColumns + `
+		FROM runs
+		WHERE ran_at >= $1 AND ran_at < $2
+		ORDER BY ran_at DESC`
+
+	from, to := slugRange(slug)
+	rows, err := r.DB.QueryContext(ctx, statement, from, to)
+	if err != nil {
+		return domain.RunningActivity{}, fmt.Errorf("can't get running activity: %v", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return domain.RunningActivity{}, domain.ErrCantGetRunningSession
+	}
+
+	activity, err := scanRunningActivity(rows)
+	if err != nil {
+		return domain.RunningActivity{}, fmt.Errorf("can't scan activity: %v", err)
+	}
+
+	return activity.ToDomain()
+}
+
+func (r PostgreSQL) DeleteRunningActivity(ctx context.Context, slug domain.RunningActivitySlug) error {
+	statement := `DELETE FROM runs WHERE ran_at >= $1 AND ran_at < $2`
+	from, to := slugRange(slug)
+	rst, err := r.DB.ExecContext(ctx, statement, from, to)
+	if err != nil {
+		return fmt.Errorf("can't delete activity: %v", err)
+	}
+
+	if count, _ := rst.RowsAffected(); count == 0 {
+		return domain.ErrCantGetRunningSession
+	}
+
+	return nil
+}
+
+// ListRunningActivities returns a list of all running activities
+func (r PostgreSQL) ListRunningActivities(ctx context.Context) ([]domain.RunningActivity, error) {
+	statement := `
+		SELECT ` + runningActivityColumns + `
+		FROM runs
+		ORDER BY ran_at DESC`
+
+	return r.listRunningActivities(ctx, statement)
+}
+
+// ListUserRunningActivities returns the running activities uploaded by the athlete
+func (r PostgreSQL) ListUserRunningActivities(ctx context.Context, username string) ([]domain.RunningActivity, error) {
+	statement := `
+		SELECT ` + runningActivityColumns + `
+		FROM runs
+		WHERE username = $1
+		ORDER BY ran_at DESC`
+
+	return r.listRunningActivities(ctx, statement, username)
+}
+
+// AssignOrphanRunningActivities gives the activities recorded before they had an owner to the athlete
+func (r PostgreSQL) AssignOrphanRunningActivities(ctx context.Context, username string) error {
+	statement := `UPDATE runs SET username = $1 WHERE username = ''`
+
+	if _, err := r.DB.ExecContext(ctx, statement, username); err != nil {
+		return fmt.Errorf("can't assign running activities: %v", err)
+	}
+
+	return nil
+}
+
+func (r PostgreSQL) listRunningActivities(ctx context.Context, statement string, args ...interface{}) ([]domain.RunningActivity, error) {
+	rows, err := r.DB.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't get running activities: %v", err)
+	}
+	defer rows.Close()
+
+	var activities []domain.RunningActivity
+	for rows.Next() {
+		dbActivity, err := scanRunningActivity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan activity: %v", err)
+		}
+
+		activity, err := dbActivity.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		activities = append(activities, activity)
+	}
+
+	return activities, nil
+}
+
+// RecordRunningActivity persists the activity in database
+func (r PostgreSQL) RecordRunningActivity(ctx context.Context, activity domain.RunningActivity) error {
+	statement := `
+		INSERT INTO runs (` + runningActivityColumns + `, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27)`
+
+	cards, err := encodeCards(activity.Cards)
+	if err != nil {
+		return fmt.Errorf("can't encode cards: %v", err)
+	}
+
+	_, err = r.DB.ExecContext(
+		ctx,
+		statement,
+		uuid.NewString(),
+		activity.RanAt,
+		activity.Duration.Milliseconds(),
+		activity.Distance.Meters(),
+		activity.Speed.KilometersPerHour(),
+		activity.GPXPath.String(),
+		activity.MapPath.String(),
+		activity.ShareableMapPath.String(),
+		activity.Title,
+		activity.Description,
+		activity.Type.String(),
+		activity.MapStyle.Theme,
+		activity.MapStyle.LineColor,
+		activity.MapStyle.LineThickness,
+		activity.MapStyle.LineOpacity,
+		activity.MapStyle.Width,
+		activity.MapStyle.Height,
+		activity.MapStyle.Padding,
+		activity.MapStyle.RouteColoring.String(),
+		activity.MapStatus.String(),
+		activity.MapError,
+		activity.ElevationChartPath.String(),
+		activity.PaceChartPath.String(),
+		cards,
+		activity.Username,
+		activity.Visibility.String(),
+		time.Now(),
+	)
+
+	if isUniqueViolation(err) {
+		return fmt.Errorf("can't record activity %s: %w", activity.Slug, domain.ErrRunningActivityAlreadyExists)
+	}
+
+	if err != nil {
+		return fmt.Errorf("can't insert into table: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateRunningActivity persists the details, map style, map status, file paths and visibility of the activity
+func (r PostgreSQL) UpdateRunningActivity(ctx context.Context, activity domain.RunningActivity) error {
+	statement := `
+		UPDATE runs SET
+			title = $1, description = $2, activity_type = $3,
+			map_theme = $4, map_line_color = $5, map_line_thickness = $6, map_line_opacity = $7,
+			map_width = $8, map_height = $9, map_padding = $10, map_route_coloring = $11,
+			map_status = $12, map_error = $13, elevation_chart_path = $14, pace_chart_path = $15, cards = $16,
+			visibility = $17, gpx_path = $18, map_path = $19, shareable_map_path = $20
+		WHERE ran_at >= $21 AND ran_at < $22`
+
+	cards, err := encodeCards(activity.Cards)
+	if err != nil {
+		return fmt.Errorf("can't encode cards: %v", err)
+	}
+
+	from, to := slugRange(activity.Slug)
+	rst, err := r.DB.ExecContext(
+		ctx,
+		statement,
+		activity.Title,
+		activity.Description,
+		activity.Type.String(),
+		activity.MapStyle.Theme,
+		activity.MapStyle.LineColor,
+		activity.MapStyle.LineThickness,
+		activity.MapStyle.LineOpacity,
+		activity.MapStyle.Width,
+		activity.MapStyle.Height,
+		activity.MapStyle.Padding,
+		activity.MapStyle.RouteColoring.String(),
+		activity.MapStatus.String(),
+		activity.MapError,
+		activity.ElevationChartPath.String(),
+		activity.PaceChartPath.String(),
+		cards,
+		activity.Visibility.String(),
+		activity.GPXPath.String(),
+		activity.MapPath.String(),
+		activity.ShareableMapPath.String(),
+		from,
+		to,
+	)
+	if err != nil {
+		return fmt.Errorf("can't update activity: %v", err)
+	}
+
+	if count, _ := rst.RowsAffected(); count == 0 {
+		return domain.ErrCantGetRunningSession
+	}
+
+	return nil
+}
+
+// slugRange returns the range of wall clock times matching the minute encoded by the slug.
+//
+// ran_at is a timestamp without time zone so only the wall clock of the parameters matters.
+func slugRange(slug domain.RunningActivitySlug) (time.Time, time.Time) {
+	t := slug.Time()
+	from := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+
+	return from, from.Add(time.Minute)
+}
+
+// isUniqueViolation returns whether the error is raised by a unique index
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// shareableCard is the representation of a card in the JSON cards column
+type shareableCard struct {
+	Template string `json:"template"`
+	Path     string `json:"path"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+}
+
+// encodeCards returns the cards as JSON, activities recorded before card templates having none
+func encodeCards(cards []domain.ShareableCard) (string, error) {
+	if len(cards) == 0 {
+		return "", nil
+	}
+
+	rows := make([]shareableCard, len(cards))
+	for i, card := range cards {
+		rows[i] = shareableCard{Template: card.Template, Path: card.Path.String(), Width: card.Width, Height: card.Height}
+	}
+
+	content, err := json.Marshal(rows)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+func decodeCards(content string) ([]domain.ShareableCard, error) {
+	if content == "" {
+		return nil, nil
+	}
+
+	var rows []shareableCard
+	if err := json.Unmarshal([]byte(content), &rows); err != nil {
+		return nil, err
+	}
+
+	cards := make([]domain.ShareableCard, len(rows))
+	for i, row := range rows {
+		cards[i] = domain.ShareableCard{
+			Template: row.Template,
+			Path:     domain.ShareableMapFilePath(row.Path),
+			Width:    row.Width,
+			Height:   row.Height,
+		}
+	}
+
+	return cards, nil
+}
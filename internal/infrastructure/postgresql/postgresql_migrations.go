@@ -0,0 +1,202 @@
+// Code generated ./scripts DO NOT EDIT
+
+package postgresql
+
+import "github.com/lonepeon/golib/sqlutil"
+
+// Migrations returns an ordered list of migrations to execute
+func Migrations() []sqlutil.Migration {
+	return []sqlutil.Migration{
+		{
+			Version: "20220410090001",
+			Script: `CREATE TABLE runs (
+  id TEXT PRIMARY KEY,
+  ran_at TIMESTAMP NOT NULL,
+  duration BIGINT NOT NULL,
+  distance INTEGER NOT NULL,
+  speed DOUBLE PRECISION NOT NULL,
+  gpx_path TEXT NOT NULL,
+  map_path TEXT NOT NULL,
+  shareable_map_path TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+)
+
+`,
+		},
+		{
+			Version: "20220410120001",
+			Script: `CREATE UNIQUE INDEX runs_ran_at_idx ON runs (ran_at);
+
+`,
+		},
+		{
+			Version: "20220417090001",
+			Script: `CREATE TABLE exports (
+  id TEXT PRIMARY KEY,
+  status TEXT NOT NULL,
+  archive_path TEXT NOT NULL DEFAULT '',
+  requested_at TIMESTAMPTZ NOT NULL,
+  completed_at TIMESTAMPTZ
+);
+
+`,
+		},
+		{
+			Version: "20220418090001",
+			Script: `ALTER TABLE runs ADD COLUMN title TEXT NOT NULL DEFAULT '';
+ALTER TABLE runs ADD COLUMN description TEXT NOT NULL DEFAULT '';
+
+`,
+		},
+		{
+			Version: "20220418100001",
+			Script: `CREATE TABLE imports (
+  id TEXT PRIMARY KEY,
+  archive_path TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE import_items (
+  import_id TEXT NOT NULL REFERENCES imports (id) ON DELETE CASCADE,
+  external_id TEXT NOT NULL,
+  file_name TEXT NOT NULL DEFAULT '',
+  ran_at TIMESTAMPTZ NOT NULL,
+  type TEXT NOT NULL DEFAULT '',
+  name TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL,
+  reason TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY (import_id, external_id)
+);
+
+`,
+		},
+		{
+			Version: "20220419090001",
+			Script: `ALTER TABLE runs ADD COLUMN activity_type TEXT NOT NULL DEFAULT 'run';
+ALTER TABLE runs ADD COLUMN map_theme TEXT NOT NULL DEFAULT 'mapbox/outdoors-v11';
+ALTER TABLE runs ADD COLUMN map_line_color TEXT NOT NULL DEFAULT 'f44';
+ALTER TABLE runs ADD COLUMN map_line_thickness INTEGER NOT NULL DEFAULT 3;
+ALTER TABLE runs ADD COLUMN map_line_opacity DOUBLE PRECISION NOT NULL DEFAULT 0.8;
+ALTER TABLE runs ADD COLUMN map_width INTEGER NOT NULL DEFAULT 800;
+ALTER TABLE runs ADD COLUMN map_height INTEGER NOT NULL DEFAULT 800;
+ALTER TABLE runs ADD COLUMN map_padding INTEGER NOT NULL DEFAULT 100;
+
+`,
+		},
+		{
+			Version: "20220420090001",
+			Script: `ALTER TABLE runs ADD COLUMN map_route_coloring TEXT NOT NULL DEFAULT 'plain';
+
+`,
+		},
+		{
+			Version: "20220421090001",
+			Script: `ALTER TABLE runs ADD COLUMN map_status TEXT NOT NULL DEFAULT 'ready';
+ALTER TABLE runs ADD COLUMN map_error TEXT NOT NULL DEFAULT '';
+
+`,
+		},
+		{
+			Version: "20220422090001",
+			Script: `ALTER TABLE runs ADD COLUMN elevation_chart_path TEXT NOT NULL DEFAULT '';
+ALTER TABLE runs ADD COLUMN pace_chart_path TEXT NOT NULL DEFAULT '';
+
+`,
+		},
+		{
+			Version: "20220423090001",
+			Script: `ALTER TABLE runs ADD COLUMN cards TEXT NOT NULL DEFAULT '';
+
+`,
+		},
+		{
+			Version: "20220424090001",
+			Script: `CREATE TABLE user_preferences (
+  username TEXT PRIMARY KEY,
+  locale TEXT NOT NULL,
+  units TEXT NOT NULL,
+  speed_display TEXT NOT NULL
+);
+
+`,
+		},
+		{
+			Version: "20220425090001",
+			Script: `CREATE TABLE api_tokens (
+  id TEXT PRIMARY KEY,
+  username TEXT NOT NULL,
+  name TEXT NOT NULL,
+  scope TEXT NOT NULL,
+  hash TEXT NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL,
+  last_used_at TIMESTAMPTZ
+);
+
+`,
+		},
+		{
+			Version: "20220426090001",
+			Script: `CREATE TABLE users (
+  username TEXT PRIMARY KEY,
+  password_hash TEXT NOT NULL,
+  is_admin BOOLEAN NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  disabled_at TIMESTAMPTZ
+);
+
+`,
+		},
+		{
+			Version: "20220427090001",
+			Script: `ALTER TABLE runs ADD COLUMN username TEXT NOT NULL DEFAULT '';
+ALTER TABLE imports ADD COLUMN username TEXT NOT NULL DEFAULT '';
+CREATE INDEX runs_username_idx ON runs (username);
+
+`,
+		},
+		{
+			Version: "20220428090001",
+			Script: `ALTER TABLE runs ADD COLUMN visibility TEXT NOT NULL DEFAULT 'public';
+
+`,
+		},
+		{
+			Version: "20220429090001",
+			Script: `CREATE TABLE privacy_zones (
+  id TEXT PRIMARY KEY,
+  username TEXT NOT NULL,
+  name TEXT NOT NULL,
+  latitude DOUBLE PRECISION NOT NULL,
+  longitude DOUBLE PRECISION NOT NULL,
+  radius_meters INTEGER NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+`,
+		},
+		{
+			Version: "20220430090001",
+			Script: `ALTER TABLE exports ADD COLUMN username TEXT NOT NULL DEFAULT '';
+CREATE INDEX exports_username_idx ON exports (username);
+
+`,
+		},
+		{
+			Version: "20220501090001",
+			Script: `-- slugs and asset paths are built from the minute the activity started at, so the uniqueness of ran_at is enforced
+-- to the minute. Only the latest activity of a minute has ever been reachable, so the others are removed.
+DELETE FROM runs
+WHERE EXISTS (
+  SELECT 1 FROM runs later
+  WHERE date_trunc('minute', later.ran_at) = date_trunc('minute', runs.ran_at) AND later.ran_at > runs.ran_at
+);
+
+DROP INDEX runs_ran_at_idx;
+
+CREATE UNIQUE INDEX runs_ran_at_minute_idx ON runs (date_trunc('minute', ran_at));
+
+`,
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package postgresql_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	_ "github.com/lib/pq" // postgres adapter
+	"github.com/ory/dockertest/v3"
+
+	"github.com/lonepeon/golib/sqlutil"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/infrastructure/postgresql"
+	"github.com/lonepeon/sport/internal/repository"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
+)
+
+const (
+	databaseName     = "sport"
+	databaseUser     = "sport"
+	databasePassword = "sport123"
+)
+
+func TestIntegration(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	db := startPostgreSQLContainer(t)
+
+	migrations := [][]sqlutil.Migration{
+		postgresql.Migrations(),
+		postgresql.JobMigrations(),
+		postgresql.SessionMigrations(),
+	}
+	for _, migration := range migrations {
+		_, err := postgresql.ExecuteMigrations(context.Background(), db, migration)
+		testutils.AssertNoError(t, err, "can't run migrations")
+	}
+
+	repositorytest.RunActivityStoreSuite(t, func(t *testing.T) (repository.ActivityStore, func()) {
+		return postgresql.New(db), func() {
+			_, err := db.Exec("TRUNCATE TABLE runs")
+			testutils.AssertNoError(t, err, "can't clean runs table")
+		}
+	})
+
+	repositorytest.RunExportStoreSuite(t, func(t *testing.T) (repository.ExportStore, func()) {
+		return postgresql.New(db), func() {
+			_, err := db.Exec("TRUNCATE TABLE exports")
+			testutils.AssertNoError(t, err, "can't clean exports table")
+		}
+	})
+
+	repositorytest.RunImportStoreSuite(t, func(t *testing.T) (repository.ImportStore, func()) {
+		return postgresql.New(db), func() {
+			_, err := db.Exec("TRUNCATE TABLE imports, import_items")
+			testutils.AssertNoError(t, err, "can't clean imports tables")
+		}
+	})
+
+	repositorytest.RunUserPreferencesStoreSuite(t, func(t *testing.T) (repository.UserPreferencesStore, func()) {
+		return postgresql.New(db), func() {
+			_, err := db.Exec("TRUNCATE TABLE user_preferences")
+			testutils.AssertNoError(t, err, "can't clean user preferences table")
+		}
+	})
+
+	repositorytest.RunAPITokenStoreSuite(t, func(t *testing.T) (repository.APITokenStore, func()) {
+		return postgresql.New(db), func() {
+			_, err := db.Exec("TRUNCATE TABLE api_tokens")
+			testutils.AssertNoError(t, err, "can't clean api tokens table")
+		}
+	})
+
+	repositorytest.RunUserStoreSuite(t, func(t *testing.T) (repository.UserStore, func()) {
+		return postgresql.New(db), func() {
+			_, err := db.Exec("TRUNCATE TABLE users")
+			testutils.AssertNoError(t, err, "can't clean users table")
+		}
+	})
+
+	repositorytest.RunPrivacyZoneStoreSuite(t, func(t *testing.T) (repository.PrivacyZoneStore, func()) {
+		return postgresql.New(db), func() {
+			_, err := db.Exec("TRUNCATE TABLE privacy_zones")
+			testutils.AssertNoError(t, err, "can't clean privacy zones table")
+		}
+	})
+}
+
+func startPostgreSQLContainer(t *testing.T) *sql.DB {
+	pool, err := dockertest.NewPool("")
+	testutils.AssertNoError(t, err, "can't connnect to docker")
+
+	postgres, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "postgres",
+		Tag:        "14-alpine",
+		Env: []string{
+			fmt.Sprintf("POSTGRES_DB=%s", databaseName),
+			fmt.Sprintf("POSTGRES_USER=%s", databaseUser),
+			fmt.Sprintf("POSTGRES_PASSWORD=%s", databasePassword),
+		},
+		ExposedPorts: []string{"5432/tcp"},
+	})
+	testutils.AssertNoError(t, err, "can't start postgres container")
+
+	// this is just in case the purge doesn't work 😅
+	testutils.AssertNoError(t, postgres.Expire(120), "can't set automatic purge")
+
+	t.Cleanup(func() {
+		testutils.AssertNoError(t, pool.Purge(postgres), "can't remove postgres container")
+	})
+
+	dsn := fmt.Sprintf(
+		"postgres://%s:%s@localhost:%s/%s?sslmode=disable",
+		databaseUser, databasePassword, postgres.GetPort("5432/tcp"), databaseName,
+	)
+
+	db, err := sql.Open("postgres", dsn)
+	testutils.AssertNoError(t, err, "can't open postgres connection")
+
+	err = pool.Retry(db.Ping)
+	testutils.AssertNoError(t, err, "can't connect to postgres container")
+
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
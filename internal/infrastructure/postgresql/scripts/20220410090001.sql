@@ -0,0 +1,11 @@
+CREATE TABLE runs (
+  id TEXT PRIMARY KEY,
+  ran_at TIMESTAMP NOT NULL,
+  duration BIGINT NOT NULL,
+  distance INTEGER NOT NULL,
+  speed DOUBLE PRECISION NOT NULL,
+  gpx_path TEXT NOT NULL,
+  map_path TEXT NOT NULL,
+  shareable_map_path TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+)
@@ -0,0 +1 @@
+CREATE UNIQUE INDEX runs_ran_at_idx ON runs (ran_at);
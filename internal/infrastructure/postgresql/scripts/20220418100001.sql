@@ -0,0 +1,18 @@
+CREATE TABLE imports (
+  id TEXT PRIMARY KEY,
+  archive_path TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE import_items (
+  import_id TEXT NOT NULL REFERENCES imports (id) ON DELETE CASCADE,
+  external_id TEXT NOT NULL,
+  file_name TEXT NOT NULL DEFAULT '',
+  ran_at TIMESTAMPTZ NOT NULL,
+  type TEXT NOT NULL DEFAULT '',
+  name TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL,
+  reason TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY (import_id, external_id)
+);
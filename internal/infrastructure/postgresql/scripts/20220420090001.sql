@@ -0,0 +1 @@
+ALTER TABLE runs ADD COLUMN map_route_coloring TEXT NOT NULL DEFAULT 'plain';
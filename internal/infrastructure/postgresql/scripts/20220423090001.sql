@@ -0,0 +1 @@
+ALTER TABLE runs ADD COLUMN cards TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE runs ADD COLUMN username TEXT NOT NULL DEFAULT '';
+ALTER TABLE imports ADD COLUMN username TEXT NOT NULL DEFAULT '';
+CREATE INDEX runs_username_idx ON runs (username);
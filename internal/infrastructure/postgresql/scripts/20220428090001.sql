@@ -0,0 +1 @@
+ALTER TABLE runs ADD COLUMN visibility TEXT NOT NULL DEFAULT 'public';
@@ -0,0 +1,9 @@
+CREATE TABLE privacy_zones (
+  id TEXT PRIMARY KEY,
+  username TEXT NOT NULL,
+  name TEXT NOT NULL,
+  latitude DOUBLE PRECISION NOT NULL,
+  longitude DOUBLE PRECISION NOT NULL,
+  radius_meters INTEGER NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
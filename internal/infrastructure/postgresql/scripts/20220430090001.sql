@@ -0,0 +1,2 @@
+ALTER TABLE exports ADD COLUMN username TEXT NOT NULL DEFAULT '';
+CREATE INDEX exports_username_idx ON exports (username);
@@ -0,0 +1,11 @@
+-- slugs and asset paths are built from the minute the activity started at, so the uniqueness of ran_at is enforced
+-- to the minute. Only the latest activity of a minute has ever been reachable, so the others are removed.
+DELETE FROM runs
+WHERE EXISTS (
+  SELECT 1 FROM runs later
+  WHERE date_trunc('minute', later.ran_at) = date_trunc('minute', runs.ran_at) AND later.ran_at > runs.ran_at
+);
+
+DROP INDEX runs_ran_at_idx;
+
+CREATE UNIQUE INDEX runs_ran_at_minute_idx ON runs (date_trunc('minute', ran_at));
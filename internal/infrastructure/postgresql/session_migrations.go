@@ -0,0 +1,22 @@
+// Code generated -fname session_migrations.go -func SessionMigrations ./session_scripts DO NOT EDIT
+
+package postgresql
+
+import "github.com/lonepeon/golib/sqlutil"
+
+// SessionMigrations returns an ordered list of migrations to execute
+func SessionMigrations() []sqlutil.Migration {
+	return []sqlutil.Migration{
+		{
+			Version: "202204100002",
+			Script: `CREATE TABLE sessions (
+  id TEXT PRIMARY KEY,
+  data TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  expired_at TIMESTAMPTZ NOT NULL
+)
+
+`,
+		},
+	}
+}
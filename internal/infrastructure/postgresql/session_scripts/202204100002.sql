@@ -0,0 +1,6 @@
+CREATE TABLE sessions (
+  id TEXT PRIMARY KEY,
+  data TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  expired_at TIMESTAMPTZ NOT NULL
+)
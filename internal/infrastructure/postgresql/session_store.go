@@ -0,0 +1,153 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/securecookie"
+	"github.com/gorilla/sessions"
+)
+
+// SessionStore persists gorilla sessions in a PostgreSQL database
+type SessionStore struct {
+	db *sql.DB
+
+	Codecs  []securecookie.Codec
+	Options sessions.Options
+}
+
+// NewSessionStore initializes a session store backed by the sessions table
+func NewSessionStore(db *sql.DB, options sessions.Options, keyPairs ...[]byte) *SessionStore {
+	return &SessionStore{db: db, Options: options, Codecs: securecookie.CodecsFromPairs(keyPairs...)}
+}
+
+// Get implements gorilla.sessions: returns a cached session when it exists
+func (s *SessionStore) Get(r *http.Request, name string) (*sessions.Session, error) {
+	return sessions.GetRegistry(r).Get(s, name)
+}
+
+// New implements gorilla.sessions: creates a new session or loads the one referenced by the cookie
+func (s *SessionStore) New(r *http.Request, name string) (*sessions.Session, error) {
+	sess := sessions.NewSession(s, name)
+
+	option := s.Options
+	sess.Options = &option
+	sess.IsNew = true
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		if errors.Is(err, http.ErrNoCookie) {
+			return sess, nil
+		}
+		return sess, fmt.Errorf("can't extract cookie from request (cookie=%s): %w", name, err)
+	}
+
+	if err = securecookie.DecodeMulti(name, cookie.Value, &sess.ID, s.Codecs...); err != nil {
+		return sess, fmt.Errorf("can't decode cookie value: %w", err)
+	}
+
+	if err := s.fillSession(r.Context(), sess); err != nil {
+		return sess, fmt.Errorf("cant fill session: %w", err)
+	}
+
+	sess.IsNew = false
+
+	return sess, nil
+}
+
+// Save implements gorilla.sessions: persists the session and sets the cookie
+func (s *SessionStore) Save(r *http.Request, w http.ResponseWriter, sess *sessions.Session) error {
+	if err := s.saveSession(r.Context(), sess); err != nil {
+		return err
+	}
+
+	data, err := securecookie.EncodeMulti(sess.Name(), sess.ID, s.Codecs...)
+	if err != nil {
+		return fmt.Errorf("can't encode session ID (id=%s): %w", sess.ID, err)
+	}
+
+	http.SetCookie(w, sessions.NewCookie(sess.Name(), data, sess.Options))
+
+	return nil
+}
+
+func (s *SessionStore) saveSession(ctx context.Context, sess *sessions.Session) error {
+	data, err := securecookie.EncodeMulti(sess.Name(), sess.Values, s.Codecs...)
+	if err != nil {
+		return fmt.Errorf("can't encode session values: %w", err)
+	}
+
+	if sess.ID == "" || sess.IsNew {
+		return s.insertSession(ctx, sess, data)
+	}
+
+	return s.updateSession(ctx, sess, data)
+}
+
+func (s *SessionStore) insertSession(ctx context.Context, sess *sessions.Session, data string) error {
+	now := time.Now()
+	sessID := uuid.NewString()
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO sessions (id, data, created_at, expired_at) VALUES ($1, $2, $3, $4)`,
+		sessID, data, now, now.Add(time.Duration(sess.Options.MaxAge)*time.Second),
+	)
+	if err != nil {
+		return fmt.Errorf("can't persist new session in database: %v", err)
+	}
+
+	sess.IsNew = false
+	sess.ID = sessID
+
+	return nil
+}
+
+func (s *SessionStore) updateSession(ctx context.Context, sess *sessions.Session, data string) error {
+	result, err := s.db.ExecContext(ctx, `UPDATE sessions SET data = $1 WHERE id = $2`, data, sess.ID)
+	if err != nil {
+		return fmt.Errorf("can't persist existing session in database (id=%s): %v", sess.ID, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't be sure if session has been updated: can't get the number of row affected: %v", err)
+	}
+
+	if count == 0 {
+		return fmt.Errorf("can't update session (id=%s): session not found", sess.ID)
+	}
+
+	return nil
+}
+
+func (s *SessionStore) fillSession(ctx context.Context, sess *sessions.Session) error {
+	var data string
+	var expiredAt time.Time
+
+	row := s.db.QueryRowContext(ctx, "SELECT data, expired_at FROM sessions WHERE id = $1", sess.ID)
+	if err := row.Scan(&data, &expiredAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("can't find session with id (id=%s)", sess.ID)
+		}
+		return fmt.Errorf("can't scan session row (id=%s): %v", sess.ID, err)
+	}
+
+	if expiredAt.Before(time.Now()) {
+		return fmt.Errorf("can't get expired session (id=%s, expiredAt=%s)", sess.ID, expiredAt)
+	}
+
+	if err := securecookie.DecodeMulti(sess.Name(), data, &sess.Values, s.Codecs...); err != nil {
+		return fmt.Errorf("can't decode session data (id=%s): %w", sess.ID, err)
+	}
+
+	sess.Options.MaxAge = int(time.Until(expiredAt).Seconds())
+
+	return nil
+}
+
+var _ sessions.Store = &SessionStore{}
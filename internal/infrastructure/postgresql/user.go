@@ -0,0 +1,113 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+type user struct {
+	Username     string
+	PasswordHash string
+	IsAdmin      bool
+	CreatedAt    time.Time
+	DisabledAt   sql.NullTime
+}
+
+func (u user) ToDomain() domain.User {
+	result := domain.User{
+		Username:     u.Username,
+		PasswordHash: domain.UserPasswordHash(u.PasswordHash),
+		IsAdmin:      u.IsAdmin,
+		CreatedAt:    u.CreatedAt.UTC(),
+	}
+
+	if u.DisabledAt.Valid {
+		result.DisabledAt = u.DisabledAt.Time.UTC()
+	}
+
+	return result
+}
+
+func (u *user) fields() []interface{} {
+	return []interface{}{&u.Username, &u.PasswordHash, &u.IsAdmin, &u.CreatedAt, &u.DisabledAt}
+}
+
+// RecordUser persists the user in database
+func (r PostgreSQL) RecordUser(ctx context.Context, u domain.User) error {
+	statement := `
+		INSERT INTO users (username, password_hash, is_admin, created_at, disabled_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.DB.ExecContext(ctx, statement, u.Username, u.PasswordHash.String(), u.IsAdmin, u.CreatedAt,
+		nullableTime(u.DisabledAt))
+	if err != nil {
+		return fmt.Errorf("can't insert into table: %v", err)
+	}
+
+	return nil
+}
+
+// GetUser returns the user with this username
+func (r PostgreSQL) GetUser(ctx context.Context, username string) (domain.User, error) {
+	statement := `
+		SELECT username, password_hash, is_admin, created_at, disabled_at
+		FROM users
+		WHERE username = $1`
+
+	var dbUser user
+	err := r.DB.QueryRowContext(ctx, statement, username).Scan(dbUser.fields()...)
+	if err == sql.ErrNoRows {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return domain.User{}, fmt.Errorf("can't get user: %v", err)
+	}
+
+	return dbUser.ToDomain(), nil
+}
+
+// ListUsers returns all the users, sorted by username
+func (r PostgreSQL) ListUsers(ctx context.Context) ([]domain.User, error) {
+	statement := `
+		SELECT username, password_hash, is_admin, created_at, disabled_at
+		FROM users
+		ORDER BY username`
+
+	rows, err := r.DB.QueryContext(ctx, statement)
+	if err != nil {
+		return nil, fmt.Errorf("can't get users: %v", err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var dbUser user
+		if err := rows.Scan(dbUser.fields()...); err != nil {
+			return nil, fmt.Errorf("can't scan user: %v", err)
+		}
+
+		users = append(users, dbUser.ToDomain())
+	}
+
+	return users, nil
+}
+
+// UpdateUser saves the password, role and status of the user
+func (r PostgreSQL) UpdateUser(ctx context.Context, u domain.User) error {
+	statement := `UPDATE users SET password_hash = $1, is_admin = $2, disabled_at = $3 WHERE username = $4`
+
+	rst, err := r.DB.ExecContext(ctx, statement, u.PasswordHash.String(), u.IsAdmin, nullableTime(u.DisabledAt), u.Username)
+	if err != nil {
+		return fmt.Errorf("can't update user: %v", err)
+	}
+
+	if count, _ := rst.RowsAffected(); count == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
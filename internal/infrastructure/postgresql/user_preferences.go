@@ -0,0 +1,44 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// GetUserPreferences returns the preferences saved by the user
+func (r PostgreSQL) GetUserPreferences(ctx context.Context, username string) (domain.UserPreferences, error) {
+	statement := `SELECT locale, units, speed_display FROM user_preferences WHERE username = $1`
+
+	var locale, units, speedDisplay string
+	err := r.DB.QueryRowContext(ctx, statement, username).Scan(&locale, &units, &speedDisplay)
+	if err == sql.ErrNoRows {
+		return domain.UserPreferences{}, domain.ErrUserPreferencesNotFound
+	}
+	if err != nil {
+		return domain.UserPreferences{}, fmt.Errorf("can't get user preferences: %v", err)
+	}
+
+	prefs, err := domain.NewUserPreferences(locale, units, speedDisplay)
+	if err != nil {
+		return domain.UserPreferences{}, fmt.Errorf("can't parse preferences of user %s: %v", username, err)
+	}
+
+	return prefs, nil
+}
+
+// SaveUserPreferences inserts or replaces the preferences of the user
+func (r PostgreSQL) SaveUserPreferences(ctx context.Context, username string, prefs domain.UserPreferences) error {
+	statement := `
+		INSERT INTO user_preferences (username, locale, units, speed_display) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (username) DO UPDATE SET locale = excluded.locale, units = excluded.units, speed_display = excluded.speed_display`
+
+	_, err := r.DB.ExecContext(ctx, statement, username, prefs.Locale.String(), prefs.Units.String(), prefs.SpeedDisplay.String())
+	if err != nil {
+		return fmt.Errorf("can't save user preferences: %v", err)
+	}
+
+	return nil
+}
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"path"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -45,6 +48,11 @@ func (b *Bucket) StoreAsset(r io.Reader, dest string) error {
 
 	uploader := s3manager.NewUploader(sess)
 	input := s3manager.UploadInput{Bucket: aws.String(b.name), Key: aws.String(dest), Body: r}
+	// browsers only display SVG images served with their content type
+	if contentType := mime.TypeByExtension(path.Ext(dest)); contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+
 	if _, err := uploader.Upload(&input); err != nil {
 		return fmt.Errorf("can't upload file: %w: %v", ErrGeneric, err)
 	}
@@ -66,6 +74,63 @@ func (b *Bucket) DeleteAsset(path string) error {
 	return nil
 }
 
+// FetchAsset returns the content of a stored file. The caller is in charge of closing it.
+func (b *Bucket) FetchAsset(path string) (io.ReadCloser, error) {
+	sess, err := b.openSession()
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize s3 client: %w: %v", ErrGeneric, err)
+	}
+
+	svc := s3.New(sess)
+	output, err := svc.GetObject(&s3.GetObjectInput{Bucket: &b.name, Key: aws.String(path)})
+	if err != nil {
+		return nil, fmt.Errorf("can't get s3 file: %v", err)
+	}
+
+	return output.Body, nil
+}
+
+// PresignAsset returns a URL giving access to a stored file, even in a private bucket, until it expires
+func (b *Bucket) PresignAsset(path string, expiry time.Duration) (string, error) {
+	sess, err := b.openSession()
+	if err != nil {
+		return "", fmt.Errorf("can't initialize s3 client: %w: %v", ErrGeneric, err)
+	}
+
+	svc := s3.New(sess)
+	req, _ := svc.GetObjectRequest(&s3.GetObjectInput{Bucket: &b.name, Key: aws.String(path)})
+	url, err := req.Presign(expiry)
+	if err != nil {
+		return "", fmt.Errorf("can't presign s3 file: %v", err)
+	}
+
+	return url, nil
+}
+
+// ListAssets returns the name of all the files starting with prefix, sorted alphabetically
+func (b *Bucket) ListAssets(prefix string) ([]string, error) {
+	sess, err := b.openSession()
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize s3 client: %w: %v", ErrGeneric, err)
+	}
+
+	var names []string
+	svc := s3.New(sess)
+	input := s3.ListObjectsV2Input{Bucket: &b.name, Prefix: aws.String(prefix)}
+	err = svc.ListObjectsV2Pages(&input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			names = append(names, aws.StringValue(object.Key))
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't list s3 files (prefix=%s): %v", prefix, err)
+	}
+
+	return names, nil
+}
+
 func (b *Bucket) openSession() (*session.Session, error) {
 	return session.NewSessionWithOptions(session.Options{
 		Config: aws.Config{
@@ -29,6 +29,74 @@ func TestIntegration(t *testing.T) {
 	t.Run("StoreAssetFileInvalidCredentials", testStoreAssetFileInvalidCredentials)
 	t.Run("DeleteAssetFileSuccess", testDeleteAssetFileSuccess)
 	t.Run("DeleteAssetFileNotExistingFile", testDeleteAssetFileNotExistingFile)
+	t.Run("FetchAssetFileSuccess", testFetchAssetFileSuccess)
+	t.Run("ListAssetsSuccess", testListAssetsSuccess)
+	t.Run("PresignAssetSuccess", testPresignAssetSuccess)
+}
+
+func testFetchAssetFileSuccess(t *testing.T) {
+	bucketEndpoint := setupS3(t)
+	os.Setenv("AWS_ACCESS_KEY_ID", bucketUser)
+	os.Setenv("AWS_SECRET_ACCESS_KEY", bucketPassword)
+	bucket := s3.NewBucket(bucketName, "eu-west-3")
+	bucket.Endpoint = bucketEndpoint
+
+	expectedFileContent := "an important note"
+
+	err := bucket.StoreAsset(strings.NewReader(expectedFileContent), "/a/nice/file.txt")
+	testutils.AssertNoError(t, err, "can't store file")
+
+	file, err := bucket.FetchAsset("/a/nice/file.txt")
+	testutils.AssertNoError(t, err, "can't fetch file")
+	defer file.Close()
+
+	actualFileContent, err := io.ReadAll(file)
+	testutils.AssertNoError(t, err, "can't read fetched file")
+	testutils.AssertEqualString(t, expectedFileContent, string(actualFileContent), "unexpected file content")
+}
+
+func testPresignAssetSuccess(t *testing.T) {
+	bucketEndpoint := setupS3(t)
+	os.Setenv("AWS_ACCESS_KEY_ID", bucketUser)
+	os.Setenv("AWS_SECRET_ACCESS_KEY", bucketPassword)
+	bucket := s3.NewBucket(bucketName, "eu-west-3")
+	bucket.Endpoint = bucketEndpoint
+
+	expectedFileContent := "an important note"
+
+	err := bucket.StoreAsset(strings.NewReader(expectedFileContent), "a/nice/file.txt")
+	testutils.AssertNoError(t, err, "can't store file")
+
+	url, err := bucket.PresignAsset("a/nice/file.txt", time.Minute)
+	testutils.RequireNoError(t, err, "can't presign file")
+
+	resp, err := http.Get(url)
+	testutils.RequireNoError(t, err, "can't download presigned file")
+	defer resp.Body.Close()
+
+	actualFileContent, err := io.ReadAll(resp.Body)
+	testutils.AssertNoError(t, err, "can't read presigned file")
+	testutils.AssertEqualInt(t, http.StatusOK, resp.StatusCode, "unexpected status code: %s", actualFileContent)
+	testutils.AssertEqualString(t, expectedFileContent, string(actualFileContent), "unexpected file content")
+}
+
+func testListAssetsSuccess(t *testing.T) {
+	bucketEndpoint := setupS3(t)
+	os.Setenv("AWS_ACCESS_KEY_ID", bucketUser)
+	os.Setenv("AWS_SECRET_ACCESS_KEY", bucketPassword)
+	bucket := s3.NewBucket(bucketName, "eu-west-3")
+	bucket.Endpoint = bucketEndpoint
+
+	for _, name := range []string{"backups/2.txt", "backups/1.txt", "other/3.txt"} {
+		err := bucket.StoreAsset(strings.NewReader("content"), name)
+		testutils.AssertNoError(t, err, "can't store file %s", name)
+	}
+
+	names, err := bucket.ListAssets("backups/")
+	testutils.AssertNoError(t, err, "can't list files")
+	testutils.AssertEqualInt(t, 2, len(names), "unexpected number of files: %v", names)
+	testutils.AssertEqualString(t, "backups/1.txt", names[0], "unexpected first file")
+	testutils.AssertEqualString(t, "backups/2.txt", names[1], "unexpected second file")
 }
 
 func testStoreAssetFileInvalidCredentials(t *testing.T) {
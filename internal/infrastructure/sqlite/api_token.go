@@ -0,0 +1,149 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+type apiToken struct {
+	ID         string
+	Username   string
+	Name       string
+	Scope      string
+	Hash       string
+	CreatedAt  int64
+	LastUsedAt sql.NullInt64
+}
+
+func (t apiToken) ToDomain() (domain.APIToken, error) {
+	id, err := domain.ParseID(t.ID)
+	if err != nil {
+		return domain.APIToken{}, fmt.Errorf("can't parse api token id: %v", err)
+	}
+
+	scope, err := domain.ParseAPITokenScope(t.Scope)
+	if err != nil {
+		return domain.APIToken{}, fmt.Errorf("can't parse scope of api token %s: %v", t.ID, err)
+	}
+
+	result := domain.APIToken{
+		ID:        id,
+		Username:  t.Username,
+		Name:      t.Name,
+		Scope:     scope,
+		Hash:      domain.APITokenHash(t.Hash),
+		CreatedAt: time.Unix(t.CreatedAt, 0).UTC(),
+	}
+
+	if t.LastUsedAt.Valid {
+		result.LastUsedAt = time.Unix(t.LastUsedAt.Int64, 0).UTC()
+	}
+
+	return result, nil
+}
+
+func (t *apiToken) fields() []interface{} {
+	return []interface{}{&t.ID, &t.Username, &t.Name, &t.Scope, &t.Hash, &t.CreatedAt, &t.LastUsedAt}
+}
+
+// RecordAPIToken persists the token in database
+func (r SQLite) RecordAPIToken(ctx context.Context, t domain.APIToken) error {
+	statement := `
+		INSERT INTO api_tokens (id, username, name, scope, hash, created_at, last_used_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.DB.ExecContext(ctx, statement, t.ID.String(), t.Username, t.Name, t.Scope.String(), t.Hash.String(),
+		t.CreatedAt.Unix(), nullableUnix(t.LastUsedAt))
+	if err != nil {
+		return fmt.Errorf("can't insert into table: %v", err)
+	}
+
+	return nil
+}
+
+// ListAPITokens returns all the tokens of the user, from the most recent one
+func (r SQLite) ListAPITokens(ctx context.Context, username string) ([]domain.APIToken, error) {
+	statement := `
+		SELECT id, username, name, scope, hash, created_at, last_used_at
+		FROM api_tokens
+		WHERE username = ?
+		ORDER BY created_at DESC`
+
+	rows, err := r.DB.QueryContext(ctx, statement, username)
+	if err != nil {
+		return nil, fmt.Errorf("can't get api tokens: %v", err)
+	}
+	defer rows.Close()
+
+	var tokens []domain.APIToken
+	for rows.Next() {
+		var dbToken apiToken
+		if err := rows.Scan(dbToken.fields()...); err != nil {
+			return nil, fmt.Errorf("can't scan api token: %v", err)
+		}
+
+		t, err := dbToken.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, t)
+	}
+
+	return tokens, nil
+}
+
+// GetAPITokenByHash returns the token whose secret matches the hash
+func (r SQLite) GetAPITokenByHash(ctx context.Context, hash domain.APITokenHash) (domain.APIToken, error) {
+	statement := `
+		SELECT id, username, name, scope, hash, created_at, last_used_at
+		FROM api_tokens
+		WHERE hash = ?`
+
+	var dbToken apiToken
+	err := r.DB.QueryRowContext(ctx, statement, hash.String()).Scan(dbToken.fields()...)
+	if err == sql.ErrNoRows {
+		return domain.APIToken{}, domain.ErrAPITokenNotFound
+	}
+	if err != nil {
+		return domain.APIToken{}, fmt.Errorf("can't get api token: %v", err)
+	}
+
+	return dbToken.ToDomain()
+}
+
+// TouchAPIToken records when the token was last used
+func (r SQLite) TouchAPIToken(ctx context.Context, id domain.ID, usedAt time.Time) error {
+	statement := `UPDATE api_tokens SET last_used_at = ? WHERE id = ?`
+
+	rst, err := r.DB.ExecContext(ctx, statement, usedAt.Unix(), id.String())
+	if err != nil {
+		return fmt.Errorf("can't update api token: %v", err)
+	}
+
+	if count, _ := rst.RowsAffected(); count == 0 {
+		return domain.ErrAPITokenNotFound
+	}
+
+	return nil
+}
+
+// DeleteAPIToken revokes the token when it belongs to the user
+func (r SQLite) DeleteAPIToken(ctx context.Context, username string, id domain.ID) error {
+	statement := `DELETE FROM api_tokens WHERE id = ? AND username = ?`
+
+	rst, err := r.DB.ExecContext(ctx, statement, id.String(), username)
+	if err != nil {
+		return fmt.Errorf("can't delete api token: %v", err)
+	}
+
+	if count, _ := rst.RowsAffected(); count == 0 {
+		return domain.ErrAPITokenNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+type export struct {
+	ID          string
+	Status      string
+	ArchivePath string
+	RequestedAt int64
+	Username    string
+	CompletedAt sql.NullInt64
+}
+
+func (e export) ToDomain() (domain.Export, error) {
+	id, err := domain.ParseID(e.ID)
+	if err != nil {
+		return domain.Export{}, fmt.Errorf("can't parse export id: %v", err)
+	}
+
+	result := domain.Export{
+		ID:          id,
+		Status:      domain.ExportStatus(e.Status),
+		ArchivePath: domain.ExportArchivePath(e.ArchivePath),
+		Username:    e.Username,
+		RequestedAt: time.Unix(e.RequestedAt, 0).UTC(),
+	}
+
+	if e.CompletedAt.Valid {
+		result.CompletedAt = time.Unix(e.CompletedAt.Int64, 0).UTC()
+	}
+
+	return result, nil
+}
+
+// GetExport returns the export matching the identifier
+func (r SQLite) GetExport(ctx context.Context, id domain.ID) (domain.Export, error) {
+	statement := `
+		SELECT id, status, archive_path, requested_at, completed_at, username
+		FROM exports
+		WHERE id = ?`
+
+	var dbExport export
+	err := r.DB.QueryRowContext(ctx, statement, id.String()).
+		Scan(&dbExport.ID, &dbExport.Status, &dbExport.ArchivePath, &dbExport.RequestedAt, &dbExport.CompletedAt, &dbExport.Username)
+	if err == sql.ErrNoRows {
+		return domain.Export{}, domain.ErrExportNotFound
+	}
+	if err != nil {
+		return domain.Export{}, fmt.Errorf("can't get export: %v", err)
+	}
+
+	return dbExport.ToDomain()
+}
+
+// ListExports returns the exports requested by the athlete, from the most recent one
+func (r SQLite) ListExports(ctx context.Context, username string) ([]domain.Export, error) {
+	statement := `
+		SELECT id, status, archive_path, requested_at, completed_at, username
+		FROM exports
+		WHERE username = ?
+		ORDER BY requested_at DESC`
+
+	rows, err := r.DB.QueryContext(ctx, statement, username)
+	if err != nil {
+		return nil, fmt.Errorf("can't get exports: %v", err)
+	}
+	defer rows.Close()
+
+	var exports []domain.Export
+	for rows.Next() {
+		var dbExport export
+		err := rows.Scan(&dbExport.ID, &dbExport.Status, &dbExport.ArchivePath, &dbExport.RequestedAt, &dbExport.CompletedAt, &dbExport.Username)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan export: %v", err)
+		}
+
+		e, err := dbExport.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		exports = append(exports, e)
+	}
+
+	return exports, nil
+}
+
+// RecordExport persists the export in database
+func (r SQLite) RecordExport(ctx context.Context, e domain.Export) error {
+	statement := `INSERT INTO exports (id, status, archive_path, requested_at, completed_at, username) VALUES (?, ?, ?, ?, ?, ?)`
+
+	_, err := r.DB.ExecContext(ctx, statement, e.ID.String(), e.Status.String(), e.ArchivePath.String(), e.RequestedAt.Unix(), nullableUnix(e.CompletedAt), e.Username)
+	if err != nil {
+		return fmt.Errorf("can't insert into table: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateExport persists the status and archive of an existing export
+func (r SQLite) UpdateExport(ctx context.Context, e domain.Export) error {
+	statement := `UPDATE exports SET status = ?, archive_path = ?, completed_at = ? WHERE id = ?`
+
+	rst, err := r.DB.ExecContext(ctx, statement, e.Status.String(), e.ArchivePath.String(), nullableUnix(e.CompletedAt), e.ID.String())
+	if err != nil {
+		return fmt.Errorf("can't update export: %v", err)
+	}
+
+	if count, _ := rst.RowsAffected(); count == 0 {
+		return domain.ErrExportNotFound
+	}
+
+	return nil
+}
+
+func nullableUnix(t time.Time) sql.NullInt64 {
+	if t.IsZero() {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: t.Unix(), Valid: true}
+}
@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+type privacyZone struct {
+	ID           string
+	Username     string
+	Name         string
+	Latitude     float64
+	Longitude    float64
+	RadiusMeters int
+	CreatedAt    int64
+}
+
+func (z privacyZone) ToDomain() (domain.PrivacyZone, error) {
+	id, err := domain.ParseID(z.ID)
+	if err != nil {
+		return domain.PrivacyZone{}, fmt.Errorf("can't parse privacy zone id: %v", err)
+	}
+
+	return domain.PrivacyZone{
+		ID:           id,
+		Username:     z.Username,
+		Name:         z.Name,
+		Latitude:     z.Latitude,
+		Longitude:    z.Longitude,
+		RadiusMeters: z.RadiusMeters,
+		CreatedAt:    time.Unix(z.CreatedAt, 0).UTC(),
+	}, nil
+}
+
+func (z *privacyZone) fields() []interface{} {
+	return []interface{}{&z.ID, &z.Username, &z.Name, &z.Latitude, &z.Longitude, &z.RadiusMeters, &z.CreatedAt}
+}
+
+// RecordPrivacyZone persists the zone in database
+func (r SQLite) RecordPrivacyZone(ctx context.Context, z domain.PrivacyZone) error {
+	statement := `
+		INSERT INTO privacy_zones (id, username, name, latitude, longitude, radius_meters, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.DB.ExecContext(ctx, statement, z.ID.String(), z.Username, z.Name, z.Latitude, z.Longitude,
+		z.RadiusMeters, z.CreatedAt.Unix())
+	if err != nil {
+		return fmt.Errorf("can't insert into table: %v", err)
+	}
+
+	return nil
+}
+
+// ListPrivacyZones returns all the zones of the user, from the most recent one
+func (r SQLite) ListPrivacyZones(ctx context.Context, username string) (domain.PrivacyZones, error) {
+	statement := `
+		SELECT id, username, name, latitude, longitude, radius_meters, created_at
+		FROM privacy_zones
+		WHERE username = ?
+		ORDER BY created_at DESC`
+
+	rows, err := r.DB.QueryContext(ctx, statement, username)
+	if err != nil {
+		return nil, fmt.Errorf("can't get privacy zones: %v", err)
+	}
+	defer rows.Close()
+
+	var zones domain.PrivacyZones
+	for rows.Next() {
+		var dbZone privacyZone
+		if err := rows.Scan(dbZone.fields()...); err != nil {
+			return nil, fmt.Errorf("can't scan privacy zone: %v", err)
+		}
+
+		z, err := dbZone.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		zones = append(zones, z)
+	}
+
+	return zones, nil
+}
+
+// DeletePrivacyZone removes the zone when it belongs to the user
+func (r SQLite) DeletePrivacyZone(ctx context.Context, username string, id domain.ID) error {
+	statement := `DELETE FROM privacy_zones WHERE id = ? AND username = ?`
+
+	rst, err := r.DB.ExecContext(ctx, statement, id.String(), username)
+	if err != nil {
+		return fmt.Errorf("can't delete privacy zone: %v", err)
+	}
+
+	if count, _ := rst.RowsAffected(); count == 0 {
+		return domain.ErrPrivacyZoneNotFound
+	}
+
+	return nil
+}
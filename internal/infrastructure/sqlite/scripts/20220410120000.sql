@@ -0,0 +1,64 @@
+-- ran_at holds the wall clock time the activity started at, as a unix timestamp in seconds,
+-- so the slug built from it doesn't depend on the offset stored by the previous TEXT column.
+-- duration holds milliseconds and is parsed back from the time.Duration.String() format.
+BEGIN TRANSACTION;
+
+CREATE TABLE runs_typed (
+  id TEXT PRIMARY KEY,
+  ran_at INTEGER NOT NULL,
+  duration INTEGER NOT NULL,
+  distance INTEGER NOT NULL,
+  speed REAL NOT NULL,
+  gpx_path TEXT NOT NULL,
+  map_path TEXT NOT NULL,
+  shareable_map_path TEXT NOT NULL,
+  created_at INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX runs_typed_ran_at_idx ON runs_typed (ran_at);
+
+-- rows sharing the same ran_at also share the same slug and asset paths: only the first
+-- recorded one has ever been reachable so the others are ignored.
+INSERT OR IGNORE INTO runs_typed (id, ran_at, duration, distance, speed, gpx_path, map_path, shareable_map_path, created_at)
+WITH
+  hours AS (
+    SELECT
+      *,
+      CASE WHEN instr(duration, 'h') > 0 THEN CAST(substr(duration, 1, instr(duration, 'h') - 1) AS INTEGER) ELSE 0 END AS duration_hours,
+      substr(duration, instr(duration, 'h') + 1) AS duration_after_hours
+    FROM runs
+  ),
+  minutes AS (
+    SELECT
+      *,
+      CASE WHEN instr(duration_after_hours, 'm') > 0 THEN CAST(substr(duration_after_hours, 1, instr(duration_after_hours, 'm') - 1) AS INTEGER) ELSE 0 END AS duration_minutes,
+      substr(duration_after_hours, instr(duration_after_hours, 'm') + 1) AS duration_after_minutes
+    FROM hours
+  )
+SELECT
+  id,
+  CAST(strftime('%s', substr(ran_at, 1, 19)) AS INTEGER),
+  CASE
+    WHEN duration LIKE '%ms' THEN CAST(round(CAST(substr(duration, 1, length(duration) - 2) AS REAL)) AS INTEGER)
+    WHEN duration LIKE '%µs' OR duration LIKE '%ns' THEN 0
+    ELSE (duration_hours * 3600 + duration_minutes * 60) * 1000
+      + CAST(round(CAST(substr(duration_after_minutes, 1, length(duration_after_minutes) - 1) AS REAL) * 1000) AS INTEGER)
+  END,
+  CAST(round(distance) AS INTEGER),
+  speed,
+  gpx_path,
+  map_path,
+  COALESCE(shareable_map_path, ''),
+  CAST(strftime('%s', created_at) AS INTEGER)
+FROM minutes
+ORDER BY created_at ASC;
+
+DROP TABLE runs;
+
+ALTER TABLE runs_typed RENAME TO runs;
+
+DROP INDEX runs_typed_ran_at_idx;
+
+CREATE UNIQUE INDEX runs_ran_at_idx ON runs (ran_at);
+
+COMMIT;
@@ -0,0 +1,7 @@
+CREATE TABLE exports (
+  id TEXT PRIMARY KEY,
+  status TEXT NOT NULL,
+  archive_path TEXT NOT NULL DEFAULT '',
+  requested_at INTEGER NOT NULL,
+  completed_at INTEGER
+);
@@ -0,0 +1,2 @@
+ALTER TABLE runs ADD COLUMN title TEXT NOT NULL DEFAULT '';
+ALTER TABLE runs ADD COLUMN description TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,8 @@
+ALTER TABLE runs ADD COLUMN activity_type TEXT NOT NULL DEFAULT 'run';
+ALTER TABLE runs ADD COLUMN map_theme TEXT NOT NULL DEFAULT 'mapbox/outdoors-v11';
+ALTER TABLE runs ADD COLUMN map_line_color TEXT NOT NULL DEFAULT 'f44';
+ALTER TABLE runs ADD COLUMN map_line_thickness INTEGER NOT NULL DEFAULT 3;
+ALTER TABLE runs ADD COLUMN map_line_opacity REAL NOT NULL DEFAULT 0.8;
+ALTER TABLE runs ADD COLUMN map_width INTEGER NOT NULL DEFAULT 800;
+ALTER TABLE runs ADD COLUMN map_height INTEGER NOT NULL DEFAULT 800;
+ALTER TABLE runs ADD COLUMN map_padding INTEGER NOT NULL DEFAULT 100;
@@ -0,0 +1,2 @@
+ALTER TABLE runs ADD COLUMN map_status TEXT NOT NULL DEFAULT 'ready';
+ALTER TABLE runs ADD COLUMN map_error TEXT NOT NULL DEFAULT '';
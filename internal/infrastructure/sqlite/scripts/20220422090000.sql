@@ -0,0 +1,2 @@
+ALTER TABLE runs ADD COLUMN elevation_chart_path TEXT NOT NULL DEFAULT '';
+ALTER TABLE runs ADD COLUMN pace_chart_path TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+CREATE TABLE user_preferences (
+  username TEXT PRIMARY KEY,
+  locale TEXT NOT NULL,
+  units TEXT NOT NULL,
+  speed_display TEXT NOT NULL
+);
@@ -0,0 +1,7 @@
+CREATE TABLE users (
+  username TEXT PRIMARY KEY,
+  password_hash TEXT NOT NULL,
+  is_admin INTEGER NOT NULL,
+  created_at INTEGER NOT NULL,
+  disabled_at INTEGER
+);
@@ -0,0 +1,9 @@
+CREATE TABLE privacy_zones (
+  id TEXT PRIMARY KEY,
+  username TEXT NOT NULL,
+  name TEXT NOT NULL,
+  latitude REAL NOT NULL,
+  longitude REAL NOT NULL,
+  radius_meters INTEGER NOT NULL,
+  created_at INTEGER NOT NULL
+);
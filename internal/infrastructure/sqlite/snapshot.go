@@ -0,0 +1,15 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+)
+
+// Snapshot writes a consistent copy of the database in a new file. The destination must not exist.
+func (r SQLite) Snapshot(ctx context.Context, dest string) error {
+	if _, err := r.DB.ExecContext(ctx, `VACUUM INTO ?`, dest); err != nil {
+		return fmt.Errorf("can't snapshot database (dest=%s): %v", dest, err)
+	}
+
+	return nil
+}
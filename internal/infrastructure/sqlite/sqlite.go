@@ -3,11 +3,14 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/lonepeon/sport/internal/domain"
+	"github.com/mattn/go-sqlite3"
 )
 
 //go:generate go run ../../../vendor/github.com/lonepeon/golib/sqlutil/cmd/sql-migration ./scripts
@@ -25,29 +28,93 @@ func New(db *sql.DB) SQLite {
 }
 
 type runningActivity struct {
-	ID               string
-	RanAt            string
-	Duration         string
-	Distance         int
-	Speed            float64
-	GPXPath          string
-	MapPath          string
-	ShareableMapPath string
+	ID                 string
+	RanAt              int64
+	Duration           int64
+	Distance           int
+	Speed              float64
+	GPXPath            string
+	MapPath            string
+	ShareableMapPath   string
+	ElevationChartPath string
+	PaceChartPath      string
+	Cards              string
+	Title              string
+	Description        string
+	Type               string
+	MapStyle           domain.MapStyle
+	MapStatus          string
+	MapError           string
+	Username           string
+	Visibility         string
 }
 
-func (r runningActivity) ToDomain() (domain.RunningActivity, error) {
-	timeLayout := "2006-01-02 15:04:05.999999999-07:00"
+// runningActivityColumns lists the columns read by scanRunningActivity, in order
+const runningActivityColumns = `id, ran_at, duration, distance, speed, gpx_path, map_path, shareable_map_path, title, description, ` +
+	`activity_type, map_theme, map_line_color, map_line_thickness, map_line_opacity, map_width, map_height, map_padding, map_route_coloring, map_status, map_error, ` +
+	`elevation_chart_path, pace_chart_path, cards, username, visibility`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRunningActivity(row scanner) (runningActivity, error) {
+	var activity runningActivity
+	err := row.Scan(
+		&activity.ID,
+		&activity.RanAt,
+		&activity.Duration,
+		&activity.Distance,
+		&activity.Speed,
+		&activity.GPXPath,
+		&activity.MapPath,
+		&activity.ShareableMapPath,
+		&activity.Title,
+		&activity.Description,
+		&activity.Type,
+		&activity.MapStyle.Theme,
+		&activity.MapStyle.LineColor,
+		&activity.MapStyle.LineThickness,
+		&activity.MapStyle.LineOpacity,
+		&activity.MapStyle.Width,
+		&activity.MapStyle.Height,
+		&activity.MapStyle.Padding,
+		&activity.MapStyle.RouteColoring,
+		&activity.MapStatus,
+		&activity.MapError,
+		&activity.ElevationChartPath,
+		&activity.PaceChartPath,
+		&activity.Cards,
+		&activity.Username,
+		&activity.Visibility,
+	)
 
+	return activity, err
+}
+
+func (r runningActivity) ToDomain() (domain.RunningActivity, error) {
 	var activity domain.RunningActivity
 
 	activity.GPXPath = domain.GPXFilePath(r.GPXPath)
 	activity.MapPath = domain.MapFilePath(r.MapPath)
 	activity.ShareableMapPath = domain.ShareableMapFilePath(r.ShareableMapPath)
+	activity.ElevationChartPath = domain.ElevationChartFilePath(r.ElevationChartPath)
+	activity.PaceChartPath = domain.PaceChartFilePath(r.PaceChartPath)
 
-	ranAt, err := time.Parse(timeLayout, r.RanAt)
+	cards, err := decodeCards(r.Cards)
 	if err != nil {
-		return domain.RunningActivity{}, fmt.Errorf("can't parse ran at for activity (id=%s): %v", r.ID, err)
+		return domain.RunningActivity{}, fmt.Errorf("can't parse cards for activity (id=%s): %v", r.ID, err)
 	}
+	activity.Cards = cards
+	activity.Title = r.Title
+	activity.Description = r.Description
+	activity.MapStyle = r.MapStyle
+	activity.MapStatus = domain.MapStatus(r.MapStatus)
+	activity.MapError = r.MapError
+	activity.Username = r.Username
+	activity.Duration = time.Duration(r.Duration) * time.Millisecond
+
+	ranAt := time.Unix(r.RanAt, 0).UTC()
 	activity.RanAt = ranAt
 
 	slug, err := domain.NewRunnningActivitySlugFromTime(ranAt)
@@ -56,12 +123,6 @@ func (r runningActivity) ToDomain() (domain.RunningActivity, error) {
 	}
 	activity.Slug = slug
 
-	duration, err := time.ParseDuration(r.Duration)
-	if err != nil {
-		return domain.RunningActivity{}, fmt.Errorf("can't parse duration for activity (id=%s): %v", r.ID, err)
-	}
-	activity.Duration = duration
-
 	speed, err := domain.NewSpeedFromKmh(r.Speed)
 	if err != nil {
 		return domain.RunningActivity{}, fmt.Errorf("can't parse speed for activity (id=%s): %v", r.ID, err)
@@ -74,18 +135,31 @@ func (r runningActivity) ToDomain() (domain.RunningActivity, error) {
 	}
 	activity.Distance = distance
 
+	activityType, err := domain.ParseActivityType(r.Type)
+	if err != nil {
+		return domain.RunningActivity{}, fmt.Errorf("can't parse type for activity (id=%s): %v", r.ID, err)
+	}
+	activity.Type = activityType
+
+	visibility, err := domain.ParseActivityVisibility(r.Visibility)
+	if err != nil {
+		return domain.RunningActivity{}, fmt.Errorf("can't parse visibility for activity (id=%s): %v", r.ID, err)
+	}
+	activity.Visibility = visibility
+
 	return activity, nil
 }
 
-// GetRunningActivity returns a list of all running activity
+// GetRunningActivity returns the running activity matching the slug
 func (r SQLite) GetRunningActivity(ctx context.Context, slug domain.RunningActivitySlug) (domain.RunningActivity, error) {
 	statement := `
-		SELECT id, ran_at, duration, distance, speed, gpx_path, map_path, shareable_map_path
+		SELECT ` + runningActivityColumns + `
 		FROM runs
-		WHERE ran_at = ?
+		WHERE ran_at >= ? AND ran_at < ?
 		ORDER BY ran_at DESC`
 
-	rows, err := r.DB.QueryContext(ctx, statement, slug.Time())
+	from, to := slugRange(slug)
+	rows, err := r.DB.QueryContext(ctx, statement, from, to)
 	if err != nil {
 		return domain.RunningActivity{}, fmt.Errorf("can't get running activity: %v", err)
 	}
@@ -95,8 +169,7 @@ func (r SQLite) GetRunningActivity(ctx context.Context, slug domain.RunningActiv
 		return domain.RunningActivity{}, domain.ErrCantGetRunningSession
 	}
 
-	var activity runningActivity
-	err = rows.Scan(&activity.ID, &activity.RanAt, &activity.Duration, &activity.Distance, &activity.Speed, &activity.GPXPath, &activity.MapPath, &activity.ShareableMapPath)
+	activity, err := scanRunningActivity(rows)
 	if err != nil {
 		return domain.RunningActivity{}, fmt.Errorf("can't scan activity: %v", err)
 	}
@@ -105,8 +178,9 @@ func (r SQLite) GetRunningActivity(ctx context.Context, slug domain.RunningActiv
 }
 
 func (r SQLite) DeleteRunningActivity(ctx context.Context, slug domain.RunningActivitySlug) error {
-	statement := `DELETE FROM runs WHERE ran_at = ?`
-	rst, err := r.DB.ExecContext(ctx, statement, slug.Time())
+	statement := `DELETE FROM runs WHERE ran_at >= ? AND ran_at < ?`
+	from, to := slugRange(slug)
+	rst, err := r.DB.ExecContext(ctx, statement, from, to)
 	if err != nil {
 		return fmt.Errorf("can't delete activity: %v", err)
 	}
@@ -121,20 +195,45 @@ func (r SQLite) DeleteRunningActivity(ctx context.Context, slug domain.RunningAc
 // ListRunningActivities returns a list of all running activities
 func (r SQLite) ListRunningActivities(ctx context.Context) ([]domain.RunningActivity, error) {
 	statement := `
-		SELECT id, ran_at, duration, distance, speed, gpx_path, map_path, shareable_map_path
+		SELECT ` + runningActivityColumns + `
 		FROM runs
 		ORDER BY ran_at DESC`
 
-	rows, err := r.DB.QueryContext(ctx, statement)
+	return r.listRunningActivities(ctx, statement)
+}
+
+// ListUserRunningActivities returns the running activities uploaded by the athlete
+func (r SQLite) ListUserRunningActivities(ctx context.Context, username string) ([]domain.RunningActivity, error) {
+	statement := `
+		SELECT ` + runningActivityColumns + `
+		FROM runs
+		WHERE username = ?
+		ORDER BY ran_at DESC`
+
+	return r.listRunningActivities(ctx, statement, username)
+}
+
+// AssignOrphanRunningActivities gives the activities recorded before they had an owner to the athlete
+func (r SQLite) AssignOrphanRunningActivities(ctx context.Context, username string) error {
+	statement := `UPDATE runs SET username = ? WHERE username = ''`
+
+	if _, err := r.DB.ExecContext(ctx, statement, username); err != nil {
+		return fmt.Errorf("can't assign running activities: %v", err)
+	}
+
+	return nil
+}
+
+func (r SQLite) listRunningActivities(ctx context.Context, statement string, args ...interface{}) ([]domain.RunningActivity, error) {
+	rows, err := r.DB.QueryContext(ctx, statement, args...)
 	if err != nil {
 		return nil, fmt.Errorf("can't get running activities: %v", err)
 	}
 	defer rows.Close()
 
-	var dbActivity runningActivity
 	var activities []domain.RunningActivity
 	for rows.Next() {
-		err := rows.Scan(&dbActivity.ID, &dbActivity.RanAt, &dbActivity.Duration, &dbActivity.Distance, &dbActivity.Speed, &dbActivity.GPXPath, &dbActivity.MapPath, &dbActivity.ShareableMapPath)
+		dbActivity, err := scanRunningActivity(rows)
 		if err != nil {
 			return nil, fmt.Errorf("can't scan activity: %v", err)
 		}
@@ -152,25 +251,177 @@ func (r SQLite) ListRunningActivities(ctx context.Context) ([]domain.RunningActi
 
 // RecordRunningActivity persists the activity in database
 func (r SQLite) RecordRunningActivity(ctx context.Context, activity domain.RunningActivity) error {
-	statement := `INSERT INTO runs (id, ran_at, duration, distance, speed, gpx_path, map_path, shareable_map_path, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	statement := `INSERT INTO runs (` + runningActivityColumns + `, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	_, err := r.DB.ExecContext(
+	cards, err := encodeCards(activity.Cards)
+	if err != nil {
+		return fmt.Errorf("can't encode cards: %v", err)
+	}
+
+	_, err = r.DB.ExecContext(
 		ctx,
 		statement,
 		uuid.NewString(),
-		activity.RanAt,
-		activity.Duration.String(),
+		wallClockUnix(activity.RanAt),
+		activity.Duration.Milliseconds(),
 		activity.Distance.Meters(),
 		activity.Speed.KilometersPerHour(),
 		activity.GPXPath.String(),
 		activity.MapPath.String(),
 		activity.ShareableMapPath.String(),
-		time.Now(),
+		activity.Title,
+		activity.Description,
+		activity.Type.String(),
+		activity.MapStyle.Theme,
+		activity.MapStyle.LineColor,
+		activity.MapStyle.LineThickness,
+		activity.MapStyle.LineOpacity,
+		activity.MapStyle.Width,
+		activity.MapStyle.Height,
+		activity.MapStyle.Padding,
+		activity.MapStyle.RouteColoring.String(),
+		activity.MapStatus.String(),
+		activity.MapError,
+		activity.ElevationChartPath.String(),
+		activity.PaceChartPath.String(),
+		cards,
+		activity.Username,
+		activity.Visibility.String(),
+		time.Now().Unix(),
 	)
 
+	if isUniqueViolation(err) {
+		return fmt.Errorf("can't record activity %s: %w", activity.Slug, domain.ErrRunningActivityAlreadyExists)
+	}
+
 	if err != nil {
 		return fmt.Errorf("can't insert into table: %v", err)
 	}
 
 	return nil
 }
+
+// UpdateRunningActivity persists the details, map style, map status, file paths and visibility of the activity
+func (r SQLite) UpdateRunningActivity(ctx context.Context, activity domain.RunningActivity) error {
+	statement := `
+		UPDATE runs SET
+			title = ?, description = ?, activity_type = ?,
+			map_theme = ?, map_line_color = ?, map_line_thickness = ?, map_line_opacity = ?,
+			map_width = ?, map_height = ?, map_padding = ?, map_route_coloring = ?,
+			map_status = ?, map_error = ?, elevation_chart_path = ?, pace_chart_path = ?, cards = ?,
+			visibility = ?, gpx_path = ?, map_path = ?, shareable_map_path = ?
+		WHERE ran_at >= ? AND ran_at < ?`
+
+	cards, err := encodeCards(activity.Cards)
+	if err != nil {
+		return fmt.Errorf("can't encode cards: %v", err)
+	}
+
+	from, to := slugRange(activity.Slug)
+	rst, err := r.DB.ExecContext(
+		ctx,
+		statement,
+		activity.Title,
+		activity.Description,
+		activity.Type.String(),
+		activity.MapStyle.Theme,
+		activity.MapStyle.LineColor,
+		activity.MapStyle.LineThickness,
+		activity.MapStyle.LineOpacity,
+		activity.MapStyle.Width,
+		activity.MapStyle.Height,
+		activity.MapStyle.Padding,
+		activity.MapStyle.RouteColoring.String(),
+		activity.MapStatus.String(),
+		activity.MapError,
+		activity.ElevationChartPath.String(),
+		activity.PaceChartPath.String(),
+		cards,
+		activity.Visibility.String(),
+		activity.GPXPath.String(),
+		activity.MapPath.String(),
+		activity.ShareableMapPath.String(),
+		from,
+		to,
+	)
+	if err != nil {
+		return fmt.Errorf("can't update activity: %v", err)
+	}
+
+	if count, _ := rst.RowsAffected(); count == 0 {
+		return domain.ErrCantGetRunningSession
+	}
+
+	return nil
+}
+
+// wallClockUnix returns the unix timestamp of the wall clock time, ignoring the time zone, so the slug built back
+// from the stored value is the one the activity was recorded with.
+func wallClockUnix(t time.Time) int64 {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC).Unix()
+}
+
+// slugRange returns the range of timestamps matching the minute encoded by the slug
+func slugRange(slug domain.RunningActivitySlug) (int64, int64) {
+	minute := int64(time.Minute.Seconds())
+	from := wallClockUnix(slug.Time())
+	from -= from % minute
+
+	return from, from + minute
+}
+
+// isUniqueViolation returns whether the error is raised by a unique index
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
+
+// shareableCard is the representation of a card in the JSON cards column
+type shareableCard struct {
+	Template string `json:"template"`
+	Path     string `json:"path"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+}
+
+// encodeCards returns the cards as JSON, activities recorded before card templates having none
+func encodeCards(cards []domain.ShareableCard) (string, error) {
+	if len(cards) == 0 {
+		return "", nil
+	}
+
+	rows := make([]shareableCard, len(cards))
+	for i, card := range cards {
+		rows[i] = shareableCard{Template: card.Template, Path: card.Path.String(), Width: card.Width, Height: card.Height}
+	}
+
+	content, err := json.Marshal(rows)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+func decodeCards(content string) ([]domain.ShareableCard, error) {
+	if content == "" {
+		return nil, nil
+	}
+
+	var rows []shareableCard
+	if err := json.Unmarshal([]byte(content), &rows); err != nil {
+		return nil, err
+	}
+
+	cards := make([]domain.ShareableCard, len(rows))
+	for i, row := range rows {
+		cards[i] = domain.ShareableCard{
+			Template: row.Template,
+			Path:     domain.ShareableMapFilePath(row.Path),
+			Width:    row.Width,
+			Height:   row.Height,
+		}
+	}
+
+	return cards, nil
+}
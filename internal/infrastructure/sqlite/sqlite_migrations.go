@@ -26,6 +26,241 @@ func Migrations() []sqlutil.Migration {
 			Version: "20211220002500",
 			Script: `ALTER TABLE runs ADD COLUMN shareable_map_path TEXT;
 
+`,
+		},
+		{
+			Version: "20220410120000",
+			Script: `-- ran_at holds the wall clock time the activity started at, as a unix timestamp in seconds,
+-- so the slug built from it doesn't depend on the offset stored by the previous TEXT column.
+-- duration holds milliseconds and is parsed back from the time.Duration.String() format.
+BEGIN TRANSACTION;
+
+CREATE TABLE runs_typed (
+  id TEXT PRIMARY KEY,
+  ran_at INTEGER NOT NULL,
+  duration INTEGER NOT NULL,
+  distance INTEGER NOT NULL,
+  speed REAL NOT NULL,
+  gpx_path TEXT NOT NULL,
+  map_path TEXT NOT NULL,
+  shareable_map_path TEXT NOT NULL,
+  created_at INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX runs_typed_ran_at_idx ON runs_typed (ran_at);
+
+-- rows sharing the same ran_at also share the same slug and asset paths: only the first
+-- recorded one has ever been reachable so the others are ignored.
+INSERT OR IGNORE INTO runs_typed (id, ran_at, duration, distance, speed, gpx_path, map_path, shareable_map_path, created_at)
+WITH
+  hours AS (
+    SELECT
+      *,
+      CASE WHEN instr(duration, 'h') > 0 THEN CAST(substr(duration, 1, instr(duration, 'h') - 1) AS INTEGER) ELSE 0 END AS duration_hours,
+      substr(duration, instr(duration, 'h') + 1) AS duration_after_hours
+    FROM runs
+  ),
+  minutes AS (
+    SELECT
+      *,
+      CASE WHEN instr(duration_after_hours, 'm') > 0 THEN CAST(substr(duration_after_hours, 1, instr(duration_after_hours, 'm') - 1) AS INTEGER) ELSE 0 END AS duration_minutes,
+      substr(duration_after_hours, instr(duration_after_hours, 'm') + 1) AS duration_after_minutes
+    FROM hours
+  )
+SELECT
+  id,
+  CAST(strftime('%s', substr(ran_at, 1, 19)) AS INTEGER),
+  CASE
+    WHEN duration LIKE '%ms' THEN CAST(round(CAST(substr(duration, 1, length(duration) - 2) AS REAL)) AS INTEGER)
+    WHEN duration LIKE '%µs' OR duration LIKE '%ns' THEN 0
+    ELSE (duration_hours * 3600 + duration_minutes * 60) * 1000
+      + CAST(round(CAST(substr(duration_after_minutes, 1, length(duration_after_minutes) - 1) AS REAL) * 1000) AS INTEGER)
+  END,
+  CAST(round(distance) AS INTEGER),
+  speed,
+  gpx_path,
+  map_path,
+  COALESCE(shareable_map_path, ''),
+  CAST(strftime('%s', created_at) AS INTEGER)
+FROM minutes
+ORDER BY created_at ASC;
+
+DROP TABLE runs;
+
+ALTER TABLE runs_typed RENAME TO runs;
+
+DROP INDEX runs_typed_ran_at_idx;
+
+CREATE UNIQUE INDEX runs_ran_at_idx ON runs (ran_at);
+
+COMMIT;
+
+`,
+		},
+		{
+			Version: "20220417090000",
+			Script: `CREATE TABLE exports (
+  id TEXT PRIMARY KEY,
+  status TEXT NOT NULL,
+  archive_path TEXT NOT NULL DEFAULT '',
+  requested_at INTEGER NOT NULL,
+  completed_at INTEGER
+);
+
+`,
+		},
+		{
+			Version: "20220418090000",
+			Script: `ALTER TABLE runs ADD COLUMN title TEXT NOT NULL DEFAULT '';
+ALTER TABLE runs ADD COLUMN description TEXT NOT NULL DEFAULT '';
+
+`,
+		},
+		{
+			Version: "20220418100000",
+			Script: `CREATE TABLE imports (
+  id TEXT PRIMARY KEY,
+  archive_path TEXT NOT NULL,
+  created_at INTEGER NOT NULL
+);
+
+CREATE TABLE import_items (
+  import_id TEXT NOT NULL REFERENCES imports (id) ON DELETE CASCADE,
+  external_id TEXT NOT NULL,
+  file_name TEXT NOT NULL DEFAULT '',
+  ran_at INTEGER NOT NULL,
+  type TEXT NOT NULL DEFAULT '',
+  name TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL,
+  reason TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY (import_id, external_id)
+);
+
+`,
+		},
+		{
+			Version: "20220419090000",
+			Script: `ALTER TABLE runs ADD COLUMN activity_type TEXT NOT NULL DEFAULT 'run';
+ALTER TABLE runs ADD COLUMN map_theme TEXT NOT NULL DEFAULT 'mapbox/outdoors-v11';
+ALTER TABLE runs ADD COLUMN map_line_color TEXT NOT NULL DEFAULT 'f44';
+ALTER TABLE runs ADD COLUMN map_line_thickness INTEGER NOT NULL DEFAULT 3;
+ALTER TABLE runs ADD COLUMN map_line_opacity REAL NOT NULL DEFAULT 0.8;
+ALTER TABLE runs ADD COLUMN map_width INTEGER NOT NULL DEFAULT 800;
+ALTER TABLE runs ADD COLUMN map_height INTEGER NOT NULL DEFAULT 800;
+ALTER TABLE runs ADD COLUMN map_padding INTEGER NOT NULL DEFAULT 100;
+
+`,
+		},
+		{
+			Version: "20220420090000",
+			Script: `ALTER TABLE runs ADD COLUMN map_route_coloring TEXT NOT NULL DEFAULT 'plain';
+
+`,
+		},
+		{
+			Version: "20220421090000",
+			Script: `ALTER TABLE runs ADD COLUMN map_status TEXT NOT NULL DEFAULT 'ready';
+ALTER TABLE runs ADD COLUMN map_error TEXT NOT NULL DEFAULT '';
+
+`,
+		},
+		{
+			Version: "20220422090000",
+			Script: `ALTER TABLE runs ADD COLUMN elevation_chart_path TEXT NOT NULL DEFAULT '';
+ALTER TABLE runs ADD COLUMN pace_chart_path TEXT NOT NULL DEFAULT '';
+
+`,
+		},
+		{
+			Version: "20220423090000",
+			Script: `ALTER TABLE runs ADD COLUMN cards TEXT NOT NULL DEFAULT '';
+
+`,
+		},
+		{
+			Version: "20220424090000",
+			Script: `CREATE TABLE user_preferences (
+  username TEXT PRIMARY KEY,
+  locale TEXT NOT NULL,
+  units TEXT NOT NULL,
+  speed_display TEXT NOT NULL
+);
+
+`,
+		},
+		{
+			Version: "20220425090000",
+			Script: `CREATE TABLE api_tokens (
+  id TEXT PRIMARY KEY,
+  username TEXT NOT NULL,
+  name TEXT NOT NULL,
+  scope TEXT NOT NULL,
+  hash TEXT NOT NULL UNIQUE,
+  created_at INTEGER NOT NULL,
+  last_used_at INTEGER
+);
+
+`,
+		},
+		{
+			Version: "20220426090000",
+			Script: `CREATE TABLE users (
+  username TEXT PRIMARY KEY,
+  password_hash TEXT NOT NULL,
+  is_admin INTEGER NOT NULL,
+  created_at INTEGER NOT NULL,
+  disabled_at INTEGER
+);
+
+`,
+		},
+		{
+			Version: "20220427090000",
+			Script: `ALTER TABLE runs ADD COLUMN username TEXT NOT NULL DEFAULT '';
+ALTER TABLE imports ADD COLUMN username TEXT NOT NULL DEFAULT '';
+CREATE INDEX runs_username_idx ON runs (username);
+
+`,
+		},
+		{
+			Version: "20220428090000",
+			Script: `ALTER TABLE runs ADD COLUMN visibility TEXT NOT NULL DEFAULT 'public';
+
+`,
+		},
+		{
+			Version: "20220429090000",
+			Script: `CREATE TABLE privacy_zones (
+  id TEXT PRIMARY KEY,
+  username TEXT NOT NULL,
+  name TEXT NOT NULL,
+  latitude REAL NOT NULL,
+  longitude REAL NOT NULL,
+  radius_meters INTEGER NOT NULL,
+  created_at INTEGER NOT NULL
+);
+
+`,
+		},
+		{
+			Version: "20220430090000",
+			Script: `ALTER TABLE exports ADD COLUMN username TEXT NOT NULL DEFAULT '';
+CREATE INDEX exports_username_idx ON exports (username);
+
+`,
+		},
+		{
+			Version: "20220501090000",
+			Script: `-- slugs and asset paths are built from the minute the activity started at, so the uniqueness of ran_at is enforced
+-- to the minute. Only the latest activity of a minute has ever been reachable, so the others are removed.
+DELETE FROM runs
+WHERE EXISTS (SELECT 1 FROM runs later WHERE later.ran_at / 60 = runs.ran_at / 60 AND later.ran_at > runs.ran_at);
+
+DROP INDEX runs_ran_at_idx;
+
+CREATE UNIQUE INDEX runs_ran_at_minute_idx ON runs (ran_at / 60);
+
 `,
 		},
 	}
@@ -5,15 +5,18 @@ import (
 	"database/sql"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3" // sqlite3 adapter
 
 	"github.com/lonepeon/golib/sqlutil"
 	"github.com/lonepeon/golib/testutils"
-	"github.com/lonepeon/sport/internal/domain"
 	"github.com/lonepeon/sport/internal/domain/domaintest"
 	"github.com/lonepeon/sport/internal/infrastructure/sqlite"
+	"github.com/lonepeon/sport/internal/repository"
+	"github.com/lonepeon/sport/internal/repository/repositorytest"
 )
 
 func TestIntegration(t *testing.T) {
@@ -23,89 +26,101 @@ func TestIntegration(t *testing.T) {
 
 	t.Parallel()
 
-	t.Run("ListRunningActivities", testListRunningActivities)
-	t.Run("GetRunningActivitySuccess", testGetRunningActivitySuccess)
-	t.Run("GetRunningActivityNotFound", testGetRunningActivityNotFound)
-	t.Run("DeleteRunningActivitySuccess", testDeleteRunningActivitySuccess)
-	t.Run("DeleteRunningActivityWhenActivityDoesNotMatch", testDeleteRunningActivityWhenActivityDoesNotMatch)
+	repositorytest.RunActivityStoreSuite(t, func(t *testing.T) (repository.ActivityStore, func()) {
+		return setupDatabase(t)
+	})
+	repositorytest.RunExportStoreSuite(t, func(t *testing.T) (repository.ExportStore, func()) {
+		return setupDatabase(t)
+	})
+	repositorytest.RunImportStoreSuite(t, func(t *testing.T) (repository.ImportStore, func()) {
+		return setupDatabase(t)
+	})
+	repositorytest.RunUserPreferencesStoreSuite(t, func(t *testing.T) (repository.UserPreferencesStore, func()) {
+		return setupDatabase(t)
+	})
+	repositorytest.RunAPITokenStoreSuite(t, func(t *testing.T) (repository.APITokenStore, func()) {
+		return setupDatabase(t)
+	})
+	repositorytest.RunUserStoreSuite(t, func(t *testing.T) (repository.UserStore, func()) {
+		return setupDatabase(t)
+	})
+	repositorytest.RunPrivacyZoneStoreSuite(t, func(t *testing.T) (repository.PrivacyZoneStore, func()) {
+		return setupDatabase(t)
+	})
+	t.Run("MigrateLegacyDatabase", testMigrateLegacyDatabase)
+	t.Run("SnapshotSuccess", testSnapshotSuccess)
 }
 
-func testGetRunningActivitySuccess(t *testing.T) {
+func testSnapshotSuccess(t *testing.T) {
 	repo, cleanup := setupDatabase(t)
 	defer cleanup()
-	expectedActivity := domaintest.NewRunningActivity(t).Build()
 
-	recordActivity(t, repo, expectedActivity)
-
-	actualActivity, err := repo.GetRunningActivity(context.Background(), expectedActivity.Slug)
-
-	testutils.AssertNoError(t, err, "can't get activity")
-	domaintest.AssertEqualRunningActivity(t, expectedActivity, actualActivity, "unexpected activity")
-}
-
-func testGetRunningActivityNotFound(t *testing.T) {
-	repo, cleanup := setupDatabase(t)
-	defer cleanup()
 	activity := domaintest.NewRunningActivity(t).Build()
-	recordActivity(t, repo, activity)
+	err := repo.RecordRunningActivity(context.Background(), activity)
+	testutils.AssertNoError(t, err, "can't record activity")
 
-	slug, err := domain.NewRunnningActivitySlugFromString("202101010000")
-	testutils.AssertNoError(t, err, "can't build slug")
+	dest := filepath.Join(t.TempDir(), "snapshot.sqlite")
+	err = repo.Snapshot(context.Background(), dest)
+	testutils.AssertNoError(t, err, "can't snapshot database")
 
-	_, err = repo.GetRunningActivity(context.Background(), slug)
+	db, err := sql.Open("sqlite3", dest)
+	testutils.AssertNoError(t, err, "can't open snapshot")
+	defer db.Close()
 
-	testutils.AssertErrorIs(t, domain.ErrCantGetRunningSession, err, "can't get activity")
+	actualActivity, err := sqlite.New(db).GetRunningActivity(context.Background(), activity.Slug)
+	testutils.AssertNoError(t, err, "can't get activity from snapshot")
+	domaintest.AssertEqualRunningActivity(t, activity, actualActivity, "unexpected activity")
 }
 
-func testListRunningActivities(t *testing.T) {
-	repo, cleanup := setupDatabase(t)
+func testMigrateLegacyDatabase(t *testing.T) {
+	db, cleanup := copyDatabase(t, "testdata/legacy.sqlite")
 	defer cleanup()
 
-	activity1 := domaintest.NewRunningActivity(t).WithRawSlug("202101010000").Build()
-	activity2 := domaintest.NewRunningActivity(t).WithRawSlug("202303030000").Build()
-	activity3 := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").Build()
-
-	recordActivity(t, repo, activity1)
-	recordActivity(t, repo, activity2)
-	recordActivity(t, repo, activity3)
-
-	activities, err := repo.ListRunningActivities(context.Background())
+	versions, err := sqlutil.ExecuteMigrations(context.Background(), db, sqlite.Migrations())
+	testutils.AssertNoError(t, err, "can't run migrations")
+	testutils.RequireEqualBool(t, true, len(versions) > 0, "expected pending migrations")
+	testutils.AssertEqualString(t, "20220410120000", versions[0], "unexpected first executed migration: %v", versions)
 
+	activities, err := sqlite.New(db).ListRunningActivities(context.Background())
 	testutils.AssertNoError(t, err, "can't list activities")
 	testutils.AssertEqualInt(t, 3, len(activities), "unexpected number of activities")
 
-	domaintest.AssertEqualRunningActivity(t, activity2, activities[0], "unexpected activity")
-	domaintest.AssertEqualRunningActivity(t, activity3, activities[1], "unexpected activity")
-	domaintest.AssertEqualRunningActivity(t, activity1, activities[2], "unexpected activity")
+	testutils.AssertEqualString(t, "202203041830", activities[0].Slug.String(), "unexpected slug")
+	testutils.AssertEqualTime(t, time.Date(2022, 3, 4, 18, 30, 0, 0, time.UTC), activities[0].RanAt, "unexpected ran at")
+	testutils.AssertEqualDuration(t, 850*time.Millisecond, activities[0].Duration, "unexpected duration")
+	testutils.AssertEqualInt(t, 4, activities[0].Distance.Meters(), "unexpected distance")
+	testutils.AssertEqualFloat64(t, 16.94, activities[0].Speed.KilometersPerHour(), "unexpected speed")
+
+	testutils.AssertEqualString(t, "202201020805", activities[1].Slug.String(), "unexpected slug")
+	testutils.AssertEqualDuration(t, time.Hour+2*time.Minute+3500*time.Millisecond, activities[1].Duration, "unexpected duration")
+	testutils.AssertEqualInt(t, 12050, activities[1].Distance.Meters(), "unexpected distance")
+	testutils.AssertEqualString(t, "runs/2022-01-02.08h05/share-map.png", activities[1].ShareableMapPath.String(), "unexpected shareable map path")
+
+	testutils.AssertEqualString(t, "202111212328", activities[2].Slug.String(), "unexpected slug")
+	testutils.AssertEqualDuration(t, 45*time.Minute+12*time.Second, activities[2].Duration, "unexpected duration")
+	testutils.AssertEqualInt(t, 5432, activities[2].Distance.Meters(), "unexpected distance")
+	testutils.AssertEqualString(t, "runs/2021-11-21.23h28/map.png", activities[2].MapPath.String(), "unexpected map path")
+	testutils.AssertEqualString(t, "", activities[2].ShareableMapPath.String(), "unexpected shareable map path")
 }
 
-func testDeleteRunningActivitySuccess(t *testing.T) {
-	repo, cleanup := setupDatabase(t)
-	defer cleanup()
-	expectedActivity := domaintest.NewRunningActivity(t).Build()
-
-	recordActivity(t, repo, expectedActivity)
+func copyDatabase(t *testing.T, path string) (*sql.DB, func()) {
+	content, err := ioutil.ReadFile(path)
+	testutils.AssertNoError(t, err, "can't read database fixture")
 
-	err := repo.DeleteRunningActivity(context.Background(), expectedActivity.Slug)
-	testutils.AssertNoError(t, err, "can't delete activity")
-
-	_, err = repo.GetRunningActivity(context.Background(), expectedActivity.Slug)
-	testutils.AssertErrorIs(t, domain.ErrCantGetRunningSession, err, "activity should have been deleted")
-}
-
-func testDeleteRunningActivityWhenActivityDoesNotMatch(t *testing.T) {
-	repo, cleanup := setupDatabase(t)
-	defer cleanup()
-
-	slug, err := domain.NewRunnningActivitySlugFromString("202101010000")
-	testutils.AssertNoError(t, err, "can't build slug")
+	file, err := ioutil.TempFile("/tmp", "sqlite.XXXX")
+	testutils.AssertNoError(t, err, "can't create sqlite temp file")
 
-	expectedActivity := domaintest.NewRunningActivity(t).WithRawSlug("202303030000").Build()
+	_, err = file.Write(content)
+	testutils.AssertNoError(t, err, "can't copy database fixture")
 
-	recordActivity(t, repo, expectedActivity)
+	db, err := sql.Open("sqlite3", file.Name())
+	testutils.AssertNoError(t, err, "can't open sqlite connection")
 
-	err = repo.DeleteRunningActivity(context.Background(), slug)
-	testutils.AssertErrorIs(t, domain.ErrCantGetRunningSession, err, "activty should have been not found")
+	return db, func() {
+		file.Close()
+		os.Remove(file.Name())
+		db.Close()
+	}
 }
 
 func setupDatabase(t *testing.T) (sqlite.SQLite, func()) {
@@ -124,8 +139,3 @@ func setupDatabase(t *testing.T) (sqlite.SQLite, func()) {
 		db.Close()
 	}
 }
-
-func recordActivity(t *testing.T, repo sqlite.SQLite, activity domain.RunningActivity) {
-	err := repo.RecordRunningActivity(context.Background(), activity)
-	testutils.AssertNoError(t, err, "can't record activity")
-}
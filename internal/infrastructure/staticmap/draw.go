@@ -0,0 +1,184 @@
+package staticmap
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+var labelFont *opentype.Font
+
+func init() {
+	f, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		panic(fmt.Sprintf("can't parse Go regular font: %v", err))
+	}
+
+	labelFont = f
+}
+
+type point struct {
+	X float64
+	Y float64
+}
+
+// fillPolygon draws the polygon on dst, only rasterizing its bounding box
+func fillPolygon(dst draw.Image, src image.Image, polygon []point) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range polygon {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+
+	bounds := image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY))).
+		Intersect(dst.Bounds())
+	if bounds.Empty() {
+		return
+	}
+
+	offsetX, offsetY := float32(bounds.Min.X), float32(bounds.Min.Y)
+	r := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+	r.MoveTo(float32(polygon[0].X)-offsetX, float32(polygon[0].Y)-offsetY)
+	for _, p := range polygon[1:] {
+		r.LineTo(float32(p.X)-offsetX, float32(p.Y)-offsetY)
+	}
+	r.ClosePath()
+	r.Draw(dst, bounds, src, bounds.Min)
+}
+
+func circle(center point, radius float64) []point {
+	const sides = 24
+
+	polygon := make([]point, sides)
+	for i := range polygon {
+		angle := 2 * math.Pi * float64(i) / sides
+		polygon[i] = point{X: center.X + radius*math.Cos(angle), Y: center.Y + radius*math.Sin(angle)}
+	}
+
+	return polygon
+}
+
+// drawPolyline draws a line with round joins. The line is first drawn on a mask so its opacity is uniform where
+// segments overlap.
+func drawPolyline(dst draw.Image, points []point, thickness float64, c color.Color) {
+	mask := image.NewAlpha(dst.Bounds())
+	half := thickness / 2
+
+	for i, p := range points {
+		fillPolygon(mask, image.Opaque, circle(p, half))
+		if i == 0 {
+			continue
+		}
+
+		previous := points[i-1]
+		dx, dy := p.X-previous.X, p.Y-previous.Y
+		length := math.Hypot(dx, dy)
+		if length == 0 {
+			continue
+		}
+
+		nx, ny := -dy/length*half, dx/length*half
+		fillPolygon(mask, image.Opaque, []point{
+			{X: previous.X + nx, Y: previous.Y + ny},
+			{X: p.X + nx, Y: p.Y + ny},
+			{X: p.X - nx, Y: p.Y - ny},
+			{X: previous.X - nx, Y: previous.Y - ny},
+		})
+	}
+
+	draw.DrawMask(dst, dst.Bounds(), image.NewUniform(c), image.Point{}, mask, dst.Bounds().Min, draw.Over)
+}
+
+func drawMarker(dst draw.Image, center point, radius float64, c color.Color) {
+	fillPolygon(dst, image.White, circle(center, radius+radius/3))
+	fillPolygon(dst, image.NewUniform(c), circle(center, radius))
+}
+
+// drawDistanceMarker draws a marker with its label centered in white
+func drawDistanceMarker(dst draw.Image, center point, radius float64, c color.Color, label string, face font.Face) {
+	drawMarker(dst, center, radius, c)
+
+	drawer := font.Drawer{Dst: dst, Src: image.White, Face: face}
+	metrics := face.Metrics()
+	drawer.Dot = fixed.Point26_6{
+		X: fixed.Int26_6(center.X*64) - drawer.MeasureString(label)/2,
+		Y: fixed.Int26_6(center.Y*64) + (metrics.Ascent-metrics.Descent)/2,
+	}
+	drawer.DrawString(label)
+}
+
+// drawScaleBar draws, in the bottom left corner, a bar representing a round distance close to a fifth of the image
+func drawScaleBar(dst draw.Image, metersPerPixel float64, margin int, fontSize float64) error {
+	bounds := dst.Bounds()
+	meters := roundDistance(metersPerPixel * float64(bounds.Dx()) / 5)
+	length := int(math.Round(meters / metersPerPixel))
+	thickness := int(math.Max(2, fontSize/6))
+
+	face, err := opentype.NewFace(labelFont, &opentype.FaceOptions{Size: fontSize, DPI: 72, Hinting: font.HintingNone})
+	if err != nil {
+		return fmt.Errorf("can't setup font: %v", err)
+	}
+	defer face.Close()
+
+	label := formatDistance(meters)
+	labelWidth := font.MeasureString(face, label).Ceil()
+
+	bottom := bounds.Max.Y - margin
+	top := bottom - thickness - int(fontSize*1.4)
+	background := image.Rect(bounds.Min.X+margin/2, top-margin/2, bounds.Min.X+margin+maxInt(length, labelWidth)+margin/2, bottom+margin/2)
+	draw.Draw(dst, background, image.NewUniform(color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xAA}), image.Point{}, draw.Over)
+
+	bar := image.Rect(bounds.Min.X+margin, bottom-thickness, bounds.Min.X+margin+length, bottom)
+	draw.Draw(dst, bar, image.Black, image.Point{}, draw.Over)
+
+	drawer := font.Drawer{
+		Dst:  dst,
+		Src:  image.Black,
+		Face: face,
+		Dot:  fixed.P(bounds.Min.X+margin, bottom-thickness-int(fontSize*0.4)),
+	}
+	drawer.DrawString(label)
+
+	return nil
+}
+
+// roundDistance returns the biggest 1, 2 or 5 multiple of a power of ten lower than meters
+func roundDistance(meters float64) float64 {
+	if meters < 1 {
+		return 1
+	}
+
+	magnitude := math.Pow(10, math.Floor(math.Log10(meters)))
+	for _, factor := range []float64{5, 2, 1} {
+		if factor*magnitude <= meters {
+			return factor * magnitude
+		}
+	}
+
+	return magnitude
+}
+
+func formatDistance(meters float64) string {
+	if meters >= 1000 {
+		return fmt.Sprintf("%g km", meters/1000)
+	}
+
+	return fmt.Sprintf("%g m", meters)
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,88 @@
+package staticmap
+
+import (
+	"math"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+const (
+	tileSize      = 256
+	earthEquator  = 40075016.686
+	maxZoom       = 17
+	maxLatitude   = 85.05112878
+	degreesToRads = math.Pi / 180
+)
+
+// worldPixel projects a coordinate on the Web Mercator plane at the zoom level, in pixels
+func worldPixel(latitude float64, longitude float64, zoom float64) (float64, float64) {
+	latitude = math.Max(-maxLatitude, math.Min(maxLatitude, latitude))
+	scale := tileSize * math.Pow(2, zoom)
+	sin := math.Sin(latitude * degreesToRads)
+
+	x := (longitude + 180) / 360 * scale
+	y := (0.5 - math.Log((1+sin)/(1-sin))/(4*math.Pi)) * scale
+
+	return x, y
+}
+
+// viewport represents the part of the Web Mercator plane drawn in the image
+type viewport struct {
+	zoom     float64
+	originX  float64
+	originY  float64
+	latitude float64
+}
+
+// fitViewport returns the viewport centering the points in an image of width x height pixels, keeping padding pixels
+// around them. integerZoom restricts the zoom to the levels tiles are available at.
+func fitViewport(points domain.GPXPoints, width int, height int, padding int, integerZoom bool) viewport {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, point := range points {
+		x, y := worldPixel(point.Latitude, point.Longitude, 0)
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+	}
+
+	zoom := float64(maxZoom)
+	if spanX := maxX - minX; spanX > 0 {
+		zoom = math.Min(zoom, math.Log2(float64(width-2*padding)/spanX))
+	}
+	if spanY := maxY - minY; spanY > 0 {
+		zoom = math.Min(zoom, math.Log2(float64(height-2*padding)/spanY))
+	}
+	zoom = math.Max(0, zoom)
+	if integerZoom {
+		zoom = math.Floor(zoom)
+	}
+
+	scale := math.Pow(2, zoom)
+	centerX, centerY := (minX+maxX)/2*scale, (minY+maxY)/2*scale
+
+	return viewport{
+		zoom:     zoom,
+		originX:  centerX - float64(width)/2,
+		originY:  centerY - float64(height)/2,
+		latitude: latitudeAt((minY + maxY) / 2),
+	}
+}
+
+// pixel returns the position of the coordinate in the image
+func (v viewport) pixel(latitude float64, longitude float64) (float64, float64) {
+	x, y := worldPixel(latitude, longitude, v.zoom)
+
+	return x - v.originX, y - v.originY
+}
+
+// metersPerPixel returns the ground distance covered by one pixel at the center of the viewport
+func (v viewport) metersPerPixel() float64 {
+	return earthEquator * math.Cos(v.latitude*degreesToRads) / (tileSize * math.Pow(2, v.zoom))
+}
+
+// latitudeAt returns the latitude of a vertical position on the Web Mercator plane at zoom 0
+func latitudeAt(y float64) float64 {
+	n := math.Pi - 2*math.Pi*y/tileSize
+
+	return math.Atan(math.Sinh(n)) / degreesToRads
+}
@@ -0,0 +1,184 @@
+package staticmap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/lonepeon/sport/internal/domain"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+)
+
+var (
+	ErrNoPoints = errors.New("track has no point")
+)
+
+// Renderer draws static maps of tracks without any network access.
+//
+// The track is drawn on a plain background, or on the tiles found in TileFolder when it is set. The theme of the map
+// style is ignored since tiles aren't styled on the fly.
+type Renderer struct {
+	// TileFolder contains PNG tiles stored as <zoom>/<x>/<y>.png. Missing tiles are left plain.
+	TileFolder string
+	// Scale is the number of pixels per point of the map style
+	Scale           int
+	BackgroundColor color.Color
+	StartColor      color.Color
+	EndColor        color.Color
+	DistanceColor   color.Color
+}
+
+// New initializes a renderer generating maps of the same density as the Mapbox provider
+func New(tileFolder string) *Renderer {
+	return &Renderer{
+		TileFolder:      tileFolder,
+		Scale:           domain.MapPixelDensity,
+		BackgroundColor: color.NRGBA{R: 0xF2, G: 0xEF, B: 0xE9, A: 0xFF},
+		StartColor:      color.NRGBA{R: 0x2E, G: 0xA0, B: 0x43, A: 0xFF},
+		EndColor:        color.NRGBA{R: 0xD1, G: 0x24, B: 0x2F, A: 0xFF},
+		DistanceColor:   color.NRGBA{R: 0x55, G: 0x55, B: 0x55, A: 0xFF},
+	}
+}
+
+// GenerateMap draws the track colored as configured by the style, its start, finish and distance markers and a scale
+// bar
+func (r *Renderer) GenerateMap(ctx context.Context, gpx domain.GPXFile, style domain.MapStyle) (domain.MapFile, error) {
+	if len(gpx.Points) == 0 {
+		return domain.MapFile{}, ErrNoPoints
+	}
+
+	width, height, padding := style.Width*r.Scale, style.Height*r.Scale, style.Padding*r.Scale
+	thickness := float64(style.LineThickness * r.Scale)
+
+	view := fitViewport(gpx.Points, width, height, padding, r.TileFolder != "")
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(r.BackgroundColor), image.Point{}, draw.Src)
+
+	if r.TileFolder != "" {
+		r.drawTiles(img, view)
+	}
+
+	route := domain.NewRoute(gpx.Points, style)
+	if err := r.drawRoute(img, view, route, thickness, style.LineOpacity); err != nil {
+		return domain.MapFile{}, err
+	}
+
+	if err := drawScaleBar(img, view.metersPerPixel(), width/40, float64(width)/50); err != nil {
+		return domain.MapFile{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return domain.MapFile{}, fmt.Errorf("can't encode map to png: %v", err)
+	}
+
+	return domain.NewMapFile(buf.Bytes()), nil
+}
+
+func (r *Renderer) drawRoute(img draw.Image, view viewport, route domain.Route, thickness float64, opacity float64) error {
+	for _, section := range route.Sections {
+		sectionColor, err := parseColor(section.Color, opacity)
+		if err != nil {
+			return err
+		}
+
+		track := make([]point, len(section.Points))
+		for i, gpxPoint := range section.Points {
+			x, y := view.pixel(gpxPoint.Latitude, gpxPoint.Longitude)
+			track[i] = point{X: x, Y: y}
+		}
+
+		drawPolyline(img, track, thickness, sectionColor)
+	}
+
+	face, err := opentype.NewFace(labelFont, &opentype.FaceOptions{Size: thickness * 2, DPI: 72, Hinting: font.HintingNone})
+	if err != nil {
+		return fmt.Errorf("can't setup font: %v", err)
+	}
+	defer face.Close()
+
+	for _, marker := range route.Markers {
+		x, y := view.pixel(marker.Latitude, marker.Longitude)
+		switch marker.Kind {
+		case domain.RouteMarkerStart:
+			drawMarker(img, point{X: x, Y: y}, thickness*2, r.StartColor)
+		case domain.RouteMarkerFinish:
+			drawMarker(img, point{X: x, Y: y}, thickness*2, r.EndColor)
+		default:
+			drawDistanceMarker(img, point{X: x, Y: y}, thickness*2, r.DistanceColor, marker.Label, face)
+		}
+	}
+
+	return nil
+}
+
+func (r *Renderer) drawTiles(img draw.Image, view viewport) {
+	zoom := int(view.zoom)
+	tiles := 1 << zoom
+
+	firstX := int(math.Floor(view.originX / tileSize))
+	firstY := int(math.Floor(view.originY / tileSize))
+	lastX := int(math.Floor((view.originX + float64(img.Bounds().Dx())) / tileSize))
+	lastY := int(math.Floor((view.originY + float64(img.Bounds().Dy())) / tileSize))
+
+	for x := firstX; x <= lastX; x++ {
+		for y := firstY; y <= lastY; y++ {
+			if y < 0 || y >= tiles {
+				continue
+			}
+
+			tile, ok := r.loadTile(zoom, ((x%tiles)+tiles)%tiles, y)
+			if !ok {
+				continue
+			}
+
+			at := image.Pt(int(math.Round(float64(x*tileSize)-view.originX)), int(math.Round(float64(y*tileSize)-view.originY)))
+			draw.Draw(img, image.Rectangle{Min: at, Max: at.Add(image.Pt(tileSize, tileSize))}, tile, tile.Bounds().Min, draw.Src)
+		}
+	}
+}
+
+func (r *Renderer) loadTile(zoom int, x int, y int) (image.Image, bool) {
+	path := filepath.Join(r.TileFolder, strconv.Itoa(zoom), strconv.Itoa(x), strconv.Itoa(y)+".png")
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	tile, err := png.Decode(f)
+	if err != nil {
+		return nil, false
+	}
+
+	return tile, true
+}
+
+// parseColor converts a 3 or 6 digits hexadecimal color
+func parseColor(hex string, opacity float64) (color.NRGBA, error) {
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return color.NRGBA{}, fmt.Errorf("can't parse line color %s", hex)
+	}
+
+	return color.NRGBA{
+		R: uint8(value >> 16),
+		G: uint8(value >> 8),
+		B: uint8(value),
+		A: uint8(math.Round(opacity * 0xFF)),
+	}, nil
+}
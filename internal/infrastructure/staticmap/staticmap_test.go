@@ -0,0 +1,187 @@
+package staticmap_test
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/staticmap"
+)
+
+func TestGenerateMapSuccess(t *testing.T) {
+	renderer := staticmap.New("")
+
+	gpxFile := domaintest.NewGPXFile(t).WithFileContent(domaintest.GetGPXBytes()).Build()
+
+	mapFile, err := renderer.GenerateMap(context.Background(), gpxFile, domain.DefaultMapStyle())
+	testutils.AssertNoError(t, err, "can't generate map")
+
+	img := decodeMap(t, mapFile)
+	testutils.AssertEqualInt(t, 1600, img.Bounds().Dx(), "unexpected map width")
+	testutils.AssertEqualInt(t, 1600, img.Bounds().Dy(), "unexpected map height")
+	assertColor(t, renderer.BackgroundColor, img.At(1600/2, 5), "unexpected background color")
+}
+
+func TestGenerateMapStyle(t *testing.T) {
+	renderer := staticmap.New("")
+	style := domain.DefaultMapStyle()
+	style.Width, style.Height, style.Padding = 300, 200, 20
+	style.LineColor, style.LineOpacity, style.LineThickness = "00f", 1, 10
+
+	gpxFile := domaintest.NewGPXFile(t).WithPoints(domain.GPXPoints{
+		{Latitude: 48.8566, Longitude: 2.3522},
+		{Latitude: 48.8566, Longitude: 2.3922},
+	}).Build()
+
+	mapFile, err := renderer.GenerateMap(context.Background(), gpxFile, style)
+	testutils.AssertNoError(t, err, "can't generate map")
+
+	img := decodeMap(t, mapFile)
+	testutils.AssertEqualInt(t, 600, img.Bounds().Dx(), "unexpected map width")
+	testutils.AssertEqualInt(t, 400, img.Bounds().Dy(), "unexpected map height")
+	assertColor(t, color.NRGBA{B: 0xFF, A: 0xFF}, img.At(300, 200), "unexpected line color")
+}
+
+func TestGenerateMapPaceColoring(t *testing.T) {
+	renderer := staticmap.New("")
+	style := domain.DefaultMapStyle()
+	style.LineOpacity, style.LineThickness = 1, 10
+
+	// a 3km track heading east, run slowly on its first half and fast on its second half
+	const steps = 300
+	points := make(domain.GPXPoints, steps+1)
+	points[0] = domain.GPXPoint{Time: time.Date(2022, time.April, 20, 9, 0, 0, 0, time.UTC), Latitude: 48.8566, Longitude: 2.3522}
+	for i := 1; i <= steps; i++ {
+		interval := 2 * time.Second
+		if i <= steps/2 {
+			interval = 5 * time.Second
+		}
+
+		points[i] = domain.GPXPoint{
+			Time:      points[i-1].Time.Add(interval),
+			Latitude:  48.8566,
+			Longitude: 2.3522 + 0.04*float64(i)/steps,
+			Distance:  10,
+		}
+	}
+
+	mapFile, err := renderer.GenerateMap(context.Background(), domaintest.NewGPXFile(t).WithPoints(points).Build(), style)
+	testutils.AssertNoError(t, err, "can't generate map")
+
+	img := decodeMap(t, mapFile)
+	padding := style.Padding * renderer.Scale
+	slowest, fastest := domain.PaceColors[0], domain.PaceColors[len(domain.PaceColors)-1]
+	assertColor(t, hexColor(t, slowest), img.At(padding+(1600-2*padding)/4, 1600/2), "unexpected color of the slow half")
+	assertColor(t, hexColor(t, fastest), img.At(1600-padding-(1600-2*padding)/8, 1600/2), "unexpected color of the fast half")
+}
+
+func TestGenerateMapMarkers(t *testing.T) {
+	renderer := staticmap.New("")
+	gpxFile := domaintest.NewGPXFile(t).WithPoints(domain.GPXPoints{
+		{Latitude: 48.8566, Longitude: 2.3522},
+		{Latitude: 48.8566, Longitude: 2.3922},
+	}).Build()
+
+	mapFile, err := renderer.GenerateMap(context.Background(), gpxFile, domain.DefaultMapStyle())
+	testutils.AssertNoError(t, err, "can't generate map")
+
+	img := decodeMap(t, mapFile)
+	padding := domain.DefaultMapStyle().Padding * renderer.Scale
+	assertColor(t, renderer.StartColor, img.At(padding, 1600/2), "unexpected start marker color")
+	assertColor(t, renderer.EndColor, img.At(1600-padding, 1600/2), "unexpected end marker color")
+}
+
+func TestGenerateMapTileBackground(t *testing.T) {
+	folder := t.TempDir()
+	tileColor := color.NRGBA{R: 0x11, G: 0x22, B: 0x33, A: 0xFF}
+	gpxFile := domaintest.NewGPXFile(t).WithPoints(domain.GPXPoints{
+		{Latitude: 48.8566, Longitude: 2.3522},
+		{Latitude: 48.8566, Longitude: 2.3922},
+	}).Build()
+
+	// the track spans ~3km which fits at zoom 15 with the default size
+	const zoom = 15
+	centerX, centerY := tileAt(48.8566, 2.3722, zoom)
+	for x := centerX - 4; x <= centerX+4; x++ {
+		for y := centerY - 4; y <= centerY+4; y++ {
+			writeTile(t, folder, zoom, x, y, tileColor)
+		}
+	}
+
+	renderer := staticmap.New(folder)
+	mapFile, err := renderer.GenerateMap(context.Background(), gpxFile, domain.DefaultMapStyle())
+	testutils.AssertNoError(t, err, "can't generate map")
+
+	img := decodeMap(t, mapFile)
+	assertColor(t, tileColor, img.At(1600/2, 5), "unexpected tile color")
+}
+
+func TestGenerateMapNoPoints(t *testing.T) {
+	gpxFile := domaintest.NewGPXFile(t).WithPoints(nil).Build()
+
+	_, err := staticmap.New("").GenerateMap(context.Background(), gpxFile, domain.DefaultMapStyle())
+
+	testutils.AssertErrorIs(t, staticmap.ErrNoPoints, err, "unexpected error")
+}
+
+func decodeMap(t *testing.T, mapFile domain.MapFile) image.Image {
+	img, err := png.Decode(mapFile.File())
+	testutils.AssertNoError(t, err, "can't decode map")
+
+	return img
+}
+
+func writeTile(t *testing.T, folder string, zoom int, x int, y int, c color.Color) {
+	path := filepath.Join(folder, strconv.Itoa(zoom), strconv.Itoa(x), strconv.Itoa(y)+".png")
+	testutils.AssertNoError(t, os.MkdirAll(filepath.Dir(path), 0755), "can't create tile folder")
+
+	tile := image.NewNRGBA(image.Rect(0, 0, 256, 256))
+	for i := 0; i < 256; i++ {
+		for j := 0; j < 256; j++ {
+			tile.Set(i, j, c)
+		}
+	}
+
+	f, err := os.Create(path)
+	testutils.AssertNoError(t, err, "can't create tile")
+	defer f.Close()
+
+	testutils.AssertNoError(t, png.Encode(f, tile), "can't encode tile")
+}
+
+func tileAt(latitude float64, longitude float64, zoom int) (int, int) {
+	tiles := math.Pow(2, float64(zoom))
+	lat := latitude * math.Pi / 180
+
+	x := (longitude + 180) / 360 * tiles
+	y := (1 - math.Log(math.Tan(lat)+1/math.Cos(lat))/math.Pi) / 2 * tiles
+
+	return int(x), int(y)
+}
+
+func hexColor(t *testing.T, hex string) color.Color {
+	value, err := strconv.ParseUint(hex, 16, 32)
+	testutils.AssertNoError(t, err, "can't parse color %s", hex)
+
+	return color.NRGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 0xFF}
+}
+
+func assertColor(t *testing.T, expected color.Color, actual color.Color, msg string) {
+	t.Helper()
+
+	er, eg, eb, _ := expected.RGBA()
+	ar, ag, ab, _ := actual.RGBA()
+	if er != ar || eg != ag || eb != ab {
+		t.Fatalf("%s\nexpected: %v\nactual: %v", msg, expected, actual)
+	}
+}
@@ -0,0 +1,258 @@
+package strava
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+const (
+	activitiesFileName = "activities.csv"
+	activityDateLayout = "Jan 2, 2006, 3:04:05 PM"
+)
+
+// Strava reads the bulk export archives of Strava accounts
+type Strava struct{}
+
+// ExtractStravaArchive lists the activities described in activities.csv and extracts their files next to the archive.
+//
+// Extracting the same archive again overwrites the previously extracted files.
+func (s Strava) ExtractStravaArchive(ctx context.Context, imp domain.Import) ([]domain.ImportItem, error) {
+	archive, err := zip.OpenReader(imp.ArchivePath)
+	if err != nil {
+		return nil, fmt.Errorf("can't open archive (path=%s): %v", imp.ArchivePath, err)
+	}
+	defer archive.Close()
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[path.Clean(file.Name)] = file
+	}
+
+	activities, ok := files[activitiesFileName]
+	if !ok {
+		return nil, fmt.Errorf("archive doesn't contain %s", activitiesFileName)
+	}
+
+	items, err := readActivities(activities, imp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	folder := extractFolder(imp)
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		file, ok := files[item.FileName]
+		if item.FileName == "" || !ok {
+			continue
+		}
+
+		if err := extractFile(file, folder, item.FileName); err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+// OpenImportItemFile returns the GPX content of an extracted activity file.
+//
+// Compressed files are decompressed and TCX files are converted. Other formats return a domain.ErrUnsupportedActivityFormat.
+func (s Strava) OpenImportItemFile(ctx context.Context, imp domain.Import, item domain.ImportItem) (io.ReadCloser, error) {
+	if item.FileName == "" {
+		return nil, fmt.Errorf("%w: activity has no file", domain.ErrUnsupportedActivityFormat)
+	}
+
+	name := item.FileName
+	file, err := os.Open(filepath.Join(extractFolder(imp), filepath.FromSlash(name)))
+	if err != nil {
+		return nil, fmt.Errorf("can't open activity file (name=%s): %v", name, err)
+	}
+	defer file.Close()
+
+	var content io.Reader = file
+	if strings.HasSuffix(name, ".gz") {
+		decompressed, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("can't decompress activity file (name=%s): %v", name, err)
+		}
+		defer decompressed.Close()
+
+		content = decompressed
+		name = strings.TrimSuffix(name, ".gz")
+	}
+
+	switch strings.ToLower(path.Ext(name)) {
+	case ".gpx":
+		data, err := io.ReadAll(content)
+		if err != nil {
+			return nil, fmt.Errorf("can't read activity file (name=%s): %v", item.FileName, err)
+		}
+
+		return io.NopCloser(strings.NewReader(strings.TrimSpace(string(data)))), nil
+	case ".tcx":
+		data, err := convertTCXToGPX(content)
+		if err != nil {
+			return nil, fmt.Errorf("can't convert activity file (name=%s): %v", item.FileName, err)
+		}
+
+		return io.NopCloser(strings.NewReader(string(data))), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", domain.ErrUnsupportedActivityFormat, path.Ext(name))
+	}
+}
+
+func readActivities(file *zip.File, importID domain.ID) ([]domain.ImportItem, error) {
+	content, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("can't open %s: %v", activitiesFileName, err)
+	}
+	defer content.Close()
+
+	reader := csv.NewReader(content)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("can't parse %s: %v", activitiesFileName, err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s is empty", activitiesFileName)
+	}
+
+	columns, err := newActivityColumns(records[0])
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.ImportItem, 0, len(records)-1)
+	for _, record := range records[1:] {
+		items = append(items, columns.importItem(importID, record))
+	}
+
+	return items, nil
+}
+
+type activityColumns struct {
+	id          int
+	date        int
+	name        int
+	kind        int
+	description int
+	fileName    int
+}
+
+func newActivityColumns(header []string) (activityColumns, error) {
+	indexes := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if _, ok := indexes[name]; !ok {
+			indexes[name] = i
+		}
+	}
+
+	var missing []string
+	lookup := func(name string) int {
+		i, ok := indexes[name]
+		if !ok {
+			missing = append(missing, name)
+		}
+
+		return i
+	}
+
+	columns := activityColumns{
+		id:          lookup("Activity ID"),
+		date:        lookup("Activity Date"),
+		name:        lookup("Activity Name"),
+		kind:        lookup("Activity Type"),
+		description: lookup("Activity Description"),
+		fileName:    lookup("Filename"),
+	}
+
+	if len(missing) > 0 {
+		return activityColumns{}, fmt.Errorf("%s misses columns: %s", activitiesFileName, strings.Join(missing, ", "))
+	}
+
+	return columns, nil
+}
+
+func (c activityColumns) importItem(importID domain.ID, record []string) domain.ImportItem {
+	field := func(i int) string {
+		if i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	item := domain.ImportItem{
+		ImportID:    importID,
+		ExternalID:  field(c.id),
+		FileName:    path.Clean(field(c.fileName)),
+		Type:        field(c.kind),
+		Name:        field(c.name),
+		Description: field(c.description),
+		Status:      domain.ImportItemStatusPending,
+	}
+
+	if item.FileName == "." {
+		item.FileName = ""
+	}
+
+	ranAt, err := time.Parse(activityDateLayout, field(c.date))
+	if err != nil {
+		return item.Fail(fmt.Sprintf("can't parse activity date '%s'", field(c.date)))
+	}
+	item.RanAt = ranAt
+
+	return item
+}
+
+func extractFolder(imp domain.Import) string {
+	return filepath.Join(filepath.Dir(imp.ArchivePath), imp.ID.String())
+}
+
+func extractFile(file *zip.File, folder string, name string) error {
+	if path.IsAbs(name) || strings.HasPrefix(name, "../") {
+		return fmt.Errorf("invalid file name in archive (name=%s)", name)
+	}
+
+	dest := filepath.Join(folder, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("can't create extraction folder (path=%s): %v", filepath.Dir(dest), err)
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("can't open file in archive (name=%s): %v", name, err)
+	}
+	defer content.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("can't create extracted file (path=%s): %v", dest, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, content); err != nil {
+		return fmt.Errorf("can't extract file (name=%s): %v", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package strava_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/strava"
+)
+
+const activitiesCSV = `Activity ID,Activity Date,Activity Name,Activity Type,Activity Description,Elapsed Time,Distance,Filename,Elapsed Time
+1001,"Apr 10, 2022, 7:30:12 AM",Morning Run,Run,"Along the river, slowly",1800,5.2,activities/1001.gpx,1800
+1002,"Apr 11, 2022, 6:05:00 PM",Evening Ride,Ride,,3600,20.1,activities/1002.tcx.gz,3600
+1003,"Apr 12, 2022, 8:00:00 AM",Treadmill,Run,,1200,3.0,,1200
+1004,"not a date",Broken Run,Run,,1200,3.0,activities/1004.fit.gz,1200
+`
+
+const gpxContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="StravaGPX"><trk><trkseg><trkpt lat="48.1" lon="2.1"><ele>35</ele><time>2022-04-10T07:30:12Z</time></trkpt></trkseg></trk></gpx>`
+
+const tcxContent = `<?xml version="1.0" encoding="UTF-8"?>
+<TrainingCenterDatabase xmlns="http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2">
+ <Activities>
+  <Activity Sport="Running">
+   <Lap>
+    <Track>
+     <Trackpoint><Time>2022-04-11T18:05:00Z</Time><Position><LatitudeDegrees>48.1</LatitudeDegrees><LongitudeDegrees>2.1</LongitudeDegrees></Position><AltitudeMeters>35.5</AltitudeMeters></Trackpoint>
+     <Trackpoint><Time>2022-04-11T18:05:01Z</Time><HeartRateBpm><Value>120</Value></HeartRateBpm></Trackpoint>
+    </Track>
+   </Lap>
+   <Lap>
+    <Track>
+     <Trackpoint><Time>2022-04-11T18:06:00Z</Time><Position><LatitudeDegrees>48.2</LatitudeDegrees><LongitudeDegrees>2.2</LongitudeDegrees></Position><AltitudeMeters>36</AltitudeMeters></Trackpoint>
+    </Track>
+   </Lap>
+  </Activity>
+ </Activities>
+</TrainingCenterDatabase>`
+
+func TestExtractStravaArchiveSuccess(t *testing.T) {
+	imp := writeArchive(t, map[string][]byte{
+		"activities.csv":           []byte(activitiesCSV),
+		"activities/1001.gpx":      []byte(gpxContent),
+		"activities/1002.tcx.gz":   compress(t, tcxContent),
+		"activities/1004.fit.gz":   compress(t, "fit content"),
+		"media/unrelated-file.jpg": []byte("image"),
+	})
+
+	items, err := strava.Strava{}.ExtractStravaArchive(context.Background(), imp)
+	testutils.RequireNoError(t, err, "can't extract archive")
+	testutils.RequireEqualInt(t, 4, len(items), "unexpected number of items")
+
+	testutils.AssertEqualString(t, imp.ID.String(), items[0].ImportID.String(), "unexpected import id")
+	testutils.AssertEqualString(t, "1001", items[0].ExternalID, "unexpected external id")
+	testutils.AssertEqualString(t, "activities/1001.gpx", items[0].FileName, "unexpected file name")
+	testutils.AssertEqualTime(t, time.Date(2022, 4, 10, 7, 30, 12, 0, time.UTC), items[0].RanAt, "unexpected ran at")
+	testutils.AssertEqualString(t, "Run", items[0].Type, "unexpected type")
+	testutils.AssertEqualString(t, "Morning Run", items[0].Name, "unexpected name")
+	testutils.AssertEqualString(t, "Along the river, slowly", items[0].Description, "unexpected description")
+	testutils.AssertEqualString(t, domain.ImportItemStatusPending.String(), items[0].Status.String(), "unexpected status")
+
+	testutils.AssertEqualTime(t, time.Date(2022, 4, 11, 18, 5, 0, 0, time.UTC), items[1].RanAt, "unexpected ran at")
+	testutils.AssertEqualString(t, "", items[2].FileName, "unexpected file name")
+	testutils.AssertEqualString(t, domain.ImportItemStatusFailed.String(), items[3].Status.String(), "unexpected status")
+	testutils.AssertContainsString(t, "not a date", items[3].Reason, "unexpected reason")
+}
+
+func TestExtractStravaArchiveWithoutActivities(t *testing.T) {
+	imp := writeArchive(t, map[string][]byte{"activities/1001.gpx": []byte(gpxContent)})
+
+	_, err := strava.Strava{}.ExtractStravaArchive(context.Background(), imp)
+	testutils.AssertErrorContains(t, "activities.csv", err, "unexpected error")
+}
+
+func TestExtractStravaArchiveInvalidFileName(t *testing.T) {
+	imp := writeArchive(t, map[string][]byte{
+		"activities.csv": []byte("Activity ID,Activity Date,Activity Name,Activity Type,Activity Description,Filename\n" +
+			`1,"Apr 10, 2022, 7:30:12 AM",Run,Run,,../../evil.gpx` + "\n"),
+		"../../evil.gpx": []byte(gpxContent),
+	})
+
+	_, err := strava.Strava{}.ExtractStravaArchive(context.Background(), imp)
+	testutils.AssertErrorContains(t, "invalid file name", err, "unexpected error")
+}
+
+func TestOpenImportItemFileGPX(t *testing.T) {
+	imp, items := extractArchive(t)
+
+	content := readImportItemFile(t, imp, items[0])
+
+	testutils.AssertEqualString(t, strings.TrimSpace(gpxContent), content, "unexpected gpx content")
+}
+
+func TestOpenImportItemFileCompressedTCX(t *testing.T) {
+	imp, items := extractArchive(t)
+
+	content := readImportItemFile(t, imp, items[1])
+
+	testutils.AssertContainsString(t, `<trkpt lat="48.1" lon="2.1"><ele>35.5</ele><time>2022-04-11T18:05:00Z</time></trkpt>`, content, "unexpected first point")
+	testutils.AssertContainsString(t, `<trkpt lat="48.2" lon="2.2"><ele>36</ele><time>2022-04-11T18:06:00Z</time></trkpt>`, content, "unexpected second point")
+	testutils.AssertEqualInt(t, 2, strings.Count(content, "<trkpt"), "points without position should be ignored")
+	testutils.AssertEqualInt(t, 1, strings.Count(content, "<trkseg>"), "laps should be merged in a single segment")
+}
+
+func TestOpenImportItemFileUnsupportedFormat(t *testing.T) {
+	imp, items := extractArchive(t)
+
+	_, err := strava.Strava{}.OpenImportItemFile(context.Background(), imp, items[3])
+	testutils.AssertErrorIs(t, domain.ErrUnsupportedActivityFormat, err, "unexpected error")
+
+	_, err = strava.Strava{}.OpenImportItemFile(context.Background(), imp, items[2])
+	testutils.AssertErrorIs(t, domain.ErrUnsupportedActivityFormat, err, "unexpected error")
+}
+
+func extractArchive(t *testing.T) (domain.Import, []domain.ImportItem) {
+	imp := writeArchive(t, map[string][]byte{
+		"activities.csv":         []byte(activitiesCSV),
+		"activities/1001.gpx":    []byte(gpxContent),
+		"activities/1002.tcx.gz": compress(t, tcxContent),
+		"activities/1004.fit.gz": compress(t, "fit content"),
+	})
+
+	items, err := strava.Strava{}.ExtractStravaArchive(context.Background(), imp)
+	testutils.RequireNoError(t, err, "can't extract archive")
+	testutils.RequireEqualInt(t, 4, len(items), "unexpected number of items")
+
+	return imp, items
+}
+
+func readImportItemFile(t *testing.T, imp domain.Import, item domain.ImportItem) string {
+	file, err := strava.Strava{}.OpenImportItemFile(context.Background(), imp, item)
+	testutils.RequireNoError(t, err, "can't open file")
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	testutils.RequireNoError(t, err, "can't read file")
+
+	return string(content)
+}
+
+func writeArchive(t *testing.T, files map[string][]byte) domain.Import {
+	path := filepath.Join(t.TempDir(), "export.zip")
+	out, err := os.Create(path)
+	testutils.RequireNoError(t, err, "can't create archive")
+	defer out.Close()
+
+	w := zip.NewWriter(out)
+	for name, content := range files {
+		f, err := w.Create(name)
+		testutils.RequireNoError(t, err, "can't add file to archive")
+		_, err = f.Write(content)
+		testutils.RequireNoError(t, err, "can't write file in archive")
+	}
+	testutils.RequireNoError(t, w.Close(), "can't close archive")
+
+	return domain.NewImport("alice", path, time.Now())
+}
+
+func compress(t *testing.T, content string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(content))
+	testutils.RequireNoError(t, err, "can't compress content")
+	testutils.RequireNoError(t, w.Close(), "can't close compressed content")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,94 @@
+package strava
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+type tcxDatabase struct {
+	Activities []tcxActivity `xml:"Activities>Activity"`
+}
+
+type tcxActivity struct {
+	Laps []tcxLap `xml:"Lap"`
+}
+
+type tcxLap struct {
+	Trackpoints []tcxTrackpoint `xml:"Track>Trackpoint"`
+}
+
+type tcxTrackpoint struct {
+	Time     time.Time    `xml:"Time"`
+	Position *tcxPosition `xml:"Position"`
+	Altitude float64      `xml:"AltitudeMeters"`
+}
+
+type tcxPosition struct {
+	Latitude  float64 `xml:"LatitudeDegrees"`
+	Longitude float64 `xml:"LongitudeDegrees"`
+}
+
+type gpxFile struct {
+	XMLName xml.Name   `xml:"gpx"`
+	Version string     `xml:"version,attr"`
+	Creator string     `xml:"creator,attr"`
+	Tracks  []gpxTrack `xml:"trk"`
+}
+
+type gpxTrack struct {
+	Segments []gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+type gpxPoint struct {
+	Latitude  float64   `xml:"lat,attr"`
+	Longitude float64   `xml:"lon,attr"`
+	Elevation float64   `xml:"ele"`
+	Time      time.Time `xml:"time"`
+}
+
+// convertTCXToGPX merges all the laps of a TCX file in a single GPX track segment, ignoring points without position
+func convertTCXToGPX(r io.Reader) ([]byte, error) {
+	var database tcxDatabase
+	if err := xml.NewDecoder(r).Decode(&database); err != nil {
+		return nil, fmt.Errorf("can't decode tcx file: %v", err)
+	}
+
+	var segment gpxSegment
+	for _, activity := range database.Activities {
+		for _, lap := range activity.Laps {
+			for _, point := range lap.Trackpoints {
+				if point.Position == nil {
+					continue
+				}
+
+				segment.Points = append(segment.Points, gpxPoint{
+					Latitude:  point.Position.Latitude,
+					Longitude: point.Position.Longitude,
+					Elevation: point.Altitude,
+					Time:      point.Time,
+				})
+			}
+		}
+	}
+
+	if len(segment.Points) == 0 {
+		return nil, fmt.Errorf("tcx file contains no position")
+	}
+
+	content, err := xml.Marshal(gpxFile{
+		Version: "1.1",
+		Creator: "sport",
+		Tracks:  []gpxTrack{{Segments: []gpxSegment{segment}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't encode gpx file: %v", err)
+	}
+
+	return append([]byte(xml.Header), content...), nil
+}
@@ -0,0 +1,29 @@
+package www
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+)
+
+// EnsureAdmin rejects the requests of users who aren't administrators
+func EnsureAdmin(app application.Application, currentUser CurrentUser, h web.HandlerFunc) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		user, err := app.GetUser(ctx.StdCtx(), currentUser(r))
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't get current user: %v", err)
+		}
+
+		if !user.IsAdmin {
+			response := ctx.Response(http.StatusForbidden, "templates/403.html.tmpl", map[string]interface{}{
+				"Message": "This area is reserved to administrators.",
+			})
+			response.LogMessage = fmt.Sprintf("user %s isn't an administrator (method=%s, path=%s)", user.Username, r.Method, r.URL.Path)
+			return response
+		}
+
+		return h(ctx, w, r)
+	}
+}
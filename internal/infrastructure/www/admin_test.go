@@ -0,0 +1,75 @@
+package www_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestEnsureAdminSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/admin/users", nil)
+
+	expectedResponse := webtest.MockedResponse("users")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetUser(gomock.Any(), "alice").Return(domaintest.NewUser(t).WithUsername("alice").WithAdmin().Build(), nil)
+
+	handler := func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		return expectedResponse
+	}
+
+	actualResponse := www.EnsureAdmin(app, currentUser("alice"), handler)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestEnsureAdminForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/admin/users", nil)
+
+	expectedResponse := webtest.MockedResponse("forbidden")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetUser(gomock.Any(), "alice").Return(domaintest.NewUser(t).WithUsername("alice").Build(), nil)
+	ctx.EXPECT().Response(http.StatusForbidden, "templates/403.html.tmpl", webtest.MatchDataContains("Message", "This area is reserved to administrators.")).Return(expectedResponse)
+
+	handler := func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		t.Fatalf("handler must not be called")
+		return web.Response{}
+	}
+
+	actualResponse := www.EnsureAdmin(app, currentUser("alice"), handler)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestEnsureAdminError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/admin/users", nil)
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetUser(gomock.Any(), "alice").Return(domain.User{}, errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.EnsureAdmin(app, currentUser("alice"), nil)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
@@ -0,0 +1,23 @@
+package www
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func APITokensIndex(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		tokens, err := app.ListAPITokens(ctx.StdCtx(), currentUser(r))
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't list api tokens: %v", err)
+		}
+
+		return ctx.Response(200, "templates/settings/tokens.html.tmpl", map[string]interface{}{
+			"Tokens": tokens,
+			"Scopes": domain.APITokenScopes(),
+		})
+	}
+}
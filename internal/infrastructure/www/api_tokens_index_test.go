@@ -0,0 +1,52 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestAPITokensIndexSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/settings/tokens", nil)
+	tokens := []domain.APIToken{domaintest.NewAPIToken(t).Build()}
+
+	expectedResponse := webtest.MockedResponse("tokens")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ListAPITokens(gomock.Any(), "alice").Return(tokens, nil)
+	ctx.EXPECT().Response(200, "templates/settings/tokens.html.tmpl", gomock.All(
+		webtest.MatchDataContains("Tokens", tokens),
+		webtest.MatchDataContains("Scopes", domain.APITokenScopes()),
+	)).Return(expectedResponse)
+
+	actualResponse := www.APITokensIndex(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestAPITokensIndexError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/settings/tokens", nil)
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ListAPITokens(gomock.Any(), "alice").Return(nil, errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.APITokensIndex(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
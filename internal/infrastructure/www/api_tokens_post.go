@@ -0,0 +1,45 @@
+package www
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// APITokensPost creates the token and shows its secret. The secret isn't stored so the page is rendered instead of
+// redirecting to the list of tokens.
+func APITokensPost(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		username := currentUser(r)
+
+		token, secret, err := app.CreateAPIToken(ctx.StdCtx(), username, strings.TrimSpace(r.FormValue("name")), r.FormValue("scope"))
+		if err != nil {
+			var invalidErr *domain.InvalidInputErrors
+			if !errors.As(err, &invalidErr) {
+				return ctx.InternalServerErrorResponse("can't create api token: %v", err)
+			}
+
+			ctx.AddFlash(web.NewFlashMessageError(fmt.Sprintf("token can't be created: %s", strings.Join(invalidErr.Detail(), ", "))))
+			response := ctx.Redirect(w, http.StatusSeeOther, "/settings/tokens")
+			response.LogMessage = fmt.Sprintf("invalid api token: %v", err)
+			return response
+		}
+
+		tokens, err := app.ListAPITokens(ctx.StdCtx(), username)
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't list api tokens: %v", err)
+		}
+
+		return ctx.Response(201, "templates/settings/tokens.html.tmpl", map[string]interface{}{
+			"Tokens":       tokens,
+			"Scopes":       domain.APITokenScopes(),
+			"CreatedToken": token,
+			"Secret":       secret,
+		})
+	}
+}
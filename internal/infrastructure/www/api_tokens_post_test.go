@@ -0,0 +1,84 @@
+package www_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestAPITokensPostInvalidInput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := newAPITokensPostRequest(url.Values{"name": {" "}, "scope": {"read"}})
+
+	invalidErr := domain.InvalidInputErrors{"name is required"}
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().CreateAPIToken(gomock.Any(), "alice", "", "read").Return(domain.APIToken{}, "", &invalidErr)
+	ctx.EXPECT().AddFlash(webtest.MatchFlashErrorContains("name is required"))
+	ctx.EXPECT().Redirect(w, 303, "/settings/tokens").Return(expectedResponse)
+
+	actualResponse := www.APITokensPost(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestAPITokensPostCannotCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := newAPITokensPostRequest(url.Values{"name": {"backup"}, "scope": {"read"}})
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().CreateAPIToken(gomock.Any(), "alice", "backup", "read").Return(domain.APIToken{}, "", errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.APITokensPost(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestAPITokensPostSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := newAPITokensPostRequest(url.Values{"name": {"backup"}, "scope": {"write"}})
+	token := domaintest.NewAPIToken(t).WithScope(domain.APITokenScopeWrite).Build()
+
+	expectedResponse := webtest.MockedResponse("token created")
+	ctx.EXPECT().StdCtx().Times(2)
+	app.EXPECT().CreateAPIToken(gomock.Any(), "alice", "backup", "write").Return(token, "secret", nil)
+	app.EXPECT().ListAPITokens(gomock.Any(), "alice").Return([]domain.APIToken{token}, nil)
+	ctx.EXPECT().Response(201, "templates/settings/tokens.html.tmpl", gomock.All(
+		webtest.MatchDataContains("Tokens", []domain.APIToken{token}),
+		webtest.MatchDataContains("CreatedToken", token),
+		webtest.MatchDataContains("Secret", "secret"),
+	)).Return(expectedResponse)
+
+	actualResponse := www.APITokensPost(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func newAPITokensPostRequest(form url.Values) *http.Request {
+	r := httptest.NewRequest("POST", "/settings/tokens", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return r
+}
@@ -0,0 +1,31 @@
+package www
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func APITokensRevoke(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		vars := ctx.Vars(r)
+
+		id, err := domain.ParseID(vars["id"])
+		if err != nil {
+			return ctx.NotFoundResponse("can't parse api token id (id=%s): %v", vars["id"], err)
+		}
+
+		if err := app.RevokeAPIToken(ctx.StdCtx(), currentUser(r), id); err != nil {
+			if errors.Is(err, domain.ErrAPITokenNotFound) {
+				return ctx.NotFoundResponse("can't find api token (id=%s): %v", vars["id"], err)
+			}
+			return ctx.InternalServerErrorResponse("can't revoke api token (id=%s): %v", vars["id"], err)
+		}
+
+		ctx.AddFlash(web.NewFlashMessageSuccess("token revoked"))
+		return ctx.Redirect(w, http.StatusSeeOther, "/settings/tokens")
+	}
+}
@@ -0,0 +1,87 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestAPITokensRevokeInvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/settings/tokens/{id}/revoke", nil)
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"id": "wrong-id"})
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.APITokensRevoke(nil, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestAPITokensRevokeNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/settings/tokens/{id}/revoke", nil)
+	id := domain.NewID()
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"id": id.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().RevokeAPIToken(gomock.Any(), "alice", id).Return(domain.ErrAPITokenNotFound)
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.APITokensRevoke(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestAPITokensRevokeError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/settings/tokens/{id}/revoke", nil)
+	id := domain.NewID()
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"id": id.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().RevokeAPIToken(gomock.Any(), "alice", id).Return(errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.APITokensRevoke(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestAPITokensRevokeSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/settings/tokens/{id}/revoke", nil)
+	id := domain.NewID()
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"id": id.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().RevokeAPIToken(gomock.Any(), "alice", id).Return(nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("token revoked"))
+	ctx.EXPECT().Redirect(w, 303, "/settings/tokens").Return(expectedResponse)
+
+	actualResponse := www.APITokensRevoke(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
@@ -0,0 +1,41 @@
+package www
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// AthletesShow lists the activities uploaded by the athlete and listed to the current user
+func AthletesShow(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		username := ctx.Vars(r)["username"]
+
+		athlete, err := app.GetUser(ctx.StdCtx(), username)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return ctx.NotFoundResponse("can't find athlete (username=%s): %v", username, err)
+		}
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't get athlete (username=%s): %v", username, err)
+		}
+
+		activities, err := app.ListUserRunningSessions(ctx.StdCtx(), currentUser(r), athlete.Username)
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't list activities of %s: %v", athlete.Username, err)
+		}
+
+		user, err := viewer(ctx, app, currentUser, r)
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't get current user: %v", err)
+		}
+
+		return ctx.Response(200, "templates/athletes/show.html.tmpl", map[string]interface{}{
+			"Athlete":    athlete,
+			"Activities": activities,
+			"Viewer":     user,
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils/gomockutils"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestAthletesShowSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	app := applicationtest.NewMockApplication(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/athletes/alice", nil)
+	athlete := domaintest.NewUser(t).WithUsername("alice").Build()
+	activities := []domain.RunningActivity{domaintest.NewRunningActivity(t).WithUsername("alice").Build()}
+
+	ctx.EXPECT().StdCtx().AnyTimes()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"username": "alice"})
+	app.EXPECT().GetUser(gomock.Any(), "alice").Return(athlete, nil)
+	app.EXPECT().ListUserRunningSessions(gomock.Any(), "", "alice").Return(activities, nil)
+
+	expected := webtest.MockedResponse("ok response")
+	ctx.EXPECT().
+		Response(200, "templates/athletes/show.html.tmpl", gomock.All(
+			webtest.MatchDataContains("Athlete", athlete),
+			webtest.MatchDataContains("Activities", activities),
+			webtest.MatchDataContains("Viewer", domain.User{}),
+		)).
+		Return(expected)
+
+	actual := www.AthletesShow(app, currentUser(""))(ctx, w, r)
+
+	webtest.AssertResponse(t, expected, actual, "unexpected response")
+}
+
+func TestAthletesShowNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	app := applicationtest.NewMockApplication(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/athletes/bob", nil)
+
+	ctx.EXPECT().StdCtx().AnyTimes()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"username": "bob"})
+	app.EXPECT().GetUser(gomock.Any(), "bob").Return(domain.User{}, domain.ErrUserNotFound)
+
+	expected := webtest.MockedResponse("not found")
+	ctx.EXPECT().NotFoundResponse(gomockutils.ContainsString("can't find athlete"), gomock.Any()).Return(expected)
+
+	actual := www.AthletesShow(app, currentUser(""))(ctx, w, r)
+
+	webtest.AssertResponse(t, expected, actual, "unexpected response")
+}
+
+func TestAthletesShowCannotListActivities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	app := applicationtest.NewMockApplication(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/athletes/alice", nil)
+
+	ctx.EXPECT().StdCtx().AnyTimes()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"username": "alice"})
+	app.EXPECT().GetUser(gomock.Any(), "alice").Return(domaintest.NewUser(t).WithUsername("alice").Build(), nil)
+	app.EXPECT().ListUserRunningSessions(gomock.Any(), "", "alice").Return(nil, errors.New("boom"))
+
+	expected := webtest.MockedResponse("server error")
+	ctx.EXPECT().InternalServerErrorResponse(gomockutils.ContainsString("can't list activities"), gomock.Any()).Return(expected)
+
+	actual := www.AthletesShow(app, currentUser(""))(ctx, w, r)
+
+	webtest.AssertResponse(t, expected, actual, "unexpected response")
+}
@@ -0,0 +1,65 @@
+package www
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// AuthenticationBackend checks the credentials of the login form against the users of the application. The ID of a
+// user is its username.
+type AuthenticationBackend struct {
+	app application.Application
+}
+
+// NewAuthenticationBackend initializes an authentication storage backed by the users of the application
+func NewAuthenticationBackend(app application.Application) AuthenticationBackend {
+	return AuthenticationBackend{app: app}
+}
+
+// Register creates a regular user
+func (a AuthenticationBackend) Register(username string, password string) (web.AuthenticationUserID, error) {
+	user, err := a.app.CreateUser(context.Background(), username, password, false)
+	if errors.Is(err, domain.ErrUserAlreadyExists) {
+		return "", fmt.Errorf("%v: %w", err, web.ErrUserAlreadyExist)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return web.AuthenticationUserID(user.Username), nil
+}
+
+// Authenticate returns the ID of the user when the password is correct and the user isn't disabled
+func (a AuthenticationBackend) Authenticate(username string, password string) (web.AuthenticationUserID, error) {
+	user, err := a.app.AuthenticateUser(context.Background(), username, password)
+	if errors.Is(err, domain.ErrInvalidCredentials) {
+		return "", fmt.Errorf("%v: %w", err, web.ErrUserInvalidCredentials)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return web.AuthenticationUserID(user.Username), nil
+}
+
+// Lookup returns the user of the ID. Disabled users are reported as not found, which logs them out.
+func (a AuthenticationBackend) Lookup(id web.AuthenticationUserID) (web.AuthenticationUser, error) {
+	user, err := a.app.GetUser(context.Background(), id.String())
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return web.AuthenticationUser{}, fmt.Errorf("%v: %w", err, web.ErrUserNotFound)
+	}
+	if err != nil {
+		return web.AuthenticationUser{}, err
+	}
+
+	if user.IsDisabled() {
+		return web.AuthenticationUser{}, fmt.Errorf("user %s is disabled: %w", user.Username, web.ErrUserNotFound)
+	}
+
+	return web.AuthenticationUser{ID: id, Username: user.Username}, nil
+}
@@ -0,0 +1,109 @@
+package www_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestAuthenticationBackendRegisterSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+
+	app.EXPECT().CreateUser(gomock.Any(), "alice", "correct horse", false).Return(domaintest.NewUser(t).WithUsername("alice").Build(), nil)
+
+	id, err := www.NewAuthenticationBackend(app).Register("alice", "correct horse")
+	testutils.RequireNoError(t, err, "can't register user")
+
+	testutils.AssertEqualString(t, "alice", id.String(), "unexpected id")
+}
+
+func TestAuthenticationBackendRegisterAlreadyExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+
+	app.EXPECT().CreateUser(gomock.Any(), "alice", "correct horse", false).Return(domain.User{}, domain.ErrUserAlreadyExists)
+
+	_, err := www.NewAuthenticationBackend(app).Register("alice", "correct horse")
+
+	testutils.AssertErrorIs(t, web.ErrUserAlreadyExist, err, "unexpected error")
+}
+
+func TestAuthenticationBackendAuthenticateSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+
+	app.EXPECT().AuthenticateUser(gomock.Any(), "alice", "correct horse").Return(domaintest.NewUser(t).WithUsername("alice").Build(), nil)
+
+	id, err := www.NewAuthenticationBackend(app).Authenticate("alice", "correct horse")
+	testutils.RequireNoError(t, err, "can't authenticate user")
+
+	testutils.AssertEqualString(t, "alice", id.String(), "unexpected id")
+}
+
+func TestAuthenticationBackendAuthenticateInvalidCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+
+	app.EXPECT().AuthenticateUser(gomock.Any(), "alice", "wrong").Return(domain.User{}, domain.ErrInvalidCredentials)
+
+	_, err := www.NewAuthenticationBackend(app).Authenticate("alice", "wrong")
+
+	testutils.AssertErrorIs(t, web.ErrUserInvalidCredentials, err, "unexpected error")
+}
+
+func TestAuthenticationBackendAuthenticateError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+
+	app.EXPECT().AuthenticateUser(gomock.Any(), "alice", "correct horse").Return(domain.User{}, errors.New("boom"))
+
+	_, err := www.NewAuthenticationBackend(app).Authenticate("alice", "correct horse")
+
+	testutils.AssertErrorContains(t, "boom", err, "unexpected error")
+	testutils.AssertEqualBool(t, false, errors.Is(err, web.ErrUserInvalidCredentials), "storage errors aren't invalid credentials")
+}
+
+func TestAuthenticationBackendLookupSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+
+	app.EXPECT().GetUser(gomock.Any(), "alice").Return(domaintest.NewUser(t).WithUsername("alice").Build(), nil)
+
+	user, err := www.NewAuthenticationBackend(app).Lookup("alice")
+	testutils.RequireNoError(t, err, "can't lookup user")
+
+	testutils.AssertEqualString(t, "alice", user.ID.String(), "unexpected id")
+	testutils.AssertEqualString(t, "alice", user.Username, "unexpected username")
+}
+
+func TestAuthenticationBackendLookupNotFound(t *testing.T) {
+	tcs := map[string]struct {
+		User domain.User
+		Err  error
+	}{
+		"unknownUser":  {Err: domain.ErrUserNotFound},
+		"disabledUser": {User: domaintest.NewUser(t).WithUsername("alice").WithDisabledAt(time.Now()).Build()},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			app := applicationtest.NewMockApplication(ctrl)
+
+			app.EXPECT().GetUser(gomock.Any(), "alice").Return(tc.User, tc.Err)
+
+			_, err := www.NewAuthenticationBackend(app).Lookup("alice")
+
+			testutils.AssertErrorIs(t, web.ErrUserNotFound, err, "unexpected error")
+		})
+	}
+}
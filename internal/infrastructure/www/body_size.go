@@ -0,0 +1,16 @@
+package www
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+)
+
+// LimitBodySize fails the reads of request bodies bigger than size. It must wrap the middlewares parsing forms, such as EnsureCSRFToken, for the limit to apply.
+func LimitBodySize(size int64, h web.HandlerFunc) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		r.Body = http.MaxBytesReader(w, r.Body, size)
+
+		return h(ctx, w, r)
+	}
+}
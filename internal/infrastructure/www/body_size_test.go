@@ -0,0 +1,30 @@
+package www_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestLimitBodySize(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/imports", strings.NewReader("0123456789"))
+
+	expectedResponse := webtest.MockedResponse("page")
+	handler := func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		_, err := ioutil.ReadAll(r.Body)
+		testutils.AssertHasError(t, err, "expected body to be too large")
+		return expectedResponse
+	}
+
+	actualResponse := www.LimitBodySize(5, handler)(nil, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
@@ -0,0 +1,135 @@
+package www
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/sessions"
+	"github.com/lonepeon/golib/web"
+)
+
+// CSRFFieldName is the name of the hidden field carrying the CSRF token in every form
+const CSRFFieldName = "csrf_token"
+
+const (
+	csrfSessionName = "csrf"
+	csrfSessionKey  = "token"
+	csrfTokenSize   = 32
+)
+
+// ErrInvalidCSRFToken is returned when a request doesn't send back the CSRF token of its session
+var ErrInvalidCSRFToken = errors.New("csrf token is invalid")
+
+// CSRFSessionStore keeps a random token per session, that forms send back to prove they were rendered by the site
+type CSRFSessionStore struct {
+	store sessions.Store
+}
+
+// NewCSRFSessionStore initializes a CSRF token storage on top of the session store
+func NewCSRFSessionStore(store sessions.Store) CSRFSessionStore {
+	return CSRFSessionStore{store: store}
+}
+
+// Token returns the CSRF token of the session, creating it on the first visit
+func (c CSRFSessionStore) Token(w http.ResponseWriter, r *http.Request) (string, error) {
+	session, err := c.store.Get(r, csrfSessionName)
+	if err != nil {
+		return "", fmt.Errorf("can't get session '%s': %v", csrfSessionName, err)
+	}
+
+	if token, ok := session.Values[csrfSessionKey].(string); ok && token != "" {
+		return token, nil
+	}
+
+	token, err := newCSRFToken()
+	if err != nil {
+		return "", err
+	}
+
+	session.Values[csrfSessionKey] = token
+	if err := session.Save(r, w); err != nil {
+		return "", fmt.Errorf("can't save csrf token to session '%s': %v", csrfSessionName, err)
+	}
+
+	return token, nil
+}
+
+// Verify returns ErrInvalidCSRFToken when the token isn't the one of the session
+func (c CSRFSessionStore) Verify(r *http.Request, token string) error {
+	session, err := c.store.Get(r, csrfSessionName)
+	if err != nil {
+		return fmt.Errorf("can't get session '%s': %v", csrfSessionName, err)
+	}
+
+	expected, _ := session.Values[csrfSessionKey].(string)
+	if expected == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(token)) != 1 {
+		return ErrInvalidCSRFToken
+	}
+
+	return nil
+}
+
+// WithCSRFToken exposes the CSRF token of the session to the templates rendered by the handler. It creates a session
+// on the first visit, so it only wraps the pages rendering forms.
+func WithCSRFToken(csrf CSRFSessionStore, h web.HandlerFunc) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		token, err := csrf.Token(w, r)
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't get csrf token: %v", err)
+		}
+
+		ctx.AddData("CSRFToken", token)
+
+		return h(ctx, w, r)
+	}
+}
+
+// WithUserCSRFToken exposes the CSRF token to the templates rendered for logged-in users only, on the pages browsed
+// by visitors whose forms are only shown to users: visitors don't get a session
+func WithUserCSRFToken(csrf CSRFSessionStore, currentUser CurrentUser, h web.HandlerFunc) web.HandlerFunc {
+	withToken := WithCSRFToken(csrf, h)
+
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		if currentUser(r) == "" {
+			return h(ctx, w, r)
+		}
+
+		return withToken(ctx, w, r)
+	}
+}
+
+// ProtectCSRF verifies the CSRF token on the requests changing state, and exposes it to the forms they render again.
+// GET requests are left untouched.
+func ProtectCSRF(csrf CSRFSessionStore, method string, h web.HandlerFunc) web.HandlerFunc {
+	if method == http.MethodGet {
+		return h
+	}
+
+	return EnsureCSRFToken(csrf, WithCSRFToken(csrf, h))
+}
+
+// EnsureCSRFToken rejects the requests which don't send back the CSRF token of their session, such as forms submitted from another site
+func EnsureCSRFToken(csrf CSRFSessionStore, h web.HandlerFunc) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		if err := csrf.Verify(r, r.PostFormValue(CSRFFieldName)); err != nil {
+			response := ctx.Response(http.StatusForbidden, "templates/403.html.tmpl", nil)
+			response.LogMessage = fmt.Sprintf("can't verify csrf token (method=%s, path=%s): %v", r.Method, r.URL.Path, err)
+			return response
+		}
+
+		return h(ctx, w, r)
+	}
+}
+
+func newCSRFToken() (string, error) {
+	token := make([]byte, csrfTokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("can't generate csrf token: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
@@ -0,0 +1,225 @@
+package www_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/sessions"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestCSRFSessionStoreTokenIsKeptInSession(t *testing.T) {
+	csrf := www.NewCSRFSessionStore(sessions.NewCookieStore([]byte("secret")))
+
+	w := httptest.NewRecorder()
+	token, err := csrf.Token(w, httptest.NewRequest("GET", "/login", nil))
+	testutils.RequireNoError(t, err, "can't create csrf token")
+
+	r := requestWithCookies(httptest.NewRequest("GET", "/login", nil), w)
+	sameToken, err := csrf.Token(httptest.NewRecorder(), r)
+	testutils.RequireNoError(t, err, "can't get csrf token")
+	testutils.AssertEqualString(t, token, sameToken, "token should be kept in session")
+
+	otherToken, err := csrf.Token(httptest.NewRecorder(), httptest.NewRequest("GET", "/login", nil))
+	testutils.RequireNoError(t, err, "can't create csrf token")
+	testutils.AssertEqualBool(t, false, token == otherToken, "each session should have its own token")
+}
+
+func TestWithCSRFToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	csrf := www.NewCSRFSessionStore(sessions.NewCookieStore([]byte("secret")))
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/login", nil)
+
+	expectedResponse := webtest.MockedResponse("page")
+	var token string
+	ctx.EXPECT().AddData("CSRFToken", gomock.Any()).Do(func(key string, value interface{}) {
+		token = value.(string)
+	})
+
+	handler := func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		return expectedResponse
+	}
+
+	actualResponse := www.WithCSRFToken(csrf, handler)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+	testutils.AssertNoError(t, csrf.Verify(requestWithCookies(httptest.NewRequest("POST", "/login", nil), w), token), "exposed token should be valid")
+}
+
+func TestWithUserCSRFTokenVisitor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	csrf := www.NewCSRFSessionStore(sessions.NewCookieStore([]byte("secret")))
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+
+	expectedResponse := webtest.MockedResponse("page")
+	handler := func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		return expectedResponse
+	}
+
+	actualResponse := www.WithUserCSRFToken(csrf, currentUser(""), handler)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+	testutils.AssertEqualInt(t, 0, len(w.Result().Cookies()), "visitors shouldn't get a session")
+}
+
+func TestWithUserCSRFTokenLoggedIn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	csrf := www.NewCSRFSessionStore(sessions.NewCookieStore([]byte("secret")))
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+
+	expectedResponse := webtest.MockedResponse("page")
+	ctx.EXPECT().AddData("CSRFToken", gomock.Any())
+	handler := func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		return expectedResponse
+	}
+
+	actualResponse := www.WithUserCSRFToken(csrf, currentUser("alice"), handler)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestProtectCSRFGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	csrf := www.NewCSRFSessionStore(sessions.NewCookieStore([]byte("secret")))
+	w := httptest.NewRecorder()
+
+	expectedResponse := webtest.MockedResponse("page")
+	handler := func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		return expectedResponse
+	}
+
+	actualResponse := www.ProtectCSRF(csrf, "GET", handler)(ctx, w, httptest.NewRequest("GET", "/", nil))
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+	testutils.AssertEqualInt(t, 0, len(w.Result().Cookies()), "GET requests shouldn't get a session")
+}
+
+func TestProtectCSRFPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	csrf, cookies := newCSRFSession(t)
+	token, err := csrf.Token(httptest.NewRecorder(), requestWithCookies(httptest.NewRequest("GET", "/", nil), cookies))
+	testutils.RequireNoError(t, err, "can't get csrf token")
+
+	handler := func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		t.Fatalf("handler must not be called")
+		return web.Response{}
+	}
+
+	expectedResponse := webtest.MockedResponse("forbidden")
+	ctx.EXPECT().Response(http.StatusForbidden, "templates/403.html.tmpl", nil).Return(expectedResponse)
+	r := postForm("/settings", url.Values{})
+	actualResponse := www.ProtectCSRF(csrf, "POST", handler)(ctx, httptest.NewRecorder(), requestWithCookies(r, cookies))
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "missing token should be rejected")
+
+	expectedResponse = webtest.MockedResponse("saved")
+	ctx.EXPECT().AddData("CSRFToken", token)
+	handler = func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		return expectedResponse
+	}
+	r = postForm("/settings", url.Values{www.CSRFFieldName: []string{token}})
+	actualResponse = www.ProtectCSRF(csrf, "POST", handler)(ctx, httptest.NewRecorder(), requestWithCookies(r, cookies))
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "valid token should be accepted")
+}
+
+func TestEnsureCSRFTokenMissing(t *testing.T) {
+	csrf, cookies := newCSRFSession(t)
+	r := postForm("/running-session/202204250900/delete", url.Values{})
+
+	assertCSRFForbidden(t, csrf, requestWithCookies(r, cookies))
+}
+
+func TestEnsureCSRFTokenInvalid(t *testing.T) {
+	csrf, cookies := newCSRFSession(t)
+	r := postForm("/running-session/202204250900/delete", url.Values{www.CSRFFieldName: []string{"forged"}})
+
+	assertCSRFForbidden(t, csrf, requestWithCookies(r, cookies))
+}
+
+func TestEnsureCSRFTokenWithoutSession(t *testing.T) {
+	csrf, _ := newCSRFSession(t)
+	token, err := csrf.Token(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	testutils.RequireNoError(t, err, "can't create csrf token")
+
+	r := postForm("/running-session/202204250900/delete", url.Values{www.CSRFFieldName: []string{token}})
+
+	assertCSRFForbidden(t, csrf, r)
+}
+
+func TestEnsureCSRFTokenSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	csrf, cookies := newCSRFSession(t)
+	token, err := csrf.Token(httptest.NewRecorder(), requestWithCookies(httptest.NewRequest("GET", "/", nil), cookies))
+	testutils.RequireNoError(t, err, "can't get csrf token")
+
+	r := postForm("/running-session/202204250900/delete", url.Values{www.CSRFFieldName: []string{token}})
+	expectedResponse := webtest.MockedResponse("deleted")
+
+	handler := func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		return expectedResponse
+	}
+
+	actualResponse := www.EnsureCSRFToken(csrf, handler)(ctx, httptest.NewRecorder(), requestWithCookies(r, cookies))
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func assertCSRFForbidden(t *testing.T, csrf www.CSRFSessionStore, r *http.Request) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+
+	expectedResponse := webtest.MockedResponse("forbidden")
+	ctx.EXPECT().Response(http.StatusForbidden, "templates/403.html.tmpl", nil).Return(expectedResponse)
+
+	handler := func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		t.Fatalf("handler must not be called")
+		return web.Response{}
+	}
+
+	actualResponse := www.EnsureCSRFToken(csrf, handler)(ctx, httptest.NewRecorder(), r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func newCSRFSession(t *testing.T) (www.CSRFSessionStore, *httptest.ResponseRecorder) {
+	t.Helper()
+
+	csrf := www.NewCSRFSessionStore(sessions.NewCookieStore([]byte("secret")))
+	w := httptest.NewRecorder()
+	_, err := csrf.Token(w, httptest.NewRequest("GET", "/", nil))
+	testutils.RequireNoError(t, err, "can't create csrf token")
+
+	return csrf, w
+}
+
+func postForm(target string, values url.Values) *http.Request {
+	r := httptest.NewRequest("POST", target, strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return r
+}
+
+func requestWithCookies(r *http.Request, w *httptest.ResponseRecorder) *http.Request {
+	for _, cookie := range w.Result().Cookies() {
+		r.AddCookie(cookie)
+	}
+
+	return r
+}
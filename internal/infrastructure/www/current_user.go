@@ -0,0 +1,27 @@
+package www
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+)
+
+// CurrentUser returns the username of the authenticated user, or an empty string for visitors
+type CurrentUser func(r *http.Request) string
+
+// NewCurrentUser looks up the user stored in the session
+func NewCurrentUser(front web.AuthenticationFrontendStorer, back web.AuthenticationBackendStorer) CurrentUser {
+	return func(r *http.Request) string {
+		id, err := front.CurrentUserID(r)
+		if err != nil || id == "" {
+			return ""
+		}
+
+		user, err := back.Lookup(id)
+		if err != nil {
+			return ""
+		}
+
+		return user.Username
+	}
+}
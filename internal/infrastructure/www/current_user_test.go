@@ -0,0 +1,46 @@
+package www_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/sessions"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/authenticationstore"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func currentUser(username string) www.CurrentUser {
+	return func(r *http.Request) string { return username }
+}
+
+func TestNewCurrentUserVisitor(t *testing.T) {
+	front := web.NewCurrentAuthenticatedUserSessionStore(sessions.NewCookieStore([]byte("secret")))
+	back := authenticationstore.NewInMemory()
+	r := httptest.NewRequest("GET", "/", nil)
+
+	username := www.NewCurrentUser(front, back)(r)
+
+	testutils.AssertEqualString(t, "", username, "unexpected username")
+}
+
+func TestNewCurrentUserAuthenticated(t *testing.T) {
+	front := web.NewCurrentAuthenticatedUserSessionStore(sessions.NewCookieStore([]byte("secret")))
+	back := authenticationstore.NewInMemory()
+	id, err := back.Register("alice", "password")
+	testutils.RequireNoError(t, err, "can't register user")
+
+	w := httptest.NewRecorder()
+	testutils.RequireNoError(t, front.StoreUserID(w, httptest.NewRequest("POST", "/login", nil), id), "can't store user id")
+
+	r := httptest.NewRequest("GET", "/", nil)
+	for _, cookie := range w.Result().Cookies() {
+		r.AddCookie(cookie)
+	}
+
+	username := www.NewCurrentUser(front, back)(r)
+
+	testutils.AssertEqualString(t, "alice", username, "unexpected username")
+}
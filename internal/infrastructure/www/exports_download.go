@@ -0,0 +1,45 @@
+package www
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl"
+)
+
+func ExportsDownload(app application.Application, urls asseturl.Builder, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		vars := ctx.Vars(r)
+
+		id, err := domain.ParseID(vars["id"])
+		if err != nil {
+			return ctx.NotFoundResponse("can't parse export id (id=%s): %v", vars["id"], err)
+		}
+
+		export, err := app.GetExport(ctx.StdCtx(), currentUser(r), id)
+		if err != nil {
+			if errors.Is(err, domain.ErrExportNotFound) {
+				return ctx.NotFoundResponse("can't find export (id=%s): %v", vars["id"], err)
+			}
+			return ctx.InternalServerErrorResponse("failed while finding export (id=%s): %v", vars["id"], err)
+		}
+
+		if !export.IsReady() {
+			ctx.AddFlash(web.NewFlashMessageError("export is not ready yet"))
+			redirection := ctx.Redirect(w, http.StatusSeeOther, "/exports")
+			redirection.LogMessage = fmt.Sprintf("export is still pending (id=%s)", vars["id"])
+			return redirection
+		}
+
+		url, err := urls.PresignedURL(export.ArchivePath.String())
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't build url of export (id=%s): %v", vars["id"], err)
+		}
+
+		return ctx.Redirect(w, http.StatusFound, url)
+	}
+}
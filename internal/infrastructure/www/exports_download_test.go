@@ -0,0 +1,132 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl/asseturltest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestExportsDownloadInvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/exports/{id}/download", nil)
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(request).Return(map[string]string{"id": "wrong-id"})
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.ExportsDownload(nil, assetURLs, currentUser("alice"))(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestExportsDownloadNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/exports/{id}/download", nil)
+	id := domain.NewID()
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(request).Return(map[string]string{"id": id.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetExport(gomock.Any(), "alice", gomock.Eq(id)).Return(domain.Export{}, domain.ErrExportNotFound)
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.ExportsDownload(app, assetURLs, currentUser("alice"))(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestExportsDownloadError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/exports/{id}/download", nil)
+	id := domain.NewID()
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().Vars(request).Return(map[string]string{"id": id.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetExport(gomock.Any(), "alice", gomock.Eq(id)).Return(domain.Export{}, errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.ExportsDownload(app, assetURLs, currentUser("alice"))(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestExportsDownloadPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/exports/{id}/download", nil)
+	export := domaintest.NewExport(t).WithUsername("alice").Build()
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Vars(request).Return(map[string]string{"id": export.ID.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetExport(gomock.Any(), "alice", gomock.Eq(export.ID)).Return(export, nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageError("export is not ready yet"))
+	ctx.EXPECT().Redirect(response, 303, "/exports").Return(expectedResponse)
+
+	actualResponse := www.ExportsDownload(app, assetURLs, currentUser("alice"))(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+	testutils.AssertContainsString(t, "still pending", actualResponse.LogMessage, "unexpected log message")
+}
+
+func TestExportsDownloadCannotPresign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/exports/{id}/download", nil)
+	export := domaintest.NewExport(t).WithUsername("alice").Ready().Build()
+	urls := asseturl.NewBuilder("https://cdn.example.com", asseturltest.Presigner{Err: errors.New("boom")}, time.Hour)
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().Vars(request).Return(map[string]string{"id": export.ID.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetExport(gomock.Any(), "alice", gomock.Eq(export.ID)).Return(export, nil)
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.ExportsDownload(app, urls, currentUser("alice"))(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestExportsDownloadSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/exports/{id}/download", nil)
+	export := domaintest.NewExport(t).WithUsername("alice").Ready().Build()
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Vars(request).Return(map[string]string{"id": export.ID.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetExport(gomock.Any(), "alice", gomock.Eq(export.ID)).Return(export, nil)
+	ctx.EXPECT().Redirect(response, 302, "https://bucket.example.com/"+export.ArchivePath.String()+"?expires=3600").Return(expectedResponse)
+
+	actualResponse := www.ExportsDownload(app, assetURLs, currentUser("alice"))(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
@@ -0,0 +1,30 @@
+package www
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+)
+
+func ExportsIndex(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		exports, err := app.ListExports(ctx.StdCtx(), currentUser(r))
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't list exports: %v", err)
+		}
+
+		var pending bool
+		for _, export := range exports {
+			if !export.IsReady() {
+				pending = true
+				break
+			}
+		}
+
+		return ctx.Response(200, "templates/exports/index.html.tmpl", map[string]interface{}{
+			"Exports": exports,
+			"Pending": pending,
+		})
+	}
+}
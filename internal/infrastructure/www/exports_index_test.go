@@ -0,0 +1,83 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils/gomockutils"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestExportsIndexError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/exports", nil)
+	app := applicationtest.NewMockApplication(ctrl)
+
+	app.EXPECT().ListExports(gomock.Any(), "alice").Return(nil, errors.New("boom"))
+
+	expected := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx().AnyTimes()
+	ctx.EXPECT().
+		InternalServerErrorResponse(gomockutils.ContainsString("can't list"), gomock.Any()).
+		Return(expected)
+
+	actual := www.ExportsIndex(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expected, actual, "unexpected response")
+}
+
+func TestExportsIndexSuccess(t *testing.T) {
+	tcs := map[string]struct {
+		exports []domain.Export
+		pending bool
+	}{
+		"noExports": {},
+		"readyExports": {
+			exports: []domain.Export{domaintest.NewExport(t).Ready().Build()},
+		},
+		"pendingExports": {
+			exports: []domain.Export{
+				domaintest.NewExport(t).Build(),
+				domaintest.NewExport(t).Ready().Build(),
+			},
+			pending: true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctx := webtest.NewMockContext(ctrl)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/exports", nil)
+			app := applicationtest.NewMockApplication(ctrl)
+
+			app.EXPECT().ListExports(gomock.Any(), "alice").Return(tc.exports, nil)
+
+			expected := webtest.MockedResponse("ok response")
+			ctx.EXPECT().StdCtx().AnyTimes()
+			ctx.EXPECT().
+				Response(
+					200,
+					gomock.Any(),
+					gomock.All(
+						webtest.MatchDataContains("Exports", tc.exports),
+						webtest.MatchDataContains("Pending", tc.pending),
+					),
+				).
+				Return(expected)
+
+			actual := www.ExportsIndex(app, currentUser("alice"))(ctx, w, r)
+
+			webtest.AssertResponse(t, expected, actual, "unexpected response")
+		})
+	}
+}
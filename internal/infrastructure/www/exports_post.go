@@ -0,0 +1,27 @@
+package www
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+)
+
+func ExportsPost(app application.Application, enqueuer job.Enqueuer, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		username := currentUser(r)
+		export, err := app.RequestExport(ctx.StdCtx(), username)
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't request export: %v", err)
+		}
+
+		input := job.GenerateExportJobInput{ID: export.ID.String(), Username: username}
+		if err := job.EnqueueGenerateExportJob(enqueuer, input); err != nil {
+			return ctx.InternalServerErrorResponse("can't enqueue export job: %v", err)
+		}
+
+		ctx.AddFlash(web.NewFlashMessageSuccess("export is being prepared, it will be available for download on this page"))
+		return ctx.Redirect(w, http.StatusSeeOther, "/exports")
+	}
+}
@@ -0,0 +1,84 @@
+package www_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestExportsPostCannotRequestExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("POST", "/exports", nil)
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().RequestExport(gomock.Any(), "alice").Return(domain.Export{}, errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.ExportsPost(app, nil, currentUser("alice"))(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestExportsPostCannotEnqueueJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("POST", "/exports", nil)
+	export := domaintest.NewExport(t).Build()
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().RequestExport(gomock.Any(), "alice").Return(export, nil)
+	enqueuer.EXPECT().Enqueue(gomock.Any()).Return(fmt.Errorf("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.ExportsPost(app, enqueuer, currentUser("alice"))(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestExportsPostSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("POST", "/exports", nil)
+	export := domaintest.NewExport(t).Build()
+	expectedJob := jobtest.NewJobMatcher(
+		"generate-export-job",
+		&job.GenerateExportJobInput{},
+		func(arg interface{}) bool {
+			input := arg.(*job.GenerateExportJobInput)
+
+			return input.ID == export.ID.String() && input.Username == "alice"
+		})
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().RequestExport(gomock.Any(), "alice").Return(export, nil)
+	enqueuer.EXPECT().Enqueue(expectedJob).Return(nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("export is being prepared, it will be available for download on this page"))
+	ctx.EXPECT().Redirect(response, 303, "/exports").Return(expectedResponse)
+
+	actualResponse := www.ExportsPost(app, enqueuer, currentUser("alice"))(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
@@ -0,0 +1,30 @@
+package www
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+)
+
+func ImportsIndex(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		imports, err := app.ListImports(ctx.StdCtx(), currentUser(r))
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't list imports: %v", err)
+		}
+
+		var pending bool
+		for _, imp := range imports {
+			if !imp.IsDone() {
+				pending = true
+				break
+			}
+		}
+
+		return ctx.Response(200, "templates/imports/index.html.tmpl", map[string]interface{}{
+			"Imports": imports,
+			"Pending": pending,
+		})
+	}
+}
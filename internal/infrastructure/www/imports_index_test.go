@@ -0,0 +1,87 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils/gomockutils"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestImportsIndexError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/imports", nil)
+	app := applicationtest.NewMockApplication(ctrl)
+
+	app.EXPECT().ListImports(gomock.Any(), "alice").Return(nil, errors.New("boom"))
+
+	expected := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx().AnyTimes()
+	ctx.EXPECT().
+		InternalServerErrorResponse(gomockutils.ContainsString("can't list"), gomock.Any()).
+		Return(expected)
+
+	actual := www.ImportsIndex(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expected, actual, "unexpected response")
+}
+
+func TestImportsIndexSuccess(t *testing.T) {
+	done := domaintest.NewImport(t).Build()
+	done.Progress = domain.ImportProgress{Imported: 3, Skipped: 1}
+	running := domaintest.NewImport(t).Build()
+	running.Progress = domain.ImportProgress{Imported: 1, Pending: 2}
+
+	tcs := map[string]struct {
+		imports []domain.Import
+		pending bool
+	}{
+		"noImports":   {},
+		"doneImports": {imports: []domain.Import{done}},
+		"pendingImports": {
+			imports: []domain.Import{running, done},
+			pending: true,
+		},
+		"notPreparedImports": {
+			imports: []domain.Import{domaintest.NewImport(t).Build()},
+			pending: true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctx := webtest.NewMockContext(ctrl)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/imports", nil)
+			app := applicationtest.NewMockApplication(ctrl)
+
+			app.EXPECT().ListImports(gomock.Any(), "alice").Return(tc.imports, nil)
+
+			expected := webtest.MockedResponse("ok response")
+			ctx.EXPECT().StdCtx().AnyTimes()
+			ctx.EXPECT().
+				Response(
+					200,
+					gomock.Any(),
+					gomock.All(
+						webtest.MatchDataContains("Imports", tc.imports),
+						webtest.MatchDataContains("Pending", tc.pending),
+					),
+				).
+				Return(expected)
+
+			actual := www.ImportsIndex(app, currentUser("alice"))(ctx, w, r)
+
+			webtest.AssertResponse(t, expected, actual, "unexpected response")
+		})
+	}
+}
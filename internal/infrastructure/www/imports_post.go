@@ -0,0 +1,62 @@
+package www
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+)
+
+const (
+	// MaxImportArchiveSize is the biggest account archive accepted by the import form
+	MaxImportArchiveSize = 1024 * 1024 * 1024
+	maxImportFormMemory  = 32 * 1024 * 1024
+)
+
+func ImportsPost(app application.Application, currentUser CurrentUser, enqueuer job.Enqueuer, uploadFolder string) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		r.Body = http.MaxBytesReader(w, r.Body, MaxImportArchiveSize)
+		if err := r.ParseMultipartForm(maxImportFormMemory); err != nil {
+			ctx.AddFlash(web.NewFlashMessageError("can't read the uploaded archive, it must be smaller than 1Gb"))
+
+			response := ctx.Redirect(w, http.StatusSeeOther, "/imports")
+			response.LogMessage = fmt.Sprintf("can't parse form: %v", err)
+			return response
+		}
+
+		file, _, err := r.FormFile("archive")
+		if err != nil {
+			ctx.AddFlash(web.NewFlashMessageError("strava archive must be sent"))
+			response := ctx.Redirect(w, http.StatusSeeOther, "/imports")
+			response.LogMessage = fmt.Sprintf("can't get archive from http form: %v", err)
+			return response
+		}
+		defer file.Close()
+
+		dest, err := os.CreateTemp(uploadFolder, "strava-export-*.zip")
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't create archive file in upload folder: %v", err)
+		}
+		defer dest.Close()
+
+		if _, err := io.Copy(dest, file); err != nil {
+			return ctx.InternalServerErrorResponse("can't copy uploaded archive to upload folder (path=%s): %v", dest.Name(), err)
+		}
+
+		imp, err := app.StartImport(ctx.StdCtx(), currentUser(r), dest.Name())
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't start import: %v", err)
+		}
+
+		if err := job.EnqueuePrepareImportJob(enqueuer, job.PrepareImportJobInput{ID: imp.ID.String()}); err != nil {
+			return ctx.InternalServerErrorResponse("can't enqueue import job: %v", err)
+		}
+
+		ctx.AddFlash(web.NewFlashMessageSuccess("archive is being imported, progress is available on this page"))
+		return ctx.Redirect(w, http.StatusSeeOther, "/imports/"+imp.ID.String())
+	}
+}
@@ -0,0 +1,98 @@
+package www_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestImportsPostMissingArchive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+
+	var body bytes.Buffer
+	bodyWriter := multipart.NewWriter(&body)
+	bodyWriter.Close()
+
+	r := httptest.NewRequest("POST", "/imports", &body)
+	r.Header.Set("Content-Type", bodyWriter.FormDataContentType())
+
+	ctx.EXPECT().AddFlash(webtest.MatchFlashErrorContains("strava archive must be sent"))
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Redirect(w, 303, "/imports").Return(expectedResponse)
+
+	response := www.ImportsPost(nil, currentUser("alice"), nil, t.TempDir())(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, response, "unexpected response")
+	testutils.AssertContainsString(t, "can't get archive", response.LogMessage, "unexpected log message")
+}
+
+func TestImportsPostSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	uploadFolder := t.TempDir()
+	imp := domaintest.NewImport(t).Build()
+
+	var body bytes.Buffer
+	bodyWriter := multipart.NewWriter(&body)
+	archive, err := bodyWriter.CreateFormFile("archive", "export_12345.zip")
+	testutils.RequireNoError(t, err, "can't create archive field")
+	_, err = archive.Write([]byte("zip content"))
+	testutils.RequireNoError(t, err, "can't write archive content")
+	bodyWriter.Close()
+
+	r := httptest.NewRequest("POST", "/imports", &body)
+	r.Header.Set("Content-Type", bodyWriter.FormDataContentType())
+
+	var archivePath string
+	expectedJob := jobtest.NewJobMatcher(
+		"prepare-import-job",
+		&job.PrepareImportJobInput{},
+		func(arg interface{}) bool {
+			input := arg.(*job.PrepareImportJobInput)
+
+			return input.ID == imp.ID.String()
+		})
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().
+		StartImport(gomock.Any(), "alice", gomock.Any()).
+		DoAndReturn(func(_ interface{}, _ string, path string) (domain.Import, error) {
+			archivePath = path
+			return imp, nil
+		})
+	enqueuer.EXPECT().Enqueue(expectedJob).Return(nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("archive is being imported, progress is available on this page"))
+	ctx.EXPECT().Redirect(w, 303, "/imports/"+imp.ID.String()).Return(expectedResponse)
+
+	response := www.ImportsPost(app, currentUser("alice"), enqueuer, uploadFolder)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, response, "unexpected response")
+	testutils.AssertEqualString(t, uploadFolder, filepath.Dir(archivePath), "archive should be stored in the upload folder")
+	testutils.AssertEqualBool(t, true, strings.HasSuffix(archivePath, ".zip"), "unexpected archive extension: %s", archivePath)
+
+	content, err := os.ReadFile(archivePath)
+	testutils.AssertNoError(t, err, "can't read uploaded archive")
+	testutils.AssertEqualString(t, "zip content", string(content), "unexpected archive content")
+}
@@ -0,0 +1,36 @@
+package www
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+)
+
+func ImportsResume(app application.Application, currentUser CurrentUser, enqueuer job.Enqueuer) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		vars := ctx.Vars(r)
+
+		id, err := domain.ParseID(vars["id"])
+		if err != nil {
+			return ctx.NotFoundResponse("can't parse import id (id=%s): %v", vars["id"], err)
+		}
+
+		if _, err := app.GetImport(ctx.StdCtx(), currentUser(r), id); err != nil {
+			if errors.Is(err, domain.ErrImportNotFound) {
+				return ctx.NotFoundResponse("can't find import (id=%s): %v", vars["id"], err)
+			}
+			return ctx.InternalServerErrorResponse("failed while finding import (id=%s): %v", vars["id"], err)
+		}
+
+		if err := job.EnqueuePrepareImportJob(enqueuer, job.PrepareImportJobInput{ID: id.String()}); err != nil {
+			return ctx.InternalServerErrorResponse("can't enqueue import job: %v", err)
+		}
+
+		ctx.AddFlash(web.NewFlashMessageSuccess("import is resuming, activities already processed are kept"))
+		return ctx.Redirect(w, http.StatusSeeOther, "/imports/"+id.String())
+	}
+}
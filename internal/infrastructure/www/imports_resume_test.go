@@ -0,0 +1,65 @@
+package www_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestImportsResumeNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("POST", "/imports/{id}/resume", nil)
+	id := domain.NewID()
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(request).Return(map[string]string{"id": id.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetImport(gomock.Any(), "alice", gomock.Eq(id)).Return(domain.Import{}, domain.ErrImportNotFound)
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.ImportsResume(app, currentUser("alice"), nil)(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestImportsResumeSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("POST", "/imports/{id}/resume", nil)
+	imp := domaintest.NewImport(t).Build()
+	expectedJob := jobtest.NewJobMatcher(
+		"prepare-import-job",
+		&job.PrepareImportJobInput{},
+		func(arg interface{}) bool {
+			input := arg.(*job.PrepareImportJobInput)
+
+			return input.ID == imp.ID.String()
+		})
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Vars(request).Return(map[string]string{"id": imp.ID.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetImport(gomock.Any(), "alice", gomock.Eq(imp.ID)).Return(imp, nil)
+	enqueuer.EXPECT().Enqueue(expectedJob).Return(nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("import is resuming, activities already processed are kept"))
+	ctx.EXPECT().Redirect(response, 303, "/imports/"+imp.ID.String()).Return(expectedResponse)
+
+	actualResponse := www.ImportsResume(app, currentUser("alice"), enqueuer)(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
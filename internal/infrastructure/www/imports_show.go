@@ -0,0 +1,39 @@
+package www
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func ImportsShow(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		vars := ctx.Vars(r)
+
+		id, err := domain.ParseID(vars["id"])
+		if err != nil {
+			return ctx.NotFoundResponse("can't parse import id (id=%s): %v", vars["id"], err)
+		}
+
+		imp, err := app.GetImport(ctx.StdCtx(), currentUser(r), id)
+		if err != nil {
+			if errors.Is(err, domain.ErrImportNotFound) {
+				return ctx.NotFoundResponse("can't find import (id=%s): %v", vars["id"], err)
+			}
+			return ctx.InternalServerErrorResponse("failed while finding import (id=%s): %v", vars["id"], err)
+		}
+
+		items, err := app.ListImportItems(ctx.StdCtx(), id)
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't list import items (id=%s): %v", vars["id"], err)
+		}
+
+		return ctx.Response(200, "templates/imports/show.html.tmpl", map[string]interface{}{
+			"Import": imp,
+			"Items":  items,
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestImportsShowInvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/imports/{id}", nil)
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(request).Return(map[string]string{"id": "wrong-id"})
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.ImportsShow(nil, currentUser("alice"))(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestImportsShowNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/imports/{id}", nil)
+	id := domain.NewID()
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(request).Return(map[string]string{"id": id.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetImport(gomock.Any(), "alice", gomock.Eq(id)).Return(domain.Import{}, domain.ErrImportNotFound)
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.ImportsShow(app, currentUser("alice"))(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestImportsShowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/imports/{id}", nil)
+	imp := domaintest.NewImport(t).Build()
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().Vars(request).Return(map[string]string{"id": imp.ID.String()})
+	ctx.EXPECT().StdCtx().AnyTimes()
+	app.EXPECT().GetImport(gomock.Any(), "alice", gomock.Eq(imp.ID)).Return(imp, nil)
+	app.EXPECT().ListImportItems(gomock.Any(), gomock.Eq(imp.ID)).Return(nil, errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.ImportsShow(app, currentUser("alice"))(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestImportsShowSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/imports/{id}", nil)
+	imp := domaintest.NewImport(t).Build()
+	items := []domain.ImportItem{
+		domaintest.NewImportItem(t, imp.ID).Build(),
+		domaintest.NewImportItem(t, imp.ID).Build().Skip("Ride is not a run"),
+	}
+
+	expectedResponse := webtest.MockedResponse("ok response")
+	ctx.EXPECT().Vars(request).Return(map[string]string{"id": imp.ID.String()})
+	ctx.EXPECT().StdCtx().AnyTimes()
+	app.EXPECT().GetImport(gomock.Any(), "alice", gomock.Eq(imp.ID)).Return(imp, nil)
+	app.EXPECT().ListImportItems(gomock.Any(), gomock.Eq(imp.ID)).Return(items, nil)
+	ctx.EXPECT().
+		Response(
+			200,
+			"templates/imports/show.html.tmpl",
+			gomock.All(
+				webtest.MatchDataContains("Import", imp),
+				webtest.MatchDataContains("Items", items),
+			),
+		).
+		Return(expectedResponse)
+
+	actualResponse := www.ImportsShow(app, currentUser("alice"))(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
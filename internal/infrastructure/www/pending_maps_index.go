@@ -0,0 +1,21 @@
+package www
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+)
+
+func PendingMapsIndex(app application.Application) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		activities, err := app.ListPendingMaps(ctx.StdCtx())
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't list pending maps: %v", err)
+		}
+
+		return ctx.Response(200, "templates/admin/pending-maps.html.tmpl", map[string]interface{}{
+			"Activities": activities,
+		})
+	}
+}
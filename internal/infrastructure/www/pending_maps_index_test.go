@@ -0,0 +1,56 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils/gomockutils"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestPendingMapsIndexError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/admin/pending-maps", nil)
+	app := applicationtest.NewMockApplication(ctrl)
+
+	app.EXPECT().ListPendingMaps(gomock.Any()).Return(nil, errors.New("boom"))
+
+	expected := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx().AnyTimes()
+	ctx.EXPECT().
+		InternalServerErrorResponse(gomockutils.ContainsString("can't list"), gomock.Any()).
+		Return(expected)
+
+	actual := www.PendingMapsIndex(app)(ctx, w, r)
+
+	webtest.AssertResponse(t, expected, actual, "unexpected response")
+}
+
+func TestPendingMapsIndexSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/admin/pending-maps", nil)
+	app := applicationtest.NewMockApplication(ctrl)
+	activities := []domain.RunningActivity{domaintest.NewRunningActivity(t).WithPendingMap("mapbox is down").Build()}
+
+	app.EXPECT().ListPendingMaps(gomock.Any()).Return(activities, nil)
+
+	expected := webtest.MockedResponse("ok response")
+	ctx.EXPECT().StdCtx().AnyTimes()
+	ctx.EXPECT().
+		Response(200, "templates/admin/pending-maps.html.tmpl", webtest.MatchDataContains("Activities", activities)).
+		Return(expected)
+
+	actual := www.PendingMapsIndex(app)(ctx, w, r)
+
+	webtest.AssertResponse(t, expected, actual, "unexpected response")
+}
@@ -0,0 +1,19 @@
+package www
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+)
+
+func PendingMapsPost(enqueuer job.Enqueuer) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		if err := job.EnqueueGeneratePendingMapsJob(enqueuer); err != nil {
+			return ctx.InternalServerErrorResponse("can't enqueue pending maps job: %v", err)
+		}
+
+		ctx.AddFlash(web.NewFlashMessageSuccess("pending maps are being generated"))
+		return ctx.Redirect(w, http.StatusSeeOther, "/admin/pending-maps")
+	}
+}
@@ -0,0 +1,47 @@
+package www_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestPendingMapsPostCannotEnqueueJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("POST", "/admin/pending-maps", nil)
+
+	expectedResponse := webtest.MockedResponse("server error")
+	enqueuer.EXPECT().Enqueue(gomock.Any()).Return(fmt.Errorf("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.PendingMapsPost(enqueuer)(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestPendingMapsPostSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("POST", "/admin/pending-maps", nil)
+	expectedJob := jobtest.NewJobMatcher("generate-pending-maps-job", map[string]interface{}{}, func(interface{}) bool { return true })
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	enqueuer.EXPECT().Enqueue(expectedJob).Return(nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("pending maps are being generated"))
+	ctx.EXPECT().Redirect(response, 303, "/admin/pending-maps").Return(expectedResponse)
+
+	actualResponse := www.PendingMapsPost(enqueuer)(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
@@ -0,0 +1,22 @@
+package www
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+)
+
+// WithPreferences exposes the preferences of the current user to the templates rendered by the handler
+func WithPreferences(app application.Application, currentUser CurrentUser, h web.HandlerFunc) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		prefs, err := app.GetUserPreferences(ctx.StdCtx(), currentUser(r))
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't get user preferences: %v", err)
+		}
+
+		ctx.AddData("Preferences", prefs)
+
+		return h(ctx, w, r)
+	}
+}
@@ -0,0 +1,59 @@
+package www_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestWithPreferencesCannotGetPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetUserPreferences(gomock.Any(), "alice").Return(domain.UserPreferences{}, errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	handler := func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		t.Fatalf("handler must not be called")
+		return web.Response{}
+	}
+
+	actualResponse := www.WithPreferences(app, currentUser("alice"), handler)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestWithPreferencesSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	prefs := domain.UserPreferences{Locale: domain.LocaleFrench, Units: domain.UnitSystemImperial, SpeedDisplay: domain.SpeedDisplaySpeed}
+
+	expectedResponse := webtest.MockedResponse("page")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetUserPreferences(gomock.Any(), "alice").Return(prefs, nil)
+	ctx.EXPECT().AddData("Preferences", prefs)
+
+	handler := func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		return expectedResponse
+	}
+
+	actualResponse := www.WithPreferences(app, currentUser("alice"), handler)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
@@ -0,0 +1,38 @@
+package www
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+)
+
+// PrivacyZonesDelete deletes the zone and enqueues the regeneration of the maps of the activities crossing it
+func PrivacyZonesDelete(app application.Application, currentUser CurrentUser, enqueuer job.Enqueuer) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		vars := ctx.Vars(r)
+
+		id, err := domain.ParseID(vars["id"])
+		if err != nil {
+			return ctx.NotFoundResponse("can't parse privacy zone id (id=%s): %v", vars["id"], err)
+		}
+
+		zone, err := app.DeletePrivacyZone(ctx.StdCtx(), currentUser(r), id)
+		if errors.Is(err, domain.ErrPrivacyZoneNotFound) {
+			return ctx.NotFoundResponse("can't find privacy zone (id=%s): %v", vars["id"], err)
+		}
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't delete privacy zone (id=%s): %v", vars["id"], err)
+		}
+
+		if err := job.EnqueueRegeneratePrivacyZoneActivitiesJob(enqueuer, job.NewRegeneratePrivacyZoneActivitiesJobInput(zone)); err != nil {
+			return ctx.InternalServerErrorResponse("can't enqueue regeneration of activities crossing privacy zone: %v", err)
+		}
+
+		ctx.AddFlash(web.NewFlashMessageSuccess("privacy zone deleted, the maps of the activities crossing it are being generated again"))
+		return ctx.Redirect(w, http.StatusSeeOther, "/settings/privacy-zones")
+	}
+}
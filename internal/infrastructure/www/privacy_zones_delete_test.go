@@ -0,0 +1,91 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestPrivacyZonesDeleteInvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/settings/privacy-zones/{id}/delete", nil)
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"id": "wrong-id"})
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.PrivacyZonesDelete(nil, currentUser("alice"), nil)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestPrivacyZonesDeleteNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/settings/privacy-zones/{id}/delete", nil)
+	id := domain.NewID()
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"id": id.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().DeletePrivacyZone(gomock.Any(), "alice", id).Return(domain.PrivacyZone{}, domain.ErrPrivacyZoneNotFound)
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.PrivacyZonesDelete(app, currentUser("alice"), nil)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestPrivacyZonesDeleteError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/settings/privacy-zones/{id}/delete", nil)
+	id := domain.NewID()
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"id": id.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().DeletePrivacyZone(gomock.Any(), "alice", id).Return(domain.PrivacyZone{}, errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.PrivacyZonesDelete(app, currentUser("alice"), nil)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestPrivacyZonesDeleteSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/settings/privacy-zones/{id}/delete", nil)
+	zone := domaintest.NewPrivacyZone(t).Build()
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"id": zone.ID.String()})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().DeletePrivacyZone(gomock.Any(), "alice", zone.ID).Return(zone, nil)
+	enqueuer.EXPECT().Enqueue(newRegeneratePrivacyZoneActivitiesJobMatcher(zone)).Return(nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("privacy zone deleted, the maps of the activities crossing it are being generated again"))
+	ctx.EXPECT().Redirect(w, 303, "/settings/privacy-zones").Return(expectedResponse)
+
+	actualResponse := www.PrivacyZonesDelete(app, currentUser("alice"), enqueuer)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
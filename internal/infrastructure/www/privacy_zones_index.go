@@ -0,0 +1,21 @@
+package www
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+)
+
+func PrivacyZonesIndex(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		zones, err := app.ListPrivacyZones(ctx.StdCtx(), currentUser(r))
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't list privacy zones: %v", err)
+		}
+
+		return ctx.Response(200, "templates/settings/privacy-zones.html.tmpl", map[string]interface{}{
+			"Zones": zones,
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestPrivacyZonesIndexSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/settings/privacy-zones", nil)
+	zones := domain.PrivacyZones{domaintest.NewPrivacyZone(t).Build()}
+
+	expectedResponse := webtest.MockedResponse("privacy zones")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ListPrivacyZones(gomock.Any(), "alice").Return(zones, nil)
+	ctx.EXPECT().Response(200, "templates/settings/privacy-zones.html.tmpl", webtest.MatchDataContains("Zones", zones)).
+		Return(expectedResponse)
+
+	actualResponse := www.PrivacyZonesIndex(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestPrivacyZonesIndexError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/settings/privacy-zones", nil)
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ListPrivacyZones(gomock.Any(), "alice").Return(nil, errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.PrivacyZonesIndex(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
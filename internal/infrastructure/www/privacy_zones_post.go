@@ -0,0 +1,39 @@
+package www
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+)
+
+// PrivacyZonesPost creates the zone and enqueues the regeneration of the maps of the activities crossing it
+func PrivacyZonesPost(app application.Application, currentUser CurrentUser, enqueuer job.Enqueuer) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		zone, err := app.CreatePrivacyZone(ctx.StdCtx(), currentUser(r), strings.TrimSpace(r.FormValue("name")),
+			r.FormValue("latitude"), r.FormValue("longitude"), r.FormValue("radius"))
+		if err != nil {
+			var invalidErr *domain.InvalidInputErrors
+			if !errors.As(err, &invalidErr) {
+				return ctx.InternalServerErrorResponse("can't create privacy zone: %v", err)
+			}
+
+			ctx.AddFlash(web.NewFlashMessageError(fmt.Sprintf("privacy zone can't be created: %s", strings.Join(invalidErr.Detail(), ", "))))
+			response := ctx.Redirect(w, http.StatusSeeOther, "/settings/privacy-zones")
+			response.LogMessage = fmt.Sprintf("invalid privacy zone: %v", err)
+			return response
+		}
+
+		if err := job.EnqueueRegeneratePrivacyZoneActivitiesJob(enqueuer, job.NewRegeneratePrivacyZoneActivitiesJobInput(zone)); err != nil {
+			return ctx.InternalServerErrorResponse("can't enqueue regeneration of activities crossing privacy zone: %v", err)
+		}
+
+		ctx.AddFlash(web.NewFlashMessageSuccess("privacy zone created, the maps of the activities crossing it are being generated again"))
+		return ctx.Redirect(w, http.StatusSeeOther, "/settings/privacy-zones")
+	}
+}
@@ -0,0 +1,109 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/job"
+	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+var privacyZoneForm = url.Values{"name": {" Home "}, "latitude": {"38.5"}, "longitude": {"-120.2"}, "radius": {"200"}}
+
+func TestPrivacyZonesPostInvalidInput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/settings/privacy-zones", url.Values{"name": {"Home"}, "latitude": {"north"}, "longitude": {"-120.2"}, "radius": {"200"}})
+
+	invalidErr := domain.InvalidInputErrors{"latitude must be a number between -90 and 90"}
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().CreatePrivacyZone(gomock.Any(), "alice", "Home", "north", "-120.2", "200").Return(domain.PrivacyZone{}, &invalidErr)
+	ctx.EXPECT().AddFlash(webtest.MatchFlashErrorContains("latitude must be a number"))
+	ctx.EXPECT().Redirect(w, 303, "/settings/privacy-zones").Return(expectedResponse)
+
+	actualResponse := www.PrivacyZonesPost(app, currentUser("alice"), nil)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestPrivacyZonesPostCannotCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/settings/privacy-zones", privacyZoneForm)
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().CreatePrivacyZone(gomock.Any(), "alice", "Home", "38.5", "-120.2", "200").Return(domain.PrivacyZone{}, errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.PrivacyZonesPost(app, currentUser("alice"), nil)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestPrivacyZonesPostCannotEnqueueJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/settings/privacy-zones", privacyZoneForm)
+	zone := domaintest.NewPrivacyZone(t).Build()
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().CreatePrivacyZone(gomock.Any(), "alice", "Home", "38.5", "-120.2", "200").Return(zone, nil)
+	enqueuer.EXPECT().Enqueue(gomock.Any()).Return(errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.PrivacyZonesPost(app, currentUser("alice"), enqueuer)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestPrivacyZonesPostSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	enqueuer := jobtest.NewMockEnqueuer(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/settings/privacy-zones", privacyZoneForm)
+	zone := domaintest.NewPrivacyZone(t).Build()
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().CreatePrivacyZone(gomock.Any(), "alice", "Home", "38.5", "-120.2", "200").Return(zone, nil)
+	enqueuer.EXPECT().Enqueue(newRegeneratePrivacyZoneActivitiesJobMatcher(zone)).Return(nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("privacy zone created, the maps of the activities crossing it are being generated again"))
+	ctx.EXPECT().Redirect(w, 303, "/settings/privacy-zones").Return(expectedResponse)
+
+	actualResponse := www.PrivacyZonesPost(app, currentUser("alice"), enqueuer)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func newRegeneratePrivacyZoneActivitiesJobMatcher(zone domain.PrivacyZone) gomock.Matcher {
+	return jobtest.NewJobMatcher(
+		"regenerate-privacy-zone-activities-job",
+		&job.RegeneratePrivacyZoneActivitiesJobInput{},
+		func(arg interface{}) bool {
+			input := arg.(*job.RegeneratePrivacyZoneActivitiesJobInput)
+
+			return *input == job.NewRegeneratePrivacyZoneActivitiesJobInput(zone)
+		})
+}
@@ -0,0 +1,46 @@
+package www
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// ProfilePasswordPost changes the password of the current user, who must confirm their current password
+func ProfilePasswordPost(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		newPassword := r.FormValue("new-password")
+		if newPassword != r.FormValue("new-password-confirmation") {
+			ctx.AddFlash(web.NewFlashMessageError("password can't be changed: new password and its confirmation don't match"))
+			return ctx.Redirect(w, http.StatusSeeOther, "/profile")
+		}
+
+		err := app.ChangeUserPassword(ctx.StdCtx(), currentUser(r), r.FormValue("current-password"), newPassword)
+		if errors.Is(err, domain.ErrInvalidCredentials) {
+			ctx.AddFlash(web.NewFlashMessageError("password can't be changed: current password is invalid"))
+			response := ctx.Redirect(w, http.StatusSeeOther, "/profile")
+			response.LogMessage = fmt.Sprintf("invalid current password: %v", err)
+			return response
+		}
+
+		var invalidErr *domain.InvalidInputErrors
+		if errors.As(err, &invalidErr) {
+			ctx.AddFlash(web.NewFlashMessageError(fmt.Sprintf("password can't be changed: %s", strings.Join(invalidErr.Detail(), ", "))))
+			response := ctx.Redirect(w, http.StatusSeeOther, "/profile")
+			response.LogMessage = fmt.Sprintf("invalid new password: %v", err)
+			return response
+		}
+
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't change password: %v", err)
+		}
+
+		ctx.AddFlash(web.NewFlashMessageSuccess("password changed"))
+		return ctx.Redirect(w, http.StatusSeeOther, "/profile")
+	}
+}
@@ -0,0 +1,124 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestProfilePasswordPostConfirmationMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/profile/password", url.Values{
+		"current-password":          []string{"correct horse"},
+		"new-password":              []string{"battery staple"},
+		"new-password-confirmation": []string{"battery stapler"},
+	})
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().AddFlash(web.NewFlashMessageError("password can't be changed: new password and its confirmation don't match"))
+	ctx.EXPECT().Redirect(w, 303, "/profile").Return(expectedResponse)
+
+	actualResponse := www.ProfilePasswordPost(nil, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestProfilePasswordPostInvalidCurrentPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/profile/password", url.Values{
+		"current-password":          []string{"wrong"},
+		"new-password":              []string{"battery staple"},
+		"new-password-confirmation": []string{"battery staple"},
+	})
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ChangeUserPassword(gomock.Any(), "alice", "wrong", "battery staple").Return(domain.ErrInvalidCredentials)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageError("password can't be changed: current password is invalid"))
+	ctx.EXPECT().Redirect(w, 303, "/profile").Return(expectedResponse)
+
+	actualResponse := www.ProfilePasswordPost(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestProfilePasswordPostInvalidNewPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/profile/password", url.Values{
+		"current-password":          []string{"correct horse"},
+		"new-password":              []string{"short"},
+		"new-password-confirmation": []string{"short"},
+	})
+
+	var invalidErr domain.InvalidInputErrors
+	invalidErr.Append("password must be at least 8 characters long")
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ChangeUserPassword(gomock.Any(), "alice", "correct horse", "short").Return(&invalidErr)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageError("password can't be changed: password must be at least 8 characters long"))
+	ctx.EXPECT().Redirect(w, 303, "/profile").Return(expectedResponse)
+
+	actualResponse := www.ProfilePasswordPost(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestProfilePasswordPostError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/profile/password", url.Values{
+		"current-password":          []string{"correct horse"},
+		"new-password":              []string{"battery staple"},
+		"new-password-confirmation": []string{"battery staple"},
+	})
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ChangeUserPassword(gomock.Any(), "alice", "correct horse", "battery staple").Return(errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.ProfilePasswordPost(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestProfilePasswordPostSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/profile/password", url.Values{
+		"current-password":          []string{"correct horse"},
+		"new-password":              []string{"battery staple"},
+		"new-password-confirmation": []string{"battery staple"},
+	})
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ChangeUserPassword(gomock.Any(), "alice", "correct horse", "battery staple").Return(nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("password changed"))
+	ctx.EXPECT().Redirect(w, 303, "/profile").Return(expectedResponse)
+
+	actualResponse := www.ProfilePasswordPost(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
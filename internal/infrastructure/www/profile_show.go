@@ -0,0 +1,21 @@
+package www
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+)
+
+func ProfileShow(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		user, err := app.GetUser(ctx.StdCtx(), currentUser(r))
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't get current user: %v", err)
+		}
+
+		return ctx.Response(200, "templates/profile/show.html.tmpl", map[string]interface{}{
+			"User": user,
+		})
+	}
+}
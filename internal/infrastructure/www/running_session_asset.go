@@ -0,0 +1,87 @@
+package www
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl"
+)
+
+// AssetTemplate writes the raw content of a file
+const AssetTemplate = "templates/asset.tmpl"
+
+var assetContentTypes = map[string]string{
+	".geojson": "application/geo+json",
+	".gpx":     "application/gpx+xml",
+	".kml":     "application/vnd.google-earth.kml+xml",
+	".png":     "image/png",
+	".svg":     "image/svg+xml",
+}
+
+// ActivityAssetURL returns the URL of a file of the activity. GPX files are served by RunningSessionsAsset, which
+// checks who is viewing them and hides privacy zones. Files of private activities are linked through presigned URLs,
+// the other ones through the CDN.
+func ActivityAssetURL(urls asseturl.Builder, activity domain.RunningActivity, assetPath string) (string, error) {
+	if assetPath == activity.GPXPath.String() {
+		return "/running-session/" + activity.Slug.String() + "/assets/" + path.Base(assetPath), nil
+	}
+
+	if activity.HasPublicAsset(assetPath) {
+		return urls.PublicURL(assetPath)
+	}
+
+	return urls.PresignedURL(assetPath)
+}
+
+// RunningSessionsAsset serves a file of the activity, such as map.png, when the current user is allowed to see it
+func RunningSessionsAsset(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		vars := ctx.Vars(r)
+
+		slug, err := domain.NewRunnningActivitySlugFromString(vars["slug"])
+		if err != nil {
+			return ctx.NotFoundResponse("can't parse activity slug (slug=%s): %v", vars["slug"], err)
+		}
+
+		content, err := app.GetRunningSessionAsset(ctx.StdCtx(), currentUser(r), slug, vars["name"])
+		if errors.Is(err, domain.ErrCantGetRunningSession) || errors.Is(err, domain.ErrRunningActivityAssetNotFound) {
+			return ctx.NotFoundResponse("can't find file of activity (slug=%s, name=%s): %v", vars["slug"], vars["name"], err)
+		}
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't get file of activity (slug=%s, name=%s): %v", vars["slug"], vars["name"], err)
+		}
+		defer content.Close()
+
+		body, err := ioutil.ReadAll(content)
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't read file of activity (slug=%s, name=%s): %v", vars["slug"], vars["name"], err)
+		}
+
+		return AssetResponse(w, vars["name"], body, fmt.Sprintf("file %s of activity %s sent", vars["name"], vars["slug"]))
+	}
+}
+
+// AssetResponse writes the content of the file, which only the current user may be allowed to see and so must not
+// be kept by shared caches
+func AssetResponse(w http.ResponseWriter, name string, content []byte, logMessage string) web.Response {
+	contentType, ok := assetContentTypes[path.Ext(name)]
+	if !ok {
+		contentType = "application/octet-stream"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "private")
+
+	return web.Response{
+		HTTPCode:   http.StatusOK,
+		Template:   AssetTemplate,
+		Data:       string(content),
+		LogMessage: logMessage,
+	}
+}
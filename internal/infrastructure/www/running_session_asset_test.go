@@ -0,0 +1,148 @@
+package www_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl/asseturltest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+// assetURLs links the public files through the CDN and signs fake URLs for the other ones
+var assetURLs = asseturl.NewBuilder("https://cdn.example.com", asseturltest.Presigner{}, time.Hour)
+
+func TestActivityAssetURL(t *testing.T) {
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202204170900").Build()
+
+	for name, tc := range map[string]struct {
+		visibility domain.ActivityVisibility
+		assetPath  string
+		expected   string
+	}{
+		"public":      {domain.ActivityVisibilityPublic, activity.MapPath.String(), "https://cdn.example.com/" + activity.MapPath.String()},
+		"unlisted":    {domain.ActivityVisibilityUnlisted, activity.MapPath.String(), "https://cdn.example.com/" + activity.MapPath.String()},
+		"private":     {domain.ActivityVisibilityPrivate, activity.MapPath.String(), "https://bucket.example.com/" + activity.MapPath.String() + "?expires=3600"},
+		"public gpx":  {domain.ActivityVisibilityPublic, activity.GPXPath.String(), "/running-session/202204170900/assets/run.gpx"},
+		"private gpx": {domain.ActivityVisibilityPrivate, activity.GPXPath.String(), "/running-session/202204170900/assets/run.gpx"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			url, err := www.ActivityAssetURL(assetURLs, activity.WithVisibility(tc.visibility), tc.assetPath)
+			testutils.RequireNoError(t, err, "can't build url")
+
+			testutils.AssertEqualString(t, tc.expected, url, "unexpected url")
+		})
+	}
+}
+
+func TestActivityAssetURLPresignError(t *testing.T) {
+	activity := domaintest.NewRunningActivity(t).WithVisibility(domain.ActivityVisibilityPrivate).Build()
+	urls := asseturl.NewBuilder("https://cdn.example.com", asseturltest.Presigner{Err: errors.New("boom")}, time.Hour)
+
+	_, err := www.ActivityAssetURL(urls, activity, activity.MapPath.String())
+
+	testutils.AssertErrorContains(t, "boom", err, "expected presigner error")
+}
+
+func TestRunningSessionAssetNotFound(t *testing.T) {
+	for name, err := range map[string]error{
+		"activity": domain.ErrCantGetRunningSession,
+		"file":     domain.ErrRunningActivityAssetNotFound,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			app := applicationtest.NewMockApplication(ctrl)
+			ctx := webtest.NewMockContext(ctrl)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/running-session/{slug}/assets/{name}", nil)
+
+			expectedResponse := webtest.MockedResponse("not found")
+			ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900", "name": "map.png"})
+			ctx.EXPECT().StdCtx()
+			app.EXPECT().
+				GetRunningSessionAsset(gomock.Any(), "bob", domaintest.MatchRunningActivitySlug("202204170900"), "map.png").
+				Return(nil, err)
+			ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+			actualResponse := www.RunningSessionsAsset(app, currentUser("bob"))(ctx, w, r)
+
+			webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+		})
+	}
+}
+
+func TestRunningSessionAssetError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/running-session/{slug}/assets/{name}", nil)
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900", "name": "map.png"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetRunningSessionAsset(gomock.Any(), "alice", gomock.Any(), "map.png").Return(nil, errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.RunningSessionsAsset(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestRunningSessionAssetSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/running-session/{slug}/assets/{name}", nil)
+
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900", "name": "elevation.svg"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().
+		GetRunningSessionAsset(gomock.Any(), "alice", domaintest.MatchRunningActivitySlug("202204170900"), "elevation.svg").
+		Return(ioutil.NopCloser(strings.NewReader("<svg></svg>")), nil)
+
+	response := www.RunningSessionsAsset(app, currentUser("alice"))(ctx, w, r)
+
+	testutils.AssertEqualInt(t, 200, response.HTTPCode, "unexpected http code")
+	testutils.AssertEqualString(t, www.AssetTemplate, response.Template, "unexpected template")
+	testutils.AssertEqualString(t, "<svg></svg>", response.Data.(string), "unexpected content")
+	testutils.AssertEqualString(t, "image/svg+xml", w.Header().Get("Content-Type"), "unexpected content type")
+	testutils.AssertEqualString(t, "private", w.Header().Get("Cache-Control"), "unexpected cache control")
+}
+
+func TestRunningSessionAssetKeepsBytes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/running-session/{slug}/assets/{name}", nil)
+	stored := []byte("\x89PNG\r\n\x1a\n\x00<&>\n")
+
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900", "name": "map.png"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().
+		GetRunningSessionAsset(gomock.Any(), "alice", domaintest.MatchRunningActivitySlug("202204170900"), "map.png").
+		Return(ioutil.NopCloser(bytes.NewReader(stored)), nil)
+
+	response := www.RunningSessionsAsset(app, currentUser("alice"))(ctx, w, r)
+
+	tmpl, err := template.ParseFiles("../../../" + response.Template)
+	testutils.RequireNoError(t, err, "can't parse template")
+	var downloaded bytes.Buffer
+	testutils.RequireNoError(t, tmpl.Execute(&downloaded, web.TmplResponse{Data: response.Data}), "can't render template")
+	testutils.AssertEqualString(t, string(stored), downloaded.String(), "downloaded file should match the stored one")
+}
@@ -1,6 +1,7 @@
 package www
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -10,7 +11,7 @@ import (
 	"github.com/lonepeon/sport/internal/infrastructure/job"
 )
 
-func RunningSessionsDelete(app application.Application, enqueuer job.Enqueuer) web.HandlerFunc {
+func RunningSessionsDelete(app application.Application, currentUser CurrentUser, enqueuer job.Enqueuer) web.HandlerFunc {
 	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
 		vars := ctx.Vars(r)
 
@@ -22,7 +23,13 @@ func RunningSessionsDelete(app application.Application, enqueuer job.Enqueuer) w
 			return redirection
 		}
 
-		_, err = app.GetRunningSession(ctx.StdCtx(), slug)
+		_, err = app.GetManageableRunningSession(ctx.StdCtx(), currentUser(r), slug)
+		if errors.Is(err, domain.ErrRunningActivityForbidden) {
+			ctx.AddFlash(web.NewFlashMessageError("you can only delete your own activities"))
+			redirection := ctx.Redirect(w, http.StatusSeeOther, "/running-session/"+slug.String())
+			redirection.LogMessage = fmt.Sprintf("can't delete activity (slug=%v): %v", vars["slug"], err)
+			return redirection
+		}
 		if err != nil {
 			ctx.AddFlash(web.NewFlashMessageError("no activity recorded with slug '%v'", vars["slug"]))
 			redirection := ctx.Redirect(w, http.StatusSeeOther, "/")
@@ -29,7 +29,7 @@ func TestRunningSessionDeleteInvalidDate(t *testing.T) {
 	ctx.EXPECT().AddFlash(web.NewFlashMessageError("no activity recorded with slug 'wrong-date'"))
 	ctx.EXPECT().Redirect(response, 303, "/").Return(expectedResponse)
 
-	actualResponse := www.RunningSessionsDelete(nil, nil)(ctx, response, request)
+	actualResponse := www.RunningSessionsDelete(nil, currentUser("alice"), nil)(ctx, response, request)
 
 	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
 	testutils.AssertContainsString(t, "can't parse activity time", actualResponse.LogMessage, "unexpected log message")
@@ -49,18 +49,42 @@ func TestRunningSessionDeleteRunningSessionDoesNotExist(t *testing.T) {
 	ctx.EXPECT().Vars(request).Return(map[string]string{"slug": "202101101105"})
 	ctx.EXPECT().StdCtx()
 	application.EXPECT().
-		GetRunningSession(gomock.Any(), slug).
+		GetManageableRunningSession(gomock.Any(), "alice", slug).
 		Return(domain.RunningActivity{}, domain.ErrCantGetRunningSession)
 	ctx.EXPECT().AddFlash(web.NewFlashMessageError("no activity recorded with slug '202101101105'"))
 	ctx.EXPECT().Redirect(response, 303, "/").Return(expectedResponse)
 
-	actualResponse := www.RunningSessionsDelete(application, nil)(ctx, response, request)
+	actualResponse := www.RunningSessionsDelete(application, currentUser("alice"), nil)(ctx, response, request)
 
 	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
 	testutils.AssertContainsString(t, "can't find activity", actualResponse.LogMessage, "unexpected log message")
 	testutils.AssertContainsString(t, "202101101105", actualResponse.LogMessage, "unexpected log message")
 }
 
+func TestRunningSessionDeleteRunningSessionOfAnotherAthlete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("DELETE", "/running-session/{slug}", nil)
+	slug, err := domain.NewRunnningActivitySlugFromString("202101101105")
+	testutils.AssertNoError(t, err, "can't parse slug")
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Vars(request).Return(map[string]string{"slug": "202101101105"})
+	ctx.EXPECT().StdCtx()
+	application.EXPECT().
+		GetManageableRunningSession(gomock.Any(), "alice", slug).
+		Return(domain.RunningActivity{}, fmt.Errorf("wrapped: %w", domain.ErrRunningActivityForbidden))
+	ctx.EXPECT().AddFlash(web.NewFlashMessageError("you can only delete your own activities"))
+	ctx.EXPECT().Redirect(response, 303, "/running-session/202101101105").Return(expectedResponse)
+
+	actualResponse := www.RunningSessionsDelete(application, currentUser("alice"), nil)(ctx, response, request)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+	testutils.AssertContainsString(t, "can't delete activity", actualResponse.LogMessage, "unexpected log message")
+}
+
 func TestRunningSessionDeleteCannotEnqueueJob(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	application := applicationtest.NewMockApplication(ctrl)
@@ -82,12 +106,12 @@ func TestRunningSessionDeleteCannotEnqueueJob(t *testing.T) {
 	ctx.EXPECT().Vars(request).Return(map[string]string{"slug": activity.Slug.String()})
 	ctx.EXPECT().StdCtx()
 	application.EXPECT().
-		GetRunningSession(gomock.Any(), gomockutils.Equal(activity.Slug)).
+		GetManageableRunningSession(gomock.Any(), "alice", gomockutils.Equal(activity.Slug)).
 		Return(activity, nil)
 	enqueuer.EXPECT().Enqueue(expectedJob).Return(fmt.Errorf("boom"))
 	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
 
-	actualResponse := www.RunningSessionsDelete(application, enqueuer)(ctx, response, request)
+	actualResponse := www.RunningSessionsDelete(application, currentUser("alice"), enqueuer)(ctx, response, request)
 
 	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
 }
@@ -113,13 +137,13 @@ func TestRunningSessionDeleteSuccess(t *testing.T) {
 	ctx.EXPECT().Vars(request).Return(map[string]string{"slug": activity.Slug.String()})
 	ctx.EXPECT().StdCtx()
 	application.EXPECT().
-		GetRunningSession(gomock.Any(), gomockutils.Equal(activity.Slug)).
+		GetManageableRunningSession(gomock.Any(), "alice", gomockutils.Equal(activity.Slug)).
 		Return(activity, nil)
 	enqueuer.EXPECT().Enqueue(expectedJob).Return(nil)
 	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("activity recorded with slug '%s' is being deleted", activity.Slug))
 	ctx.EXPECT().Redirect(response, 303, "/").Return(expectedResponse)
 
-	actualResponse := www.RunningSessionsDelete(application, enqueuer)(ctx, response, request)
+	actualResponse := www.RunningSessionsDelete(application, currentUser("alice"), enqueuer)(ctx, response, request)
 
 	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
 }
@@ -7,15 +7,21 @@ import (
 	"github.com/lonepeon/sport/internal/application"
 )
 
-func RunningSessionsIndex(usecase application.Application) web.HandlerFunc {
+func RunningSessionsIndex(usecase application.Application, currentUser CurrentUser) web.HandlerFunc {
 	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
-		activities, err := usecase.ListRunningSessions(ctx.StdCtx())
+		activities, err := usecase.ListRunningSessions(ctx.StdCtx(), currentUser(r))
 		if err != nil {
 			return ctx.InternalServerErrorResponse("can't list activities: %v", err)
 		}
 
+		user, err := viewer(ctx, usecase, currentUser, r)
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't get current user: %v", err)
+		}
+
 		return ctx.Response(200, "templates/running-sessions/index.html.tmpl", map[string]interface{}{
 			"Activities": activities,
+			"Viewer":     user,
 		})
 	}
 }
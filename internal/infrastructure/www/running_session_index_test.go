@@ -21,7 +21,7 @@ func TestRunningSessionIndexError(t *testing.T) {
 	r := httptest.NewRequest("GET", "/", nil)
 	usecase := applicationtest.NewMockApplication(ctrl)
 
-	usecase.EXPECT().ListRunningSessions(gomock.Any()).Return(nil, errors.New("boom"))
+	usecase.EXPECT().ListRunningSessions(gomock.Any(), "").Return(nil, errors.New("boom"))
 
 	expected := webtest.MockedResponse("server error")
 	ctx.EXPECT().StdCtx().AnyTimes()
@@ -32,7 +32,7 @@ func TestRunningSessionIndexError(t *testing.T) {
 		).
 		Return(expected)
 
-	actual := www.RunningSessionsIndex(usecase)(ctx, w, r)
+	actual := www.RunningSessionsIndex(usecase, currentUser(""))(ctx, w, r)
 
 	webtest.AssertResponse(t, expected, actual, "unexpected response")
 }
@@ -59,7 +59,7 @@ func TestRunningSessionIndexSuccess(t *testing.T) {
 			r := httptest.NewRequest("GET", "/", nil)
 			usecase := applicationtest.NewMockApplication(ctrl)
 
-			usecase.EXPECT().ListRunningSessions(gomock.Any()).Return(tc.activities, nil)
+			usecase.EXPECT().ListRunningSessions(gomock.Any(), "").Return(tc.activities, nil)
 
 			expected := webtest.MockedResponse("ok response")
 			ctx.EXPECT().StdCtx().AnyTimes()
@@ -67,13 +67,38 @@ func TestRunningSessionIndexSuccess(t *testing.T) {
 				Response(
 					200,
 					gomock.Any(),
-					webtest.MatchDataContains("Activities", tc.activities),
+					gomock.All(
+						webtest.MatchDataContains("Activities", tc.activities),
+						webtest.MatchDataContains("Viewer", domain.User{}),
+					),
 				).
 				Return(expected)
 
-			actual := www.RunningSessionsIndex(usecase)(ctx, w, r)
+			actual := www.RunningSessionsIndex(usecase, currentUser(""))(ctx, w, r)
 
 			webtest.AssertResponse(t, expected, actual, "unexpected response")
 		})
 	}
 }
+
+func TestRunningSessionIndexLoggedIn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	usecase := applicationtest.NewMockApplication(ctrl)
+	user := domaintest.NewUser(t).WithUsername("alice").Build()
+
+	usecase.EXPECT().ListRunningSessions(gomock.Any(), "alice").Return(nil, nil)
+	usecase.EXPECT().GetUser(gomock.Any(), "alice").Return(user, nil)
+
+	expected := webtest.MockedResponse("ok response")
+	ctx.EXPECT().StdCtx().AnyTimes()
+	ctx.EXPECT().
+		Response(200, gomock.Any(), webtest.MatchDataContains("Viewer", user)).
+		Return(expected)
+
+	actual := www.RunningSessionsIndex(usecase, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expected, actual, "unexpected response")
+}
@@ -4,10 +4,14 @@ import (
 	"net/http"
 
 	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/domain"
 )
 
 func RunningSessionNew() web.HandlerFunc {
 	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
-		return ctx.Response(200, "templates/running-sessions/new.html.tmpl", nil)
+		return ctx.Response(200, "templates/running-sessions/new.html.tmpl", map[string]interface{}{
+			"ActivityTypes": domain.ActivityTypes,
+			"Visibilities":  domain.ActivityVisibilities,
+		})
 	}
 }
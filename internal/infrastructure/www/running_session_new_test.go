@@ -6,6 +6,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/domain"
 	"github.com/lonepeon/sport/internal/infrastructure/www"
 )
 
@@ -16,7 +17,12 @@ func TestRunningSessionNewSuccess(t *testing.T) {
 	r := httptest.NewRequest("GET", "/running-session/new", nil)
 
 	expected := webtest.MockedResponse("ok response")
-	ctx.EXPECT().Response(200, gomock.Any(), nil).Return(expected)
+	ctx.EXPECT().
+		Response(200, gomock.Any(), gomock.All(
+			webtest.MatchDataContains("ActivityTypes", domain.ActivityTypes),
+			webtest.MatchDataContains("Visibilities", domain.ActivityVisibilities),
+		)).
+		Return(expected)
 
 	actual := www.RunningSessionNew()(ctx, w, r)
 
@@ -9,12 +9,13 @@ import (
 	"time"
 
 	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/domain"
 	"github.com/lonepeon/sport/internal/infrastructure/job"
 )
 
 const MaxGPXFileSize = 5 * 1024 * 1024
 
-func RunningSessionPost(enqueuer job.Enqueuer, uploadFolder string) web.HandlerFunc {
+func RunningSessionPost(enqueuer job.Enqueuer, currentUser CurrentUser, uploadFolder string) web.HandlerFunc {
 	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
 		if err := r.ParseMultipartForm(MaxGPXFileSize); err != nil {
 			ctx.AddFlash(web.NewFlashMessageError("can't parse request parameters. Please try again"))
@@ -36,6 +37,24 @@ func RunningSessionPost(enqueuer job.Enqueuer, uploadFolder string) web.HandlerF
 			return response
 		}
 
+		activityType, err := domain.ParseActivityType(r.FormValue("type"))
+		if err != nil {
+			ctx.AddFlash(web.NewFlashMessageError("activity type is not supported"))
+
+			response := ctx.Redirect(w, http.StatusSeeOther, "/running-session/new")
+			response.LogMessage = fmt.Sprintf("can't parse activity type: %v", err)
+			return response
+		}
+
+		visibility, err := domain.ParseActivityVisibility(r.FormValue("visibility"))
+		if err != nil {
+			ctx.AddFlash(web.NewFlashMessageError("activity visibility is not supported"))
+
+			response := ctx.Redirect(w, http.StatusSeeOther, "/running-session/new")
+			response.LogMessage = fmt.Sprintf("can't parse activity visibility: %v", err)
+			return response
+		}
+
 		file, header, err := r.FormFile("gpx")
 		if err != nil {
 			ctx.AddFlash(web.NewFlashMessageError("gpx file must be sent"))
@@ -57,7 +76,15 @@ func RunningSessionPost(enqueuer job.Enqueuer, uploadFolder string) web.HandlerF
 			return ctx.InternalServerErrorResponse(err.Error())
 		}
 
-		input := job.TrackRunningSessionJobInput{When: when, GPXFilepath: filepath}
+		input := job.TrackRunningSessionJobInput{
+			When:        when,
+			GPXFilepath: filepath,
+			Title:       r.FormValue("title"),
+			Description: r.FormValue("description"),
+			Type:        activityType,
+			Visibility:  visibility,
+			Username:    currentUser(r),
+		}
 		if err = job.EnqueueTrackRunningSessionJob(enqueuer, input); err != nil {
 			return ctx.InternalServerErrorResponse("can't enqueue running session job: %v", err)
 		}
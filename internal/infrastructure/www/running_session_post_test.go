@@ -14,6 +14,7 @@ import (
 	"github.com/lonepeon/golib/testutils"
 	"github.com/lonepeon/golib/testutils/gomockutils"
 	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/domain"
 	"github.com/lonepeon/sport/internal/infrastructure/job"
 	"github.com/lonepeon/sport/internal/infrastructure/job/jobtest"
 	"github.com/lonepeon/sport/internal/infrastructure/www"
@@ -49,7 +50,7 @@ func TestRunningSessionPostInvalidDate(t *testing.T) {
 			expectedResponse := webtest.MockedResponse("redirection")
 			ctx.EXPECT().Redirect(w, 303, "/running-session/new").Return(expectedResponse)
 
-			response := www.RunningSessionPost(nil, "")(ctx, w, r)
+			response := www.RunningSessionPost(nil, nil, "")(ctx, w, r)
 
 			webtest.AssertResponse(t, expectedResponse, response, "unexpected response")
 			testutils.AssertContainsString(t, "date format", response.LogMessage, "unexpected log message")
@@ -58,6 +59,56 @@ func TestRunningSessionPostInvalidDate(t *testing.T) {
 	}
 }
 
+func TestRunningSessionPostInvalidType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+
+	var body bytes.Buffer
+	bodyWriter := multipart.NewWriter(&body)
+	testutils.AssertNoError(t, bodyWriter.WriteField("date", "2022-02-20T21:27"), "can't write date to form")
+	testutils.AssertNoError(t, bodyWriter.WriteField("type", "swim"), "can't write type to form")
+	bodyWriter.Close()
+
+	r := httptest.NewRequest("POST", "/running-session/", &body)
+	r.Header.Set("Content-Type", bodyWriter.FormDataContentType())
+
+	ctx.EXPECT().AddFlash(webtest.MatchFlashErrorContains("activity type"))
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Redirect(w, 303, "/running-session/new").Return(expectedResponse)
+
+	response := www.RunningSessionPost(nil, nil, "")(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, response, "unexpected response")
+	testutils.AssertContainsString(t, "swim", response.LogMessage, "unexpected log message")
+}
+
+func TestRunningSessionPostInvalidVisibility(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+
+	var body bytes.Buffer
+	bodyWriter := multipart.NewWriter(&body)
+	testutils.AssertNoError(t, bodyWriter.WriteField("date", "2022-02-20T21:27"), "can't write date to form")
+	testutils.AssertNoError(t, bodyWriter.WriteField("visibility", "friends"), "can't write visibility to form")
+	bodyWriter.Close()
+
+	r := httptest.NewRequest("POST", "/running-session/", &body)
+	r.Header.Set("Content-Type", bodyWriter.FormDataContentType())
+
+	ctx.EXPECT().AddFlash(webtest.MatchFlashErrorContains("activity visibility"))
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Redirect(w, 303, "/running-session/new").Return(expectedResponse)
+
+	response := www.RunningSessionPost(nil, nil, "")(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, response, "unexpected response")
+	testutils.AssertContainsString(t, "friends", response.LogMessage, "unexpected log message")
+}
+
 func TestRunningSessionPostMissingGPXFile(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	ctx := webtest.NewMockContext(ctrl)
@@ -76,7 +127,7 @@ func TestRunningSessionPostMissingGPXFile(t *testing.T) {
 	expectedResponse := webtest.MockedResponse("redirection")
 	ctx.EXPECT().Redirect(w, 303, "/running-session/new").Return(expectedResponse)
 
-	response := www.RunningSessionPost(nil, "")(ctx, w, r)
+	response := www.RunningSessionPost(nil, nil, "")(ctx, w, r)
 
 	webtest.AssertResponse(t, expectedResponse, response, "unexpected response")
 	testutils.AssertContainsString(t, "can't get gpx", response.LogMessage, "unexpected log message")
@@ -109,7 +160,7 @@ func TestRunningSessionPostHugeGPXFile(t *testing.T) {
 	expectedResponse := webtest.MockedResponse("redirection")
 	ctx.EXPECT().Redirect(w, 303, "/running-session/new").Return(expectedResponse)
 
-	response := www.RunningSessionPost(nil, "")(ctx, w, r)
+	response := www.RunningSessionPost(nil, nil, "")(ctx, w, r)
 
 	webtest.AssertResponse(t, expectedResponse, response, "unexpected response")
 	testutils.AssertContainsString(t, "too big", response.LogMessage, "unexpected log message")
@@ -139,7 +190,7 @@ func TestRunningSessionPostInvalidUploadFolder(t *testing.T) {
 		gomockutils.ContainsString("/an/invalid/path/on/the/system"),
 	)).Return(expectedResponse)
 
-	response := www.RunningSessionPost(nil, "/an/invalid/path/on/the/system")(ctx, w, r)
+	response := www.RunningSessionPost(nil, nil, "/an/invalid/path/on/the/system")(ctx, w, r)
 
 	webtest.AssertResponse(t, expectedResponse, response, "unexpected response")
 }
@@ -175,7 +226,7 @@ func TestRunningSessionPostCantEnqueueJob(t *testing.T) {
 		gomock.Any(),
 	).Return(expectedResponse)
 
-	response := www.RunningSessionPost(enqueuer, uploadFolder)(ctx, w, r)
+	response := www.RunningSessionPost(enqueuer, currentUser(""), uploadFolder)(ctx, w, r)
 
 	webtest.AssertResponse(t, expectedResponse, response, "unexpected response")
 }
@@ -193,6 +244,8 @@ func TestRunningSessionPostSuccess(t *testing.T) {
 	var body bytes.Buffer
 	bodyWriter := multipart.NewWriter(&body)
 	testutils.AssertNoError(t, bodyWriter.WriteField("date", when), "can't write date to form")
+	testutils.AssertNoError(t, bodyWriter.WriteField("type", "hike"), "can't write type to form")
+	testutils.AssertNoError(t, bodyWriter.WriteField("visibility", "private"), "can't write visibility to form")
 	gpxFile, err := bodyWriter.CreateFormFile("gpx", "my-huge-file.gpx")
 	testutils.AssertNoError(t, err, "can't create form file")
 	for i := 0; i < 1024; i++ {
@@ -210,7 +263,10 @@ func TestRunningSessionPostSuccess(t *testing.T) {
 			input := arg.(*job.TrackRunningSessionJobInput)
 
 			return strings.HasPrefix(input.GPXFilepath, uploadFolder) &&
-				input.When.Format("2006-01-02T15:04") == when
+				input.When.Format("2006-01-02T15:04") == when &&
+				input.Type == domain.ActivityTypeHike &&
+				input.Visibility == domain.ActivityVisibilityPrivate &&
+				input.Username == "alice"
 		},
 	)).Return(nil)
 
@@ -219,7 +275,7 @@ func TestRunningSessionPostSuccess(t *testing.T) {
 	expectedResponse := webtest.MockedResponse("server error")
 	ctx.EXPECT().Redirect(w, 303, "/").Return(expectedResponse)
 
-	response := www.RunningSessionPost(enqueuer, uploadFolder)(ctx, w, r)
+	response := www.RunningSessionPost(enqueuer, currentUser("alice"), uploadFolder)(ctx, w, r)
 
 	webtest.AssertResponse(t, expectedResponse, response, "unexpected response")
 }
@@ -9,7 +9,9 @@ import (
 	"github.com/lonepeon/sport/internal/domain"
 )
 
-func RunningSessionsShow(app application.Application) web.HandlerFunc {
+// RunningSessionsShow displays the activity when the current user is allowed to see it, private activities of other
+// athletes being not found
+func RunningSessionsShow(app application.Application, currentUser CurrentUser) web.HandlerFunc {
 	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
 		vars := ctx.Vars(r)
 
@@ -18,7 +20,7 @@ func RunningSessionsShow(app application.Application) web.HandlerFunc {
 			return ctx.NotFoundResponse("can't parse activity slug (slug=%s): %v", vars["slug"], err)
 		}
 
-		activity, err := app.GetRunningSession(ctx.StdCtx(), when)
+		activity, err := app.GetRunningSession(ctx.StdCtx(), currentUser(r), when)
 		if err != nil {
 			if errors.Is(err, domain.ErrCantGetRunningSession) {
 				return ctx.NotFoundResponse("can't find activity (slug=%s): %v", vars["slug"], err)
@@ -26,8 +28,16 @@ func RunningSessionsShow(app application.Application) web.HandlerFunc {
 			return ctx.InternalServerErrorResponse("failed while finding activity (slug=%s): %v", vars["slug"], err)
 		}
 
+		user, err := viewer(ctx, app, currentUser, r)
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't get current user: %v", err)
+		}
+
 		return ctx.Response(200, "templates/running-sessions/show.html.tmpl", map[string]interface{}{
-			"Activity": activity,
+			"Activity":     activity,
+			"Viewer":       user,
+			"Visibilities": domain.ActivityVisibilities,
+			"TrackFormats": domain.TrackFormats,
 		})
 	}
 }
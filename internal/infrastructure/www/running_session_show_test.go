@@ -26,7 +26,7 @@ func TestRunningSessionShowInvalidSlug(t *testing.T) {
 		NotFoundResponse(gomockutils.ContainsString("can't parse"), gomock.Any(), gomock.Any()).
 		Return(expected)
 
-	actual := www.RunningSessionsShow(nil)(ctx, w, r)
+	actual := www.RunningSessionsShow(nil, currentUser(""))(ctx, w, r)
 
 	webtest.AssertResponse(t, expected, actual, "unexpected response")
 }
@@ -41,14 +41,14 @@ func TestRunningSessionShowActivityNotFound(t *testing.T) {
 	ctx.EXPECT().StdCtx().AnyTimes()
 	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202102122146"})
 	application.EXPECT().
-		GetRunningSession(gomock.Any(), domaintest.MatchRunningActivitySlug("202102122146")).
+		GetRunningSession(gomock.Any(), "", domaintest.MatchRunningActivitySlug("202102122146")).
 		Return(domain.RunningActivity{}, domain.ErrCantGetRunningSession)
 	expected := webtest.MockedResponse("not found")
 	ctx.EXPECT().
 		NotFoundResponse(gomockutils.ContainsString("can't find"), gomock.Any(), gomock.Any()).
 		Return(expected)
 
-	actual := www.RunningSessionsShow(application)(ctx, w, r)
+	actual := www.RunningSessionsShow(application, currentUser(""))(ctx, w, r)
 
 	webtest.AssertResponse(t, expected, actual, "unexpected response")
 }
@@ -63,14 +63,14 @@ func TestRunningSessionShowActivityUnexpectedError(t *testing.T) {
 	ctx.EXPECT().StdCtx().AnyTimes()
 	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202102122146"})
 	application.EXPECT().
-		GetRunningSession(gomock.Any(), domaintest.MatchRunningActivitySlug("202102122146")).
+		GetRunningSession(gomock.Any(), "", domaintest.MatchRunningActivitySlug("202102122146")).
 		Return(domain.RunningActivity{}, errors.New("boom"))
 	expected := webtest.MockedResponse("server error")
 	ctx.EXPECT().
 		InternalServerErrorResponse(gomockutils.ContainsString("failed"), gomock.Any(), gomock.Any()).
 		Return(expected)
 
-	actual := www.RunningSessionsShow(application)(ctx, w, r)
+	actual := www.RunningSessionsShow(application, currentUser(""))(ctx, w, r)
 
 	webtest.AssertResponse(t, expected, actual, "unexpected response")
 }
@@ -87,14 +87,47 @@ func TestRunningSessionShowActivitySuccess(t *testing.T) {
 	ctx.EXPECT().StdCtx().AnyTimes()
 	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": rawSlug})
 	application.EXPECT().
-		GetRunningSession(gomock.Any(), domaintest.MatchRunningActivitySlug(rawSlug)).
+		GetRunningSession(gomock.Any(), "", domaintest.MatchRunningActivitySlug(rawSlug)).
 		Return(activity, nil)
 	expected := webtest.MockedResponse("ok")
 	ctx.EXPECT().
-		Response(200, gomock.Any(), webtest.MatchDataContains("Activity", activity)).
+		Response(200, gomock.Any(), gomock.All(
+			webtest.MatchDataContains("Activity", activity),
+			webtest.MatchDataContains("Viewer", domain.User{}),
+		)).
 		Return(expected)
 
-	actual := www.RunningSessionsShow(application)(ctx, w, r)
+	actual := www.RunningSessionsShow(application, currentUser(""))(ctx, w, r)
+
+	webtest.AssertResponse(t, expected, actual, "unexpected response")
+}
+
+func TestRunningSessionShowPrivateActivityOfOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/running-session/{slug}", nil)
+	rawSlug := "202102122146"
+	user := domaintest.NewUser(t).WithUsername("alice").Build()
+	activity := domaintest.NewRunningActivity(t).WithRawSlug(rawSlug).WithUsername("alice").
+		WithVisibility(domain.ActivityVisibilityPrivate).Build()
+
+	ctx.EXPECT().StdCtx().AnyTimes()
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": rawSlug})
+	application.EXPECT().
+		GetRunningSession(gomock.Any(), "alice", domaintest.MatchRunningActivitySlug(rawSlug)).
+		Return(activity, nil)
+	application.EXPECT().GetUser(gomock.Any(), "alice").Return(user, nil)
+	expected := webtest.MockedResponse("ok")
+	ctx.EXPECT().
+		Response(200, gomock.Any(), gomock.All(
+			webtest.MatchDataContains("Activity", activity),
+			webtest.MatchDataContains("Viewer", user),
+		)).
+		Return(expected)
+
+	actual := www.RunningSessionsShow(application, currentUser("alice"))(ctx, w, r)
 
 	webtest.AssertResponse(t, expected, actual, "unexpected response")
 }
@@ -0,0 +1,50 @@
+package www
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// RunningSessionsTrack downloads the track of the activity as a GPX, GeoJSON or KML file when the current user is
+// allowed to see it. The owner gets the points inside their privacy zones unless the privacy-zones query parameter is
+// set to hide.
+func RunningSessionsTrack(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		vars := ctx.Vars(r)
+
+		slug, err := domain.NewRunnningActivitySlugFromString(vars["slug"])
+		if err != nil {
+			return ctx.NotFoundResponse("can't parse activity slug (slug=%s): %v", vars["slug"], err)
+		}
+
+		format, err := domain.ParseTrackFormat(vars["format"])
+		if err != nil {
+			return ctx.NotFoundResponse("can't parse track format (format=%s): %v", vars["format"], err)
+		}
+
+		hidePrivacyZones := r.URL.Query().Get("privacy-zones") == "hide"
+		content, err := app.GetRunningSessionTrack(ctx.StdCtx(), currentUser(r), slug, format, hidePrivacyZones)
+		if errors.Is(err, domain.ErrCantGetRunningSession) {
+			return ctx.NotFoundResponse("can't find activity (slug=%s): %v", vars["slug"], err)
+		}
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't get track of activity (slug=%s, format=%s): %v", vars["slug"], format, err)
+		}
+
+		body, err := ioutil.ReadAll(content)
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't read track of activity (slug=%s, format=%s): %v", vars["slug"], format, err)
+		}
+
+		name := slug.String() + "." + format.String()
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+
+		return AssetResponse(w, name, body, fmt.Sprintf("track of activity %s sent as %s", vars["slug"], format))
+	}
+}
@@ -0,0 +1,92 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestRunningSessionTrackUnknownFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/running-session/{slug}.{format}", nil)
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900", "format": "tcx"})
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.RunningSessionsTrack(nil, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestRunningSessionTrackNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/running-session/{slug}.{format}", nil)
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900", "format": "gpx"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().
+		GetRunningSessionTrack(gomock.Any(), "bob", domaintest.MatchRunningActivitySlug("202204170900"), domain.TrackFormatGPX, false).
+		Return(nil, domain.ErrCantGetRunningSession)
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.RunningSessionsTrack(app, currentUser("bob"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestRunningSessionTrackError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/running-session/{slug}.{format}", nil)
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900", "format": "kml"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().GetRunningSessionTrack(gomock.Any(), "alice", gomock.Any(), domain.TrackFormatKML, false).Return(nil, errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.RunningSessionsTrack(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestRunningSessionTrackSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/running-session/202204170900.geojson?privacy-zones=hide", nil)
+
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202204170900", "format": "geojson"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().
+		GetRunningSessionTrack(gomock.Any(), "alice", domaintest.MatchRunningActivitySlug("202204170900"), domain.TrackFormatGeoJSON, true).
+		Return(strings.NewReader(`{"type":"FeatureCollection"}`), nil)
+
+	response := www.RunningSessionsTrack(app, currentUser("alice"))(ctx, w, r)
+
+	testutils.AssertEqualInt(t, 200, response.HTTPCode, "unexpected http code")
+	testutils.AssertEqualString(t, www.AssetTemplate, response.Template, "unexpected template")
+	testutils.AssertEqualString(t, `{"type":"FeatureCollection"}`, response.Data.(string), "unexpected content")
+	testutils.AssertEqualString(t, "application/geo+json", w.Header().Get("Content-Type"), "unexpected content type")
+	testutils.AssertEqualString(t, `attachment; filename="202204170900.geojson"`, w.Header().Get("Content-Disposition"), "unexpected content disposition")
+	testutils.AssertEqualString(t, "private", w.Header().Get("Cache-Control"), "unexpected cache control")
+}
@@ -0,0 +1,49 @@
+package www
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// RunningSessionsVisibility changes who can see the activity, when the current user is allowed to manage it
+func RunningSessionsVisibility(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		vars := ctx.Vars(r)
+
+		slug, err := domain.NewRunnningActivitySlugFromString(vars["slug"])
+		if err != nil {
+			return ctx.NotFoundResponse("can't parse activity slug (slug=%s): %v", vars["slug"], err)
+		}
+
+		showPage := "/running-session/" + slug.String()
+		visibility, err := domain.ParseActivityVisibility(r.FormValue("visibility"))
+		if err != nil {
+			ctx.AddFlash(web.NewFlashMessageError("activity visibility is not supported"))
+			redirection := ctx.Redirect(w, http.StatusSeeOther, showPage)
+			redirection.LogMessage = fmt.Sprintf("can't parse activity visibility: %v", err)
+			return redirection
+		}
+
+		err = app.ChangeRunningSessionVisibility(ctx.StdCtx(), currentUser(r), slug, visibility)
+		if errors.Is(err, domain.ErrRunningActivityForbidden) {
+			ctx.AddFlash(web.NewFlashMessageError("you can only change the visibility of your own activities"))
+			redirection := ctx.Redirect(w, http.StatusSeeOther, showPage)
+			redirection.LogMessage = fmt.Sprintf("can't change visibility of activity (slug=%v): %v", vars["slug"], err)
+			return redirection
+		}
+		if errors.Is(err, domain.ErrCantGetRunningSession) {
+			return ctx.NotFoundResponse("can't find activity (slug=%s): %v", vars["slug"], err)
+		}
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't change visibility of activity (slug=%s): %v", vars["slug"], err)
+		}
+
+		ctx.AddFlash(web.NewFlashMessageSuccess("activity is now %s", visibility))
+		return ctx.Redirect(w, http.StatusSeeOther, showPage)
+	}
+}
@@ -0,0 +1,118 @@
+package www_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestRunningSessionVisibilityInvalidVisibility(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/running-session/{slug}/visibility", url.Values{"visibility": {"friends"}})
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202101101105"})
+	ctx.EXPECT().AddFlash(web.NewFlashMessageError("activity visibility is not supported"))
+	ctx.EXPECT().Redirect(w, 303, "/running-session/202101101105").Return(expectedResponse)
+
+	actualResponse := www.RunningSessionsVisibility(nil, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+	testutils.AssertContainsString(t, "can't parse activity visibility", actualResponse.LogMessage, "unexpected log message")
+}
+
+func TestRunningSessionVisibilityOfAnotherAthlete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/running-session/{slug}/visibility", url.Values{"visibility": {"private"}})
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202101101105"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().
+		ChangeRunningSessionVisibility(gomock.Any(), "bob", domaintest.MatchRunningActivitySlug("202101101105"), domain.ActivityVisibilityPrivate).
+		Return(fmt.Errorf("wrapped: %w", domain.ErrRunningActivityForbidden))
+	ctx.EXPECT().AddFlash(web.NewFlashMessageError("you can only change the visibility of your own activities"))
+	ctx.EXPECT().Redirect(w, 303, "/running-session/202101101105").Return(expectedResponse)
+
+	actualResponse := www.RunningSessionsVisibility(app, currentUser("bob"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+	testutils.AssertContainsString(t, "can't change visibility", actualResponse.LogMessage, "unexpected log message")
+}
+
+func TestRunningSessionVisibilityNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/running-session/{slug}/visibility", url.Values{"visibility": {"private"}})
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202101101105"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().
+		ChangeRunningSessionVisibility(gomock.Any(), "alice", gomock.Any(), domain.ActivityVisibilityPrivate).
+		Return(domain.ErrCantGetRunningSession)
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.RunningSessionsVisibility(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestRunningSessionVisibilityError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/running-session/{slug}/visibility", url.Values{"visibility": {"private"}})
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202101101105"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().
+		ChangeRunningSessionVisibility(gomock.Any(), "alice", gomock.Any(), domain.ActivityVisibilityPrivate).
+		Return(errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.RunningSessionsVisibility(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestRunningSessionVisibilitySuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/running-session/{slug}/visibility", url.Values{"visibility": {"unlisted"}})
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"slug": "202101101105"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().
+		ChangeRunningSessionVisibility(gomock.Any(), "alice", domaintest.MatchRunningActivitySlug("202101101105"), domain.ActivityVisibilityUnlisted).
+		Return(nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("activity is now unlisted"))
+	ctx.EXPECT().Redirect(w, 303, "/running-session/202101101105").Return(expectedResponse)
+
+	actualResponse := www.RunningSessionsVisibility(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
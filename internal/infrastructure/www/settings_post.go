@@ -0,0 +1,30 @@
+package www
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func SettingsPost(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		prefs, err := domain.NewUserPreferences(r.FormValue("locale"), r.FormValue("units"), r.FormValue("speed-display"))
+		if err != nil {
+			ctx.AddFlash(web.NewFlashMessageError("preferences are not supported"))
+
+			response := ctx.Redirect(w, http.StatusSeeOther, "/settings")
+			response.LogMessage = fmt.Sprintf("can't parse preferences: %v", err)
+			return response
+		}
+
+		if err := app.UpdateUserPreferences(ctx.StdCtx(), currentUser(r), prefs); err != nil {
+			return ctx.InternalServerErrorResponse("can't update user preferences: %v", err)
+		}
+
+		ctx.AddFlash(web.NewFlashMessageSuccess("preferences updated"))
+		return ctx.Redirect(w, http.StatusSeeOther, "/settings")
+	}
+}
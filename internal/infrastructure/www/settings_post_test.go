@@ -0,0 +1,73 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestSettingsPostInvalidPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	form := url.Values{"locale": {"de"}, "units": {"metric"}, "speed-display": {"pace"}}
+	r := httptest.NewRequest("POST", "/settings", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().AddFlash(webtest.MatchFlashErrorContains("not supported"))
+	ctx.EXPECT().Redirect(w, 303, "/settings").Return(expectedResponse)
+
+	actualResponse := www.SettingsPost(nil, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestSettingsPostCannotUpdatePreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	form := url.Values{"locale": {"fr"}, "units": {"imperial"}, "speed-display": {"speed"}}
+	r := httptest.NewRequest("POST", "/settings", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().UpdateUserPreferences(gomock.Any(), "alice", gomock.Any()).Return(errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.SettingsPost(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestSettingsPostSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	form := url.Values{"locale": {"fr"}, "units": {"imperial"}, "speed-display": {"speed"}}
+	r := httptest.NewRequest("POST", "/settings", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	prefs := domain.UserPreferences{Locale: domain.LocaleFrench, Units: domain.UnitSystemImperial, SpeedDisplay: domain.SpeedDisplaySpeed}
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().UpdateUserPreferences(gomock.Any(), "alice", prefs).Return(nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("preferences updated"))
+	ctx.EXPECT().Redirect(w, 303, "/settings").Return(expectedResponse)
+
+	actualResponse := www.SettingsPost(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
@@ -0,0 +1,18 @@
+package www
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func SettingsShow() web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		return ctx.Response(200, "templates/settings/show.html.tmpl", map[string]interface{}{
+			"Locales":       domain.Locales,
+			"UnitSystems":   domain.UnitSystems,
+			"SpeedDisplays": domain.SpeedDisplays,
+		})
+	}
+}
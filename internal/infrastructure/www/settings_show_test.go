@@ -0,0 +1,29 @@
+package www_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestSettingsShowSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/settings", nil)
+
+	expectedResponse := webtest.MockedResponse("settings")
+	ctx.EXPECT().Response(200, "templates/settings/show.html.tmpl", gomock.All(
+		webtest.MatchDataContains("Locales", domain.Locales),
+		webtest.MatchDataContains("UnitSystems", domain.UnitSystems),
+		webtest.MatchDataContains("SpeedDisplays", domain.SpeedDisplays),
+	)).Return(expectedResponse)
+
+	actualResponse := www.SettingsShow()(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
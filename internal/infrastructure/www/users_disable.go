@@ -0,0 +1,33 @@
+package www
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// UsersDisable prevents the user from logging in. Administrators can't disable themselves, so there is always one
+// left to enable the others.
+func UsersDisable(app application.Application, currentUser CurrentUser) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		username := ctx.Vars(r)["username"]
+		if username == currentUser(r) {
+			ctx.AddFlash(web.NewFlashMessageError("you can't disable your own account"))
+			return ctx.Redirect(w, http.StatusSeeOther, "/admin/users")
+		}
+
+		if err := app.DisableUser(ctx.StdCtx(), username); err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return ctx.NotFoundResponse("can't find user (username=%s): %v", username, err)
+			}
+			return ctx.InternalServerErrorResponse("can't disable user (username=%s): %v", username, err)
+		}
+
+		ctx.AddFlash(web.NewFlashMessageSuccess(fmt.Sprintf("user %s disabled", username)))
+		return ctx.Redirect(w, http.StatusSeeOther, "/admin/users")
+	}
+}
@@ -0,0 +1,85 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestUsersDisableOwnAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/admin/users/{username}/disable", nil)
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"username": "alice"})
+	ctx.EXPECT().AddFlash(web.NewFlashMessageError("you can't disable your own account"))
+	ctx.EXPECT().Redirect(w, 303, "/admin/users").Return(expectedResponse)
+
+	actualResponse := www.UsersDisable(nil, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestUsersDisableNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/admin/users/{username}/disable", nil)
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"username": "bob"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().DisableUser(gomock.Any(), "bob").Return(domain.ErrUserNotFound)
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.UsersDisable(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestUsersDisableError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/admin/users/{username}/disable", nil)
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"username": "bob"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().DisableUser(gomock.Any(), "bob").Return(errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.UsersDisable(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestUsersDisableSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/admin/users/{username}/disable", nil)
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"username": "bob"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().DisableUser(gomock.Any(), "bob").Return(nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("user bob disabled"))
+	ctx.EXPECT().Redirect(w, 303, "/admin/users").Return(expectedResponse)
+
+	actualResponse := www.UsersDisable(app, currentUser("alice"))(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
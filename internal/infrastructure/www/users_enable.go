@@ -0,0 +1,27 @@
+package www
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func UsersEnable(app application.Application) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		username := ctx.Vars(r)["username"]
+
+		if err := app.EnableUser(ctx.StdCtx(), username); err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return ctx.NotFoundResponse("can't find user (username=%s): %v", username, err)
+			}
+			return ctx.InternalServerErrorResponse("can't enable user (username=%s): %v", username, err)
+		}
+
+		ctx.AddFlash(web.NewFlashMessageSuccess(fmt.Sprintf("user %s enabled", username)))
+		return ctx.Redirect(w, http.StatusSeeOther, "/admin/users")
+	}
+}
@@ -0,0 +1,69 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestUsersEnableNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/admin/users/{username}/enable", nil)
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"username": "bob"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().EnableUser(gomock.Any(), "bob").Return(domain.ErrUserNotFound)
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.UsersEnable(app)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestUsersEnableError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/admin/users/{username}/enable", nil)
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"username": "bob"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().EnableUser(gomock.Any(), "bob").Return(errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.UsersEnable(app)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestUsersEnableSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/admin/users/{username}/enable", nil)
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"username": "bob"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().EnableUser(gomock.Any(), "bob").Return(nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("user bob enabled"))
+	ctx.EXPECT().Redirect(w, 303, "/admin/users").Return(expectedResponse)
+
+	actualResponse := www.UsersEnable(app)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
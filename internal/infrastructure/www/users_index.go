@@ -0,0 +1,21 @@
+package www
+
+import (
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+)
+
+func UsersIndex(app application.Application) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		users, err := app.ListUsers(ctx.StdCtx())
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't list users: %v", err)
+		}
+
+		return ctx.Response(200, "templates/admin/users.html.tmpl", map[string]interface{}{
+			"Users": users,
+		})
+	}
+}
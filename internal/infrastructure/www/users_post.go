@@ -0,0 +1,39 @@
+package www
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+func UsersPost(app application.Application) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		username := strings.TrimSpace(r.FormValue("username"))
+
+		_, err := app.CreateUser(ctx.StdCtx(), username, r.FormValue("password"), r.FormValue("admin") == "on")
+		if errors.Is(err, domain.ErrUserAlreadyExists) {
+			ctx.AddFlash(web.NewFlashMessageError(fmt.Sprintf("user %s already exists", username)))
+			return ctx.Redirect(w, http.StatusSeeOther, "/admin/users")
+		}
+
+		var invalidErr *domain.InvalidInputErrors
+		if errors.As(err, &invalidErr) {
+			ctx.AddFlash(web.NewFlashMessageError(fmt.Sprintf("user can't be created: %s", strings.Join(invalidErr.Detail(), ", "))))
+			response := ctx.Redirect(w, http.StatusSeeOther, "/admin/users")
+			response.LogMessage = fmt.Sprintf("invalid user: %v", err)
+			return response
+		}
+
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't create user: %v", err)
+		}
+
+		ctx.AddFlash(web.NewFlashMessageSuccess(fmt.Sprintf("user %s created", username)))
+		return ctx.Redirect(w, http.StatusSeeOther, "/admin/users")
+	}
+}
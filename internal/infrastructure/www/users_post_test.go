@@ -0,0 +1,94 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestUsersPostSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/admin/users", url.Values{
+		"username": []string{" bob "},
+		"password": []string{"correct horse"},
+		"admin":    []string{"on"},
+	})
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().CreateUser(gomock.Any(), "bob", "correct horse", true).Return(domaintest.NewUser(t).WithUsername("bob").Build(), nil)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageSuccess("user bob created"))
+	ctx.EXPECT().Redirect(w, 303, "/admin/users").Return(expectedResponse)
+
+	actualResponse := www.UsersPost(app)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestUsersPostAlreadyExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/admin/users", url.Values{"username": []string{"bob"}, "password": []string{"correct horse"}})
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().CreateUser(gomock.Any(), "bob", "correct horse", false).Return(domain.User{}, domain.ErrUserAlreadyExists)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageError("user bob already exists"))
+	ctx.EXPECT().Redirect(w, 303, "/admin/users").Return(expectedResponse)
+
+	actualResponse := www.UsersPost(app)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestUsersPostInvalidInput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/admin/users", url.Values{"username": []string{"bob"}, "password": []string{"short"}})
+
+	var invalidErr domain.InvalidInputErrors
+	invalidErr.Append("password must be at least 8 characters long")
+
+	expectedResponse := webtest.MockedResponse("redirection")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().CreateUser(gomock.Any(), "bob", "short", false).Return(domain.User{}, &invalidErr)
+	ctx.EXPECT().AddFlash(web.NewFlashMessageError("user can't be created: password must be at least 8 characters long"))
+	ctx.EXPECT().Redirect(w, 303, "/admin/users").Return(expectedResponse)
+
+	actualResponse := www.UsersPost(app)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestUsersPostError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := postForm("/admin/users", url.Values{"username": []string{"bob"}, "password": []string{"correct horse"}})
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().CreateUser(gomock.Any(), "bob", "correct horse", false).Return(domain.User{}, errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.UsersPost(app)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
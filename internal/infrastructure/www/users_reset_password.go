@@ -0,0 +1,37 @@
+package www
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// UsersResetPassword generates a new password for the user and shows it. The password isn't stored so the page is
+// rendered instead of redirecting to the list of users.
+func UsersResetPassword(app application.Application) web.HandlerFunc {
+	return func(ctx web.Context, w http.ResponseWriter, r *http.Request) web.Response {
+		username := ctx.Vars(r)["username"]
+
+		password, err := app.ResetUserPassword(ctx.StdCtx(), username)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return ctx.NotFoundResponse("can't find user (username=%s): %v", username, err)
+			}
+			return ctx.InternalServerErrorResponse("can't reset password of user (username=%s): %v", username, err)
+		}
+
+		users, err := app.ListUsers(ctx.StdCtx())
+		if err != nil {
+			return ctx.InternalServerErrorResponse("can't list users: %v", err)
+		}
+
+		return ctx.Response(200, "templates/admin/users.html.tmpl", map[string]interface{}{
+			"Users":         users,
+			"ResetUsername": username,
+			"ResetPassword": password,
+		})
+	}
+}
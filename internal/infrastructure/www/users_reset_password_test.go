@@ -0,0 +1,74 @@
+package www_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lonepeon/golib/web/webtest"
+	"github.com/lonepeon/sport/internal/application/applicationtest"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/infrastructure/www"
+)
+
+func TestUsersResetPasswordNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/admin/users/{username}/reset-password", nil)
+
+	expectedResponse := webtest.MockedResponse("not found")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"username": "bob"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ResetUserPassword(gomock.Any(), "bob").Return("", domain.ErrUserNotFound)
+	ctx.EXPECT().NotFoundResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.UsersResetPassword(app)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestUsersResetPasswordError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/admin/users/{username}/reset-password", nil)
+
+	expectedResponse := webtest.MockedResponse("server error")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"username": "bob"})
+	ctx.EXPECT().StdCtx()
+	app.EXPECT().ResetUserPassword(gomock.Any(), "bob").Return("", errors.New("boom"))
+	ctx.EXPECT().InternalServerErrorResponse(gomock.Any(), gomock.Any()).Return(expectedResponse)
+
+	actualResponse := www.UsersResetPassword(app)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
+
+func TestUsersResetPasswordSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := applicationtest.NewMockApplication(ctrl)
+	ctx := webtest.NewMockContext(ctrl)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/admin/users/{username}/reset-password", nil)
+	users := []domain.User{domaintest.NewUser(t).WithUsername("bob").Build()}
+
+	expectedResponse := webtest.MockedResponse("users")
+	ctx.EXPECT().Vars(r).Return(map[string]string{"username": "bob"})
+	ctx.EXPECT().StdCtx().Times(2)
+	app.EXPECT().ResetUserPassword(gomock.Any(), "bob").Return("generated-password", nil)
+	app.EXPECT().ListUsers(gomock.Any()).Return(users, nil)
+	ctx.EXPECT().Response(200, "templates/admin/users.html.tmpl", gomock.All(
+		webtest.MatchDataContains("Users", users),
+		webtest.MatchDataContains("ResetUsername", "bob"),
+		webtest.MatchDataContains("ResetPassword", "generated-password"),
+	)).Return(expectedResponse)
+
+	actualResponse := www.UsersResetPassword(app)(ctx, w, r)
+
+	webtest.AssertResponse(t, expectedResponse, actualResponse, "invalid response")
+}
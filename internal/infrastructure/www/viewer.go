@@ -0,0 +1,25 @@
+package www
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lonepeon/golib/web"
+	"github.com/lonepeon/sport/internal/application"
+	"github.com/lonepeon/sport/internal/domain"
+)
+
+// viewer returns the user browsing the page, or an empty user for visitors
+func viewer(ctx web.Context, app application.Application, currentUser CurrentUser, r *http.Request) (domain.User, error) {
+	username := currentUser(r)
+	if username == "" {
+		return domain.User{}, nil
+	}
+
+	user, err := app.GetUser(ctx.StdCtx(), username)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.User{}, nil
+	}
+
+	return user, err
+}
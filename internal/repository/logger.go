@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/lonepeon/sport/internal/domain"
 )
@@ -33,6 +34,18 @@ func (l Logger) StoreAsset(file io.Reader, fileName string) error {
 	return nil
 }
 
+func (l Logger) FetchAsset(fileName string) (io.ReadCloser, error) {
+	l.logger.Infof("repository fetches file %s", fileName)
+	content, err := l.repo.FetchAsset(fileName)
+	if err != nil {
+		l.logger.Infof("repository failed to fetch file: %v", err)
+		return content, err
+	}
+
+	l.logger.Info("repository fetched file")
+	return content, nil
+}
+
 func (l Logger) DeleteAsset(fileName string) error {
 	l.logger.Infof("repository deletes file %s", fileName)
 	err := l.repo.DeleteAsset(fileName)
@@ -70,6 +83,29 @@ func (l Logger) ListRunningActivities(ctx context.Context) ([]domain.RunningActi
 	return activities, nil
 }
 
+func (l Logger) ListUserRunningActivities(ctx context.Context, username string) ([]domain.RunningActivity, error) {
+	l.logger.Infof("repository fetches running activities of %s", username)
+	activities, err := l.repo.ListUserRunningActivities(ctx, username)
+	if err != nil {
+		l.logger.Infof("repository failed to find running activities: %v", err)
+		return activities, err
+	}
+
+	l.logger.Infof("repository found %d running activities", len(activities))
+	return activities, nil
+}
+
+func (l Logger) AssignOrphanRunningActivities(ctx context.Context, username string) error {
+	l.logger.Infof("repository assigns running activities without owner to %s", username)
+	if err := l.repo.AssignOrphanRunningActivities(ctx, username); err != nil {
+		l.logger.Infof("repository failed to assign running activities: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository assigned running activities")
+	return nil
+}
+
 func (l Logger) RecordRunningActivity(ctx context.Context, activity domain.RunningActivity) error {
 	l.logger.Infof("repository records a new running activity at %s", activity.Slug)
 	err := l.repo.RecordRunningActivity(ctx, activity)
@@ -82,6 +118,17 @@ func (l Logger) RecordRunningActivity(ctx context.Context, activity domain.Runni
 	return nil
 }
 
+func (l Logger) UpdateRunningActivity(ctx context.Context, activity domain.RunningActivity) error {
+	l.logger.Infof("repository updates running activity %s", activity.Slug)
+	if err := l.repo.UpdateRunningActivity(ctx, activity); err != nil {
+		l.logger.Infof("repository failed to update the running activity: %v", err)
+		return err
+	}
+
+	l.logger.Infof("repository updated running activity")
+	return nil
+}
+
 func (l Logger) DeleteRunningActivity(ctx context.Context, slug domain.RunningActivitySlug) error {
 	l.logger.Infof("repository deletes running activity with slug %s", slug)
 	if err := l.repo.DeleteRunningActivity(ctx, slug); err != nil {
@@ -93,9 +140,9 @@ func (l Logger) DeleteRunningActivity(ctx context.Context, slug domain.RunningAc
 	return nil
 }
 
-func (l Logger) GenerateMap(ctx context.Context, gpx domain.GPXFile) (domain.MapFile, error) {
-	l.logger.Info("repository generates map from points")
-	mapFile, err := l.repo.GenerateMap(ctx, gpx)
+func (l Logger) GenerateMap(ctx context.Context, gpx domain.GPXFile, style domain.MapStyle) (domain.MapFile, error) {
+	l.logger.Infof("repository generates map from points (theme=%s)", style.Theme)
+	mapFile, err := l.repo.GenerateMap(ctx, gpx, style)
 	if err != nil {
 		l.logger.Infof("repository failed to generate map from points: %v", err)
 		return mapFile, err
@@ -117,6 +164,380 @@ func (l Logger) CleanGPXFile(ctx context.Context, r io.Reader) (domain.GPXFile,
 	return gpx, nil
 }
 
-func (l Logger) AnnotateMapWithStats(ctx context.Context, file domain.MapFile, distance domain.Distance, speed domain.Speed) (domain.ShareableMapFile, error) {
-	return l.repo.AnnotateMapWithStats(ctx, file, distance, speed)
+func (l Logger) WriteGPXFile(ctx context.Context, points domain.GPXPoints) (io.Reader, error) {
+	l.logger.Infof("repository writes gpx file of %d points", len(points))
+	content, err := l.repo.WriteGPXFile(ctx, points)
+	if err != nil {
+		l.logger.Infof("repository failed to write gpx file: %v", err)
+		return content, err
+	}
+
+	l.logger.Info("repository wrote gpx file")
+	return content, nil
+}
+
+func (l Logger) WriteGeoJSONFile(ctx context.Context, points domain.GPXPoints) (io.Reader, error) {
+	l.logger.Infof("repository writes geojson file of %d points", len(points))
+	content, err := l.repo.WriteGeoJSONFile(ctx, points)
+	if err != nil {
+		l.logger.Infof("repository failed to write geojson file: %v", err)
+		return content, err
+	}
+
+	l.logger.Info("repository wrote geojson file")
+	return content, nil
+}
+
+func (l Logger) WriteKMLFile(ctx context.Context, points domain.GPXPoints) (io.Reader, error) {
+	l.logger.Infof("repository writes kml file of %d points", len(points))
+	content, err := l.repo.WriteKMLFile(ctx, points)
+	if err != nil {
+		l.logger.Infof("repository failed to write kml file: %v", err)
+		return content, err
+	}
+
+	l.logger.Info("repository wrote kml file")
+	return content, nil
+}
+
+func (l Logger) DrawCard(ctx context.Context, file domain.MapFile, template domain.CardTemplate, stats domain.CardStats, prefs domain.UserPreferences) (domain.ShareableMapFile, error) {
+	return l.repo.DrawCard(ctx, file, template, stats, prefs)
+}
+
+func (l Logger) DrawChart(ctx context.Context, chart domain.Chart) (domain.ChartFile, error) {
+	return l.repo.DrawChart(ctx, chart)
+}
+
+func (l Logger) GetExport(ctx context.Context, id domain.ID) (domain.Export, error) {
+	l.logger.Infof("repository fetches export %s", id)
+	export, err := l.repo.GetExport(ctx, id)
+	if err != nil {
+		l.logger.Infof("repository failed to find export: %v", err)
+		return export, err
+	}
+
+	l.logger.Info("repository found export")
+	return export, nil
+}
+
+func (l Logger) ListExports(ctx context.Context, username string) ([]domain.Export, error) {
+	l.logger.Infof("repository fetches exports of %s", username)
+	exports, err := l.repo.ListExports(ctx, username)
+	if err != nil {
+		l.logger.Infof("repository failed to find exports: %v", err)
+		return exports, err
+	}
+
+	l.logger.Infof("repository found %d exports", len(exports))
+	return exports, nil
+}
+
+func (l Logger) RecordExport(ctx context.Context, export domain.Export) error {
+	l.logger.Infof("repository records a new export %s", export.ID)
+	if err := l.repo.RecordExport(ctx, export); err != nil {
+		l.logger.Infof("repository failed to record the export: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository recorded export")
+	return nil
+}
+
+func (l Logger) UpdateExport(ctx context.Context, export domain.Export) error {
+	l.logger.Infof("repository updates export %s", export.ID)
+	if err := l.repo.UpdateExport(ctx, export); err != nil {
+		l.logger.Infof("repository failed to update the export: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository updated export")
+	return nil
+}
+
+func (l Logger) BuildExportArchive(ctx context.Context, activities []domain.RunningActivity, prefs domain.UserPreferences) (domain.ExportArchive, error) {
+	l.logger.Infof("repository builds export archive with %d running activities", len(activities))
+	archive, err := l.repo.BuildExportArchive(ctx, activities, prefs)
+	if err != nil {
+		l.logger.Infof("repository failed to build export archive: %v", err)
+		return archive, err
+	}
+
+	l.logger.Info("repository built export archive")
+	return archive, nil
+}
+
+func (l Logger) GetImport(ctx context.Context, id domain.ID) (domain.Import, error) {
+	l.logger.Infof("repository fetches import %s", id)
+	imp, err := l.repo.GetImport(ctx, id)
+	if err != nil {
+		l.logger.Infof("repository failed to find import: %v", err)
+		return imp, err
+	}
+
+	l.logger.Info("repository found import")
+	return imp, nil
+}
+
+func (l Logger) ListImports(ctx context.Context, username string) ([]domain.Import, error) {
+	l.logger.Infof("repository fetches imports of %s", username)
+	imports, err := l.repo.ListImports(ctx, username)
+	if err != nil {
+		l.logger.Infof("repository failed to find imports: %v", err)
+		return imports, err
+	}
+
+	l.logger.Infof("repository found %d imports", len(imports))
+	return imports, nil
+}
+
+func (l Logger) RecordImport(ctx context.Context, imp domain.Import) error {
+	l.logger.Infof("repository records a new import %s", imp.ID)
+	if err := l.repo.RecordImport(ctx, imp); err != nil {
+		l.logger.Infof("repository failed to record the import: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository recorded import")
+	return nil
+}
+
+func (l Logger) GetImportItem(ctx context.Context, importID domain.ID, externalID string) (domain.ImportItem, error) {
+	l.logger.Infof("repository fetches item %s of import %s", externalID, importID)
+	item, err := l.repo.GetImportItem(ctx, importID, externalID)
+	if err != nil {
+		l.logger.Infof("repository failed to find import item: %v", err)
+		return item, err
+	}
+
+	l.logger.Info("repository found import item")
+	return item, nil
+}
+
+func (l Logger) ListImportItems(ctx context.Context, importID domain.ID) ([]domain.ImportItem, error) {
+	l.logger.Infof("repository fetches all items of import %s", importID)
+	items, err := l.repo.ListImportItems(ctx, importID)
+	if err != nil {
+		l.logger.Infof("repository failed to find import items: %v", err)
+		return items, err
+	}
+
+	l.logger.Infof("repository found %d import items", len(items))
+	return items, nil
+}
+
+func (l Logger) RecordImportItems(ctx context.Context, items []domain.ImportItem) error {
+	l.logger.Infof("repository records %d import items", len(items))
+	if err := l.repo.RecordImportItems(ctx, items); err != nil {
+		l.logger.Infof("repository failed to record the import items: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository recorded import items")
+	return nil
+}
+
+func (l Logger) UpdateImportItem(ctx context.Context, item domain.ImportItem) error {
+	l.logger.Infof("repository updates item %s of import %s", item.ExternalID, item.ImportID)
+	if err := l.repo.UpdateImportItem(ctx, item); err != nil {
+		l.logger.Infof("repository failed to update the import item: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository updated import item")
+	return nil
+}
+
+func (l Logger) AssignOrphanImports(ctx context.Context, username string) error {
+	l.logger.Infof("repository assigns imports without owner to %s", username)
+	if err := l.repo.AssignOrphanImports(ctx, username); err != nil {
+		l.logger.Infof("repository failed to assign imports: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository assigned imports")
+	return nil
+}
+
+func (l Logger) ExtractStravaArchive(ctx context.Context, imp domain.Import) ([]domain.ImportItem, error) {
+	l.logger.Infof("repository extracts strava archive of import %s", imp.ID)
+	items, err := l.repo.ExtractStravaArchive(ctx, imp)
+	if err != nil {
+		l.logger.Infof("repository failed to extract strava archive: %v", err)
+		return items, err
+	}
+
+	l.logger.Infof("repository extracted %d activities from strava archive", len(items))
+	return items, nil
+}
+
+func (l Logger) OpenImportItemFile(ctx context.Context, imp domain.Import, item domain.ImportItem) (io.ReadCloser, error) {
+	l.logger.Infof("repository opens file %s of import %s", item.FileName, imp.ID)
+	file, err := l.repo.OpenImportItemFile(ctx, imp, item)
+	if err != nil {
+		l.logger.Infof("repository failed to open import item file: %v", err)
+		return file, err
+	}
+
+	l.logger.Info("repository opened import item file")
+	return file, nil
+}
+
+func (l Logger) GetUserPreferences(ctx context.Context, username string) (domain.UserPreferences, error) {
+	l.logger.Infof("repository fetches preferences of user %s", username)
+	prefs, err := l.repo.GetUserPreferences(ctx, username)
+	if err != nil {
+		l.logger.Infof("repository failed to find user preferences: %v", err)
+		return prefs, err
+	}
+
+	l.logger.Info("repository found user preferences")
+	return prefs, nil
+}
+
+func (l Logger) SaveUserPreferences(ctx context.Context, username string, prefs domain.UserPreferences) error {
+	l.logger.Infof("repository saves preferences of user %s", username)
+	if err := l.repo.SaveUserPreferences(ctx, username, prefs); err != nil {
+		l.logger.Infof("repository failed to save user preferences: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository saved user preferences")
+	return nil
+}
+
+func (l Logger) RecordAPIToken(ctx context.Context, token domain.APIToken) error {
+	l.logger.Infof("repository records api token %s of user %s", token.ID, token.Username)
+	if err := l.repo.RecordAPIToken(ctx, token); err != nil {
+		l.logger.Infof("repository failed to record the api token: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository recorded api token")
+	return nil
+}
+
+func (l Logger) ListAPITokens(ctx context.Context, username string) ([]domain.APIToken, error) {
+	l.logger.Infof("repository fetches api tokens of user %s", username)
+	tokens, err := l.repo.ListAPITokens(ctx, username)
+	if err != nil {
+		l.logger.Infof("repository failed to find api tokens: %v", err)
+		return tokens, err
+	}
+
+	l.logger.Infof("repository found %d api tokens", len(tokens))
+	return tokens, nil
+}
+
+func (l Logger) GetAPITokenByHash(ctx context.Context, hash domain.APITokenHash) (domain.APIToken, error) {
+	l.logger.Info("repository fetches api token by hash")
+	token, err := l.repo.GetAPITokenByHash(ctx, hash)
+	if err != nil {
+		l.logger.Infof("repository failed to find api token: %v", err)
+		return token, err
+	}
+
+	l.logger.Infof("repository found api token %s", token.ID)
+	return token, nil
+}
+
+func (l Logger) TouchAPIToken(ctx context.Context, id domain.ID, usedAt time.Time) error {
+	l.logger.Infof("repository marks api token %s as used", id)
+	if err := l.repo.TouchAPIToken(ctx, id, usedAt); err != nil {
+		l.logger.Infof("repository failed to mark the api token as used: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository marked api token as used")
+	return nil
+}
+
+func (l Logger) DeleteAPIToken(ctx context.Context, username string, id domain.ID) error {
+	l.logger.Infof("repository deletes api token %s of user %s", id, username)
+	if err := l.repo.DeleteAPIToken(ctx, username, id); err != nil {
+		l.logger.Infof("repository failed to delete the api token: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository deleted api token")
+	return nil
+}
+
+func (l Logger) RecordUser(ctx context.Context, user domain.User) error {
+	l.logger.Infof("repository records user %s", user.Username)
+	if err := l.repo.RecordUser(ctx, user); err != nil {
+		l.logger.Infof("repository failed to record the user: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository recorded user")
+	return nil
+}
+
+func (l Logger) GetUser(ctx context.Context, username string) (domain.User, error) {
+	l.logger.Infof("repository fetches user %s", username)
+	user, err := l.repo.GetUser(ctx, username)
+	if err != nil {
+		l.logger.Infof("repository failed to find user: %v", err)
+		return user, err
+	}
+
+	l.logger.Info("repository found user")
+	return user, nil
+}
+
+func (l Logger) ListUsers(ctx context.Context) ([]domain.User, error) {
+	l.logger.Info("repository fetches users")
+	users, err := l.repo.ListUsers(ctx)
+	if err != nil {
+		l.logger.Infof("repository failed to find users: %v", err)
+		return users, err
+	}
+
+	l.logger.Infof("repository found %d users", len(users))
+	return users, nil
+}
+
+func (l Logger) UpdateUser(ctx context.Context, user domain.User) error {
+	l.logger.Infof("repository updates user %s", user.Username)
+	if err := l.repo.UpdateUser(ctx, user); err != nil {
+		l.logger.Infof("repository failed to update the user: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository updated user")
+	return nil
+}
+
+func (l Logger) RecordPrivacyZone(ctx context.Context, zone domain.PrivacyZone) error {
+	l.logger.Infof("repository records privacy zone %s of user %s", zone.ID, zone.Username)
+	if err := l.repo.RecordPrivacyZone(ctx, zone); err != nil {
+		l.logger.Infof("repository failed to record the privacy zone: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository recorded privacy zone")
+	return nil
+}
+
+func (l Logger) ListPrivacyZones(ctx context.Context, username string) (domain.PrivacyZones, error) {
+	l.logger.Infof("repository fetches privacy zones of user %s", username)
+	zones, err := l.repo.ListPrivacyZones(ctx, username)
+	if err != nil {
+		l.logger.Infof("repository failed to find privacy zones: %v", err)
+		return zones, err
+	}
+
+	l.logger.Infof("repository found %d privacy zones", len(zones))
+	return zones, nil
+}
+
+func (l Logger) DeletePrivacyZone(ctx context.Context, username string, id domain.ID) error {
+	l.logger.Infof("repository deletes privacy zone %s of user %s", id, username)
+	if err := l.repo.DeletePrivacyZone(ctx, username, id); err != nil {
+		l.logger.Infof("repository failed to delete the privacy zone: %v", err)
+		return err
+	}
+
+	l.logger.Info("repository deleted privacy zone")
+	return nil
 }
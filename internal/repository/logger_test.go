@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/lonepeon/golib/testutils"
 	"github.com/lonepeon/sport/internal/domain"
@@ -90,6 +92,37 @@ func TestDeleteAssetError(t *testing.T) {
 	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected error message")
 }
 
+func TestFetchAssetSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	testutils.AssertNoError(t, repo.StoreAsset(bytes.NewBufferString("content"), "myfile.txt"), "can't store asset")
+
+	content, err := repository.NewLogger(&log, repo).FetchAsset("myfile.txt")
+	testutils.AssertNoError(t, err, "unexpected repository error")
+	defer content.Close()
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "fetches", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "myfile.txt", log.Infos[0], "unexpected file name in info message")
+	testutils.AssertContainsString(t, "fetched", log.Infos[1], "unexpected info message")
+}
+
+func TestFetchAssetError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideFetchAsset("myfile.txt", expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).FetchAsset("myfile.txt")
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "fetches", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "failed", log.Infos[1], "unexpected error message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected error message")
+}
+
 func TestGetRunningActivitySuccess(t *testing.T) {
 	repo := repositorytest.NewFake(t)
 	log := FakeLogger{}
@@ -156,6 +189,64 @@ func TestListRunningActivitiesError(t *testing.T) {
 	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
 }
 
+func TestListUserRunningActivitiesSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	domaintest.NewRunningActivity(t).WithRawSlug("202102182208").WithUsername("alice").Persist(repo)
+	domaintest.NewRunningActivity(t).WithRawSlug("202202182208").WithUsername("bob").Persist(repo)
+
+	activities, err := repository.NewLogger(&log, repo).ListUserRunningActivities(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 1, len(activities), "unexpected number of activities")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "alice", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "found 1", log.Infos[1], "unexpected info message")
+}
+
+func TestListUserRunningActivitiesError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideListActivities(expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).ListUserRunningActivities(context.Background(), "alice")
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to find", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestAssignOrphanRunningActivitiesSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+
+	err := repository.NewLogger(&log, repo).AssignOrphanRunningActivities(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "assigns", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "alice", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "assigned", log.Infos[1], "unexpected info message")
+}
+
+func TestAssignOrphanRunningActivitiesError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideAssignOrphanActivities(expectedErr)
+
+	err := repository.NewLogger(&log, repo).AssignOrphanRunningActivities(context.Background(), "alice")
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to assign", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
 func TestRecordRunningActivitySuccess(t *testing.T) {
 	repo := repositorytest.NewFake(t)
 	log := FakeLogger{}
@@ -188,6 +279,38 @@ func TestRecordRunningActivityError(t *testing.T) {
 	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
 }
 
+func TestUpdateRunningActivitySuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202202190640").Persist(repo)
+
+	err := repository.NewLogger(&log, repo).UpdateRunningActivity(context.Background(), activity.WithPendingMap("boom"))
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "updates", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "202202190640", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "updated", log.Infos[1], "unexpected info message")
+}
+
+func TestUpdateRunningActivityError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202202190640").Build()
+	expectedErr := errors.New("boom")
+
+	repo.OverrideUpdateActivity(activity.Slug, expectedErr)
+
+	err := repository.NewLogger(&log, repo).UpdateRunningActivity(context.Background(), activity)
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "updates", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "202202190640", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "failed to update", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
 func TestDeleteRunningActivitySuccess(t *testing.T) {
 	repo := repositorytest.NewFake(t)
 	log := FakeLogger{}
@@ -225,7 +348,7 @@ func TestGenerateMapSuccess(t *testing.T) {
 	log := FakeLogger{}
 	gpx := domaintest.NewGPXFile(t).Build()
 
-	mapFile, err := repository.NewLogger(&log, repo).GenerateMap(context.Background(), gpx)
+	mapFile, err := repository.NewLogger(&log, repo).GenerateMap(context.Background(), gpx, domain.DefaultMapStyle())
 	testutils.AssertNoError(t, err, "unexpected repository error")
 	testutils.AssertNotEqualNil(t, mapFile.File(), "unexpected result")
 
@@ -242,7 +365,7 @@ func TestGenerateMapError(t *testing.T) {
 
 	repo.OverrideGenerateMap(gpx, domain.MapFile{}, expectedErr)
 
-	_, err := repository.NewLogger(&log, repo).GenerateMap(context.Background(), gpx)
+	_, err := repository.NewLogger(&log, repo).GenerateMap(context.Background(), gpx, domain.DefaultMapStyle())
 	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
 
 	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
@@ -286,3 +409,926 @@ func TestCleanGPXFileError(t *testing.T) {
 	testutils.AssertContainsString(t, "cleans", log.Infos[0], "unexpected info message")
 	testutils.AssertContainsString(t, "failed to clean", log.Infos[1], "unexpected info message")
 }
+
+func TestGetExportSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expected := domaintest.NewExport(t).Persist(repo)
+
+	actual, err := repository.NewLogger(&log, repo).GetExport(context.Background(), expected.ID)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	domaintest.AssertEqualExport(t, expected, actual, "unexpected export")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "fetches", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, expected.ID.String(), log.Infos[0], "unexpected export id in info message")
+	testutils.AssertContainsString(t, "found", log.Infos[1], "unexpected info message")
+}
+
+func TestGetExportError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	export := domaintest.NewExport(t).Persist(repo)
+	expectedErr := errors.New("boom")
+
+	repo.OverrideGetExport(export.ID, expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).GetExport(context.Background(), export.ID)
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "fetches", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "failed to find", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestListExportsSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	domaintest.NewExport(t).WithUsername("alice").Persist(repo)
+	domaintest.NewExport(t).WithUsername("alice").Persist(repo)
+
+	exports, err := repository.NewLogger(&log, repo).ListExports(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(exports), "unexpected number of exports")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "fetches", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "found 2", log.Infos[1], "unexpected info message")
+}
+
+func TestListExportsError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideListExports(expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).ListExports(context.Background(), "alice")
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to find", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestRecordExportSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	export := domaintest.NewExport(t).Build()
+
+	repo.ExpectExports(export)
+
+	err := repository.NewLogger(&log, repo).RecordExport(context.Background(), export)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "records", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, export.ID.String(), log.Infos[0], "unexpected export id in info message")
+	testutils.AssertContainsString(t, "recorded", log.Infos[1], "unexpected info message")
+}
+
+func TestRecordExportError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideRecordExport(expectedErr)
+
+	err := repository.NewLogger(&log, repo).RecordExport(context.Background(), domaintest.NewExport(t).Build())
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to record", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestUpdateExportSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	export := domaintest.NewExport(t).Persist(repo)
+	completed := export.Complete(domain.ExportArchivePath("exports/archive.zip"), export.RequestedAt.Add(time.Minute))
+
+	repo.ExpectExports(completed)
+
+	err := repository.NewLogger(&log, repo).UpdateExport(context.Background(), completed)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "updates", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, export.ID.String(), log.Infos[0], "unexpected export id in info message")
+	testutils.AssertContainsString(t, "updated", log.Infos[1], "unexpected info message")
+}
+
+func TestUpdateExportError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	export := domaintest.NewExport(t).Persist(repo)
+	expectedErr := errors.New("boom")
+
+	repo.OverrideUpdateExport(export.ID, expectedErr)
+
+	err := repository.NewLogger(&log, repo).UpdateExport(context.Background(), export)
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to update", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestBuildExportArchiveSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	activities := []domain.RunningActivity{domaintest.NewRunningActivity(t).Build()}
+
+	repo.ExpectBuildExportArchives(activities)
+
+	archive, err := repository.NewLogger(&log, repo).BuildExportArchive(context.Background(), activities, domain.DefaultUserPreferences())
+	testutils.AssertNoError(t, err, "unexpected repository error")
+	defer archive.Close()
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "builds", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "1 running activities", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "built", log.Infos[1], "unexpected info message")
+}
+
+func TestBuildExportArchiveError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideBuildExportArchive(expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).BuildExportArchive(context.Background(), nil, domain.DefaultUserPreferences())
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to build", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestGetImportSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expected := domaintest.NewImport(t).Persist(repo)
+
+	actual, err := repository.NewLogger(&log, repo).GetImport(context.Background(), expected.ID)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	domaintest.AssertEqualImport(t, expected, actual, "unexpected import")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "fetches", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, expected.ID.String(), log.Infos[0], "unexpected import id in info message")
+	testutils.AssertContainsString(t, "found", log.Infos[1], "unexpected info message")
+}
+
+func TestGetImportError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	imp := domaintest.NewImport(t).Persist(repo)
+	expectedErr := errors.New("boom")
+
+	repo.OverrideGetImport(imp.ID, expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).GetImport(context.Background(), imp.ID)
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to find", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestListImportsSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	domaintest.NewImport(t).WithUsername("alice").Persist(repo)
+	domaintest.NewImport(t).WithUsername("alice").Persist(repo)
+
+	imports, err := repository.NewLogger(&log, repo).ListImports(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(imports), "unexpected number of imports")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "fetches", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "found 2", log.Infos[1], "unexpected info message")
+}
+
+func TestListImportsError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideListImports(expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).ListImports(context.Background(), "alice")
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to find", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestRecordImportSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	imp := domaintest.NewImport(t).Build()
+
+	repo.ExpectImports(imp)
+
+	err := repository.NewLogger(&log, repo).RecordImport(context.Background(), imp)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "records", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, imp.ID.String(), log.Infos[0], "unexpected import id in info message")
+	testutils.AssertContainsString(t, "recorded", log.Infos[1], "unexpected info message")
+}
+
+func TestRecordImportError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideRecordImport(expectedErr)
+
+	err := repository.NewLogger(&log, repo).RecordImport(context.Background(), domaintest.NewImport(t).Build())
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to record", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestGetImportItemSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	imp := domaintest.NewImport(t).Persist(repo)
+	expected := domaintest.NewImportItem(t, imp.ID).Persist(repo)
+
+	actual, err := repository.NewLogger(&log, repo).GetImportItem(context.Background(), imp.ID, expected.ExternalID)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	domaintest.AssertEqualImportItem(t, expected, actual, "unexpected import item")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "fetches", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, expected.ExternalID, log.Infos[0], "unexpected item id in info message")
+	testutils.AssertContainsString(t, "found", log.Infos[1], "unexpected info message")
+}
+
+func TestGetImportItemError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	imp := domaintest.NewImport(t).Persist(repo)
+	item := domaintest.NewImportItem(t, imp.ID).Persist(repo)
+	expectedErr := errors.New("boom")
+
+	repo.OverrideGetImportItem(item.ExternalID, expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).GetImportItem(context.Background(), imp.ID, item.ExternalID)
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to find", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestListImportItemsSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	imp := domaintest.NewImport(t).Persist(repo)
+	domaintest.NewImportItem(t, imp.ID).Persist(repo)
+	domaintest.NewImportItem(t, imp.ID).Persist(repo)
+
+	items, err := repository.NewLogger(&log, repo).ListImportItems(context.Background(), imp.ID)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(items), "unexpected number of import items")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "fetches", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "found 2", log.Infos[1], "unexpected info message")
+}
+
+func TestListImportItemsError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideListImportItems(expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).ListImportItems(context.Background(), domain.NewID())
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to find", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestRecordImportItemsSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	imp := domaintest.NewImport(t).Persist(repo)
+	item := domaintest.NewImportItem(t, imp.ID).Build()
+
+	repo.ExpectImportItems(item)
+
+	err := repository.NewLogger(&log, repo).RecordImportItems(context.Background(), []domain.ImportItem{item})
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "records 1", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "recorded", log.Infos[1], "unexpected info message")
+}
+
+func TestRecordImportItemsError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideRecordImportItems(expectedErr)
+
+	err := repository.NewLogger(&log, repo).RecordImportItems(context.Background(), nil)
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to record", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestUpdateImportItemSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	imp := domaintest.NewImport(t).Persist(repo)
+	item := domaintest.NewImportItem(t, imp.ID).Persist(repo).Imported()
+
+	repo.ExpectImportItems(item)
+
+	err := repository.NewLogger(&log, repo).UpdateImportItem(context.Background(), item)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "updates", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, item.ExternalID, log.Infos[0], "unexpected item id in info message")
+	testutils.AssertContainsString(t, "updated", log.Infos[1], "unexpected info message")
+}
+
+func TestUpdateImportItemError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	imp := domaintest.NewImport(t).Persist(repo)
+	item := domaintest.NewImportItem(t, imp.ID).Persist(repo)
+	expectedErr := errors.New("boom")
+
+	repo.OverrideUpdateImportItem(item.ExternalID, expectedErr)
+
+	err := repository.NewLogger(&log, repo).UpdateImportItem(context.Background(), item)
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to update", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestAssignOrphanImportsSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+
+	err := repository.NewLogger(&log, repo).AssignOrphanImports(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "assigns", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "alice", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "assigned", log.Infos[1], "unexpected info message")
+}
+
+func TestAssignOrphanImportsError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideAssignOrphanImports(expectedErr)
+
+	err := repository.NewLogger(&log, repo).AssignOrphanImports(context.Background(), "alice")
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to assign", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestExtractStravaArchiveSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	imp := domaintest.NewImport(t).Build()
+	items := []domain.ImportItem{domaintest.NewImportItem(t, imp.ID).Build()}
+
+	repo.OverrideExtractStravaArchive(imp.ID, items, nil)
+
+	actual, err := repository.NewLogger(&log, repo).ExtractStravaArchive(context.Background(), imp)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 1, len(actual), "unexpected number of extracted items")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "extracts", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "extracted 1", log.Infos[1], "unexpected info message")
+}
+
+func TestExtractStravaArchiveError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	imp := domaintest.NewImport(t).Build()
+	expectedErr := errors.New("boom")
+
+	repo.OverrideExtractStravaArchive(imp.ID, nil, expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).ExtractStravaArchive(context.Background(), imp)
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to extract", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestOpenImportItemFileSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	imp := domaintest.NewImport(t).Build()
+	item := domaintest.NewImportItem(t, imp.ID).Build()
+
+	repo.OverrideOpenImportItemFile(item.ExternalID, []byte("<gpx></gpx>"), nil)
+
+	file, err := repository.NewLogger(&log, repo).OpenImportItemFile(context.Background(), imp, item)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+	defer file.Close()
+
+	content, err := ioutil.ReadAll(file)
+	testutils.AssertNoError(t, err, "can't read file")
+	testutils.AssertEqualString(t, "<gpx></gpx>", string(content), "unexpected file content")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "opens", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, item.FileName, log.Infos[0], "unexpected file name in info message")
+	testutils.AssertContainsString(t, "opened", log.Infos[1], "unexpected info message")
+}
+
+func TestOpenImportItemFileError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	imp := domaintest.NewImport(t).Build()
+	item := domaintest.NewImportItem(t, imp.ID).Build()
+
+	repo.OverrideOpenImportItemFile(item.ExternalID, nil, domain.ErrUnsupportedActivityFormat)
+
+	_, err := repository.NewLogger(&log, repo).OpenImportItemFile(context.Background(), imp, item)
+	testutils.AssertErrorIs(t, domain.ErrUnsupportedActivityFormat, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to open", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestGetUserPreferencesSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expected := domain.UserPreferences{Locale: domain.LocaleFrench, Units: domain.UnitSystemImperial, SpeedDisplay: domain.SpeedDisplaySpeed}
+	testutils.RequireNoError(t, repo.SaveUserPreferences(context.Background(), "alice", expected), "can't save preferences")
+
+	actual, err := repository.NewLogger(&log, repo).GetUserPreferences(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualString(t, expected.Locale.String(), actual.Locale.String(), "unexpected locale")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "alice", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "found user preferences", log.Infos[1], "unexpected info message")
+}
+
+func TestGetUserPreferencesError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+
+	_, err := repository.NewLogger(&log, repo).GetUserPreferences(context.Background(), "alice")
+	testutils.AssertErrorIs(t, domain.ErrUserPreferencesNotFound, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to find", log.Infos[1], "unexpected info message")
+	testutils.AssertContainsString(t, err.Error(), log.Infos[1], "unexpected info message")
+}
+
+func TestSaveUserPreferencesSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+
+	err := repository.NewLogger(&log, repo).SaveUserPreferences(context.Background(), "alice", domain.DefaultUserPreferences())
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "saves preferences of user alice", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "saved user preferences", log.Infos[1], "unexpected info message")
+}
+
+func TestSaveUserPreferencesError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideSaveUserPreferences("alice", expectedErr)
+
+	err := repository.NewLogger(&log, repo).SaveUserPreferences(context.Background(), "alice", domain.DefaultUserPreferences())
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to save", log.Infos[1], "unexpected info message")
+}
+
+func TestRecordAPITokenSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	token := domaintest.NewAPIToken(t).Build()
+
+	err := repository.NewLogger(&log, repo).RecordAPIToken(context.Background(), token)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "records", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, token.ID.String(), log.Infos[0], "unexpected token id in info message")
+	testutils.AssertContainsString(t, "recorded", log.Infos[1], "unexpected info message")
+}
+
+func TestRecordAPITokenError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideRecordAPIToken(expectedErr)
+
+	err := repository.NewLogger(&log, repo).RecordAPIToken(context.Background(), domaintest.NewAPIToken(t).Build())
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to record", log.Infos[1], "unexpected info message")
+}
+
+func TestListAPITokensSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	domaintest.NewAPIToken(t).Persist(repo)
+	domaintest.NewAPIToken(t).Persist(repo)
+
+	tokens, err := repository.NewLogger(&log, repo).ListAPITokens(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(tokens), "unexpected number of tokens")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "alice", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "found 2", log.Infos[1], "unexpected info message")
+}
+
+func TestListAPITokensError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideListAPITokens(expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).ListAPITokens(context.Background(), "alice")
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to find", log.Infos[1], "unexpected info message")
+}
+
+func TestGetAPITokenByHashSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expected := domaintest.NewAPIToken(t).Persist(repo)
+
+	actual, err := repository.NewLogger(&log, repo).GetAPITokenByHash(context.Background(), expected.Hash)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	domaintest.AssertEqualAPIToken(t, expected, actual, "unexpected token")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertEqualBool(t, false, strings.Contains(log.Infos[0], expected.Hash.String()), "hash shouldn't be logged")
+	testutils.AssertContainsString(t, expected.ID.String(), log.Infos[1], "unexpected info message")
+}
+
+func TestGetAPITokenByHashError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+
+	_, err := repository.NewLogger(&log, repo).GetAPITokenByHash(context.Background(), domain.HashAPIToken("unknown"))
+	testutils.AssertErrorIs(t, domain.ErrAPITokenNotFound, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to find", log.Infos[1], "unexpected info message")
+}
+
+func TestTouchAPITokenSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	token := domaintest.NewAPIToken(t).Persist(repo)
+
+	err := repository.NewLogger(&log, repo).TouchAPIToken(context.Background(), token.ID, time.Now())
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, token.ID.String(), log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "marked", log.Infos[1], "unexpected info message")
+}
+
+func TestTouchAPITokenError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+
+	err := repository.NewLogger(&log, repo).TouchAPIToken(context.Background(), domain.NewID(), time.Now())
+	testutils.AssertErrorIs(t, domain.ErrAPITokenNotFound, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to mark", log.Infos[1], "unexpected info message")
+}
+
+func TestDeleteAPITokenSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	token := domaintest.NewAPIToken(t).Persist(repo)
+
+	err := repository.NewLogger(&log, repo).DeleteAPIToken(context.Background(), "alice", token.ID)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, token.ID.String(), log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "deleted", log.Infos[1], "unexpected info message")
+}
+
+func TestDeleteAPITokenError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+
+	err := repository.NewLogger(&log, repo).DeleteAPIToken(context.Background(), "alice", domain.NewID())
+	testutils.AssertErrorIs(t, domain.ErrAPITokenNotFound, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to delete", log.Infos[1], "unexpected info message")
+}
+
+func TestRecordUserSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	user := domaintest.NewUser(t).WithUsername("alice").Build()
+
+	err := repository.NewLogger(&log, repo).RecordUser(context.Background(), user)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "alice", log.Infos[0], "unexpected info message")
+	testutils.AssertEqualBool(t, false, strings.Contains(log.Infos[0], user.PasswordHash.String()), "hash shouldn't be logged")
+	testutils.AssertContainsString(t, "recorded", log.Infos[1], "unexpected info message")
+}
+
+func TestRecordUserError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideRecordUser(expectedErr)
+
+	err := repository.NewLogger(&log, repo).RecordUser(context.Background(), domaintest.NewUser(t).Build())
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to record", log.Infos[1], "unexpected info message")
+}
+
+func TestGetUserSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expected := domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	actual, err := repository.NewLogger(&log, repo).GetUser(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	domaintest.AssertEqualUser(t, expected, actual, "unexpected user")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "alice", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "found", log.Infos[1], "unexpected info message")
+}
+
+func TestGetUserError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+
+	_, err := repository.NewLogger(&log, repo).GetUser(context.Background(), "alice")
+	testutils.AssertErrorIs(t, domain.ErrUserNotFound, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to find", log.Infos[1], "unexpected info message")
+}
+
+func TestListUsersSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+	domaintest.NewUser(t).WithUsername("bob").Persist(repo)
+
+	users, err := repository.NewLogger(&log, repo).ListUsers(context.Background())
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(users), "unexpected number of users")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "found 2", log.Infos[1], "unexpected info message")
+}
+
+func TestListUsersError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideListUsers(expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).ListUsers(context.Background())
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to find", log.Infos[1], "unexpected info message")
+}
+
+func TestUpdateUserSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	user := domaintest.NewUser(t).WithUsername("alice").Persist(repo)
+
+	err := repository.NewLogger(&log, repo).UpdateUser(context.Background(), user)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "alice", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "updated", log.Infos[1], "unexpected info message")
+}
+
+func TestUpdateUserError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+
+	err := repository.NewLogger(&log, repo).UpdateUser(context.Background(), domaintest.NewUser(t).Build())
+	testutils.AssertErrorIs(t, domain.ErrUserNotFound, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to update", log.Infos[1], "unexpected info message")
+}
+
+func TestWriteGPXFileSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+
+	_, err := repository.NewLogger(&log, repo).WriteGPXFile(context.Background(), domain.GPXPoints{{Latitude: 48.8566, Longitude: 2.3522}})
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "1 points", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "wrote", log.Infos[1], "unexpected info message")
+}
+
+func TestWriteGPXFileError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideWriteGPXFile(expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).WriteGPXFile(context.Background(), nil)
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to write", log.Infos[1], "unexpected info message")
+}
+
+func TestWriteGeoJSONFileSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+
+	_, err := repository.NewLogger(&log, repo).WriteGeoJSONFile(context.Background(), domain.GPXPoints{{Latitude: 48.8566, Longitude: 2.3522}})
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "1 points", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "wrote", log.Infos[1], "unexpected info message")
+}
+
+func TestWriteGeoJSONFileError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideWriteGeoJSONFile(expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).WriteGeoJSONFile(context.Background(), nil)
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to write", log.Infos[1], "unexpected info message")
+}
+
+func TestWriteKMLFileSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+
+	_, err := repository.NewLogger(&log, repo).WriteKMLFile(context.Background(), domain.GPXPoints{{Latitude: 48.8566, Longitude: 2.3522}})
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "1 points", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "wrote", log.Infos[1], "unexpected info message")
+}
+
+func TestWriteKMLFileError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideWriteKMLFile(expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).WriteKMLFile(context.Background(), nil)
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to write", log.Infos[1], "unexpected info message")
+}
+
+func TestRecordPrivacyZoneSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	zone := domaintest.NewPrivacyZone(t).Build()
+
+	err := repository.NewLogger(&log, repo).RecordPrivacyZone(context.Background(), zone)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, zone.ID.String(), log.Infos[0], "unexpected zone id in info message")
+	testutils.AssertContainsString(t, "recorded", log.Infos[1], "unexpected info message")
+}
+
+func TestRecordPrivacyZoneError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideRecordPrivacyZone(expectedErr)
+
+	err := repository.NewLogger(&log, repo).RecordPrivacyZone(context.Background(), domaintest.NewPrivacyZone(t).Build())
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to record", log.Infos[1], "unexpected info message")
+}
+
+func TestListPrivacyZonesSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	domaintest.NewPrivacyZone(t).Persist(repo)
+	domaintest.NewPrivacyZone(t).Persist(repo)
+
+	zones, err := repository.NewLogger(&log, repo).ListPrivacyZones(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(zones), "unexpected number of zones")
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "alice", log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "found 2", log.Infos[1], "unexpected info message")
+}
+
+func TestListPrivacyZonesError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	expectedErr := errors.New("boom")
+
+	repo.OverrideListPrivacyZones(expectedErr)
+
+	_, err := repository.NewLogger(&log, repo).ListPrivacyZones(context.Background(), "alice")
+	testutils.AssertErrorIs(t, expectedErr, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to find", log.Infos[1], "unexpected info message")
+}
+
+func TestDeletePrivacyZoneSuccess(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+	zone := domaintest.NewPrivacyZone(t).Persist(repo)
+
+	err := repository.NewLogger(&log, repo).DeletePrivacyZone(context.Background(), "alice", zone.ID)
+	testutils.AssertNoError(t, err, "unexpected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, zone.ID.String(), log.Infos[0], "unexpected info message")
+	testutils.AssertContainsString(t, "deleted", log.Infos[1], "unexpected info message")
+}
+
+func TestDeletePrivacyZoneError(t *testing.T) {
+	repo := repositorytest.NewFake(t)
+	log := FakeLogger{}
+
+	err := repository.NewLogger(&log, repo).DeletePrivacyZone(context.Background(), "alice", domain.NewID())
+	testutils.AssertErrorIs(t, domain.ErrPrivacyZoneNotFound, err, "expected repository error")
+
+	testutils.AssertEqualInt(t, 2, len(log.Infos), "unexpected number of info message")
+	testutils.AssertContainsString(t, "failed to delete", log.Infos[1], "unexpected info message")
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/lonepeon/sport/internal/domain"
 )
@@ -15,14 +16,123 @@ type ReadWriter interface {
 type Reader interface {
 	GetRunningActivity(context.Context, domain.RunningActivitySlug) (domain.RunningActivity, error)
 	ListRunningActivities(context.Context) ([]domain.RunningActivity, error)
+	ListUserRunningActivities(ctx context.Context, username string) ([]domain.RunningActivity, error)
+	GetExport(context.Context, domain.ID) (domain.Export, error)
+	ListExports(ctx context.Context, username string) ([]domain.Export, error)
+	GetImport(context.Context, domain.ID) (domain.Import, error)
+	ListImports(ctx context.Context, username string) ([]domain.Import, error)
+	GetImportItem(ctx context.Context, importID domain.ID, externalID string) (domain.ImportItem, error)
+	ListImportItems(ctx context.Context, importID domain.ID) ([]domain.ImportItem, error)
+	GetUserPreferences(ctx context.Context, username string) (domain.UserPreferences, error)
+	ListAPITokens(ctx context.Context, username string) ([]domain.APIToken, error)
+	GetAPITokenByHash(context.Context, domain.APITokenHash) (domain.APIToken, error)
+	GetUser(ctx context.Context, username string) (domain.User, error)
+	ListUsers(context.Context) ([]domain.User, error)
+	ListPrivacyZones(ctx context.Context, username string) (domain.PrivacyZones, error)
+	FetchAsset(fileName string) (io.ReadCloser, error)
+}
+
+// ActivityStore represents a database persisting running activities
+type ActivityStore interface {
+	GetRunningActivity(context.Context, domain.RunningActivitySlug) (domain.RunningActivity, error)
+	ListRunningActivities(context.Context) ([]domain.RunningActivity, error)
+	ListUserRunningActivities(ctx context.Context, username string) ([]domain.RunningActivity, error)
+	DeleteRunningActivity(context.Context, domain.RunningActivitySlug) error
+	RecordRunningActivity(context.Context, domain.RunningActivity) error
+	UpdateRunningActivity(context.Context, domain.RunningActivity) error
+	AssignOrphanRunningActivities(ctx context.Context, username string) error
+}
+
+// ExportStore represents a database persisting export requests
+type ExportStore interface {
+	GetExport(context.Context, domain.ID) (domain.Export, error)
+	ListExports(ctx context.Context, username string) ([]domain.Export, error)
+	RecordExport(context.Context, domain.Export) error
+	UpdateExport(context.Context, domain.Export) error
+}
+
+// ImportStore represents a database persisting imports and the progress of their items
+type ImportStore interface {
+	GetImport(context.Context, domain.ID) (domain.Import, error)
+	ListImports(ctx context.Context, username string) ([]domain.Import, error)
+	RecordImport(context.Context, domain.Import) error
+	GetImportItem(ctx context.Context, importID domain.ID, externalID string) (domain.ImportItem, error)
+	ListImportItems(ctx context.Context, importID domain.ID) ([]domain.ImportItem, error)
+	RecordImportItems(context.Context, []domain.ImportItem) error
+	UpdateImportItem(context.Context, domain.ImportItem) error
+	AssignOrphanImports(ctx context.Context, username string) error
+}
+
+// UserPreferencesStore represents a database persisting how each user wants activities to be displayed
+type UserPreferencesStore interface {
+	GetUserPreferences(ctx context.Context, username string) (domain.UserPreferences, error)
+	SaveUserPreferences(ctx context.Context, username string, prefs domain.UserPreferences) error
+}
+
+// APITokenStore represents a database persisting the hashed personal access tokens of each user
+type APITokenStore interface {
+	RecordAPIToken(context.Context, domain.APIToken) error
+	ListAPITokens(ctx context.Context, username string) ([]domain.APIToken, error)
+	GetAPITokenByHash(context.Context, domain.APITokenHash) (domain.APIToken, error)
+	TouchAPIToken(ctx context.Context, id domain.ID, usedAt time.Time) error
+	DeleteAPIToken(ctx context.Context, username string, id domain.ID) error
+	RecordUser(context.Context, domain.User) error
+	UpdateUser(context.Context, domain.User) error
+	RecordPrivacyZone(context.Context, domain.PrivacyZone) error
+	DeletePrivacyZone(ctx context.Context, username string, id domain.ID) error
+}
+
+// UserStore represents a database persisting the accounts allowed to log in
+type UserStore interface {
+	RecordUser(context.Context, domain.User) error
+	GetUser(ctx context.Context, username string) (domain.User, error)
+	ListUsers(context.Context) ([]domain.User, error)
+	UpdateUser(context.Context, domain.User) error
+	RecordPrivacyZone(context.Context, domain.PrivacyZone) error
+	DeletePrivacyZone(ctx context.Context, username string, id domain.ID) error
+}
+
+// PrivacyZoneStore represents a database persisting the zones whose points are hidden from the activities of each user
+type PrivacyZoneStore interface {
+	RecordPrivacyZone(context.Context, domain.PrivacyZone) error
+	ListPrivacyZones(ctx context.Context, username string) (domain.PrivacyZones, error)
+	DeletePrivacyZone(ctx context.Context, username string, id domain.ID) error
+}
+
+// MapProvider represents a service drawing the static map of a track with a style
+type MapProvider interface {
+	GenerateMap(context.Context, domain.GPXFile, domain.MapStyle) (domain.MapFile, error)
 }
 
 type Writer interface {
-	AnnotateMapWithStats(context.Context, domain.MapFile, domain.Distance, domain.Speed) (domain.ShareableMapFile, error)
+	DrawCard(context.Context, domain.MapFile, domain.CardTemplate, domain.CardStats, domain.UserPreferences) (domain.ShareableMapFile, error)
+	DrawChart(context.Context, domain.Chart) (domain.ChartFile, error)
 	CleanGPXFile(context.Context, io.Reader) (domain.GPXFile, error)
-	GenerateMap(context.Context, domain.GPXFile) (domain.MapFile, error)
+	WriteGPXFile(context.Context, domain.GPXPoints) (io.Reader, error)
+	WriteGeoJSONFile(context.Context, domain.GPXPoints) (io.Reader, error)
+	WriteKMLFile(context.Context, domain.GPXPoints) (io.Reader, error)
+	GenerateMap(context.Context, domain.GPXFile, domain.MapStyle) (domain.MapFile, error)
 	DeleteRunningActivity(context.Context, domain.RunningActivitySlug) error
 	RecordRunningActivity(context.Context, domain.RunningActivity) error
+	UpdateRunningActivity(context.Context, domain.RunningActivity) error
+	AssignOrphanRunningActivities(ctx context.Context, username string) error
 	StoreAsset(content io.Reader, fileName string) error
 	DeleteAsset(fileName string) error
+	RecordExport(context.Context, domain.Export) error
+	UpdateExport(context.Context, domain.Export) error
+	BuildExportArchive(context.Context, []domain.RunningActivity, domain.UserPreferences) (domain.ExportArchive, error)
+	RecordImport(context.Context, domain.Import) error
+	RecordImportItems(context.Context, []domain.ImportItem) error
+	UpdateImportItem(context.Context, domain.ImportItem) error
+	AssignOrphanImports(ctx context.Context, username string) error
+	ExtractStravaArchive(context.Context, domain.Import) ([]domain.ImportItem, error)
+	OpenImportItemFile(context.Context, domain.Import, domain.ImportItem) (io.ReadCloser, error)
+	SaveUserPreferences(ctx context.Context, username string, prefs domain.UserPreferences) error
+	RecordAPIToken(context.Context, domain.APIToken) error
+	TouchAPIToken(ctx context.Context, id domain.ID, usedAt time.Time) error
+	DeleteAPIToken(ctx context.Context, username string, id domain.ID) error
+	RecordUser(context.Context, domain.User) error
+	UpdateUser(context.Context, domain.User) error
+	RecordPrivacyZone(context.Context, domain.PrivacyZone) error
+	DeletePrivacyZone(ctx context.Context, username string, id domain.ID) error
 }
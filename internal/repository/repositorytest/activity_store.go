@@ -0,0 +1,241 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// ActivityStoreSetup returns an empty store and a function cleaning it up
+type ActivityStoreSetup func(t *testing.T) (repository.ActivityStore, func())
+
+// RunActivityStoreSuite runs the integration tests every ActivityStore implementation must pass
+func RunActivityStoreSuite(t *testing.T, setup ActivityStoreSetup) {
+	suite := activityStoreSuite{setup: setup}
+
+	t.Run("ListRunningActivities", suite.testListRunningActivities)
+	t.Run("GetRunningActivitySuccess", suite.testGetRunningActivitySuccess)
+	t.Run("GetRunningActivityWithMapStyle", suite.testGetRunningActivityWithMapStyle)
+	t.Run("GetRunningActivityNotFound", suite.testGetRunningActivityNotFound)
+	t.Run("DeleteRunningActivitySuccess", suite.testDeleteRunningActivitySuccess)
+	t.Run("DeleteRunningActivityWhenActivityDoesNotMatch", suite.testDeleteRunningActivityWhenActivityDoesNotMatch)
+	t.Run("RecordRunningActivityAlreadyExisting", suite.testRecordRunningActivityAlreadyExisting)
+	t.Run("RecordRunningActivitySameMinute", suite.testRecordRunningActivitySameMinute)
+	t.Run("UpdateRunningActivitySuccess", suite.testUpdateRunningActivitySuccess)
+	t.Run("UpdateRunningActivityNotFound", suite.testUpdateRunningActivityNotFound)
+	t.Run("ListUserRunningActivities", suite.testListUserRunningActivities)
+	t.Run("AssignOrphanRunningActivities", suite.testAssignOrphanRunningActivities)
+}
+
+type activityStoreSuite struct {
+	setup ActivityStoreSetup
+}
+
+func (s activityStoreSuite) testGetRunningActivitySuccess(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+	expectedActivity := domaintest.NewRunningActivity(t).WithDetails("Morning run", "Along the river").WithUsername("alice").
+		WithVisibility(domain.ActivityVisibilityUnlisted).Build()
+
+	recordActivity(t, repo, expectedActivity)
+
+	actualActivity, err := repo.GetRunningActivity(context.Background(), expectedActivity.Slug)
+
+	testutils.AssertNoError(t, err, "can't get activity")
+	domaintest.AssertEqualRunningActivity(t, expectedActivity, actualActivity, "unexpected activity")
+}
+
+func (s activityStoreSuite) testGetRunningActivityWithMapStyle(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	style := domain.MapStyle{
+		Theme:         "mapbox/satellite-v9",
+		LineColor:     "00ff00",
+		LineThickness: 5,
+		LineOpacity:   0.5,
+		Width:         600,
+		Height:        400,
+		Padding:       50,
+		RouteColoring: domain.RouteColoringPlain,
+	}
+	expectedActivity := domaintest.NewRunningActivity(t).WithType(domain.ActivityTypeHike).WithMapStyle(style).Build()
+
+	recordActivity(t, repo, expectedActivity)
+
+	actualActivity, err := repo.GetRunningActivity(context.Background(), expectedActivity.Slug)
+
+	testutils.AssertNoError(t, err, "can't get activity")
+	domaintest.AssertEqualRunningActivity(t, expectedActivity, actualActivity, "unexpected activity")
+}
+
+func (s activityStoreSuite) testGetRunningActivityNotFound(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+	activity := domaintest.NewRunningActivity(t).Build()
+	recordActivity(t, repo, activity)
+
+	slug, err := domain.NewRunnningActivitySlugFromString("202101010000")
+	testutils.AssertNoError(t, err, "can't build slug")
+
+	_, err = repo.GetRunningActivity(context.Background(), slug)
+
+	testutils.AssertErrorIs(t, domain.ErrCantGetRunningSession, err, "can't get activity")
+}
+
+func (s activityStoreSuite) testListRunningActivities(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	activity1 := domaintest.NewRunningActivity(t).WithRawSlug("202101010000").Build()
+	activity2 := domaintest.NewRunningActivity(t).WithRawSlug("202303030000").Build()
+	activity3 := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").Build()
+
+	recordActivity(t, repo, activity1)
+	recordActivity(t, repo, activity2)
+	recordActivity(t, repo, activity3)
+
+	activities, err := repo.ListRunningActivities(context.Background())
+
+	testutils.AssertNoError(t, err, "can't list activities")
+	testutils.AssertEqualInt(t, 3, len(activities), "unexpected number of activities")
+
+	domaintest.AssertEqualRunningActivity(t, activity2, activities[0], "unexpected activity")
+	domaintest.AssertEqualRunningActivity(t, activity3, activities[1], "unexpected activity")
+	domaintest.AssertEqualRunningActivity(t, activity1, activities[2], "unexpected activity")
+}
+
+func (s activityStoreSuite) testDeleteRunningActivitySuccess(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+	expectedActivity := domaintest.NewRunningActivity(t).Build()
+
+	recordActivity(t, repo, expectedActivity)
+
+	err := repo.DeleteRunningActivity(context.Background(), expectedActivity.Slug)
+	testutils.AssertNoError(t, err, "can't delete activity")
+
+	_, err = repo.GetRunningActivity(context.Background(), expectedActivity.Slug)
+	testutils.AssertErrorIs(t, domain.ErrCantGetRunningSession, err, "activity should have been deleted")
+}
+
+func (s activityStoreSuite) testDeleteRunningActivityWhenActivityDoesNotMatch(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	slug, err := domain.NewRunnningActivitySlugFromString("202101010000")
+	testutils.AssertNoError(t, err, "can't build slug")
+
+	expectedActivity := domaintest.NewRunningActivity(t).WithRawSlug("202303030000").Build()
+
+	recordActivity(t, repo, expectedActivity)
+
+	err = repo.DeleteRunningActivity(context.Background(), slug)
+	testutils.AssertErrorIs(t, domain.ErrCantGetRunningSession, err, "activty should have been not found")
+}
+
+func (s activityStoreSuite) testRecordRunningActivityAlreadyExisting(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202303030000").Build()
+	recordActivity(t, repo, activity)
+
+	err := repo.RecordRunningActivity(context.Background(), activity)
+	testutils.AssertErrorIs(t, domain.ErrRunningActivityAlreadyExists, err, "shouldn't record two activities with the same slug")
+}
+
+func (s activityStoreSuite) testRecordRunningActivitySameMinute(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202303030000").Build()
+	recordActivity(t, repo, activity)
+
+	activity.RanAt = activity.RanAt.Add(30 * time.Second)
+	err := repo.RecordRunningActivity(context.Background(), activity)
+	testutils.AssertErrorIs(t, domain.ErrRunningActivityAlreadyExists, err, "shouldn't record two activities starting at the same minute")
+}
+
+func (s activityStoreSuite) testUpdateRunningActivitySuccess(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	activity := domaintest.NewRunningActivity(t).WithPendingMap("mapbox is down").Build()
+	recordActivity(t, repo, activity)
+
+	expectedActivity := activity.
+		WithDetails(domain.RunningActivityDetails{Title: "Evening run", Type: domain.ActivityTypeTrailRun}).
+		WithVisibility(domain.ActivityVisibilityPrivate).
+		WithAssetsFolder("private/0123/" + activity.RanAt.Format("2006-01-02.15h04")).
+		WithReadyMap()
+	err := repo.UpdateRunningActivity(context.Background(), expectedActivity)
+	testutils.AssertNoError(t, err, "can't update activity")
+
+	actualActivity, err := repo.GetRunningActivity(context.Background(), activity.Slug)
+	testutils.AssertNoError(t, err, "can't get activity")
+	domaintest.AssertEqualRunningActivity(t, expectedActivity, actualActivity, "unexpected activity")
+}
+
+func (s activityStoreSuite) testUpdateRunningActivityNotFound(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	activity := domaintest.NewRunningActivity(t).WithRawSlug("202303030000").Build()
+
+	err := repo.UpdateRunningActivity(context.Background(), activity)
+	testutils.AssertErrorIs(t, domain.ErrCantGetRunningSession, err, "activity shouldn't be found")
+}
+
+func (s activityStoreSuite) testListUserRunningActivities(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	activity1 := domaintest.NewRunningActivity(t).WithRawSlug("202101010000").WithUsername("alice").Build()
+	activity2 := domaintest.NewRunningActivity(t).WithRawSlug("202303030000").WithUsername("bob").Build()
+	activity3 := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithUsername("alice").Build()
+
+	recordActivity(t, repo, activity1)
+	recordActivity(t, repo, activity2)
+	recordActivity(t, repo, activity3)
+
+	activities, err := repo.ListUserRunningActivities(context.Background(), "alice")
+
+	testutils.AssertNoError(t, err, "can't list activities")
+	testutils.RequireEqualInt(t, 2, len(activities), "unexpected number of activities")
+
+	domaintest.AssertEqualRunningActivity(t, activity3, activities[0], "unexpected activity")
+	domaintest.AssertEqualRunningActivity(t, activity1, activities[1], "unexpected activity")
+}
+
+func (s activityStoreSuite) testAssignOrphanRunningActivities(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	orphan := domaintest.NewRunningActivity(t).WithRawSlug("202101010000").Build()
+	owned := domaintest.NewRunningActivity(t).WithRawSlug("202202020000").WithUsername("bob").Build()
+
+	recordActivity(t, repo, orphan)
+	recordActivity(t, repo, owned)
+
+	err := repo.AssignOrphanRunningActivities(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "can't assign activities")
+
+	actualActivity, err := repo.GetRunningActivity(context.Background(), orphan.Slug)
+	testutils.AssertNoError(t, err, "can't get orphan activity")
+	testutils.AssertEqualString(t, "alice", actualActivity.Username, "orphan activity should be assigned")
+
+	actualActivity, err = repo.GetRunningActivity(context.Background(), owned.Slug)
+	testutils.AssertNoError(t, err, "can't get owned activity")
+	testutils.AssertEqualString(t, "bob", actualActivity.Username, "owned activity shouldn't be assigned")
+}
+
+func recordActivity(t *testing.T, repo repository.ActivityStore, activity domain.RunningActivity) {
+	err := repo.RecordRunningActivity(context.Background(), activity)
+	testutils.AssertNoError(t, err, "can't record activity")
+}
@@ -0,0 +1,131 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// APITokenStoreSetup returns an empty store and a function cleaning it up
+type APITokenStoreSetup func(t *testing.T) (repository.APITokenStore, func())
+
+// RunAPITokenStoreSuite runs the integration tests every APITokenStore implementation must pass
+func RunAPITokenStoreSuite(t *testing.T, setup APITokenStoreSetup) {
+	suite := apiTokenStoreSuite{setup: setup}
+
+	t.Run("GetAPITokenByHashSuccess", suite.testGetAPITokenByHashSuccess)
+	t.Run("GetAPITokenByHashNotFound", suite.testGetAPITokenByHashNotFound)
+	t.Run("ListAPITokens", suite.testListAPITokens)
+	t.Run("TouchAPITokenSuccess", suite.testTouchAPITokenSuccess)
+	t.Run("TouchAPITokenNotFound", suite.testTouchAPITokenNotFound)
+	t.Run("DeleteAPITokenSuccess", suite.testDeleteAPITokenSuccess)
+	t.Run("DeleteAPITokenOfAnotherUser", suite.testDeleteAPITokenOfAnotherUser)
+}
+
+type apiTokenStoreSuite struct {
+	setup APITokenStoreSetup
+}
+
+func (s apiTokenStoreSuite) testGetAPITokenByHashSuccess(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	recordAPIToken(t, repo, domaintest.NewAPIToken(t).Build())
+	expected := recordAPIToken(t, repo, domaintest.NewAPIToken(t).WithLastUsedAt(time.Now().UTC().Truncate(time.Second)).Build())
+
+	actual, err := repo.GetAPITokenByHash(context.Background(), expected.Hash)
+
+	testutils.AssertNoError(t, err, "can't get api token")
+	domaintest.AssertEqualAPIToken(t, expected, actual, "unexpected api token")
+}
+
+func (s apiTokenStoreSuite) testGetAPITokenByHashNotFound(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	recordAPIToken(t, repo, domaintest.NewAPIToken(t).Build())
+
+	_, err := repo.GetAPITokenByHash(context.Background(), domain.HashAPIToken("unknown"))
+
+	testutils.AssertErrorIs(t, domain.ErrAPITokenNotFound, err, "unexpected error")
+}
+
+func (s apiTokenStoreSuite) testListAPITokens(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	token1 := recordAPIToken(t, repo, domaintest.NewAPIToken(t).WithCreatedAt(now.Add(-2*time.Hour)).Build())
+	token2 := recordAPIToken(t, repo, domaintest.NewAPIToken(t).WithCreatedAt(now).WithScope(domain.APITokenScopeWrite).Build())
+	recordAPIToken(t, repo, domaintest.NewAPIToken(t).WithUsername("bob").Build())
+
+	tokens, err := repo.ListAPITokens(context.Background(), "alice")
+
+	testutils.AssertNoError(t, err, "can't list api tokens")
+	testutils.AssertEqualInt(t, 2, len(tokens), "unexpected number of api tokens")
+
+	domaintest.AssertEqualAPIToken(t, token2, tokens[0], "unexpected api token")
+	domaintest.AssertEqualAPIToken(t, token1, tokens[1], "unexpected api token")
+}
+
+func (s apiTokenStoreSuite) testTouchAPITokenSuccess(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	expected := recordAPIToken(t, repo, domaintest.NewAPIToken(t).Build())
+	expected.LastUsedAt = time.Now().UTC().Truncate(time.Second)
+
+	err := repo.TouchAPIToken(context.Background(), expected.ID, expected.LastUsedAt)
+	testutils.AssertNoError(t, err, "can't touch api token")
+
+	actual, err := repo.GetAPITokenByHash(context.Background(), expected.Hash)
+	testutils.AssertNoError(t, err, "can't get api token")
+	domaintest.AssertEqualAPIToken(t, expected, actual, "unexpected api token")
+}
+
+func (s apiTokenStoreSuite) testTouchAPITokenNotFound(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	err := repo.TouchAPIToken(context.Background(), domain.NewID(), time.Now())
+
+	testutils.AssertErrorIs(t, domain.ErrAPITokenNotFound, err, "unexpected error")
+}
+
+func (s apiTokenStoreSuite) testDeleteAPITokenSuccess(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	token := recordAPIToken(t, repo, domaintest.NewAPIToken(t).Build())
+
+	err := repo.DeleteAPIToken(context.Background(), "alice", token.ID)
+	testutils.AssertNoError(t, err, "can't delete api token")
+
+	_, err = repo.GetAPITokenByHash(context.Background(), token.Hash)
+	testutils.AssertErrorIs(t, domain.ErrAPITokenNotFound, err, "expected api token to be deleted")
+}
+
+func (s apiTokenStoreSuite) testDeleteAPITokenOfAnotherUser(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	token := recordAPIToken(t, repo, domaintest.NewAPIToken(t).WithUsername("bob").Build())
+
+	err := repo.DeleteAPIToken(context.Background(), "alice", token.ID)
+	testutils.AssertErrorIs(t, domain.ErrAPITokenNotFound, err, "unexpected error")
+
+	_, err = repo.GetAPITokenByHash(context.Background(), token.Hash)
+	testutils.AssertNoError(t, err, "expected api token to be kept")
+}
+
+func recordAPIToken(t *testing.T, repo repository.APITokenStore, token domain.APIToken) domain.APIToken {
+	err := repo.RecordAPIToken(context.Background(), token)
+	testutils.AssertNoError(t, err, "can't record api token")
+
+	return token
+}
@@ -0,0 +1,104 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// ExportStoreSetup returns an empty store and a function cleaning it up
+type ExportStoreSetup func(t *testing.T) (repository.ExportStore, func())
+
+// RunExportStoreSuite runs the integration tests every ExportStore implementation must pass
+func RunExportStoreSuite(t *testing.T, setup ExportStoreSetup) {
+	suite := exportStoreSuite{setup: setup}
+
+	t.Run("GetExportSuccess", suite.testGetExportSuccess)
+	t.Run("GetExportNotFound", suite.testGetExportNotFound)
+	t.Run("ListExports", suite.testListExports)
+	t.Run("UpdateExportSuccess", suite.testUpdateExportSuccess)
+	t.Run("UpdateExportNotFound", suite.testUpdateExportNotFound)
+}
+
+type exportStoreSuite struct {
+	setup ExportStoreSetup
+}
+
+func (s exportStoreSuite) testGetExportSuccess(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	expected := recordExport(t, repo, domaintest.NewExport(t).WithUsername("alice").Build())
+
+	actual, err := repo.GetExport(context.Background(), expected.ID)
+
+	testutils.AssertNoError(t, err, "can't get export")
+	domaintest.AssertEqualExport(t, expected, actual, "unexpected export")
+}
+
+func (s exportStoreSuite) testGetExportNotFound(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	recordExport(t, repo, domaintest.NewExport(t).Build())
+
+	_, err := repo.GetExport(context.Background(), domain.NewID())
+
+	testutils.AssertErrorIs(t, domain.ErrExportNotFound, err, "unexpected error")
+}
+
+func (s exportStoreSuite) testListExports(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	export1 := recordExport(t, repo, domaintest.NewExport(t).WithUsername("alice").WithRequestedAt(now.Add(-2*time.Hour)).Build())
+	export2 := recordExport(t, repo, domaintest.NewExport(t).WithUsername("alice").WithRequestedAt(now).Ready().Build())
+	export3 := recordExport(t, repo, domaintest.NewExport(t).WithUsername("alice").WithRequestedAt(now.Add(-time.Hour)).Build())
+	recordExport(t, repo, domaintest.NewExport(t).WithUsername("bob").WithRequestedAt(now.Add(-30*time.Minute)).Build())
+
+	exports, err := repo.ListExports(context.Background(), "alice")
+
+	testutils.AssertNoError(t, err, "can't list exports")
+	testutils.AssertEqualInt(t, 3, len(exports), "unexpected number of exports")
+
+	domaintest.AssertEqualExport(t, export2, exports[0], "unexpected export")
+	domaintest.AssertEqualExport(t, export3, exports[1], "unexpected export")
+	domaintest.AssertEqualExport(t, export1, exports[2], "unexpected export")
+}
+
+func (s exportStoreSuite) testUpdateExportSuccess(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	export := recordExport(t, repo, domaintest.NewExport(t).Build())
+	expected := export.Complete(domain.ExportArchivePath("exports/archive.zip"), export.RequestedAt.Add(time.Minute))
+
+	err := repo.UpdateExport(context.Background(), expected)
+	testutils.AssertNoError(t, err, "can't update export")
+
+	actual, err := repo.GetExport(context.Background(), export.ID)
+	testutils.AssertNoError(t, err, "can't get export")
+	domaintest.AssertEqualExport(t, expected, actual, "unexpected export")
+}
+
+func (s exportStoreSuite) testUpdateExportNotFound(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	err := repo.UpdateExport(context.Background(), domaintest.NewExport(t).Build())
+
+	testutils.AssertErrorIs(t, domain.ErrExportNotFound, err, "unexpected error")
+}
+
+func recordExport(t *testing.T, repo repository.ExportStore, export domain.Export) domain.Export {
+	err := repo.RecordExport(context.Background(), export)
+	testutils.AssertNoError(t, err, "can't record export")
+
+	return export
+}
@@ -1,12 +1,14 @@
 package repositorytest
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/lonepeon/golib/testutils"
 	"github.com/lonepeon/sport/internal/domain"
@@ -36,11 +38,48 @@ type CleanGPXFileResponse struct {
 	Err     error
 }
 
-type AnnotateMapWithStatsErrorResponse struct {
-	Map domain.ShareableMapFile
+type CardErrorResponse struct {
+	Template string
+	Err      error
+}
+
+type ChartErrorResponse struct {
+	Kind domain.ChartKind
+	Err  error
+}
+
+type ExportErrorResponse struct {
+	ID  domain.ID
 	Err error
 }
 
+type ImportErrorResponse struct {
+	ID  domain.ID
+	Err error
+}
+
+type ImportItemErrorResponse struct {
+	ExternalID string
+	Err        error
+}
+
+type StravaArchiveResponse struct {
+	ImportID domain.ID
+	Items    []domain.ImportItem
+	Err      error
+}
+
+type ImportItemFileResponse struct {
+	ExternalID string
+	Content    []byte
+	Err        error
+}
+
+type UserPreferencesErrorResponse struct {
+	Username string
+	Err      error
+}
+
 type RunningActivity struct {
 	Activity domain.RunningActivity
 	Deleted  bool
@@ -55,33 +94,83 @@ type Asset struct {
 type Fake struct {
 	t *testing.T
 
-	runs                   []RunningActivity
-	cleanedGPXFiles        [][]byte
-	assets                 []Asset
-	generatedMaps          []domain.GPXFile
-	annotatedMapsWithStats []domain.MapFile
+	runs                []RunningActivity
+	cleanedGPXFiles     [][]byte
+	assets              []Asset
+	generatedMaps       []domain.GPXFile
+	drawnCards          []string
+	drawnCharts         []domain.Chart
+	exports             []domain.Export
+	builtExportArchives [][]domain.RunningActivity
+	imports             []domain.Import
+	importItems         []domain.ImportItem
+	userPreferences     map[string]domain.UserPreferences
+	apiTokens           []domain.APIToken
+	users               []domain.User
+	privacyZones        []domain.PrivacyZone
+	writtenGPXFiles     []domain.GPXPoints
 
 	overrideRecordActivityResponse []RunningActivityErrorResponse
 	overrideGetActivityResponse    []RunningActivityErrorResponse
 	overrideListActivitiesResponse error
 	overrideDeleteActivityResponse []RunningActivityErrorResponse
+	overrideUpdateActivityResponse []RunningActivityErrorResponse
+	overrideAssignOrphanActivities error
+	overrideFetchAssetResponse     []AssetErrorResponse
 	overrideDeleteAssetResponse    []AssetErrorResponse
 	overrideStoreAssetResponse     []AssetErrorResponse
 	overrideGenerateMap            []GenerateMapResponse
 	overrideCleanGPXFile           []CleanGPXFileResponse
-	overrideAnnotateMapWithStats   []AnnotateMapWithStatsErrorResponse
-
-	expectedCleanGPXFiles        [][]byte
-	expectedGenerateMap          []domain.GPXFile
-	expectedAnnotateMapWithStats []domain.MapFile
-	expectedStoreAssets          []string
-	expectedRecordActivities     []domain.RunningActivity
-	expectedDeletedAssets        []string
-	expectedDeletedActivities    []domain.RunningActivitySlug
+	overrideDrawCard               []CardErrorResponse
+	overrideDrawChart              []ChartErrorResponse
+	overrideGetExportResponse      []ExportErrorResponse
+	overrideListExportsResponse    error
+	overrideRecordExportResponse   error
+	overrideUpdateExportResponse   []ExportErrorResponse
+	overrideBuildExportArchive     error
+	overrideGetImportResponse      []ImportErrorResponse
+	overrideListImportsResponse    error
+	overrideRecordImportResponse   error
+	overrideGetImportItemResponse  []ImportItemErrorResponse
+	overrideListImportItems        error
+	overrideRecordImportItems      error
+	overrideUpdateImportItem       []ImportItemErrorResponse
+	overrideExtractStravaArchive   []StravaArchiveResponse
+	overrideOpenImportItemFile     []ImportItemFileResponse
+	overrideAssignOrphanImports    error
+	overrideGetUserPreferences     []UserPreferencesErrorResponse
+	overrideSaveUserPreferences    []UserPreferencesErrorResponse
+	overrideRecordAPIToken         error
+	overrideListAPITokens          error
+	overrideGetAPITokenByHash      error
+	overrideTouchAPIToken          error
+	overrideDeleteAPIToken         error
+	overrideRecordUser             error
+	overrideGetUser                error
+	overrideListUsers              error
+	overrideUpdateUser             error
+	overrideRecordPrivacyZone      error
+	overrideListPrivacyZones       error
+	overrideDeletePrivacyZone      error
+	overrideWriteGPXFile           error
+	overrideWriteGeoJSONFile       error
+	overrideWriteKMLFile           error
+
+	expectedCleanGPXFiles       [][]byte
+	expectedGenerateMap         []domain.GPXFile
+	expectedDrawCards           []string
+	expectedStoreAssets         []string
+	expectedRecordActivities    []domain.RunningActivity
+	expectedDeletedAssets       []string
+	expectedDeletedActivities   []domain.RunningActivitySlug
+	expectedExports             []domain.Export
+	expectedBuildExportArchives [][]domain.RunningActivity
+	expectedImports             []domain.Import
+	expectedImportItems         []domain.ImportItem
 }
 
 func NewFake(t *testing.T) *Fake {
-	return &Fake{t: t}
+	return &Fake{t: t, userPreferences: make(map[string]domain.UserPreferences)}
 }
 
 func (f *Fake) CleanGPXFile(ctx context.Context, r io.Reader) (domain.GPXFile, error) {
@@ -104,6 +193,61 @@ func (f *Fake) CleanGPXFile(ctx context.Context, r io.Reader) (domain.GPXFile, e
 	return domaintest.NewGPXFile(f.t).WithFileContent(content).Build(), nil
 }
 
+// WriteGPXFile returns the coordinates of the points, one per line
+func (f *Fake) WriteGPXFile(ctx context.Context, points domain.GPXPoints) (io.Reader, error) {
+	if f.overrideWriteGPXFile != nil {
+		return nil, f.overrideWriteGPXFile
+	}
+
+	f.writtenGPXFiles = append(f.writtenGPXFiles, points)
+
+	var content bytes.Buffer
+	for _, point := range points {
+		fmt.Fprintf(&content, "%f,%f\n", point.Latitude, point.Longitude)
+	}
+
+	return &content, nil
+}
+
+func (f *Fake) OverrideWriteGPXFile(err error) {
+	f.overrideWriteGPXFile = err
+}
+
+// WriteGeoJSONFile returns the coordinates of the points, one per line, prefixed by geojson
+func (f *Fake) WriteGeoJSONFile(ctx context.Context, points domain.GPXPoints) (io.Reader, error) {
+	if f.overrideWriteGeoJSONFile != nil {
+		return nil, f.overrideWriteGeoJSONFile
+	}
+
+	return writeFakeTrackFile("geojson", points), nil
+}
+
+func (f *Fake) OverrideWriteGeoJSONFile(err error) {
+	f.overrideWriteGeoJSONFile = err
+}
+
+// WriteKMLFile returns the coordinates of the points, one per line, prefixed by kml
+func (f *Fake) WriteKMLFile(ctx context.Context, points domain.GPXPoints) (io.Reader, error) {
+	if f.overrideWriteKMLFile != nil {
+		return nil, f.overrideWriteKMLFile
+	}
+
+	return writeFakeTrackFile("kml", points), nil
+}
+
+func (f *Fake) OverrideWriteKMLFile(err error) {
+	f.overrideWriteKMLFile = err
+}
+
+func writeFakeTrackFile(format string, points domain.GPXPoints) io.Reader {
+	var content bytes.Buffer
+	for _, point := range points {
+		fmt.Fprintf(&content, "%s:%f,%f\n", format, point.Latitude, point.Longitude)
+	}
+
+	return &content
+}
+
 func (f *Fake) GetRunningActivity(ctx context.Context, slug domain.RunningActivitySlug) (domain.RunningActivity, error) {
 	for _, response := range f.overrideGetActivityResponse {
 		if response.Slug == slug {
@@ -141,6 +285,36 @@ func (f *Fake) ListRunningActivities(ctx context.Context) ([]domain.RunningActiv
 	return activities, nil
 }
 
+func (f *Fake) ListUserRunningActivities(ctx context.Context, username string) ([]domain.RunningActivity, error) {
+	activities, err := f.ListRunningActivities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userActivities := make([]domain.RunningActivity, 0, len(activities))
+	for _, activity := range activities {
+		if activity.Username == username {
+			userActivities = append(userActivities, activity)
+		}
+	}
+
+	return userActivities, nil
+}
+
+func (f *Fake) AssignOrphanRunningActivities(ctx context.Context, username string) error {
+	if f.overrideAssignOrphanActivities != nil {
+		return f.overrideAssignOrphanActivities
+	}
+
+	for i := range f.runs {
+		if f.runs[i].Activity.Username == "" {
+			f.runs[i].Activity = f.runs[i].Activity.WithOwner(username)
+		}
+	}
+
+	return nil
+}
+
 func (f *Fake) DeleteRunningActivity(ctx context.Context, slug domain.RunningActivitySlug) error {
 	for _, response := range f.overrideDeleteActivityResponse {
 		if slug == response.Slug {
@@ -168,7 +342,7 @@ func (f *Fake) RecordRunningActivity(ctx context.Context, activity domain.Runnin
 
 	for _, recordedActivity := range f.runs {
 		if activity.Slug.String() == recordedActivity.Activity.Slug.String() {
-			return fmt.Errorf("activity with the same slug already exist")
+			return fmt.Errorf("can't record activity %s: %w", activity.Slug, domain.ErrRunningActivityAlreadyExists)
 		}
 	}
 
@@ -177,6 +351,40 @@ func (f *Fake) RecordRunningActivity(ctx context.Context, activity domain.Runnin
 	return nil
 }
 
+func (f *Fake) UpdateRunningActivity(ctx context.Context, activity domain.RunningActivity) error {
+	for _, response := range f.overrideUpdateActivityResponse {
+		if response.Slug == activity.Slug {
+			return response.Err
+		}
+	}
+
+	for i := range f.runs {
+		if !f.runs[i].Deleted && f.runs[i].Activity.Slug.String() == activity.Slug.String() {
+			f.runs[i].Activity = activity
+
+			return nil
+		}
+	}
+
+	return domain.ErrCantGetRunningSession
+}
+
+func (f *Fake) FetchAsset(filename string) (io.ReadCloser, error) {
+	for _, response := range f.overrideFetchAssetResponse {
+		if response.Filename == filename {
+			return nil, response.Err
+		}
+	}
+
+	for _, asset := range f.assets {
+		if asset.Filename == filename && !asset.Deleted {
+			return ioutil.NopCloser(bytes.NewReader(asset.Content)), nil
+		}
+	}
+
+	return nil, fmt.Errorf("asset %s not found", filename)
+}
+
 func (f *Fake) StoreAsset(content io.Reader, filename string) error {
 	for _, response := range f.overrideStoreAssetResponse {
 		if response.Filename == filename {
@@ -225,24 +433,42 @@ func (f *Fake) DeleteAsset(filename string) error {
 	return nil
 }
 
-func (f *Fake) AnnotateMapWithStats(ctx context.Context, mapFile domain.MapFile, distance domain.Distance, speed domain.Speed) (domain.ShareableMapFile, error) {
+func (f *Fake) DrawCard(ctx context.Context, mapFile domain.MapFile, template domain.CardTemplate, stats domain.CardStats, prefs domain.UserPreferences) (domain.ShareableMapFile, error) {
 	content, err := ioutil.ReadAll(mapFile.File())
 	testutils.AssertNoError(f.t, err, "can't read map content")
 
-	for _, response := range f.overrideAnnotateMapWithStats {
-		responseContent, err := ioutil.ReadAll(response.Map.File())
-		testutils.AssertNoError(f.t, err, "can't read override map content")
-		if string(responseContent) == string(content) {
+	for _, response := range f.overrideDrawCard {
+		if response.Template == template.Name {
 			return domain.ShareableMapFile{}, response.Err
 		}
 	}
 
-	f.annotatedMapsWithStats = append(f.annotatedMapsWithStats, mapFile)
+	f.drawnCards = append(f.drawnCards, template.Name)
+
+	return domain.NewSharableMapFile(append([]byte(template.Name+" card of "), content...)), nil
+}
+
+func (f *Fake) OverrideDrawCard(template string, err error) {
+	f.overrideDrawCard = append(f.overrideDrawCard, CardErrorResponse{Template: template, Err: err})
+}
+
+func (f *Fake) DrawChart(ctx context.Context, chart domain.Chart) (domain.ChartFile, error) {
+	for _, response := range f.overrideDrawChart {
+		if response.Kind == chart.Kind {
+			return domain.ChartFile{}, response.Err
+		}
+	}
+
+	f.drawnCharts = append(f.drawnCharts, chart)
+
+	return domain.NewChartFile([]byte(chart.Kind.String()+" png"), []byte(chart.Kind.String()+" svg")), nil
+}
 
-	return domain.NewSharableMapFile(content), nil
+func (f *Fake) OverrideDrawChart(kind domain.ChartKind, err error) {
+	f.overrideDrawChart = append(f.overrideDrawChart, ChartErrorResponse{Kind: kind, Err: err})
 }
 
-func (f *Fake) GenerateMap(ctx context.Context, gpx domain.GPXFile) (domain.MapFile, error) {
+func (f *Fake) GenerateMap(ctx context.Context, gpx domain.GPXFile, style domain.MapStyle) (domain.MapFile, error) {
 	content, err := ioutil.ReadAll(gpx.File())
 	testutils.AssertNoError(f.t, err, "can't read gpx content")
 
@@ -298,9 +524,9 @@ func (f *Fake) ExpectCleanGPXFiles(contents ...[]byte) {
 	f.expectedCleanGPXFiles = append(f.expectedCleanGPXFiles, contents...)
 }
 
-func (f *Fake) ExpectAnnotateMapsWithStats(files ...domain.MapFile) {
-	f.t.Cleanup(f.VerifyAnnotateMapsWithStats)
-	f.expectedAnnotateMapWithStats = append(f.expectedAnnotateMapWithStats, files...)
+func (f *Fake) ExpectDrawCards(templates ...string) {
+	f.t.Cleanup(f.VerifyDrawCards)
+	f.expectedDrawCards = append(f.expectedDrawCards, templates...)
 }
 
 func (f *Fake) ExpectGenerateMaps(files ...domain.GPXFile) {
@@ -398,6 +624,20 @@ func (f *Fake) OverrideRecordActivity(slug domain.RunningActivitySlug, err error
 	})
 }
 
+func (f *Fake) OverrideUpdateActivity(slug domain.RunningActivitySlug, err error) {
+	f.overrideUpdateActivityResponse = append(f.overrideUpdateActivityResponse, RunningActivityErrorResponse{
+		Slug: slug,
+		Err:  err,
+	})
+}
+
+func (f *Fake) OverrideFetchAsset(filename string, err error) {
+	f.overrideFetchAssetResponse = append(f.overrideFetchAssetResponse, AssetErrorResponse{
+		Filename: filename,
+		Err:      err,
+	})
+}
+
 func (f *Fake) OverrideGetActivity(slug domain.RunningActivitySlug, err error) {
 	f.overrideGetActivityResponse = append(f.overrideGetActivityResponse, RunningActivityErrorResponse{
 		Slug: slug,
@@ -409,6 +649,10 @@ func (f *Fake) OverrideListActivities(err error) {
 	f.overrideListActivitiesResponse = err
 }
 
+func (f *Fake) OverrideAssignOrphanActivities(err error) {
+	f.overrideAssignOrphanActivities = err
+}
+
 func (f *Fake) OverrideDeleteAsset(filename string, err error) {
 	f.overrideDeleteAssetResponse = append(f.overrideDeleteAssetResponse, AssetErrorResponse{
 		Filename: filename,
@@ -431,6 +675,16 @@ func (f *Fake) OverrideGenerateMap(gpx domain.GPXFile, mapFile domain.MapFile, e
 	})
 }
 
+// GeneratedMapPoints returns the points drawn on each generated map, in order
+func (f *Fake) GeneratedMapPoints() []domain.GPXPoints {
+	points := make([]domain.GPXPoints, len(f.generatedMaps))
+	for i, gpx := range f.generatedMaps {
+		points[i] = gpx.Points
+	}
+
+	return points
+}
+
 func (f *Fake) VerifyGenerateMaps() {
 	for _, expected := range f.expectedGenerateMap {
 		var found bool
@@ -475,27 +729,696 @@ func (f *Fake) VerifyCleanGPXFiles() {
 	}
 }
 
-func (f *Fake) VerifyAnnotateMapsWithStats() {
-	for _, expected := range f.expectedAnnotateMapWithStats {
+func (f *Fake) VerifyDrawCards() {
+	for _, expected := range f.expectedDrawCards {
 		var found bool
+		for _, actual := range f.drawnCards {
+			if expected == actual {
+				found = true
+				break
+			}
+		}
 
-		expectedContent, err := ioutil.ReadAll(expected.File())
-		testutils.AssertNoError(f.t, err, "unexpected error while reading expected map file")
+		if !found {
+			testutils.AssertEqualBool(f.t, true, false, "expecting card %s to have been drawn", expected)
+		}
+	}
+}
 
-		for _, actual := range f.annotatedMapsWithStats {
-			actualContent, err := ioutil.ReadAll(actual.File())
-			testutils.AssertNoError(f.t, err, "unexpected error while reading actual map file")
+func (f *Fake) GetExport(ctx context.Context, id domain.ID) (domain.Export, error) {
+	for _, response := range f.overrideGetExportResponse {
+		if response.ID == id {
+			return domain.Export{}, response.Err
+		}
+	}
 
-			if string(expectedContent) != string(actualContent) {
+	for _, export := range f.exports {
+		if export.ID == id {
+			return export, nil
+		}
+	}
+
+	return domain.Export{}, domain.ErrExportNotFound
+}
+
+func (f *Fake) ListExports(ctx context.Context, username string) ([]domain.Export, error) {
+	if f.overrideListExportsResponse != nil {
+		return nil, f.overrideListExportsResponse
+	}
+
+	var exports []domain.Export
+	for _, export := range f.exports {
+		if export.Username == username {
+			exports = append(exports, export)
+		}
+	}
+
+	sort.Slice(exports, func(i int, j int) bool {
+		return exports[i].RequestedAt.After(exports[j].RequestedAt)
+	})
+
+	return exports, nil
+}
+
+func (f *Fake) RecordExport(ctx context.Context, export domain.Export) error {
+	if f.overrideRecordExportResponse != nil {
+		return f.overrideRecordExportResponse
+	}
+
+	f.exports = append(f.exports, export)
+
+	return nil
+}
+
+func (f *Fake) UpdateExport(ctx context.Context, export domain.Export) error {
+	for _, response := range f.overrideUpdateExportResponse {
+		if response.ID == export.ID {
+			return response.Err
+		}
+	}
+
+	for i := range f.exports {
+		if f.exports[i].ID == export.ID {
+			f.exports[i] = export
+			return nil
+		}
+	}
+
+	return domain.ErrExportNotFound
+}
+
+func (f *Fake) BuildExportArchive(ctx context.Context, activities []domain.RunningActivity, prefs domain.UserPreferences) (domain.ExportArchive, error) {
+	if f.overrideBuildExportArchive != nil {
+		return domain.ExportArchive{}, f.overrideBuildExportArchive
+	}
+
+	f.builtExportArchives = append(f.builtExportArchives, activities)
+
+	var content bytes.Buffer
+	for _, activity := range activities {
+		fmt.Fprintln(&content, activity.Slug)
+	}
+
+	return domain.NewExportArchive(io.NopCloser(&content)), nil
+}
+
+func (f *Fake) OverrideGetExport(id domain.ID, err error) {
+	f.overrideGetExportResponse = append(f.overrideGetExportResponse, ExportErrorResponse{
+		ID:  id,
+		Err: err,
+	})
+}
+
+func (f *Fake) OverrideListExports(err error) {
+	f.overrideListExportsResponse = err
+}
+
+func (f *Fake) OverrideRecordExport(err error) {
+	f.overrideRecordExportResponse = err
+}
+
+func (f *Fake) OverrideUpdateExport(id domain.ID, err error) {
+	f.overrideUpdateExportResponse = append(f.overrideUpdateExportResponse, ExportErrorResponse{
+		ID:  id,
+		Err: err,
+	})
+}
+
+func (f *Fake) OverrideBuildExportArchive(err error) {
+	f.overrideBuildExportArchive = err
+}
+
+func (f *Fake) ExpectExports(exports ...domain.Export) {
+	f.t.Cleanup(f.VerifyExports)
+	f.expectedExports = append(f.expectedExports, exports...)
+}
+
+func (f *Fake) ExpectBuildExportArchives(activities ...[]domain.RunningActivity) {
+	f.t.Cleanup(f.VerifyBuildExportArchives)
+	f.expectedBuildExportArchives = append(f.expectedBuildExportArchives, activities...)
+}
+
+func (f *Fake) VerifyExports() {
+	for _, expected := range f.expectedExports {
+		var found bool
+
+		for _, export := range f.exports {
+			if expected.ID != export.ID {
 				continue
 			}
 
 			found = true
-			break
+			domaintest.AssertEqualExport(f.t, expected, export, "invalid recorded export")
 		}
 
 		if !found {
-			testutils.AssertEqualBool(f.t, true, false, "expecting annotated map to have been generated from map %#+v", string(expectedContent))
+			testutils.AssertEqualBool(f.t, true, false, "expecting export %s to be recorded", expected.ID)
+		}
+	}
+}
+
+func (f *Fake) VerifyBuildExportArchives() {
+	testutils.AssertEqualInt(f.t, len(f.expectedBuildExportArchives), len(f.builtExportArchives), "unexpected number of built export archives")
+
+	for i, expected := range f.expectedBuildExportArchives {
+		if i >= len(f.builtExportArchives) {
+			return
+		}
+
+		actual := f.builtExportArchives[i]
+		testutils.AssertEqualInt(f.t, len(expected), len(actual), "unexpected number of activities in export archive %d", i)
+		for j := 0; j < len(expected) && j < len(actual); j++ {
+			domaintest.AssertEqualRunningActivity(f.t, expected[j], actual[j], "unexpected activity in export archive %d", i)
+		}
+	}
+}
+
+func (f *Fake) GetImport(ctx context.Context, id domain.ID) (domain.Import, error) {
+	for _, response := range f.overrideGetImportResponse {
+		if response.ID == id {
+			return domain.Import{}, response.Err
+		}
+	}
+
+	for _, imp := range f.imports {
+		if imp.ID == id {
+			return f.withImportProgress(imp), nil
+		}
+	}
+
+	return domain.Import{}, domain.ErrImportNotFound
+}
+
+func (f *Fake) ListImports(ctx context.Context, username string) ([]domain.Import, error) {
+	if f.overrideListImportsResponse != nil {
+		return nil, f.overrideListImportsResponse
+	}
+
+	imports := make([]domain.Import, 0, len(f.imports))
+	for _, imp := range f.imports {
+		if imp.Username == username {
+			imports = append(imports, f.withImportProgress(imp))
+		}
+	}
+
+	sort.Slice(imports, func(i int, j int) bool {
+		return imports[i].CreatedAt.After(imports[j].CreatedAt)
+	})
+
+	return imports, nil
+}
+
+func (f *Fake) RecordImport(ctx context.Context, imp domain.Import) error {
+	if f.overrideRecordImportResponse != nil {
+		return f.overrideRecordImportResponse
+	}
+
+	f.imports = append(f.imports, imp)
+
+	return nil
+}
+
+func (f *Fake) GetImportItem(ctx context.Context, importID domain.ID, externalID string) (domain.ImportItem, error) {
+	for _, response := range f.overrideGetImportItemResponse {
+		if response.ExternalID == externalID {
+			return domain.ImportItem{}, response.Err
 		}
 	}
+
+	for _, item := range f.importItems {
+		if item.ImportID == importID && item.ExternalID == externalID {
+			return item, nil
+		}
+	}
+
+	return domain.ImportItem{}, domain.ErrImportNotFound
+}
+
+func (f *Fake) ListImportItems(ctx context.Context, importID domain.ID) ([]domain.ImportItem, error) {
+	if f.overrideListImportItems != nil {
+		return nil, f.overrideListImportItems
+	}
+
+	var items []domain.ImportItem
+	for _, item := range f.importItems {
+		if item.ImportID == importID {
+			items = append(items, item)
+		}
+	}
+
+	sort.Slice(items, func(i int, j int) bool {
+		return items[i].RanAt.Before(items[j].RanAt)
+	})
+
+	return items, nil
+}
+
+func (f *Fake) RecordImportItems(ctx context.Context, items []domain.ImportItem) error {
+	if f.overrideRecordImportItems != nil {
+		return f.overrideRecordImportItems
+	}
+
+	for _, item := range items {
+		if _, err := f.GetImportItem(ctx, item.ImportID, item.ExternalID); err == nil {
+			continue
+		}
+
+		f.importItems = append(f.importItems, item)
+	}
+
+	return nil
+}
+
+func (f *Fake) UpdateImportItem(ctx context.Context, item domain.ImportItem) error {
+	for _, response := range f.overrideUpdateImportItem {
+		if response.ExternalID == item.ExternalID {
+			return response.Err
+		}
+	}
+
+	for i := range f.importItems {
+		if f.importItems[i].ImportID == item.ImportID && f.importItems[i].ExternalID == item.ExternalID {
+			f.importItems[i] = item
+			return nil
+		}
+	}
+
+	return domain.ErrImportNotFound
+}
+
+func (f *Fake) AssignOrphanImports(ctx context.Context, username string) error {
+	if f.overrideAssignOrphanImports != nil {
+		return f.overrideAssignOrphanImports
+	}
+
+	for i := range f.imports {
+		if f.imports[i].Username == "" {
+			f.imports[i].Username = username
+		}
+	}
+
+	return nil
+}
+
+func (f *Fake) ExtractStravaArchive(ctx context.Context, imp domain.Import) ([]domain.ImportItem, error) {
+	for _, response := range f.overrideExtractStravaArchive {
+		if response.ImportID == imp.ID {
+			return response.Items, response.Err
+		}
+	}
+
+	return nil, fmt.Errorf("no strava archive configured for import %s", imp.ID)
+}
+
+func (f *Fake) OpenImportItemFile(ctx context.Context, imp domain.Import, item domain.ImportItem) (io.ReadCloser, error) {
+	for _, response := range f.overrideOpenImportItemFile {
+		if response.ExternalID == item.ExternalID {
+			if response.Err != nil {
+				return nil, response.Err
+			}
+
+			return io.NopCloser(bytes.NewReader(response.Content)), nil
+		}
+	}
+
+	return io.NopCloser(bytes.NewBufferString(item.ExternalID)), nil
+}
+
+func (f *Fake) OverrideGetImport(id domain.ID, err error) {
+	f.overrideGetImportResponse = append(f.overrideGetImportResponse, ImportErrorResponse{
+		ID:  id,
+		Err: err,
+	})
+}
+
+func (f *Fake) OverrideListImports(err error) {
+	f.overrideListImportsResponse = err
+}
+
+func (f *Fake) OverrideRecordImport(err error) {
+	f.overrideRecordImportResponse = err
+}
+
+func (f *Fake) OverrideAssignOrphanImports(err error) {
+	f.overrideAssignOrphanImports = err
+}
+
+func (f *Fake) OverrideGetImportItem(externalID string, err error) {
+	f.overrideGetImportItemResponse = append(f.overrideGetImportItemResponse, ImportItemErrorResponse{
+		ExternalID: externalID,
+		Err:        err,
+	})
+}
+
+func (f *Fake) OverrideListImportItems(err error) {
+	f.overrideListImportItems = err
+}
+
+func (f *Fake) OverrideRecordImportItems(err error) {
+	f.overrideRecordImportItems = err
+}
+
+func (f *Fake) OverrideUpdateImportItem(externalID string, err error) {
+	f.overrideUpdateImportItem = append(f.overrideUpdateImportItem, ImportItemErrorResponse{
+		ExternalID: externalID,
+		Err:        err,
+	})
+}
+
+func (f *Fake) OverrideExtractStravaArchive(importID domain.ID, items []domain.ImportItem, err error) {
+	f.overrideExtractStravaArchive = append(f.overrideExtractStravaArchive, StravaArchiveResponse{
+		ImportID: importID,
+		Items:    items,
+		Err:      err,
+	})
+}
+
+func (f *Fake) OverrideOpenImportItemFile(externalID string, content []byte, err error) {
+	f.overrideOpenImportItemFile = append(f.overrideOpenImportItemFile, ImportItemFileResponse{
+		ExternalID: externalID,
+		Content:    content,
+		Err:        err,
+	})
+}
+
+func (f *Fake) ExpectImports(imports ...domain.Import) {
+	f.t.Cleanup(f.VerifyImports)
+	f.expectedImports = append(f.expectedImports, imports...)
+}
+
+func (f *Fake) ExpectImportItems(items ...domain.ImportItem) {
+	f.t.Cleanup(f.VerifyImportItems)
+	f.expectedImportItems = append(f.expectedImportItems, items...)
+}
+
+func (f *Fake) VerifyImports() {
+	for _, expected := range f.expectedImports {
+		var found bool
+
+		for _, imp := range f.imports {
+			if expected.ID != imp.ID {
+				continue
+			}
+
+			found = true
+			domaintest.AssertEqualImport(f.t, expected, f.withImportProgress(imp), "invalid recorded import")
+		}
+
+		if !found {
+			testutils.AssertEqualBool(f.t, true, false, "expecting import %s to be recorded", expected.ID)
+		}
+	}
+}
+
+func (f *Fake) VerifyImportItems() {
+	for _, expected := range f.expectedImportItems {
+		var found bool
+
+		for _, item := range f.importItems {
+			if expected.ImportID != item.ImportID || expected.ExternalID != item.ExternalID {
+				continue
+			}
+
+			found = true
+			domaintest.AssertEqualImportItem(f.t, expected, item, "invalid recorded import item")
+		}
+
+		if !found {
+			testutils.AssertEqualBool(f.t, true, false, "expecting import item %s to be recorded", expected.ExternalID)
+		}
+	}
+}
+
+func (f *Fake) withImportProgress(imp domain.Import) domain.Import {
+	imp.Progress = domain.ImportProgress{}
+	for _, item := range f.importItems {
+		if item.ImportID != imp.ID {
+			continue
+		}
+
+		switch item.Status {
+		case domain.ImportItemStatusPending:
+			imp.Progress.Pending++
+		case domain.ImportItemStatusImported:
+			imp.Progress.Imported++
+		case domain.ImportItemStatusSkipped:
+			imp.Progress.Skipped++
+		case domain.ImportItemStatusFailed:
+			imp.Progress.Failed++
+		}
+	}
+
+	return imp
+}
+
+func (f *Fake) GetUserPreferences(ctx context.Context, username string) (domain.UserPreferences, error) {
+	for _, response := range f.overrideGetUserPreferences {
+		if response.Username == username {
+			return domain.UserPreferences{}, response.Err
+		}
+	}
+
+	prefs, ok := f.userPreferences[username]
+	if !ok {
+		return domain.UserPreferences{}, domain.ErrUserPreferencesNotFound
+	}
+
+	return prefs, nil
+}
+
+func (f *Fake) SaveUserPreferences(ctx context.Context, username string, prefs domain.UserPreferences) error {
+	for _, response := range f.overrideSaveUserPreferences {
+		if response.Username == username {
+			return response.Err
+		}
+	}
+
+	f.userPreferences[username] = prefs
+
+	return nil
+}
+
+func (f *Fake) OverrideGetUserPreferences(username string, err error) {
+	f.overrideGetUserPreferences = append(f.overrideGetUserPreferences, UserPreferencesErrorResponse{Username: username, Err: err})
+}
+
+func (f *Fake) OverrideSaveUserPreferences(username string, err error) {
+	f.overrideSaveUserPreferences = append(f.overrideSaveUserPreferences, UserPreferencesErrorResponse{Username: username, Err: err})
+}
+
+func (f *Fake) RecordAPIToken(ctx context.Context, token domain.APIToken) error {
+	if f.overrideRecordAPIToken != nil {
+		return f.overrideRecordAPIToken
+	}
+
+	f.apiTokens = append(f.apiTokens, token)
+
+	return nil
+}
+
+func (f *Fake) ListAPITokens(ctx context.Context, username string) ([]domain.APIToken, error) {
+	if f.overrideListAPITokens != nil {
+		return nil, f.overrideListAPITokens
+	}
+
+	var tokens []domain.APIToken
+	for _, token := range f.apiTokens {
+		if token.Username == username {
+			tokens = append(tokens, token)
+		}
+	}
+
+	sort.Slice(tokens, func(i int, j int) bool {
+		return tokens[i].CreatedAt.After(tokens[j].CreatedAt)
+	})
+
+	return tokens, nil
+}
+
+func (f *Fake) GetAPITokenByHash(ctx context.Context, hash domain.APITokenHash) (domain.APIToken, error) {
+	if f.overrideGetAPITokenByHash != nil {
+		return domain.APIToken{}, f.overrideGetAPITokenByHash
+	}
+
+	for _, token := range f.apiTokens {
+		if token.Hash == hash {
+			return token, nil
+		}
+	}
+
+	return domain.APIToken{}, domain.ErrAPITokenNotFound
+}
+
+func (f *Fake) TouchAPIToken(ctx context.Context, id domain.ID, usedAt time.Time) error {
+	if f.overrideTouchAPIToken != nil {
+		return f.overrideTouchAPIToken
+	}
+
+	for i := range f.apiTokens {
+		if f.apiTokens[i].ID == id {
+			f.apiTokens[i].LastUsedAt = usedAt
+			return nil
+		}
+	}
+
+	return domain.ErrAPITokenNotFound
+}
+
+func (f *Fake) DeleteAPIToken(ctx context.Context, username string, id domain.ID) error {
+	if f.overrideDeleteAPIToken != nil {
+		return f.overrideDeleteAPIToken
+	}
+
+	for i, token := range f.apiTokens {
+		if token.ID == id && token.Username == username {
+			f.apiTokens = append(f.apiTokens[:i], f.apiTokens[i+1:]...)
+			return nil
+		}
+	}
+
+	return domain.ErrAPITokenNotFound
+}
+
+func (f *Fake) OverrideRecordAPIToken(err error) {
+	f.overrideRecordAPIToken = err
+}
+
+func (f *Fake) OverrideListAPITokens(err error) {
+	f.overrideListAPITokens = err
+}
+
+func (f *Fake) OverrideGetAPITokenByHash(err error) {
+	f.overrideGetAPITokenByHash = err
+}
+
+func (f *Fake) OverrideTouchAPIToken(err error) {
+	f.overrideTouchAPIToken = err
+}
+
+func (f *Fake) OverrideDeleteAPIToken(err error) {
+	f.overrideDeleteAPIToken = err
+}
+
+func (f *Fake) RecordUser(ctx context.Context, user domain.User) error {
+	if f.overrideRecordUser != nil {
+		return f.overrideRecordUser
+	}
+
+	f.users = append(f.users, user)
+
+	return nil
+}
+
+func (f *Fake) GetUser(ctx context.Context, username string) (domain.User, error) {
+	if f.overrideGetUser != nil {
+		return domain.User{}, f.overrideGetUser
+	}
+
+	for _, user := range f.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+
+	return domain.User{}, domain.ErrUserNotFound
+}
+
+func (f *Fake) ListUsers(ctx context.Context) ([]domain.User, error) {
+	if f.overrideListUsers != nil {
+		return nil, f.overrideListUsers
+	}
+
+	users := append([]domain.User(nil), f.users...)
+	sort.Slice(users, func(i int, j int) bool {
+		return users[i].Username < users[j].Username
+	})
+
+	return users, nil
+}
+
+func (f *Fake) UpdateUser(ctx context.Context, user domain.User) error {
+	if f.overrideUpdateUser != nil {
+		return f.overrideUpdateUser
+	}
+
+	for i := range f.users {
+		if f.users[i].Username == user.Username {
+			f.users[i] = user
+			return nil
+		}
+	}
+
+	return domain.ErrUserNotFound
+}
+
+func (f *Fake) OverrideRecordUser(err error) {
+	f.overrideRecordUser = err
+}
+
+func (f *Fake) OverrideGetUser(err error) {
+	f.overrideGetUser = err
+}
+
+func (f *Fake) OverrideListUsers(err error) {
+	f.overrideListUsers = err
+}
+
+func (f *Fake) OverrideUpdateUser(err error) {
+	f.overrideUpdateUser = err
+}
+
+func (f *Fake) RecordPrivacyZone(ctx context.Context, zone domain.PrivacyZone) error {
+	if f.overrideRecordPrivacyZone != nil {
+		return f.overrideRecordPrivacyZone
+	}
+
+	f.privacyZones = append(f.privacyZones, zone)
+
+	return nil
+}
+
+func (f *Fake) ListPrivacyZones(ctx context.Context, username string) (domain.PrivacyZones, error) {
+	if f.overrideListPrivacyZones != nil {
+		return nil, f.overrideListPrivacyZones
+	}
+
+	var zones domain.PrivacyZones
+	for _, zone := range f.privacyZones {
+		if zone.Username == username {
+			zones = append(zones, zone)
+		}
+	}
+
+	sort.Slice(zones, func(i int, j int) bool {
+		return zones[i].CreatedAt.After(zones[j].CreatedAt)
+	})
+
+	return zones, nil
+}
+
+func (f *Fake) DeletePrivacyZone(ctx context.Context, username string, id domain.ID) error {
+	if f.overrideDeletePrivacyZone != nil {
+		return f.overrideDeletePrivacyZone
+	}
+
+	for i, zone := range f.privacyZones {
+		if zone.ID == id && zone.Username == username {
+			f.privacyZones = append(f.privacyZones[:i], f.privacyZones[i+1:]...)
+			return nil
+		}
+	}
+
+	return domain.ErrPrivacyZoneNotFound
+}
+
+func (f *Fake) OverrideRecordPrivacyZone(err error) {
+	f.overrideRecordPrivacyZone = err
+}
+
+func (f *Fake) OverrideListPrivacyZones(err error) {
+	f.overrideListPrivacyZones = err
+}
+
+func (f *Fake) OverrideDeletePrivacyZone(err error) {
+	f.overrideDeletePrivacyZone = err
 }
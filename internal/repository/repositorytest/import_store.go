@@ -0,0 +1,196 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// ImportStoreSetup returns an empty store and a function cleaning it up
+type ImportStoreSetup func(t *testing.T) (repository.ImportStore, func())
+
+// RunImportStoreSuite runs the integration tests every ImportStore implementation must pass
+func RunImportStoreSuite(t *testing.T, setup ImportStoreSetup) {
+	suite := importStoreSuite{setup: setup}
+
+	t.Run("GetImportSuccess", suite.testGetImportSuccess)
+	t.Run("GetImportNotFound", suite.testGetImportNotFound)
+	t.Run("ListImports", suite.testListImports)
+	t.Run("GetImportItemNotFound", suite.testGetImportItemNotFound)
+	t.Run("ListImportItems", suite.testListImportItems)
+	t.Run("RecordImportItemsAlreadyExisting", suite.testRecordImportItemsAlreadyExisting)
+	t.Run("UpdateImportItemSuccess", suite.testUpdateImportItemSuccess)
+	t.Run("UpdateImportItemNotFound", suite.testUpdateImportItemNotFound)
+	t.Run("AssignOrphanImports", suite.testAssignOrphanImports)
+}
+
+type importStoreSuite struct {
+	setup ImportStoreSetup
+}
+
+func (s importStoreSuite) testGetImportSuccess(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	expected := recordImport(t, repo, domaintest.NewImport(t).WithUsername("alice").Build())
+	recordImportItems(t, repo,
+		domaintest.NewImportItem(t, expected.ID).Build(),
+		domaintest.NewImportItem(t, expected.ID).WithStatus(domain.ImportItemStatusImported).Build(),
+		domaintest.NewImportItem(t, expected.ID).WithStatus(domain.ImportItemStatusImported).Build(),
+		domaintest.NewImportItem(t, expected.ID).WithStatus(domain.ImportItemStatusSkipped).Build(),
+	)
+	expected.Progress = domain.ImportProgress{Pending: 1, Imported: 2, Skipped: 1}
+
+	actual, err := repo.GetImport(context.Background(), expected.ID)
+
+	testutils.AssertNoError(t, err, "can't get import")
+	domaintest.AssertEqualImport(t, expected, actual, "unexpected import")
+}
+
+func (s importStoreSuite) testGetImportNotFound(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	recordImport(t, repo, domaintest.NewImport(t).Build())
+
+	_, err := repo.GetImport(context.Background(), domain.NewID())
+
+	testutils.AssertErrorIs(t, domain.ErrImportNotFound, err, "unexpected error")
+}
+
+func (s importStoreSuite) testListImports(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	import1 := recordImport(t, repo, domaintest.NewImport(t).WithUsername("alice").WithCreatedAt(now.Add(-time.Hour)).Build())
+	import2 := recordImport(t, repo, domaintest.NewImport(t).WithUsername("alice").WithCreatedAt(now).Build())
+	recordImport(t, repo, domaintest.NewImport(t).WithUsername("bob").WithCreatedAt(now.Add(-time.Minute)).Build())
+	recordImportItems(t, repo, domaintest.NewImportItem(t, import2.ID).WithStatus(domain.ImportItemStatusFailed).Build())
+	import2.Progress = domain.ImportProgress{Failed: 1}
+
+	imports, err := repo.ListImports(context.Background(), "alice")
+
+	testutils.AssertNoError(t, err, "can't list imports")
+	testutils.AssertEqualInt(t, 2, len(imports), "unexpected number of imports")
+
+	domaintest.AssertEqualImport(t, import2, imports[0], "unexpected import")
+	domaintest.AssertEqualImport(t, import1, imports[1], "unexpected import")
+}
+
+func (s importStoreSuite) testGetImportItemNotFound(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	imp := recordImport(t, repo, domaintest.NewImport(t).Build())
+	recordImportItems(t, repo, domaintest.NewImportItem(t, imp.ID).WithExternalID("1").Build())
+
+	_, err := repo.GetImportItem(context.Background(), imp.ID, "2")
+
+	testutils.AssertErrorIs(t, domain.ErrImportNotFound, err, "unexpected error")
+}
+
+func (s importStoreSuite) testListImportItems(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	imp := recordImport(t, repo, domaintest.NewImport(t).Build())
+	other := recordImport(t, repo, domaintest.NewImport(t).Build())
+	item1 := domaintest.NewImportItem(t, imp.ID).WithRawRanAt("2022-03-03T08:00:00Z").Build()
+	item2 := domaintest.NewImportItem(t, imp.ID).WithRawRanAt("2022-01-01T08:00:00Z").Build()
+	recordImportItems(t, repo, item1, item2, domaintest.NewImportItem(t, other.ID).Build())
+
+	items, err := repo.ListImportItems(context.Background(), imp.ID)
+
+	testutils.AssertNoError(t, err, "can't list import items")
+	testutils.AssertEqualInt(t, 2, len(items), "unexpected number of import items")
+
+	domaintest.AssertEqualImportItem(t, item2, items[0], "unexpected import item")
+	domaintest.AssertEqualImportItem(t, item1, items[1], "unexpected import item")
+}
+
+func (s importStoreSuite) testRecordImportItemsAlreadyExisting(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	imp := recordImport(t, repo, domaintest.NewImport(t).Build())
+	expected := domaintest.NewImportItem(t, imp.ID).WithExternalID("1").WithStatus(domain.ImportItemStatusImported).Build()
+	recordImportItems(t, repo, expected)
+
+	recordImportItems(t, repo,
+		domaintest.NewImportItem(t, imp.ID).WithExternalID("1").Build(),
+		domaintest.NewImportItem(t, imp.ID).WithExternalID("2").Build(),
+	)
+
+	actual, err := repo.GetImportItem(context.Background(), imp.ID, "1")
+	testutils.AssertNoError(t, err, "can't get import item")
+	domaintest.AssertEqualImportItem(t, expected, actual, "existing item shouldn't have been replaced")
+
+	items, err := repo.ListImportItems(context.Background(), imp.ID)
+	testutils.AssertNoError(t, err, "can't list import items")
+	testutils.AssertEqualInt(t, 2, len(items), "unexpected number of import items")
+}
+
+func (s importStoreSuite) testUpdateImportItemSuccess(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	imp := recordImport(t, repo, domaintest.NewImport(t).Build())
+	item := domaintest.NewImportItem(t, imp.ID).Build()
+	recordImportItems(t, repo, item)
+	expected := item.Fail("can't parse file")
+
+	err := repo.UpdateImportItem(context.Background(), expected)
+	testutils.AssertNoError(t, err, "can't update import item")
+
+	actual, err := repo.GetImportItem(context.Background(), imp.ID, item.ExternalID)
+	testutils.AssertNoError(t, err, "can't get import item")
+	domaintest.AssertEqualImportItem(t, expected, actual, "unexpected import item")
+}
+
+func (s importStoreSuite) testUpdateImportItemNotFound(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	imp := recordImport(t, repo, domaintest.NewImport(t).Build())
+
+	err := repo.UpdateImportItem(context.Background(), domaintest.NewImportItem(t, imp.ID).Build())
+
+	testutils.AssertErrorIs(t, domain.ErrImportNotFound, err, "unexpected error")
+}
+
+func recordImport(t *testing.T, repo repository.ImportStore, imp domain.Import) domain.Import {
+	err := repo.RecordImport(context.Background(), imp)
+	testutils.AssertNoError(t, err, "can't record import")
+
+	return imp
+}
+
+func recordImportItems(t *testing.T, repo repository.ImportStore, items ...domain.ImportItem) {
+	err := repo.RecordImportItems(context.Background(), items)
+	testutils.AssertNoError(t, err, "can't record import items")
+}
+
+func (s importStoreSuite) testAssignOrphanImports(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	orphan := recordImport(t, repo, domaintest.NewImport(t).Build())
+	owned := recordImport(t, repo, domaintest.NewImport(t).WithUsername("bob").Build())
+
+	err := repo.AssignOrphanImports(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "can't assign imports")
+
+	actual, err := repo.GetImport(context.Background(), orphan.ID)
+	testutils.AssertNoError(t, err, "can't get orphan import")
+	testutils.AssertEqualString(t, "alice", actual.Username, "orphan import should be assigned")
+
+	actual, err = repo.GetImport(context.Background(), owned.ID)
+	testutils.AssertNoError(t, err, "can't get owned import")
+	testutils.AssertEqualString(t, "bob", actual.Username, "owned import shouldn't be assigned")
+}
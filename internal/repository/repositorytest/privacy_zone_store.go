@@ -0,0 +1,94 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// PrivacyZoneStoreSetup returns an empty store and a function cleaning it up
+type PrivacyZoneStoreSetup func(t *testing.T) (repository.PrivacyZoneStore, func())
+
+// RunPrivacyZoneStoreSuite runs the integration tests every PrivacyZoneStore implementation must pass
+func RunPrivacyZoneStoreSuite(t *testing.T, setup PrivacyZoneStoreSetup) {
+	suite := privacyZoneStoreSuite{setup: setup}
+
+	t.Run("ListPrivacyZones", suite.testListPrivacyZones)
+	t.Run("ListPrivacyZonesEmpty", suite.testListPrivacyZonesEmpty)
+	t.Run("DeletePrivacyZoneSuccess", suite.testDeletePrivacyZoneSuccess)
+	t.Run("DeletePrivacyZoneOfAnotherUser", suite.testDeletePrivacyZoneOfAnotherUser)
+}
+
+type privacyZoneStoreSuite struct {
+	setup PrivacyZoneStoreSetup
+}
+
+func (s privacyZoneStoreSuite) testListPrivacyZones(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	zone1 := recordPrivacyZone(t, repo, domaintest.NewPrivacyZone(t).WithCreatedAt(now.Add(-2*time.Hour)).Build())
+	zone2 := recordPrivacyZone(t, repo, domaintest.NewPrivacyZone(t).WithCreatedAt(now).WithCentre(48.8566, 2.3522).WithRadius(1500).Build())
+	recordPrivacyZone(t, repo, domaintest.NewPrivacyZone(t).WithUsername("bob").Build())
+
+	zones, err := repo.ListPrivacyZones(context.Background(), "alice")
+
+	testutils.AssertNoError(t, err, "can't list privacy zones")
+	testutils.RequireEqualInt(t, 2, len(zones), "unexpected number of privacy zones")
+
+	domaintest.AssertEqualPrivacyZone(t, zone2, zones[0], "unexpected privacy zone")
+	domaintest.AssertEqualPrivacyZone(t, zone1, zones[1], "unexpected privacy zone")
+}
+
+func (s privacyZoneStoreSuite) testListPrivacyZonesEmpty(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	recordPrivacyZone(t, repo, domaintest.NewPrivacyZone(t).WithUsername("bob").Build())
+
+	zones, err := repo.ListPrivacyZones(context.Background(), "alice")
+
+	testutils.AssertNoError(t, err, "can't list privacy zones")
+	testutils.AssertEqualInt(t, 0, len(zones), "unexpected number of privacy zones")
+}
+
+func (s privacyZoneStoreSuite) testDeletePrivacyZoneSuccess(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	zone := recordPrivacyZone(t, repo, domaintest.NewPrivacyZone(t).Build())
+
+	err := repo.DeletePrivacyZone(context.Background(), "alice", zone.ID)
+	testutils.AssertNoError(t, err, "can't delete privacy zone")
+
+	zones, err := repo.ListPrivacyZones(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "can't list privacy zones")
+	testutils.AssertEqualInt(t, 0, len(zones), "expected privacy zone to be deleted")
+}
+
+func (s privacyZoneStoreSuite) testDeletePrivacyZoneOfAnotherUser(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	zone := recordPrivacyZone(t, repo, domaintest.NewPrivacyZone(t).WithUsername("bob").Build())
+
+	err := repo.DeletePrivacyZone(context.Background(), "alice", zone.ID)
+	testutils.AssertErrorIs(t, domain.ErrPrivacyZoneNotFound, err, "unexpected error")
+
+	zones, err := repo.ListPrivacyZones(context.Background(), "bob")
+	testutils.AssertNoError(t, err, "can't list privacy zones")
+	testutils.AssertEqualInt(t, 1, len(zones), "expected privacy zone to be kept")
+}
+
+func recordPrivacyZone(t *testing.T, repo repository.PrivacyZoneStore, zone domain.PrivacyZone) domain.PrivacyZone {
+	err := repo.RecordPrivacyZone(context.Background(), zone)
+	testutils.AssertNoError(t, err, "can't record privacy zone")
+
+	return zone
+}
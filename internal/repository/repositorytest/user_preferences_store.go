@@ -0,0 +1,75 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// UserPreferencesStoreSetup returns an empty store and a function cleaning it up
+type UserPreferencesStoreSetup func(t *testing.T) (repository.UserPreferencesStore, func())
+
+// RunUserPreferencesStoreSuite runs the integration tests every UserPreferencesStore implementation must pass
+func RunUserPreferencesStoreSuite(t *testing.T, setup UserPreferencesStoreSetup) {
+	suite := userPreferencesStoreSuite{setup: setup}
+
+	t.Run("GetUserPreferencesNotFound", suite.testGetUserPreferencesNotFound)
+	t.Run("SaveUserPreferencesInsert", suite.testSaveUserPreferencesInsert)
+	t.Run("SaveUserPreferencesUpdate", suite.testSaveUserPreferencesUpdate)
+}
+
+type userPreferencesStoreSuite struct {
+	setup UserPreferencesStoreSetup
+}
+
+func (s userPreferencesStoreSuite) testGetUserPreferencesNotFound(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	saveUserPreferences(t, repo, "bob", domain.DefaultUserPreferences())
+
+	_, err := repo.GetUserPreferences(context.Background(), "alice")
+
+	testutils.AssertErrorIs(t, domain.ErrUserPreferencesNotFound, err, "unexpected error")
+}
+
+func (s userPreferencesStoreSuite) testSaveUserPreferencesInsert(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	expected := domain.UserPreferences{Locale: domain.LocaleFrench, Units: domain.UnitSystemImperial, SpeedDisplay: domain.SpeedDisplaySpeed}
+	saveUserPreferences(t, repo, "alice", expected)
+
+	actual, err := repo.GetUserPreferences(context.Background(), "alice")
+
+	testutils.AssertNoError(t, err, "can't get user preferences")
+	assertEqualUserPreferences(t, expected, actual)
+}
+
+func (s userPreferencesStoreSuite) testSaveUserPreferencesUpdate(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	saveUserPreferences(t, repo, "alice", domain.DefaultUserPreferences())
+	expected := domain.UserPreferences{Locale: domain.LocaleFrench, Units: domain.UnitSystemMetric, SpeedDisplay: domain.SpeedDisplaySpeed}
+	saveUserPreferences(t, repo, "alice", expected)
+
+	actual, err := repo.GetUserPreferences(context.Background(), "alice")
+
+	testutils.AssertNoError(t, err, "can't get user preferences")
+	assertEqualUserPreferences(t, expected, actual)
+}
+
+func saveUserPreferences(t *testing.T, repo repository.UserPreferencesStore, username string, prefs domain.UserPreferences) {
+	err := repo.SaveUserPreferences(context.Background(), username, prefs)
+	testutils.AssertNoError(t, err, "can't save user preferences")
+}
+
+func assertEqualUserPreferences(t *testing.T, expected domain.UserPreferences, actual domain.UserPreferences) {
+	testutils.AssertEqualString(t, expected.Locale.String(), actual.Locale.String(), "unexpected locale")
+	testutils.AssertEqualString(t, expected.Units.String(), actual.Units.String(), "unexpected units")
+	testutils.AssertEqualString(t, expected.SpeedDisplay.String(), actual.SpeedDisplay.String(), "unexpected speed display")
+}
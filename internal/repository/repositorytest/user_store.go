@@ -0,0 +1,126 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lonepeon/golib/testutils"
+	"github.com/lonepeon/sport/internal/domain"
+	"github.com/lonepeon/sport/internal/domain/domaintest"
+	"github.com/lonepeon/sport/internal/repository"
+)
+
+// UserStoreSetup returns an empty store and a function cleaning it up
+type UserStoreSetup func(t *testing.T) (repository.UserStore, func())
+
+// RunUserStoreSuite runs the integration tests every UserStore implementation must pass
+func RunUserStoreSuite(t *testing.T, setup UserStoreSetup) {
+	suite := userStoreSuite{setup: setup}
+
+	t.Run("GetUserSuccess", suite.testGetUserSuccess)
+	t.Run("GetUserNotFound", suite.testGetUserNotFound)
+	t.Run("RecordUserAlreadyExists", suite.testRecordUserAlreadyExists)
+	t.Run("ListUsers", suite.testListUsers)
+	t.Run("UpdateUserSuccess", suite.testUpdateUserSuccess)
+	t.Run("UpdateUserNotFound", suite.testUpdateUserNotFound)
+}
+
+type userStoreSuite struct {
+	setup UserStoreSetup
+}
+
+func (s userStoreSuite) testGetUserSuccess(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	recordUser(t, repo, domaintest.NewUser(t).WithUsername("bob").Build())
+	expected := recordUser(t, repo, domaintest.NewUser(t).WithUsername("alice").WithAdmin().Build())
+
+	actual, err := repo.GetUser(context.Background(), "alice")
+
+	testutils.AssertNoError(t, err, "can't get user")
+	domaintest.AssertEqualUser(t, expected, actual, "unexpected user")
+}
+
+func (s userStoreSuite) testGetUserNotFound(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	recordUser(t, repo, domaintest.NewUser(t).WithUsername("bob").Build())
+
+	_, err := repo.GetUser(context.Background(), "alice")
+
+	testutils.AssertErrorIs(t, domain.ErrUserNotFound, err, "unexpected error")
+}
+
+func (s userStoreSuite) testRecordUserAlreadyExists(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	recordUser(t, repo, domaintest.NewUser(t).WithUsername("alice").Build())
+
+	err := repo.RecordUser(context.Background(), domaintest.NewUser(t).WithUsername("alice").Build())
+
+	testutils.AssertHasError(t, err, "expected username to be unique")
+}
+
+func (s userStoreSuite) testListUsers(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	disabledAt := time.Now().UTC().Truncate(time.Second)
+	charlie := recordUser(t, repo, domaintest.NewUser(t).WithUsername("charlie").WithDisabledAt(disabledAt).Build())
+	alice := recordUser(t, repo, domaintest.NewUser(t).WithUsername("alice").WithAdmin().Build())
+	bob := recordUser(t, repo, domaintest.NewUser(t).WithUsername("bob").Build())
+
+	users, err := repo.ListUsers(context.Background())
+
+	testutils.AssertNoError(t, err, "can't list users")
+	testutils.RequireEqualInt(t, 3, len(users), "unexpected number of users")
+
+	domaintest.AssertEqualUser(t, alice, users[0], "unexpected user")
+	domaintest.AssertEqualUser(t, bob, users[1], "unexpected user")
+	domaintest.AssertEqualUser(t, charlie, users[2], "unexpected user")
+}
+
+func (s userStoreSuite) testUpdateUserSuccess(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	expected := recordUser(t, repo, domaintest.NewUser(t).WithUsername("alice").Build())
+	expected.PasswordHash = domain.UserPasswordHash("new-hash")
+	expected.IsAdmin = true
+	expected.DisabledAt = time.Now().UTC().Truncate(time.Second)
+
+	err := repo.UpdateUser(context.Background(), expected)
+	testutils.AssertNoError(t, err, "can't update user")
+
+	actual, err := repo.GetUser(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "can't get user")
+	domaintest.AssertEqualUser(t, expected, actual, "unexpected user")
+
+	expected.DisabledAt = time.Time{}
+	err = repo.UpdateUser(context.Background(), expected)
+	testutils.AssertNoError(t, err, "can't update user")
+
+	actual, err = repo.GetUser(context.Background(), "alice")
+	testutils.AssertNoError(t, err, "can't get user")
+	domaintest.AssertEqualUser(t, expected, actual, "unexpected enabled user")
+}
+
+func (s userStoreSuite) testUpdateUserNotFound(t *testing.T) {
+	repo, cleanup := s.setup(t)
+	defer cleanup()
+
+	err := repo.UpdateUser(context.Background(), domaintest.NewUser(t).WithUsername("alice").Build())
+
+	testutils.AssertErrorIs(t, domain.ErrUserNotFound, err, "unexpected error")
+}
+
+func recordUser(t *testing.T, repo repository.UserStore, user domain.User) domain.User {
+	err := repo.RecordUser(context.Background(), user)
+	testutils.AssertNoError(t, err, "can't record user")
+
+	return user
+}
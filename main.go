@@ -1,11 +1,14 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"database/sql"
 	"embed"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"strings"
@@ -14,6 +17,7 @@ import (
 	"time"
 
 	"github.com/gorilla/sessions"
+	_ "github.com/lib/pq"           // postgres adapter
 	_ "github.com/mattn/go-sqlite3" // sqlite3 adapter
 
 	"github.com/lonepeon/golib/env"
@@ -21,55 +25,101 @@ import (
 	"github.com/lonepeon/golib/logger"
 	"github.com/lonepeon/golib/sqlutil"
 	"github.com/lonepeon/golib/web"
-	"github.com/lonepeon/golib/web/authenticationstore"
 	"github.com/lonepeon/golib/web/sessionstore"
 	"github.com/lonepeon/sport/internal/application/service"
 	"github.com/lonepeon/sport/internal/domain"
 	"github.com/lonepeon/sport/internal/infrastructure/annotation"
+	"github.com/lonepeon/sport/internal/infrastructure/api"
+	"github.com/lonepeon/sport/internal/infrastructure/archive"
+	"github.com/lonepeon/sport/internal/infrastructure/asseturl"
+	"github.com/lonepeon/sport/internal/infrastructure/backup"
 	"github.com/lonepeon/sport/internal/infrastructure/gpx"
 	domainjob "github.com/lonepeon/sport/internal/infrastructure/job"
 	"github.com/lonepeon/sport/internal/infrastructure/mapbox"
+	"github.com/lonepeon/sport/internal/infrastructure/postgresql"
 	"github.com/lonepeon/sport/internal/infrastructure/s3"
 	"github.com/lonepeon/sport/internal/infrastructure/sqlite"
+	"github.com/lonepeon/sport/internal/infrastructure/staticmap"
+	"github.com/lonepeon/sport/internal/infrastructure/strava"
 	"github.com/lonepeon/sport/internal/infrastructure/www"
 	"github.com/lonepeon/sport/internal/repository"
 )
 
 type Repository struct {
 	*s3.Bucket
-	sqlite.SQLite
-	*mapbox.Mapbox
+	Database
+	repository.MapProvider
 	gpx.GPX
 	annotation.Annotation
+	archive.Archive
+	strava.Strava
 }
 
+// Database represents the stores implemented by every database driver
+type Database interface {
+	repository.ActivityStore
+	repository.ExportStore
+	repository.ImportStore
+	repository.UserPreferencesStore
+	repository.APITokenStore
+	repository.UserStore
+	repository.PrivacyZoneStore
+}
+
+const (
+	databaseDriverSQLite     = "sqlite3"
+	databaseDriverPostgreSQL = "postgres"
+)
+
+const (
+	mapProviderMapbox  = "mapbox"
+	mapProviderOffline = "offline"
+)
+
 type Config struct {
-	SQLitePath         string   `env:"SPORT_SQLITE_PATH,default=./sport.sqlite"`
-	SessionKey         string   `env:"SPORT_SESSION_KEY,required=true"`
-	UploadFolder       string   `env:"SPORT_UPLOAD_FOLDER,default=./tmp/uploads,required=true"`
-	WebAddress         string   `env:"SPORT_WEB_ADDR,required=true"`
-	CDNURL             string   `env:"SPORT_CDN_URL,required=true"`
-	AWSAccessKeyID     string   `env:"SPORT_AWS_ACCESS_KEY_ID,required=true"`
-	AWSSecretAccessKey string   `env:"SPORT_AWS_SECRET_ACCESS_KEY,required=true"`
-	AWSRegion          string   `env:"SPORT_AWS_REGION,required=true"`
-	AWSBucket          string   `env:"SPORT_AWS_BUCKET,required=true"`
-	AWSEndpointURL     string   `env:"SPORT_AWS_ENDPOINT_URL"`
-	MapboxEndpointURL  string   `env:"SPORT_MAPBOX_ENDPOINT_URL"`
-	MapboxToken        string   `env:"SPORT_MAPBOX_TOKEN,required=true"`
-	Users              []string `env:"SPORT_USERS,required=true,sep=;"`
+	DatabaseDriver      string   `env:"SPORT_DATABASE_DRIVER,default=sqlite3"`
+	SQLitePath          string   `env:"SPORT_SQLITE_PATH,default=./sport.sqlite"`
+	PostgreSQLURL       string   `env:"SPORT_POSTGRESQL_URL"`
+	SessionKey          string   `env:"SPORT_SESSION_KEY,required=true"`
+	UploadFolder        string   `env:"SPORT_UPLOAD_FOLDER,default=./tmp/uploads,required=true"`
+	WebAddress          string   `env:"SPORT_WEB_ADDR,required=true"`
+	CDNURL              string   `env:"SPORT_CDN_URL"`
+	AssetURLExpiry      string   `env:"SPORT_ASSET_URL_EXPIRY,default=1h"`
+	AWSAccessKeyID      string   `env:"SPORT_AWS_ACCESS_KEY_ID,required=true"`
+	AWSSecretAccessKey  string   `env:"SPORT_AWS_SECRET_ACCESS_KEY,required=true"`
+	AWSRegion           string   `env:"SPORT_AWS_REGION,required=true"`
+	AWSBucket           string   `env:"SPORT_AWS_BUCKET,required=true"`
+	AWSEndpointURL      string   `env:"SPORT_AWS_ENDPOINT_URL"`
+	MapboxEndpointURL   string   `env:"SPORT_MAPBOX_ENDPOINT_URL"`
+	MapboxToken         string   `env:"SPORT_MAPBOX_TOKEN"`
+	MapProvider         string   `env:"SPORT_MAP_PROVIDER,default=mapbox"`
+	MapTilesFolder      string   `env:"SPORT_MAP_TILES_FOLDER"`
+	MapStyle            string   `env:"SPORT_MAP_STYLE"`
+	MapActivityStyles   []string `env:"SPORT_MAP_ACTIVITY_STYLES,sep=;"`
+	CardTemplates       []string `env:"SPORT_CARD_TEMPLATES,sep=;"`
+	DefaultLocale       string   `env:"SPORT_DEFAULT_LOCALE,default=en"`
+	DefaultUnits        string   `env:"SPORT_DEFAULT_UNITS,default=metric"`
+	DefaultSpeedDisplay string   `env:"SPORT_DEFAULT_SPEED_DISPLAY,default=pace"`
+	Users               []string `env:"SPORT_USERS,sep=;"`
+	BackupAWSBucket     string   `env:"SPORT_BACKUP_AWS_BUCKET"`
+	BackupInterval      string   `env:"SPORT_BACKUP_INTERVAL,default=24h"`
+	BackupRetention     int      `env:"SPORT_BACKUP_RETENTION,default=14"`
+	PendingMapInterval  string   `env:"SPORT_PENDING_MAP_INTERVAL,default=15m"`
+	// DefaultActivityOwner is given the activities and imports recorded before they had an owner
+	DefaultActivityOwner string `env:"SPORT_DEFAULT_ACTIVITY_OWNER"`
 }
 
 //go:embed templates/*
 var htmlTemplateFS embed.FS
 
 func main() {
-	if err := run(); err != nil {
+	if err := run(os.Args[1:]); err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
 }
 
-func run() error {
+func run(args []string) error {
 	log, closer := logger.NewLogger(os.Stdout)
 	defer func() {
 		if err := closer(); err != nil {
@@ -84,53 +134,89 @@ func run() error {
 		return fmt.Errorf("can't load config: %v", err)
 	}
 
-	db, err := initDatabase(log, cfg.SQLitePath)
+	if len(args) == 0 {
+		return serve(log, cfg)
+	}
+
+	switch args[0] {
+	case "backups":
+		return listBackups(cfg)
+	case "restore":
+		return restoreBackup(log, cfg, args[1:])
+	case "users":
+		return manageUsers(log, cfg, args[1:])
+	default:
+		return fmt.Errorf("command does not exist. possible commands: backups, restore, users")
+	}
+}
+
+func serve(log *logger.Logger, cfg Config) error {
+	db, err := initDatabase(log, cfg.DatabaseDriver, cfg.SQLitePath, cfg.PostgreSQLURL)
 	if err != nil {
 		return fmt.Errorf("can't initialize database: %v", err)
 	}
 
-	sessionstore := sessionstore.NewSQLite(db, sessions.Options{
-		HttpOnly: true,
-		MaxAge:   1 * 60 * 60 * 24 * 2,
-	}, []byte(cfg.SessionKey))
+	sessionstore := initSessionStore(cfg.DatabaseDriver, db, cfg.SessionKey)
 
-	repo := repository.NewLogger(log, Repository{
-		Bucket: initBucket(
-			cfg.AWSAccessKeyID,
-			cfg.AWSSecretAccessKey,
-			cfg.AWSRegion,
-			cfg.AWSBucket,
-			cfg.AWSEndpointURL,
-		),
-		SQLite: sqlite.New(db),
-		Mapbox: initMapbox(cfg.MapboxToken, cfg.MapboxEndpointURL),
-	})
+	preferences, err := domain.NewUserPreferences(cfg.DefaultLocale, cfg.DefaultUnits, cfg.DefaultSpeedDisplay)
+	if err != nil {
+		return fmt.Errorf("can't initialize default user preferences: %v", err)
+	}
 
-	application := service.NewApplication(repo)
+	application, err := initApplication(log, cfg, db, preferences)
+	if err != nil {
+		return err
+	}
 
-	jobServer, jobClient := initJob(db, log,
-		domainjob.NewTrackRunningSessionJob(application),
+	jobHandlers := []job.Handler{
+		domainjob.NewTrackRunningSessionJob(application, log),
 		domainjob.NewDeleteRunningSessionJob(application),
-	)
+		domainjob.NewGenerateExportJob(application),
+		domainjob.NewImportActivityJob(application),
+		domainjob.NewGeneratePendingMapsJob(application),
+		domainjob.NewRegenerateRunningSessionJob(application),
+	}
+
+	backups := initDatabaseBackups(log, cfg, db)
+	if backups != nil {
+		jobHandlers = append(jobHandlers, domainjob.NewBackupDatabaseJob(*backups))
+	}
+
+	jobRegistry, jobServer, jobClient := initJob(db, log, jobHandlers...)
+	jobRegistry.Register(domainjob.NewPrepareImportJob(application, jobClient))
+	jobRegistry.Register(domainjob.NewRegeneratePrivacyZoneActivitiesJob(application, jobClient))
+
+	if err := scheduleJobs(log, jobClient, cfg, backups); err != nil {
+		return err
+	}
+
+	if err := registerUsers(application, cfg.Users); err != nil {
+		return fmt.Errorf("can't parse SPORT_USERS environment variable: %v", err)
+	}
+
+	if err := assignOrphanActivities(application, cfg.DefaultActivityOwner); err != nil {
+		return fmt.Errorf("can't assign activities to SPORT_DEFAULT_ACTIVITY_OWNER: %v", err)
+	}
 
-	auth, err := initAutenticationMiddleware(sessionstore, cfg.Users)
+	auth, currentUser := initAutenticationMiddleware(sessionstore, application)
+
+	urls, err := initAssetURLs(cfg)
 	if err != nil {
 		return err
 	}
-	webServer := initWebServer(log, sessionstore, cfg.CDNURL)
-	webServer.HandleFunc("GET", "/login", auth.ShowLoginPage("/running-session/new"))
-	webServer.HandleFunc("POST", "/login", auth.Login("/running-session/new"))
-	webServer.HandleFunc("GET", "/logout", auth.Logout("/"))
-	webServer.HandleFunc("GET", "/", auth.IdentifyCurrentUser(www.RunningSessionsIndex(application)))
-	webServer.HandleFunc("GET", "/running-session/new", auth.EnsureAuthentication("/login", www.RunningSessionNew()))
-	webServer.HandleFunc("POST", "/running-session", auth.EnsureAuthentication("/login", www.RunningSessionPost(jobClient, cfg.UploadFolder)))
-	webServer.HandleFunc("GET", "/running-session/{slug}", auth.IdentifyCurrentUser((www.RunningSessionsShow(application))))
-	webServer.HandleFunc("POST", "/running-session/{slug}/delete", auth.EnsureAuthentication("/login", www.RunningSessionsDelete(application, jobClient)))
+
+	webServer := initWebServer(log, sessionstore, urls, preferences)
+	registerRoutes(webServer, auth, currentUser, www.NewCSRFSessionStore(sessionstore), application, jobClient, urls, cfg)
+	registerAPIRoutes(webServer, application, jobClient, urls, cfg)
 
 	return waitForServersShutdown(log, jobServer, webServer, cfg.WebAddress)
 }
 
-func registerUsers(authBackend web.AuthenticationBackendStorer, rawUsers []string) error {
+// registerUsers creates the administrators listed in SPORT_USERS, so a fresh install has someone to log in with.
+// Existing users are left untouched: their password is managed from the application. The entries aren't checked
+// against the username and password rules of the accounts created from the application, so the logins configured
+// before accounts were persisted keep working.
+func registerUsers(app service.Application, rawUsers []string) error {
 	for _, rawUserLine := range rawUsers {
 		rawUser := strings.Split(rawUserLine, ":")
 		if len(rawUser) != 2 {
@@ -146,34 +232,96 @@ func registerUsers(authBackend web.AuthenticationBackendStorer, rawUsers []strin
 			return fmt.Errorf("password part is not a valid base64 value (value='%s')", rawUser[1])
 		}
 
-		if _, err := authBackend.Register(string(username), string(password)); err != nil {
-			return fmt.Errorf("can't register user (username='%s')", rawUser[0])
+		_, err = app.CreateLegacyUser(context.Background(), string(username), string(password))
+		if err != nil && !errors.Is(err, domain.ErrUserAlreadyExists) {
+			return fmt.Errorf("can't register user (username='%s'): %v", rawUser[0], err)
 		}
 	}
 
 	return nil
 }
 
-func initDatabase(log *logger.Logger, sqlitePath string) (*sql.DB, error) {
+// assignOrphanActivities gives the activities and imports recorded before they had an owner to the user, when one is
+// configured. Without it, they stay visible to everyone but only administrators can delete them.
+func assignOrphanActivities(app service.Application, username string) error {
+	if username == "" {
+		return nil
+	}
+
+	return app.AssignOrphanActivities(context.Background(), username)
+}
+
+func initDatabase(log *logger.Logger, driver string, sqlitePath string, postgreSQLURL string) (*sql.DB, error) {
+	switch driver {
+	case databaseDriverSQLite:
+		return initSQLite(log, sqlitePath)
+	case databaseDriverPostgreSQL:
+		return initPostgreSQL(log, postgreSQLURL)
+	default:
+		return nil, fmt.Errorf("unsupported database driver (driver=%s)", driver)
+	}
+}
+
+func initSQLite(log *logger.Logger, sqlitePath string) (*sql.DB, error) {
 	log.Infof("initialize database from %v", sqlitePath)
 	db, err := sql.Open("sqlite3", sqlitePath)
 	if err != nil {
 		return nil, fmt.Errorf("can't open  sqlite file: %v", err)
 	}
 
+	if err := migrateSQLite(log, db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateSQLite(log *logger.Logger, db *sql.DB) error {
 	jobMigrationsVersions, err := sqlutil.ExecuteMigrations(context.Background(), db, job.Migrations())
 	if err != nil {
-		return nil, fmt.Errorf("can't run job migrations: %v", err)
+		return fmt.Errorf("can't run job migrations: %v", err)
 	}
 	log.Infof("database executed new sql job migrations %s", strings.Join(jobMigrationsVersions, ", "))
 
 	trackerMigrationsVersions, err := sqlutil.ExecuteMigrations(context.Background(), db, sqlite.Migrations())
 	if err != nil {
-		return nil, fmt.Errorf("can't run tracker migrations: %v", err)
+		return fmt.Errorf("can't run tracker migrations: %v", err)
 	}
 	log.Infof("database executed new sql application migrations %s", strings.Join(trackerMigrationsVersions, ", "))
 
 	sessionStoreMigrationsVersions, err := sqlutil.ExecuteMigrations(context.Background(), db, sessionstore.Migrations())
+	if err != nil {
+		return fmt.Errorf("can't run session store migrations: %v", err)
+	}
+	log.Infof("database executed new sql session store migrations %s", strings.Join(sessionStoreMigrationsVersions, ", "))
+
+	return nil
+}
+
+func initPostgreSQL(log *logger.Logger, postgreSQLURL string) (*sql.DB, error) {
+	log.Info("initialize postgresql database")
+	if postgreSQLURL == "" {
+		return nil, fmt.Errorf("SPORT_POSTGRESQL_URL is required when using the postgres driver")
+	}
+
+	db, err := sql.Open("postgres", postgreSQLURL)
+	if err != nil {
+		return nil, fmt.Errorf("can't open postgresql connection: %v", err)
+	}
+
+	jobMigrationsVersions, err := postgresql.ExecuteMigrations(context.Background(), db, postgresql.JobMigrations())
+	if err != nil {
+		return nil, fmt.Errorf("can't run job migrations: %v", err)
+	}
+	log.Infof("database executed new sql job migrations %s", strings.Join(jobMigrationsVersions, ", "))
+
+	trackerMigrationsVersions, err := postgresql.ExecuteMigrations(context.Background(), db, postgresql.Migrations())
+	if err != nil {
+		return nil, fmt.Errorf("can't run tracker migrations: %v", err)
+	}
+	log.Infof("database executed new sql application migrations %s", strings.Join(trackerMigrationsVersions, ", "))
+
+	sessionStoreMigrationsVersions, err := postgresql.ExecuteMigrations(context.Background(), db, postgresql.SessionMigrations())
 	if err != nil {
 		return nil, fmt.Errorf("can't run session store migrations: %v", err)
 	}
@@ -182,7 +330,273 @@ func initDatabase(log *logger.Logger, sqlitePath string) (*sql.DB, error) {
 	return db, nil
 }
 
-func initJob(db *sql.DB, log *logger.Logger, jobHandlers ...job.Handler) (*job.Server, *job.Client) {
+func initDatabaseStore(driver string, db *sql.DB) Database {
+	if driver == databaseDriverPostgreSQL {
+		return postgresql.New(db)
+	}
+
+	return sqlite.New(db)
+}
+
+func initSessionStore(driver string, db *sql.DB, sessionKey string) sessions.Store {
+	options := sessions.Options{
+		HttpOnly: true,
+		MaxAge:   1 * 60 * 60 * 24 * 2,
+	}
+
+	if driver == databaseDriverPostgreSQL {
+		return postgresql.NewSessionStore(db, options, []byte(sessionKey))
+	}
+
+	return sessionstore.NewSQLite(db, options, []byte(sessionKey))
+}
+
+// initDatabaseBackups returns the backups of the database, or nil when they are disabled: they require the sqlite3
+// driver and SPORT_BACKUP_AWS_BUCKET
+func initDatabaseBackups(log *logger.Logger, cfg Config, db *sql.DB) *backup.Backup {
+	if cfg.DatabaseDriver != databaseDriverSQLite {
+		return nil
+	}
+
+	if cfg.BackupAWSBucket == "" {
+		log.Error("database backups are disabled: SPORT_BACKUP_AWS_BUCKET is required to back up the sqlite3 database")
+		return nil
+	}
+
+	backups := initBackup(cfg, db)
+
+	return &backups
+}
+
+func initBackup(cfg Config, db *sql.DB) backup.Backup {
+	bucket := initBucket(
+		cfg.AWSAccessKeyID,
+		cfg.AWSSecretAccessKey,
+		cfg.AWSRegion,
+		cfg.BackupAWSBucket,
+		cfg.AWSEndpointURL,
+	)
+
+	return backup.New(sqlite.New(db), bucket, cfg.BackupRetention)
+}
+
+// scheduleJobs enqueues the periodic jobs. Database backups are only scheduled when backups isn't nil.
+func scheduleJobs(log *logger.Logger, client domainjob.Enqueuer, cfg Config, backups *backup.Backup) error {
+	if backups != nil {
+		enqueueBackup := func() error { return domainjob.EnqueueBackupDatabaseJob(client) }
+		if err := schedule(log, "database backups", cfg.BackupInterval, enqueueBackup); err != nil {
+			return err
+		}
+
+		enqueueOverdueBackup(log, client, *backups, cfg.BackupInterval)
+	}
+
+	enqueuePendingMaps := func() error { return domainjob.EnqueueGeneratePendingMapsJob(client) }
+	return schedule(log, "pending maps generation", cfg.PendingMapInterval, enqueuePendingMaps)
+}
+
+// enqueueOverdueBackup takes a snapshot at startup when the most recent one is older than the interval, since the
+// schedule only fires after a full interval and starts over on each restart
+func enqueueOverdueBackup(log *logger.Logger, client domainjob.Enqueuer, backups backup.Backup, rawInterval string) {
+	interval, err := time.ParseDuration(rawInterval)
+	if err != nil {
+		log.Errorf("can't parse database backups interval (value=%s): %v", rawInterval, err)
+		return
+	}
+
+	due, err := backups.IsDue(interval)
+	if err != nil {
+		log.Errorf("can't check when the last database backup was taken: %v", err)
+		return
+	}
+
+	if !due {
+		return
+	}
+
+	if err := domainjob.EnqueueBackupDatabaseJob(client); err != nil {
+		log.Errorf("can't schedule overdue database backup: %v", err)
+		return
+	}
+
+	log.Info("overdue database backup scheduled")
+}
+
+func schedule(log *logger.Logger, name string, rawInterval string, enqueue func() error) error {
+	interval, err := time.ParseDuration(rawInterval)
+	if err != nil {
+		return fmt.Errorf("can't parse %s interval (value=%s): %v", name, rawInterval, err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := enqueue(); err != nil {
+				log.Errorf("can't schedule %s: %v", name, err)
+			}
+		}
+	}()
+
+	log.Infof("%s scheduled every %s", name, interval)
+
+	return nil
+}
+
+func listBackups(cfg Config) error {
+	if cfg.BackupAWSBucket == "" {
+		return fmt.Errorf("SPORT_BACKUP_AWS_BUCKET is required to list backups")
+	}
+
+	names, err := initBackup(cfg, nil).ListBackups()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		fmt.Println(name)
+	}
+
+	return nil
+}
+
+// restoreBackup replaces the SQLite database by a snapshot. The argument is either the name of a snapshot
+// or a RFC3339 time, in which case the most recent snapshot taken before it is restored.
+// The application must be stopped while restoring.
+func restoreBackup(log *logger.Logger, cfg Config, args []string) error {
+	if cfg.DatabaseDriver != databaseDriverSQLite || cfg.BackupAWSBucket == "" {
+		return fmt.Errorf("restoring requires the sqlite3 driver and SPORT_BACKUP_AWS_BUCKET")
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("usage: sport restore <snapshot name|RFC3339 time>")
+	}
+
+	b := initBackup(cfg, nil)
+	name := args[0]
+	if at, err := time.Parse(time.RFC3339, name); err == nil {
+		if name, err = b.FindBackup(at); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("restoring snapshot %s into %s", name, cfg.SQLitePath)
+	err := b.RestoreDatabase(name, cfg.SQLitePath, func(path string) error {
+		return verifySQLiteSnapshot(log, path)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("snapshot %s restored", name)
+
+	return nil
+}
+
+func verifySQLiteSnapshot(log *logger.Logger, path string) error {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return fmt.Errorf("can't open snapshot: %v", err)
+	}
+	defer db.Close()
+
+	var integrity string
+	if err := db.QueryRow("PRAGMA integrity_check").Scan(&integrity); err != nil {
+		return fmt.Errorf("can't check snapshot integrity: %v", err)
+	}
+
+	if integrity != "ok" {
+		return fmt.Errorf("snapshot is corrupted: %s", integrity)
+	}
+
+	return migrateSQLite(log, db)
+}
+
+// manageUsers lists, adds, disables, enables and resets the password of users from the command line, mainly to
+// recover when no administrator can log in anymore. The password of a new user is read from the standard input.
+func manageUsers(log *logger.Logger, cfg Config, args []string) error {
+	usage := fmt.Errorf("usage: sport users list|add <username> [admin]|disable <username>|enable <username>|reset <username>")
+	if len(args) == 0 || (args[0] != "list" && len(args) < 2) {
+		return usage
+	}
+
+	db, err := initDatabase(log, cfg.DatabaseDriver, cfg.SQLitePath, cfg.PostgreSQLURL)
+	if err != nil {
+		return fmt.Errorf("can't initialize database: %v", err)
+	}
+	defer db.Close()
+
+	application, err := initApplication(log, cfg, db, domain.UserPreferences{})
+	if err != nil {
+		return err
+	}
+
+	return runUsersCommand(context.Background(), application, args, usage)
+}
+
+func runUsersCommand(ctx context.Context, application service.Application, args []string, usage error) error {
+	switch args[0] {
+	case "list":
+		return listUsers(ctx, application)
+	case "add":
+		return addUser(ctx, application, args[1], len(args) > 2 && args[2] == "admin")
+	case "disable":
+		return application.DisableUser(ctx, args[1])
+	case "enable":
+		return application.EnableUser(ctx, args[1])
+	case "reset":
+		return resetUserPassword(ctx, application, args[1])
+	default:
+		return usage
+	}
+}
+
+func listUsers(ctx context.Context, application service.Application) error {
+	users, err := application.ListUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		status := "active"
+		if user.IsDisabled() {
+			status = "disabled"
+		}
+
+		role := "user"
+		if user.IsAdmin {
+			role = "admin"
+		}
+
+		fmt.Printf("%s\t%s\t%s\n", user.Username, role, status)
+	}
+
+	return nil
+}
+
+func addUser(ctx context.Context, application service.Application, username string, isAdmin bool) error {
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("can't read password from standard input: %v", err)
+	}
+
+	_, err = application.CreateUser(ctx, username, strings.TrimRight(password, "\r\n"), isAdmin)
+	return err
+}
+
+func resetUserPassword(ctx context.Context, application service.Application, username string) error {
+	password, err := application.ResetUserPassword(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(password)
+
+	return nil
+}
+
+func initJob(db *sql.DB, log *logger.Logger, jobHandlers ...job.Handler) (*job.Registry, *job.Server, *job.Client) {
 	reg := job.NewRegistry()
 	for _, jobHandler := range jobHandlers {
 		reg.Register(jobHandler)
@@ -190,7 +604,7 @@ func initJob(db *sql.DB, log *logger.Logger, jobHandlers ...job.Handler) (*job.S
 	jobServer := job.NewServer(db, reg, log)
 	jobClient := jobServer.Client()
 
-	return jobServer, jobClient
+	return reg, jobServer, jobClient
 }
 
 func initBucket(accessKeyID string, secretAccessKey string, region string, bucketName string, endpointURL string) *s3.Bucket {
@@ -205,7 +619,26 @@ func initBucket(accessKeyID string, secretAccessKey string, region string, bucke
 	return s3Bucket
 }
 
-func initWebServer(log *logger.Logger, sessionstore sessions.Store, cdnURL string) *web.Server {
+// initAssetURLs links the files of public activities through SPORT_CDN_URL, when it's set, and the other ones through
+// URLs presigned for SPORT_ASSET_URL_EXPIRY
+func initAssetURLs(cfg Config) (asseturl.Builder, error) {
+	expiry, err := time.ParseDuration(cfg.AssetURLExpiry)
+	if err != nil {
+		return asseturl.Builder{}, fmt.Errorf("can't parse SPORT_ASSET_URL_EXPIRY (value=%s): %v", cfg.AssetURLExpiry, err)
+	}
+
+	bucket := initBucket(
+		cfg.AWSAccessKeyID,
+		cfg.AWSSecretAccessKey,
+		cfg.AWSRegion,
+		cfg.AWSBucket,
+		cfg.AWSEndpointURL,
+	)
+
+	return asseturl.NewBuilder(cfg.CDNURL, bucket, expiry), nil
+}
+
+func initWebServer(log *logger.Logger, sessionstore sessions.Store, urls asseturl.Builder, defaultPreferences domain.UserPreferences) *web.Server {
 	tmpl := web.TmplConfiguration{
 		FS:                          htmlTemplateFS,
 		Layout:                      "templates/layout.html.tmpl",
@@ -216,11 +649,15 @@ func initWebServer(log *logger.Logger, sessionstore sessions.Store, cdnURL strin
 		UnauthorizedTemplate:        "templates/401.html.tmpl",
 	}
 
-	// TODO: add form with CSRF
 	webServer := web.NewServer(log, tmpl, sessionstore)
 	webServer.AddTemplateFuncs(template.FuncMap{
-		"fmtdatetime": func(dt time.Time) string {
-			return dt.Format("2006/01/02 15:04")
+		// preferences falls back to the default ones on pages rendered without them, such as error pages
+		"preferences": func(v interface{}) domain.UserPreferences {
+			if prefs, ok := v.(domain.UserPreferences); ok {
+				return prefs
+			}
+
+			return defaultPreferences
 		},
 		"modulo": func(value int, base int) bool {
 			return value%base == 0
@@ -232,11 +669,9 @@ func initWebServer(log *logger.Logger, sessionstore sessions.Store, cdnURL strin
 
 			return iffalse
 		},
-		"mapurl": func(fname domain.MapFilePath) string {
-			return cdnURL + "/" + string(fname)
-		},
-		"shareablemapurl": func(fname domain.ShareableMapFilePath) string {
-			return cdnURL + "/" + string(fname)
+		// asseturl links the files of private activities through presigned URLs rather than the public CDN
+		"asseturl": func(activity domain.RunningActivity, fname string) (string, error) {
+			return www.ActivityAssetURL(urls, activity, fname)
 		},
 	})
 
@@ -319,6 +754,116 @@ func waitForServersShutdown(log *logger.Logger, jobServer *job.Server, webServer
 	return err
 }
 
+func initApplication(log *logger.Logger, cfg Config, db *sql.DB, preferences domain.UserPreferences) (service.Application, error) {
+	bucket := initBucket(
+		cfg.AWSAccessKeyID,
+		cfg.AWSSecretAccessKey,
+		cfg.AWSRegion,
+		cfg.AWSBucket,
+		cfg.AWSEndpointURL,
+	)
+
+	mapProvider, err := initMapProvider(cfg)
+	if err != nil {
+		return service.Application{}, fmt.Errorf("can't initialize map provider: %v", err)
+	}
+
+	mapStyles, err := domain.NewMapStyles(cfg.MapStyle, cfg.MapActivityStyles)
+	if err != nil {
+		return service.Application{}, fmt.Errorf("can't initialize map styles: %v", err)
+	}
+
+	cardTemplates, err := domain.NewCardTemplates(cfg.CardTemplates)
+	if err != nil {
+		return service.Application{}, fmt.Errorf("can't initialize card templates: %v", err)
+	}
+
+	repo := repository.NewLogger(log, Repository{
+		Bucket:      bucket,
+		Database:    initDatabaseStore(cfg.DatabaseDriver, db),
+		MapProvider: mapProvider,
+		Archive:     archive.New(bucket),
+	})
+
+	return service.NewApplication(repo, mapStyles, cardTemplates, preferences), nil
+}
+
+func registerRoutes(webServer *web.Server, auth web.Authentication, currentUser www.CurrentUser, csrf www.CSRFSessionStore, application service.Application, jobClient *job.Client, urls asseturl.Builder, cfg Config) {
+	withPreferences := func(h web.HandlerFunc) web.HandlerFunc {
+		return www.WithPreferences(application, currentUser, h)
+	}
+
+	admin := func(h web.HandlerFunc) web.HandlerFunc {
+		return www.EnsureAdmin(application, currentUser, h)
+	}
+
+	// form exposes the CSRF token to the pages rendering forms, userForm only when the forms are shown to logged-in users
+	form := func(h web.HandlerFunc) web.HandlerFunc { return www.WithCSRFToken(csrf, h) }
+	userForm := func(h web.HandlerFunc) web.HandlerFunc { return www.WithUserCSRFToken(csrf, currentUser, h) }
+
+	handle := func(method string, path string, h web.HandlerFunc) {
+		webServer.HandleFunc(method, path, www.ProtectCSRF(csrf, method, h))
+	}
+
+	handle("GET", "/login", form(withPreferences(auth.ShowLoginPage("/running-session/new"))))
+	handle("POST", "/login", withPreferences(auth.Login("/running-session/new")))
+	handle("GET", "/logout", auth.Logout("/"))
+	handle("GET", "/", auth.IdentifyCurrentUser(userForm(withPreferences(www.RunningSessionsIndex(application, currentUser)))))
+	handle("GET", "/running-session/new", auth.EnsureAuthentication("/login", form(withPreferences(www.RunningSessionNew()))))
+	handle("POST", "/running-session", auth.EnsureAuthentication("/login", www.RunningSessionPost(jobClient, currentUser, cfg.UploadFolder)))
+	handle("GET", "/running-session/{slug}.{format:gpx|geojson|kml}", auth.IdentifyCurrentUser(www.RunningSessionsTrack(application, currentUser)))
+	handle("GET", "/running-session/{slug}", auth.IdentifyCurrentUser(userForm(withPreferences(www.RunningSessionsShow(application, currentUser)))))
+	handle("GET", "/running-session/{slug}/assets/{name}", auth.IdentifyCurrentUser(www.RunningSessionsAsset(application, currentUser)))
+	handle("GET", "/athletes/{username}", auth.IdentifyCurrentUser(userForm(withPreferences(www.AthletesShow(application, currentUser)))))
+	handle("POST", "/running-session/{slug}/visibility", auth.EnsureAuthentication("/login", www.RunningSessionsVisibility(application, currentUser)))
+	handle("POST", "/running-session/{slug}/delete", auth.EnsureAuthentication("/login", www.RunningSessionsDelete(application, currentUser, jobClient)))
+	handle("GET", "/exports", auth.EnsureAuthentication("/login", form(withPreferences(www.ExportsIndex(application, currentUser)))))
+	handle("POST", "/exports", auth.EnsureAuthentication("/login", www.ExportsPost(application, jobClient, currentUser)))
+	handle("GET", "/exports/{id}/download", auth.EnsureAuthentication("/login", www.ExportsDownload(application, urls, currentUser)))
+	handle("GET", "/imports", auth.EnsureAuthentication("/login", form(withPreferences(www.ImportsIndex(application, currentUser)))))
+	webServer.HandleFunc("POST", "/imports", www.LimitBodySize(www.MaxImportArchiveSize, www.ProtectCSRF(csrf, "POST", auth.EnsureAuthentication("/login", www.ImportsPost(application, currentUser, jobClient, cfg.UploadFolder)))))
+	handle("GET", "/imports/{id}", auth.EnsureAuthentication("/login", form(withPreferences(www.ImportsShow(application, currentUser)))))
+	handle("POST", "/imports/{id}/resume", auth.EnsureAuthentication("/login", www.ImportsResume(application, currentUser, jobClient)))
+	handle("GET", "/admin/pending-maps", auth.EnsureAuthentication("/login", form(withPreferences(admin(www.PendingMapsIndex(application))))))
+	handle("POST", "/admin/pending-maps", auth.EnsureAuthentication("/login", admin(www.PendingMapsPost(jobClient))))
+	handle("GET", "/admin/users", auth.EnsureAuthentication("/login", form(withPreferences(admin(www.UsersIndex(application))))))
+	handle("POST", "/admin/users", auth.EnsureAuthentication("/login", admin(www.UsersPost(application))))
+	handle("POST", "/admin/users/{username}/disable", auth.EnsureAuthentication("/login", admin(www.UsersDisable(application, currentUser))))
+	handle("POST", "/admin/users/{username}/enable", auth.EnsureAuthentication("/login", admin(www.UsersEnable(application))))
+	handle("POST", "/admin/users/{username}/reset-password", auth.EnsureAuthentication("/login", withPreferences(admin(www.UsersResetPassword(application)))))
+	handle("GET", "/profile", auth.EnsureAuthentication("/login", form(withPreferences(www.ProfileShow(application, currentUser)))))
+	handle("POST", "/profile/password", auth.EnsureAuthentication("/login", www.ProfilePasswordPost(application, currentUser)))
+	handle("GET", "/settings", auth.EnsureAuthentication("/login", form(withPreferences(www.SettingsShow()))))
+	handle("POST", "/settings", auth.EnsureAuthentication("/login", www.SettingsPost(application, currentUser)))
+	handle("GET", "/settings/tokens", auth.EnsureAuthentication("/login", form(withPreferences(www.APITokensIndex(application, currentUser)))))
+	handle("POST", "/settings/tokens", auth.EnsureAuthentication("/login", withPreferences(www.APITokensPost(application, currentUser))))
+	handle("POST", "/settings/tokens/{id}/revoke", auth.EnsureAuthentication("/login", www.APITokensRevoke(application, currentUser)))
+	handle("GET", "/settings/privacy-zones", auth.EnsureAuthentication("/login", form(withPreferences(www.PrivacyZonesIndex(application, currentUser)))))
+	handle("POST", "/settings/privacy-zones", auth.EnsureAuthentication("/login", www.PrivacyZonesPost(application, currentUser, jobClient)))
+	handle("POST", "/settings/privacy-zones/{id}/delete", auth.EnsureAuthentication("/login", www.PrivacyZonesDelete(application, currentUser, jobClient)))
+}
+
+func registerAPIRoutes(webServer *web.Server, application service.Application, jobClient *job.Client, urls asseturl.Builder, cfg Config) {
+	for _, route := range api.Routes(application, application, jobClient, urls, cfg.UploadFolder) {
+		webServer.HandleFunc(route.Method, route.Path, route.Handler)
+	}
+}
+
+func initMapProvider(cfg Config) (repository.MapProvider, error) {
+	switch cfg.MapProvider {
+	case mapProviderMapbox:
+		if cfg.MapboxToken == "" {
+			return nil, fmt.Errorf("SPORT_MAPBOX_TOKEN is required by the %s map provider", mapProviderMapbox)
+		}
+
+		return initMapbox(cfg.MapboxToken, cfg.MapboxEndpointURL), nil
+	case mapProviderOffline:
+		return staticmap.New(cfg.MapTilesFolder), nil
+	default:
+		return nil, fmt.Errorf("unsupported map provider (provider=%s)", cfg.MapProvider)
+	}
+}
+
 func initMapbox(token string, endpointURL string) *mapbox.Mapbox {
 	box := mapbox.New(token)
 	if endpointURL != "" {
@@ -328,14 +873,12 @@ func initMapbox(token string, endpointURL string) *mapbox.Mapbox {
 	return box
 }
 
-func initAutenticationMiddleware(store sessions.Store, users []string) (web.Authentication, error) {
+func initAutenticationMiddleware(store sessions.Store, application service.Application) (web.Authentication, www.CurrentUser) {
 	authenticationBrowserStore := web.NewCurrentAuthenticatedUserSessionStore(store)
-	authenticationBackendstore := authenticationstore.NewInMemory()
-	if err := registerUsers(authenticationBackendstore, users); err != nil {
-		return web.Authentication{}, fmt.Errorf("can't parse USERS environment variable: %v", err)
-	}
+	authenticationBackendstore := www.NewAuthenticationBackend(application)
 
 	auth := web.NewAuthentication(authenticationBrowserStore, authenticationBackendstore, "templates/login/new.html.tmpl")
+	currentUser := www.NewCurrentUser(authenticationBrowserStore, authenticationBackendstore)
 
-	return auth, nil
+	return auth, currentUser
 }
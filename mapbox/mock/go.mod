@@ -1,3 +0,0 @@
-module github.com/lonepeon/sport-mapbox-mocks
-
-go 1.17
@@ -49,14 +49,22 @@ case "\$1" in
     ${APPS_FOLDER}/${APP_NAME}/sport
     ;;
   dump)
+    # legacy backups, used until SPORT_BACKUP_AWS_BUCKET is configured
     cat <<EOF | sqlite3 ${APPS_FOLDER}/${APP_NAME}/sport.sqlite | bzip2 --best | env AWS_ACCESS_KEY_ID=\${TRAX_BACKUP_AWS_ACCESS_KEY_ID} AWS_SECRET_ACCESS_KEY=\${TRAX_BACKUP_AWS_SECRET_ACCESS_KEY} AWS_REGION=\${TRAX_BACKUP_AWS_REGION} aws s3 cp - s3://\${TRAX_BACKUP_AWS_BUCKET}/dumps/sport/\$(date '+%Y-%m-%d').sql.bzip
 .output stdout
 .dump
 .exit
 EOF
     ;;
+  backups)
+    ${APPS_FOLDER}/${APP_NAME}/sport backups
+    ;;
+  restore)
+    # the service must be stopped first: systemctl stop sport
+    ${APPS_FOLDER}/${APP_NAME}/sport restore "\$2"
+    ;;
   *)
-    >&2 echo "command does not exist. possible commands: start, dump"
+    >&2 echo "command does not exist. possible commands: start, dump, backups, restore"
     exit 1;
 esac
 CODE
@@ -87,7 +95,16 @@ ProtectSystem=full
 WantedBy=multi-user.target
 EOF
 
-cat <<EOF > /etc/systemd/system/sport-backup.service
+BACKUP_BUCKET=$(source ${APPS_FOLDER}/${APP_NAME}/.env && echo "${SPORT_BACKUP_AWS_BUCKET:-}")
+
+if [ -n "${BACKUP_BUCKET}" ]; then
+  # the application takes the snapshots itself
+  systemctl disable --now sport-backup.timer sport-backup.service 2>/dev/null || true
+  rm -f /etc/systemd/system/sport-backup.service /etc/systemd/system/sport-backup.timer
+else
+  >&2 echo "SPORT_BACKUP_AWS_BUCKET is not set: keeping the weekly dumps to TRAX_BACKUP_AWS_BUCKET"
+
+  cat <<EOF > /etc/systemd/system/sport-backup.service
 [Unit]
 Description=Sport Backup
 Wants=sport-backup.timer
@@ -104,7 +121,7 @@ ExecStart=/usr/bin/sport dump
 WantedBy=multi-user.target
 EOF
 
-cat <<EOF > /etc/systemd/system/sport-backup.timer
+  cat <<EOF > /etc/systemd/system/sport-backup.timer
 [Unit]
 Description=Dump sport database every week
 Requires=sport-backup.service
@@ -117,7 +134,8 @@ OnCalendar=Sun *-*-* 14:00:00
 WantedBy=timers.target
 EOF
 
+  systemctl enable --now sport-backup.service
+  systemctl enable --now sport-backup.timer
+fi
 
-systemctl enable --now sport-backup.service
-systemctl enable --now sport-backup.timer
 systemctl enable --now sport.service